@@ -5,8 +5,12 @@ import (
 	"aita/internal/cache"
 	"aita/internal/configuration"
 	"aita/internal/db"
+	"aita/internal/dto"
 	"aita/internal/pkg/crypto"
+	"aita/internal/pkg/mailer"
 	"aita/internal/pkg/messagequeue"
+	"aita/internal/pkg/storage"
+	"aita/internal/pkg/unfurl"
 	"aita/internal/producer"
 	"aita/internal/repository"
 	"aita/internal/service"
@@ -18,6 +22,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -80,6 +85,14 @@ func main() {
 	tweetMQ :=  messagequeue.NewRedisMQ(rdb, config.TweetStream, config.FanoutGroup, "api-server-1")
 	if err := tweetMQ.InitMQ(context.Background()); err != nil {
         log.Fatalf("MQ の初期化に失敗しました: %v", err)
+    }
+	previewMQ := messagequeue.NewRedisMQ(rdb, config.TweetStream, config.PreviewGroup, "api-server-1")
+	if err := previewMQ.InitMQ(context.Background()); err != nil {
+        log.Fatalf("MQ の初期化に失敗しました: %v", err)
+    }
+	trendMQ := messagequeue.NewRedisMQ(rdb, config.TweetStream, config.TrendGroup, "api-server-1")
+	if err := trendMQ.InitMQ(context.Background()); err != nil {
+        log.Fatalf("MQ の初期化に失敗しました: %v", err)
     }
     log.Println("✅ Redis Stream (MQ) の初期化に成功しました！")
 
@@ -89,34 +102,160 @@ func main() {
 
 	fanoutProducer := producer.NewFanoutProducer(tweetMQ, workerPool)
 
+	mediaStorage, err := storage.NewLocalStorage(config.MediaDir, config.MediaBaseURL)
+	if err != nil {
+		log.Fatalf("メディアストレージの初期化に失敗しました: %v", err)
+	}
+
+	var mailSender service.Mailer
+	if config.MailDriver == "smtp" {
+		mailSender = mailer.NewSMTPMailer(config.SMTPHost, config.SMTPPort, config.SMTPUsername, config.SMTPPassword, config.MailFrom)
+	} else {
+		mailSender, err = mailer.NewLogMailer(config.MailFrom, config.MailDir)
+		if err != nil {
+			log.Fatalf("メーラーの初期化に失敗しました: %v", err)
+		}
+	}
+
 	userStore := db.NewPostgresUserStore(database)
 	sessionStore := db.NewRedisSessionStore(rdb)
 	tweetStore := db.NewPostgresTweetStore(database)
 	followStore := db.NewPostgresFollowStore(database)
+	recommendationStore := db.NewPostgresRecommendationStore(database)
+	scheduledTweetStore := db.NewPostgresScheduledTweetStore(database)
+	draftStore := db.NewPostgresDraftStore(database)
+	mediaStore := db.NewPostgresMediaStore(database)
+	pollStore := db.NewPostgresPollStore(database)
+	bookmarkStore := db.NewPostgresBookmarkStore(database)
+	notificationStore := db.NewPostgresNotificationStore(database)
+	dmStore := db.NewPostgresDMStore(database)
+	listStore := db.NewPostgresListStore(database)
+	viewStore := db.NewPostgresViewStore(database)
+	analyticsStore := db.NewPostgresAnalyticsStore(database)
+	userTokenStore := db.NewPostgresUserTokenStore(database)
+	transactor := db.NewTransactor(database)
 
 	userCache := cache.NewRedisUserCache(rdb)
 	followCache := cache.NewRedisFollowCache(rdb)
 	tweetCache := cache.NewRedisTweetCache(rdb)
 	timelineCache := cache.NewRedisTimelineCache(rdb)
+	recommendationCache := cache.NewRedisRecommendationCache(rdb)
+	scheduleCache := cache.NewRedisScheduleCache(rdb)
+	pollCache := cache.NewRedisPollCache(rdb)
+	bookmarkCache := cache.NewRedisBookmarkCache(rdb)
+	linkPreviewCache := cache.NewRedisLinkPreviewCache(rdb)
+	notificationCache := cache.NewRedisNotificationCache(rdb)
+	eventStreamCache := cache.NewRedisEventStreamCache(rdb, int64(config.StreamBacklogSize))
+	topicCache := cache.NewRedisTopicCache(rdb)
+	trendCache := cache.NewRedisTrendCache(rdb)
+	viewCache := cache.NewRedisViewCache(rdb)
+	dmCache := cache.NewRedisDMCache(rdb)
+	listCache := cache.NewRedisListCache(rdb)
+	rateLimitCache := cache.NewRedisRateLimitCache(rdb)
 
 	userRepository := repository.NewUserRepository(userStore, userCache, backfillPool)
 	serviceRepository := repository.NewSessionRepository(sessionStore)
 	followRepository := repository.NewFollowRepository(followStore, followCache, backfillPool)
-	tweetRepository := repository.NewTweetRepository(tweetStore, tweetCache, backfillPool)
+	tweetRepository := repository.NewTweetRepository(tweetStore, tweetCache, linkPreviewCache, viewStore, viewCache, backfillPool)
 	timeLineRepository := repository.NewTimeLineRepository(timelineCache, backfillPool)
+	recommendationRepository := repository.NewRecommendationRepository(recommendationStore, recommendationCache, backfillPool)
+	scheduledTweetRepository := repository.NewScheduledTweetRepository(scheduledTweetStore, scheduleCache)
+	draftRepository := repository.NewDraftRepository(draftStore)
+	mediaRepository := repository.NewMediaRepository(mediaStore)
+	pollRepository := repository.NewPollRepository(pollStore, pollCache)
+	bookmarkRepository := repository.NewBookmarkRepository(bookmarkStore, bookmarkCache, backfillPool)
+	linkPreviewRepository := repository.NewLinkPreviewRepository(linkPreviewCache)
+	notificationRepository := repository.NewNotificationRepository(notificationStore, notificationCache)
+	streamRepository := repository.NewStreamRepository(eventStreamCache)
+	topicRepository := repository.NewTopicRepository(topicCache)
+	trendRepository := repository.NewTrendRepository(trendCache)
+	viewRepository := repository.NewViewRepository(viewStore, viewCache)
+	analyticsRepository := repository.NewAnalyticsRepository(analyticsStore)
+	userTokenRepository := repository.NewUserTokenRepository(userTokenStore)
+	dmRepository := repository.NewDMRepository(dmStore, dmCache)
+	listRepository := repository.NewListRepository(listStore, listCache)
+	rateLimitRepository := repository.NewRateLimitRepository(rateLimitCache)
 
 	userService := service.NewUserService(userRepository, hasher)
-	sessionService := service.NewSessionService(serviceRepository, userService, tokenmanager)
-	tweetService := service.NewTweetService(tweetRepository, fanoutProducer)
-	followService := service.NewFollowService(followRepository, userService)
-	timeLineService := service.NewTimeLineService(timeLineRepository, tweetService, backfillPool)
-	fanoutWorker := worker.NewFanoutWorker(tweetMQ, followService, timeLineService, workerPool)
-
-	userHandler := api.NewUserHandler(userService, sessionService)
-	tweetHandler := api.NewTweetHandler(tweetService)
+	sessionPolicy := dto.NewSessionPolicy(
+		time.Duration(config.AccessTokenTTL)*time.Minute,
+		time.Duration(config.RefreshTokenTTL)*time.Hour,
+		time.Duration(config.SessionMaxLife)*time.Hour,
+	)
+	sessionService := service.NewSessionService(serviceRepository, userService, tokenmanager, sessionPolicy)
+	editPolicy := dto.NewEditPolicy(time.Duration(config.TweetEditWindow)*time.Minute, config.TweetMaxEdits)
+	deletionPolicy := dto.DeletionPolicy{
+		GracePeriod: time.Duration(config.TweetRestoreGrace) * time.Minute,
+		Retention:   time.Duration(config.TweetRetention) * time.Hour,
+	}
+	tweetService := service.NewTweetService(tweetRepository, fanoutProducer, editPolicy, deletionPolicy)
+	scheduledTweetService := service.NewScheduledTweetService(scheduledTweetRepository, fanoutProducer)
+	draftService := service.NewDraftService(draftRepository, tweetService, transactor)
+	mediaPolicy := dto.MediaPolicy{
+		MaxBytes:      int64(config.MediaMaxBytes),
+		MaxPixels:     config.MediaMaxPixels,
+		ThumbnailSize: config.MediaThumbnailSize,
+		OrphanTTL:     time.Duration(config.MediaOrphanTTL) * time.Hour,
+	}
+	mediaService := service.NewMediaService(mediaRepository, mediaStorage, mediaPolicy)
+	pollService := service.NewPollService(pollRepository)
+	bookmarkService := service.NewBookmarkService(bookmarkRepository, tweetService)
+	profileService := service.NewProfileService(userRepository, tweetService)
+	linkPreviewTimeout := time.Duration(config.LinkPreviewTimeout) * time.Second
+	previewFetcher := unfurl.NewFetcher(linkPreviewTimeout, int64(config.LinkPreviewMaxBytes))
+	linkPreviewService := service.NewLinkPreviewService(linkPreviewRepository, previewFetcher, tweetService)
+	streamService := service.NewStreamService(streamRepository, config.StreamMaxConnsPerUser)
+	notificationService := service.NewNotificationService(notificationRepository, userService, tweetService, streamService)
+	dmService := service.NewDMService(dmRepository, userService, topicRepository)
+	gatewayService := service.NewGatewayService(topicRepository, streamService, tweetService, dmService)
+	listService := service.NewListService(listRepository, tweetService, userService)
+	trendService := service.NewTrendService(trendRepository, tweetService, userService, time.Duration(config.TrendMinAccountAge)*time.Hour)
+	followService := service.NewFollowService(followRepository, userService, transactor, notificationService)
+	viewService := service.NewViewService(viewRepository, workerPool)
+	analyticsService := service.NewAnalyticsService(analyticsRepository)
+	passwordService := service.NewPasswordService(userRepository, userTokenRepository, transactor, sessionService, tokenmanager, hasher, mailSender, workerPool,
+		time.Duration(config.PasswordResetTTL)*time.Minute, config.AppBaseURL+"/password/reset")
+	emailVerificationService := service.NewEmailVerificationService(userRepository, userTokenRepository, rateLimitRepository, transactor, tokenmanager, mailSender, workerPool,
+		dto.NewVerificationPolicy(config.UnverifiedRestrictions), time.Duration(config.EmailVerificationTTL)*time.Hour, config.AppBaseURL+"/email/verify")
+	accountService := service.NewAccountService(userRepository, userTokenRepository, transactor, sessionService, emailVerificationService, hasher,
+		time.Duration(config.UsernameChangeCooldown)*24*time.Hour)
+	timeLineService := service.NewTimeLineService(timeLineRepository, tweetService, viewService, backfillPool)
+	recommendationService := service.NewRecommendationService(recommendationRepository, followService, userService)
+	fanoutWorker := worker.NewFanoutWorker(tweetMQ, followService, timeLineService, bookmarkService, notificationService, streamService, workerPool)
+	recommendationWorker := worker.NewRecommendationWorker(recommendationService, workerPool, time.Duration(config.RecommendationInterval)*time.Minute)
+	tweetPurgeWorker := worker.NewTweetPurgeWorker(tweetService, time.Duration(config.TweetPurgeInterval)*time.Minute)
+	tweetSchedulerWorker := worker.NewTweetSchedulerWorker(scheduledTweetService, time.Duration(config.TweetSchedulerInterval)*time.Second)
+	mediaGCWorker := worker.NewMediaGCWorker(mediaService, time.Duration(config.MediaGCInterval)*time.Minute)
+	pollTallyWorker := worker.NewPollTallyWorker(pollService, time.Duration(config.PollTallyInterval)*time.Second)
+	pollCloseWorker := worker.NewPollCloseWorker(pollService, time.Duration(config.PollCloseInterval)*time.Second)
+	linkPreviewWorker := worker.NewLinkPreviewWorker(previewMQ, linkPreviewService, workerPool, 2*linkPreviewTimeout)
+	trendWorker := worker.NewTrendWorker(trendMQ, trendService, workerPool)
+	trendRefreshWorker := worker.NewTrendRefreshWorker(trendService, time.Duration(config.TrendInterval)*time.Second)
+	viewFlushWorker := worker.NewViewFlushWorker(viewService, time.Duration(config.ViewFlushInterval)*time.Second)
+	analyticsRollupWorker := worker.NewAnalyticsRollupWorker(analyticsService, time.Duration(config.AnalyticsRollupInterval)*time.Minute)
+
+	userHandler := api.NewUserHandler(userService, sessionService, emailVerificationService, accountService)
+	tweetHandler := api.NewTweetHandler(tweetService, scheduledTweetService, viewService)
 	followHandler := api.NewFollowHandler(followService)
+	recommendationHandler := api.NewRecommendationHandler(recommendationService)
+	draftHandler := api.NewDraftHandler(draftService)
+	mediaHandler := api.NewMediaHandler(mediaService, int64(config.MediaMaxBytes))
+	pollHandler := api.NewPollHandler(pollService)
+	bookmarkHandler := api.NewBookmarkHandler(bookmarkService)
+	profileHandler := api.NewProfileHandler(profileService)
+	notificationHandler := api.NewNotificationHandler(notificationService)
+	streamHandler := api.NewStreamHandler(streamService, time.Duration(config.StreamHeartbeatInterval)*time.Second)
+	gatewayHandler := api.NewGatewayHandler(gatewayService, time.Duration(config.GatewayPingInterval)*time.Second, strings.Split(config.GatewayAllowedOrigins, ","))
+	dmHandler := api.NewDMHandler(dmService)
+	listHandler := api.NewListHandler(listService)
+	trendHandler := api.NewTrendHandler(trendService)
+	analyticsHandler := api.NewAnalyticsHandler(analyticsService)
+	passwordHandler := api.NewPasswordHandler(passwordService)
+	emailVerificationHandler := api.NewEmailVerificationHandler(emailVerificationService)
+	sessionHandler := api.NewSessionHandler(sessionService)
 
-	router := api.SetupRouter(userHandler, tweetHandler, followHandler, sessionService)
+	router := api.SetupRouter(userHandler, tweetHandler, followHandler, recommendationHandler, draftHandler, mediaHandler, pollHandler, bookmarkHandler, profileHandler, notificationHandler, streamHandler, gatewayHandler, dmHandler, listHandler, trendHandler, analyticsHandler, passwordHandler, emailVerificationHandler, sessionHandler, sessionService)
+	router.Static(config.MediaBaseURL, config.MediaDir)
 
 	workerCtx, workerCancel := context.WithCancel(context.Background())
     defer workerCancel()
@@ -126,10 +265,77 @@ func main() {
         fanoutWorker.Start(workerCtx)
 	}()
 
+	go func() {
+		slog.Info("Main: RecommendationWorker をバックグラウンドで開始します")
+		recommendationWorker.Start(workerCtx)
+	}()
+
+	go func() {
+		slog.Info("Main: TweetPurgeWorker をバックグラウンドで開始します")
+		tweetPurgeWorker.Start(workerCtx)
+	}()
+
+	go func() {
+		slog.Info("Main: TweetSchedulerWorker をバックグラウンドで開始します")
+		tweetSchedulerWorker.Start(workerCtx)
+	}()
+
+	go func() {
+		slog.Info("Main: MediaGCWorker をバックグラウンドで開始します")
+		mediaGCWorker.Start(workerCtx)
+	}()
+
+	go func() {
+		slog.Info("Main: PollTallyWorker をバックグラウンドで開始します")
+		pollTallyWorker.Start(workerCtx)
+	}()
+
+	go func() {
+		slog.Info("Main: PollCloseWorker をバックグラウンドで開始します")
+		pollCloseWorker.Start(workerCtx)
+	}()
+
+	go func() {
+		slog.Info("Main: LinkPreviewWorker をバックグラウンドで開始します")
+		linkPreviewWorker.Start(workerCtx)
+	}()
+
+	go func() {
+		slog.Info("Main: TrendWorker をバックグラウンドで開始します")
+		trendWorker.Start(workerCtx)
+	}()
+
+	go func() {
+		slog.Info("Main: TrendRefreshWorker をバックグラウンドで開始します")
+		trendRefreshWorker.Start(workerCtx)
+	}()
+
+	go func() {
+		slog.Info("Main: ViewFlushWorker をバックグラウンドで開始します")
+		viewFlushWorker.Start(workerCtx)
+	}()
+
+	go func() {
+		slog.Info("Main: AnalyticsRollupWorker をバックグラウンドで開始します")
+		analyticsRollupWorker.Start(workerCtx)
+	}()
+
+	go func() {
+		slog.Info("Main: StreamService をバックグラウンドで開始します")
+		streamService.Run(workerCtx)
+	}()
+
+	go func() {
+		slog.Info("Main: GatewayService をバックグラウンドで開始します")
+		gatewayService.Run(workerCtx)
+	}()
+
 	srv := &http.Server{
 		Addr:    config.ServerAddress,
 		Handler: router,
 	}
+	// 接続中の SSE が Shutdown の完了を妨げないよう先に閉じる
+	srv.RegisterOnShutdown(streamService.CloseAll)
 
 	go func() {
 		log.Printf("🚀 サーバーが启动し、ポート %s で待機中です", config.ServerAddress)
@@ -150,6 +356,10 @@ func main() {
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		slog.Error("Main: API サーバーの強制終了", "error", err)
 	}
+	// WebSocket はハイジャックされた接続のため Shutdown では待たれない。再接続を促してから閉じる
+	if err := gatewayService.Drain(shutdownCtx); err != nil {
+		slog.Error("Main: WebSocket 接続の切断待ちがタイムアウトしました", "error", err)
+	}
 
 	workerCancel()
 
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AnalyticsService interface {
+	GetAnalytics(ctx context.Context, userID int64, from, to string) (*dto.AnalyticsRecord, error)
+}
+
+type AnalyticsHandler struct {
+	analyticsService AnalyticsService
+}
+
+func NewAnalyticsHandler(svc AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{analyticsService: svc}
+}
+
+// from, to は YYYY-MM-DD(UTC)
+func (h *AnalyticsHandler) Get(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	res, err := h.analyticsService.GetAnalytics(c.Request.Context(), auth.UserID, c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.Success(res.ToAnalyticsResponse()))
+}
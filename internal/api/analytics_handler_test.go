@@ -0,0 +1,80 @@
+package api
+
+import (
+	"aita/internal/contextkeys"
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyticsGet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(ms *mockAnalyticsService)
+		expectedStatus int
+	}{
+		{
+			name:  "正常系: 期間を渡して集計を返す",
+			query: "?from=2026-03-01&to=2026-03-02",
+			setupMock: func(ms *mockAnalyticsService) {
+				ms.On("GetAnalytics", mock.Anything, int64(10), "2026-03-01", "2026-03-02").Return(&dto.AnalyticsRecord{
+					From: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+					To:   time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+					Days: []*dto.DailyStatsRecord{
+						{Day: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), FollowersGained: 2},
+						{Day: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), Impressions: 30},
+					},
+					Totals: dto.AnalyticsTotalsRecord{FollowersGained: 2, Impressions: 30},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "不正な期間",
+			query: "?from=2026-03-05&to=2026-03-01",
+			setupMock: func(ms *mockAnalyticsService) {
+				ms.On("GetAnalytics", mock.Anything, int64(10), "2026-03-05", "2026-03-01").Return(nil, errcode.ErrInvalidDateRange)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockAnalyticsService)
+			tt.setupMock(ms)
+			h := NewAnalyticsHandler(ms)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/me/analytics"+tt.query, nil)
+			c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+			h.Get(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp struct {
+					Data app.AnalyticsResponse `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.Len(t, resp.Data.Days, 2)
+				assert.EqualValues(t, 30, resp.Data.Totals.Impressions)
+				assert.EqualValues(t, 2, resp.Data.Totals.NetFollowers)
+				assert.Equal(t, "2026-03-02", resp.Data.Days[1].Day)
+			}
+			ms.AssertExpectations(t)
+		})
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"aita/internal/contextkeys"
 	"aita/internal/dto"
 	"aita/internal/errcode"
+	"aita/internal/pkg/app"
 	"aita/internal/pkg/utils"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -48,5 +51,50 @@ func GetIDParam(c *gin.Context, name string)(int64, error) {
 	return id, nil
 }
 
+func GetIntQuery(c *gin.Context, name string, defaultVal int) (int, error) {
+	s := c.Query(name)
+	if s == "" {
+		return defaultVal, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < 0 {
+		return 0, errcode.ErrInvalidRequestFormat
+	}
+	return v, nil
+}
+
+// 未指定の場合は 0 を返す
+func GetCursorQuery(c *gin.Context, name string) (int64, error) {
+	s := c.Query(name)
+	if s == "" {
+		return 0, nil
+	}
+	v, err := utils.ParseInt64WithErr(s)
+	if err != nil || v <= 0 {
+		return 0, errcode.ErrInvalidCursor
+	}
+	return v, nil
+}
 
+func toMediaAttachments(media []app.MediaAttachmentRequest) []dto.MediaAttachment {
+	if len(media) == 0 {
+		return nil
+	}
 
+	attachments := make([]dto.MediaAttachment, 0, len(media))
+	for _, m := range media {
+		attachments = append(attachments, dto.MediaAttachment{MediaID: m.MediaID, AltText: m.AltText})
+	}
+	return attachments
+}
+
+func toPollInput(poll *app.PollRequest) *dto.PollInput {
+	if poll == nil {
+		return nil
+	}
+
+	return &dto.PollInput{
+		Options:  poll.Options,
+		Duration: time.Duration(poll.DurationMinutes) * time.Minute,
+	}
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BookmarkService interface {
+	AddBookmark(ctx context.Context, userID, tweetID int64) (*dto.BookmarkRecord, error)
+	RemoveBookmark(ctx context.Context, userID, tweetID int64) error
+	ListBookmarks(ctx context.Context, userID, cursor int64, limit int) ([]*dto.TweetRecord, int64, error)
+}
+
+type BookmarkHandler struct {
+	bookmarkService BookmarkService
+}
+
+func NewBookmarkHandler(svc BookmarkService) *BookmarkHandler {
+	return &BookmarkHandler{bookmarkService: svc}
+}
+
+func (h *BookmarkHandler) Add(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	tweetID, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	bookmark, err := h.bookmarkService.AddBookmark(c.Request.Context(), auth.UserID, tweetID)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, app.Success(bookmark.ToBookmarkResponse()))
+}
+
+func (h *BookmarkHandler) Remove(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	tweetID, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	if err := h.bookmarkService.RemoveBookmark(c.Request.Context(), auth.UserID, tweetID); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.SuccessMsg("ブックマークの削除成功"))
+}
+
+func (h *BookmarkHandler) List(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	cursor, err := GetCursorQuery(c, "cursor")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+	limit, err := GetIntQuery(c, "limit", 0)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	records, nextCursor, err := h.bookmarkService.ListBookmarks(c.Request.Context(), auth.UserID, cursor, limit)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	tweets := make([]*app.TweetResponse, len(records))
+	for i, r := range records {
+		tweets[i] = r.ToTweetResponse()
+	}
+
+	meta := app.CursorMeta{}
+	if nextCursor > 0 {
+		meta.NextCursor = strconv.FormatInt(nextCursor, 10)
+	}
+	c.JSON(http.StatusOK, app.SuccessWithMeta(tweets, meta))
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"aita/internal/contextkeys"
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookmarkList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(ms *mockBookmarkService)
+		expectedStatus int
+		expectedCode   string
+		expectedCursor string
+	}{
+		{
+			name:  "次のカーソル付きで一覧を返す",
+			query: "?cursor=30&limit=2",
+			setupMock: func(ms *mockBookmarkService) {
+				ms.On("ListBookmarks", mock.Anything, int64(10), int64(30), 2).
+					Return([]*dto.TweetRecord{{ID: 5, Content: "a"}, {ID: 4, Content: "b"}}, int64(21), nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedCursor: "21",
+		},
+		{
+			name:  "最後のページではカーソルを返さない",
+			query: "",
+			setupMock: func(ms *mockBookmarkService) {
+				ms.On("ListBookmarks", mock.Anything, int64(10), int64(0), 0).
+					Return([]*dto.TweetRecord{}, int64(0), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "不正なカーソル",
+			query:          "?cursor=abc",
+			setupMock:      func(ms *mockBookmarkService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_CURSOR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockBookmarkService)
+			tt.setupMock(ms)
+			h := NewBookmarkHandler(ms)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/bookmarks"+tt.query, nil)
+			c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+			h.List(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var resp struct {
+				Data []map[string]any `json:"data"`
+				Code string           `json:"code"`
+				Meta app.CursorMeta   `json:"meta"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			if tt.expectedCode != "" {
+				assert.Equal(t, tt.expectedCode, resp.Code)
+			} else {
+				assert.Equal(t, tt.expectedCursor, resp.Meta.NextCursor)
+			}
+			ms.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookmarkAdd(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		setupMock      func(ms *mockBookmarkService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "ブックマーク成功",
+			setupMock: func(ms *mockBookmarkService) {
+				ms.On("AddBookmark", mock.Anything, int64(10), int64(5)).Return(&dto.BookmarkRecord{ID: 1, TweetID: 5}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedCode:   "SUCCESS",
+		},
+		{
+			name: "既にブックマーク済み",
+			setupMock: func(ms *mockBookmarkService) {
+				ms.On("AddBookmark", mock.Anything, int64(10), int64(5)).Return(nil, errcode.ErrAlreadyBookmarked)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "ALREADY_BOOKMARKED",
+		},
+		{
+			name: "削除済みのツイート",
+			setupMock: func(ms *mockBookmarkService) {
+				ms.On("AddBookmark", mock.Anything, int64(10), int64(5)).Return(nil, errcode.ErrTweetNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   "TWEET_NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockBookmarkService)
+			tt.setupMock(ms)
+			h := NewBookmarkHandler(ms)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/tweets/5/bookmark", nil)
+			c.Params = gin.Params{{Key: "id", Value: "5"}}
+			c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+			h.Add(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var resp app.Response
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			ms.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package api
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"aita/internal/pkg/utils"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DMService interface {
+	CreateConversation(ctx context.Context, userID int64, participantIDs []int64) (*dto.DMConversationRecord, error)
+	ListConversations(ctx context.Context, userID int64, limit int) ([]*dto.DMConversationRecord, error)
+	SendMessage(ctx context.Context, userID, conversationID int64, content string) (*dto.DMMessageRecord, error)
+	ListMessages(ctx context.Context, userID, conversationID, cursor int64, limit int) ([]*dto.DMMessageRecord, int64, error)
+	MarkRead(ctx context.Context, userID, conversationID, cursor int64) (int64, error)
+	UpdateDMPolicy(ctx context.Context, userID int64, policy string) error
+}
+
+type DMHandler struct {
+	dmService DMService
+}
+
+func NewDMHandler(svc DMService) *DMHandler {
+	return &DMHandler{dmService: svc}
+}
+
+func (h *DMHandler) CreateConversation(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	var req app.CreateConversationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errcode.FilterBindError(err)
+		c.JSON(errcode.GetStatusCode(appErr), app.Fail(appErr))
+		return
+	}
+
+	conv, err := h.dmService.CreateConversation(c.Request.Context(), auth.UserID, req.ParticipantIDs)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, app.Success(conv.ToDMConversationResponse()))
+}
+
+func (h *DMHandler) ListConversations(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	limit, err := GetIntQuery(c, "limit", 0)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	records, err := h.dmService.ListConversations(c.Request.Context(), auth.UserID, limit)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	conversations := make([]*app.DMConversationResponse, len(records))
+	for i, r := range records {
+		conversations[i] = r.ToDMConversationResponse()
+	}
+
+	c.JSON(http.StatusOK, app.Success(conversations))
+}
+
+func (h *DMHandler) SendMessage(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	conversationID, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(errcode.ErrInvalidConversationID), app.Fail(errcode.ErrInvalidConversationID))
+		return
+	}
+
+	var req app.SendDMRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errcode.FilterBindError(err)
+		c.JSON(errcode.GetStatusCode(appErr), app.Fail(appErr))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	msg, err := h.dmService.SendMessage(c.Request.Context(), auth.UserID, conversationID, req.Content)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, app.Success(msg.ToDMMessageResponse()))
+}
+
+func (h *DMHandler) ListMessages(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	conversationID, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(errcode.ErrInvalidConversationID), app.Fail(errcode.ErrInvalidConversationID))
+		return
+	}
+	cursor, err := GetCursorQuery(c, "cursor")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+	limit, err := GetIntQuery(c, "limit", 0)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	records, nextCursor, err := h.dmService.ListMessages(c.Request.Context(), auth.UserID, conversationID, cursor, limit)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	messages := make([]*app.DMMessageResponse, len(records))
+	for i, r := range records {
+		messages[i] = r.ToDMMessageResponse()
+	}
+
+	meta := app.CursorMeta{}
+	if nextCursor > 0 {
+		meta.NextCursor = strconv.FormatInt(nextCursor, 10)
+	}
+	c.JSON(http.StatusOK, app.SuccessWithMeta(messages, meta))
+}
+
+// ボディを省略した場合は最新のメッセージまで既読にする
+func (h *DMHandler) Read(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	conversationID, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(errcode.ErrInvalidConversationID), app.Fail(errcode.ErrInvalidConversationID))
+		return
+	}
+
+	var req app.MarkDMReadRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		appErr := errcode.FilterBindError(err)
+		c.JSON(errcode.GetStatusCode(appErr), app.Fail(appErr))
+		return
+	}
+
+	var cursor int64
+	if req.Cursor != "" {
+		cursor, err = utils.ParseInt64WithErr(req.Cursor)
+		if err != nil || cursor <= 0 {
+			c.JSON(errcode.GetStatusCode(errcode.ErrInvalidCursor), app.Fail(errcode.ErrInvalidCursor))
+			return
+		}
+	}
+
+	readID, err := h.dmService.MarkRead(c.Request.Context(), auth.UserID, conversationID, cursor)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.Success(app.DMReadResponse{LastReadMessageID: readID}))
+}
+
+func (h *DMHandler) UpdateSettings(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	var req app.DMSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errcode.FilterBindError(err)
+		c.JSON(errcode.GetStatusCode(appErr), app.Fail(appErr))
+		return
+	}
+
+	if err := h.dmService.UpdateDMPolicy(c.Request.Context(), auth.UserID, req.Policy); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.SuccessMsg("DM 設定の更新成功"))
+}
@@ -0,0 +1,234 @@
+package api
+
+import (
+	"aita/internal/contextkeys"
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDMCreateConversation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(ms *mockDMService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "正常系",
+			body: `{"participant_ids":[2,3]}`,
+			setupMock: func(ms *mockDMService) {
+				ms.On("CreateConversation", mock.Anything, int64(10), []int64{2, 3}).Return(&dto.DMConversationRecord{ID: 1, IsGroup: true}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedCode:   "SUCCESS",
+		},
+		{
+			name:           "参加者の指定なし",
+			body:           `{"participant_ids":[]}`,
+			setupMock:      func(ms *mockDMService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_REQUEST_FORMAT",
+		},
+		{
+			name: "DM 設定で拒否されている",
+			body: `{"participant_ids":[2]}`,
+			setupMock: func(ms *mockDMService) {
+				ms.On("CreateConversation", mock.Anything, int64(10), []int64{2}).Return(nil, errcode.ErrDMNotAllowed)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "DM_NOT_ALLOWED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockDMService)
+			tt.setupMock(ms)
+			h := NewDMHandler(ms)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/dm/conversations", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+			h.CreateConversation(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var resp app.Response
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			ms.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDMSendMessage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		setupMock      func(ms *mockDMService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "正常系: 前後の空白を除いて送信する",
+			id:   "5",
+			body: `{"content":"  hello  "}`,
+			setupMock: func(ms *mockDMService) {
+				ms.On("SendMessage", mock.Anything, int64(10), int64(5), "hello").Return(&dto.DMMessageRecord{ID: 1, ConversationID: 5}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedCode:   "SUCCESS",
+		},
+		{
+			name:           "空白のみの本文",
+			id:             "5",
+			body:           `{"content":"   "}`,
+			setupMock:      func(ms *mockDMService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "REQUIRED_FIELD_MISSING",
+		},
+		{
+			name:           "不正な会話ID",
+			id:             "abc",
+			body:           `{"content":"hello"}`,
+			setupMock:      func(ms *mockDMService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_CONVERSATION_ID",
+		},
+		{
+			name: "参加していない会話",
+			id:   "5",
+			body: `{"content":"hello"}`,
+			setupMock: func(ms *mockDMService) {
+				ms.On("SendMessage", mock.Anything, int64(10), int64(5), "hello").Return(nil, errcode.ErrConversationNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   "CONVERSATION_NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockDMService)
+			tt.setupMock(ms)
+			h := NewDMHandler(ms)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/dm/conversations/"+tt.id+"/messages", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = gin.Params{{Key: "id", Value: tt.id}}
+			c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+			h.SendMessage(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var resp app.Response
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			ms.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDMListMessages(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ms := new(mockDMService)
+	ms.On("ListMessages", mock.Anything, int64(10), int64(5), int64(30), 0).Return([]*dto.DMMessageRecord{{ID: 29}}, int64(29), nil)
+	h := NewDMHandler(ms)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/dm/conversations/5/messages?cursor=30", nil)
+	c.Params = gin.Params{{Key: "id", Value: "5"}}
+	c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+	h.ListMessages(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Meta app.CursorMeta `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "29", resp.Meta.NextCursor)
+	ms.AssertExpectations(t)
+}
+
+func TestDMRead(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ms := new(mockDMService)
+	ms.On("MarkRead", mock.Anything, int64(10), int64(5), int64(0)).Return(int64(40), nil)
+	h := NewDMHandler(ms)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/dm/conversations/5/read", nil)
+	c.Params = gin.Params{{Key: "id", Value: "5"}}
+	c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+	h.Read(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data app.DMReadResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, int64(40), resp.Data.LastReadMessageID)
+	ms.AssertExpectations(t)
+}
+
+func TestDMUpdateSettings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(ms *mockDMService)
+		expectedStatus int
+	}{
+		{
+			name: "正常系",
+			body: `{"policy":"following"}`,
+			setupMock: func(ms *mockDMService) {
+				ms.On("UpdateDMPolicy", mock.Anything, int64(10), "following").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "未知の設定",
+			body:           `{"policy":"friends"}`,
+			setupMock:      func(ms *mockDMService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockDMService)
+			tt.setupMock(ms)
+			h := NewDMHandler(ms)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/dm/settings", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+			h.UpdateSettings(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			ms.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DraftService interface {
+	SaveDraft(ctx context.Context, userID int64, content string, media []dto.MediaAttachment) (*dto.DraftRecord, error)
+	FetchDraft(ctx context.Context, draftID, userID int64) (*dto.DraftRecord, error)
+	ListDrafts(ctx context.Context, userID int64) ([]*dto.DraftRecord, error)
+	EditDraft(ctx context.Context, draftID, userID int64, content string, media []dto.MediaAttachment) (*dto.DraftRecord, error)
+	RemoveDraft(ctx context.Context, draftID, userID int64) error
+	PublishDraft(ctx context.Context, draftID, userID int64) (*dto.TweetRecord, error)
+}
+
+type DraftHandler struct {
+	draftService DraftService
+}
+
+func NewDraftHandler(svc DraftService) *DraftHandler {
+	return &DraftHandler{draftService: svc}
+}
+
+func (h *DraftHandler) Create(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	var req app.DraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errcode.FilterBindError(err)
+		c.JSON(errcode.GetStatusCode(appErr), app.Fail(appErr))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	draft, err := h.draftService.SaveDraft(c.Request.Context(), auth.UserID, req.Content, toMediaAttachments(req.Media))
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, app.Success(draft.ToDraftResponse()))
+}
+
+func (h *DraftHandler) List(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	records, err := h.draftService.ListDrafts(c.Request.Context(), auth.UserID)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	drafts := make([]*app.DraftResponse, len(records))
+	for i, r := range records {
+		drafts[i] = r.ToDraftResponse()
+	}
+
+	c.JSON(http.StatusOK, app.Success(drafts))
+}
+
+func (h *DraftHandler) Get(c *gin.Context) {
+	id, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	draft, err := h.draftService.FetchDraft(c.Request.Context(), id, auth.UserID)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.Success(draft.ToDraftResponse()))
+}
+
+func (h *DraftHandler) Update(c *gin.Context) {
+	id, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	var req app.DraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errcode.FilterBindError(err)
+		c.JSON(errcode.GetStatusCode(appErr), app.Fail(appErr))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	draft, err := h.draftService.EditDraft(c.Request.Context(), id, auth.UserID, req.Content, toMediaAttachments(req.Media))
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.Success(draft.ToDraftResponse()))
+}
+
+func (h *DraftHandler) Delete(c *gin.Context) {
+	id, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	if err := h.draftService.RemoveDraft(c.Request.Context(), id, auth.UserID); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.SuccessMsg("下書きの削除成功"))
+}
+
+func (h *DraftHandler) Publish(c *gin.Context) {
+	id, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	tweet, err := h.draftService.PublishDraft(c.Request.Context(), id, auth.UserID)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, app.Success(tweet.ToTweetResponse()))
+}
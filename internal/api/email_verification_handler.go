@@ -0,0 +1,79 @@
+package api
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EmailVerificationService interface {
+	ResendVerification(ctx context.Context, userID int64) error
+	VerifyEmail(ctx context.Context, token string) error
+	CheckAllowed(ctx context.Context, userID int64, action string) error
+}
+
+type EmailVerificationHandler struct {
+	verificationService EmailVerificationService
+}
+
+func NewEmailVerificationHandler(svc EmailVerificationService) *EmailVerificationHandler {
+	return &EmailVerificationHandler{verificationService: svc}
+}
+
+// メール内のリンクから開かれるため、ログインしていなくても確認できる
+func (h *EmailVerificationHandler) Verify(c *gin.Context) {
+	var req app.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errcode.FilterBindError(err)
+		c.JSON(errcode.GetStatusCode(appErr), app.Fail(appErr))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	if err := h.verificationService.VerifyEmail(c.Request.Context(), req.Token); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.SuccessMsg("メールアドレスを確認しました"))
+}
+
+func (h *EmailVerificationHandler) Resend(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	if err := h.verificationService.ResendVerification(c.Request.Context(), auth.UserID); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusAccepted, app.SuccessMsg("確認メールを再送しました"))
+}
+
+// ポリシーで確認が必要とされた操作の前に置く。AuthMiddleware の後で使うこと
+func (h *EmailVerificationHandler) RequireVerified(action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth, err := GetAuthContext(c)
+		if err != nil {
+			c.AbortWithStatusJSON(errcode.GetStatusCode(err), app.Fail(err))
+			return
+		}
+
+		if err := h.verificationService.CheckAllowed(c.Request.Context(), auth.UserID, action); err != nil {
+			c.AbortWithStatusJSON(errcode.GetStatusCode(err), app.Fail(err))
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"aita/internal/contextkeys"
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailVerify(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(ms *mockEmailVerificationService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "正常系: 確認できる",
+			body: `{"token":" abc "}`,
+			setupMock: func(ms *mockEmailVerificationService) {
+				ms.On("VerifyEmail", mock.Anything, "abc").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedCode:   "SUCCESS",
+		},
+		{
+			name: "無効なトークン",
+			body: `{"token":"abc"}`,
+			setupMock: func(ms *mockEmailVerificationService) {
+				ms.On("VerifyEmail", mock.Anything, "abc").Return(errcode.ErrInvalidVerificationToken)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_VERIFICATION_TOKEN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockEmailVerificationService)
+			tt.setupMock(ms)
+			h := NewEmailVerificationHandler(ms)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/email/verify", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			h.Verify(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var resp app.Response
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			ms.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEmailResend(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ms := new(mockEmailVerificationService)
+	ms.On("ResendVerification", mock.Anything, int64(10)).Return(errcode.ErrTooManyRequests)
+	h := NewEmailVerificationHandler(ms)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/email/verify/resend", nil)
+	c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+	h.Resend(c)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	ms.AssertExpectations(t)
+}
+
+func TestRequireVerified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		checkErr       error
+		expectedStatus int
+	}{
+		{name: "確認済みなら次のハンドラーに進む", expectedStatus: http.StatusNoContent},
+		{name: "未確認なら403で止める", checkErr: errcode.ErrEmailNotVerified, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockEmailVerificationService)
+			ms.On("CheckAllowed", mock.Anything, int64(10), dto.VerifiedActionTweet).Return(tt.checkErr)
+			h := NewEmailVerificationHandler(ms)
+
+			w := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(w)
+			r.POST("/tweets", func(c *gin.Context) {
+				c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+			}, h.RequireVerified(dto.VerifiedActionTweet), func(c *gin.Context) {
+				c.Status(http.StatusNoContent)
+			})
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/tweets", nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			ms.AssertExpectations(t)
+		})
+	}
+}
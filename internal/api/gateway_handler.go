@@ -0,0 +1,184 @@
+package api
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	gatewayWriteWait       = 10 * time.Second
+	gatewayMaxMessageBytes = 4096
+)
+
+type GatewayService interface {
+	Connect(ctx context.Context, userID int64) (*dto.GatewaySession, error)
+	Subscribe(ctx context.Context, session *dto.GatewaySession, channel string) error
+	Unsubscribe(ctx context.Context, session *dto.GatewaySession, channel string) error
+	Typing(ctx context.Context, session *dto.GatewaySession, channel string) error
+	Disconnect(session *dto.GatewaySession)
+}
+
+type GatewayHandler struct {
+	gatewayService GatewayService
+	pingInterval   time.Duration
+	allowedOrigins map[string]struct{}
+}
+
+// allowedOrigins は同一オリジン以外に接続を許可するオリジン (例: https://app.example.com)
+func NewGatewayHandler(svc GatewayService, pingInterval time.Duration, allowedOrigins []string) *GatewayHandler {
+	origins := make(map[string]struct{}, len(allowedOrigins))
+	for _, o := range allowedOrigins {
+		if o = strings.TrimRight(strings.TrimSpace(o), "/"); o != "" {
+			origins[strings.ToLower(o)] = struct{}{}
+		}
+	}
+	return &GatewayHandler{
+		gatewayService: svc,
+		pingInterval:   pingInterval,
+		allowedOrigins: origins,
+	}
+}
+
+// 他サイトのページから接続させないよう、ブラウザが付ける Origin を検査する。
+// Origin を付けないブラウザ以外のクライアントはそのまま通す
+func (h *GatewayHandler) checkOrigin(config *websocket.Config, req *http.Request) error {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return errcode.ErrForbidden
+	}
+	if strings.EqualFold(u.Host, req.Host) {
+		return nil
+	}
+	if _, ok := h.allowedOrigins[strings.ToLower(u.Scheme+"://"+u.Host)]; ok {
+		return nil
+	}
+	return errcode.ErrForbidden
+}
+
+// WebSocket に切り替え、subscribe / unsubscribe / typing を受け付ける。
+// サーバーからは購読中のチャンネルのイベントと定期的な ping を送る
+func (h *GatewayHandler) Serve(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	session, err := h.gatewayService.Connect(c.Request.Context(), auth.UserID)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+	defer h.gatewayService.Disconnect(session)
+
+	server := websocket.Server{
+		Handshake: h.checkOrigin,
+		Handler: func(ws *websocket.Conn) {
+			h.serveConn(c.Request.Context(), ws, session)
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+func (h *GatewayHandler) serveConn(ctx context.Context, ws *websocket.Conn, session *dto.GatewaySession) {
+	defer ws.Close()
+	ws.MaxPayloadBytes = gatewayMaxMessageBytes
+
+	var writeMu sync.Mutex
+	write := func(msg *app.GatewayMessage) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		// 書き込みが詰まるクライアントはここで切断される
+		_ = ws.SetWriteDeadline(time.Now().Add(gatewayWriteWait))
+		return websocket.JSON.Send(ws, msg)
+	}
+
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+		for {
+			// ping への応答も含め、一定時間何も届かなければ切断する
+			_ = ws.SetReadDeadline(time.Now().Add(2 * h.pingInterval))
+			var req app.GatewayRequest
+			if err := websocket.JSON.Receive(ws, &req); err != nil {
+				var syntaxErr *json.SyntaxError
+				var typeErr *json.UnmarshalTypeError
+				if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+					if write(app.GatewayFail("", errcode.ErrInvalidJSON)) != nil {
+						return
+					}
+					continue
+				}
+				return
+			}
+
+			if reply := h.handleRequest(ctx, session, &req); reply != nil {
+				if write(reply) != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	ping := time.NewTicker(h.pingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-readDone:
+			return
+		case event, ok := <-session.Events:
+			if !ok {
+				return
+			}
+			if write(event.ToGatewayMessage()) != nil {
+				return
+			}
+		case <-ping.C:
+			if write(&app.GatewayMessage{Type: "ping"}) != nil {
+				return
+			}
+		}
+	}
+}
+
+func (h *GatewayHandler) handleRequest(ctx context.Context, session *dto.GatewaySession, req *app.GatewayRequest) *app.GatewayMessage {
+	var err error
+	switch req.Type {
+	case "subscribe":
+		if err = h.gatewayService.Subscribe(ctx, session, req.Channel); err == nil {
+			return &app.GatewayMessage{Type: "subscribed", Channel: req.Channel}
+		}
+	case "unsubscribe":
+		if err = h.gatewayService.Unsubscribe(ctx, session, req.Channel); err == nil {
+			return &app.GatewayMessage{Type: "unsubscribed", Channel: req.Channel}
+		}
+	case "typing":
+		err = h.gatewayService.Typing(ctx, session, req.Channel)
+	case "pong":
+	default:
+		err = errcode.ErrInvalidRequestFormat
+	}
+
+	if err != nil {
+		return app.GatewayFail(req.Channel, err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"aita/internal/contextkeys"
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+func newGatewayTestServer(ms *mockGatewayService) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/ws", func(c *gin.Context) {
+		c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+	}, NewGatewayHandler(ms, time.Minute, []string{"https://app.example.com"}).Serve)
+	return httptest.NewServer(r)
+}
+
+func TestGatewayServe(t *testing.T) {
+	events := make(chan *dto.GatewayEventRecord, 1)
+	session := &dto.GatewaySession{ID: 1, UserID: 10, Events: events}
+	disconnected := make(chan struct{})
+
+	ms := new(mockGatewayService)
+	ms.On("Connect", mock.Anything, int64(10)).Return(session, nil)
+	ms.On("Subscribe", mock.Anything, session, "tweet:5").Return(nil)
+	ms.On("Subscribe", mock.Anything, session, "dm:1").Return(errcode.ErrInvalidChannel)
+	ms.On("Disconnect", session).Run(func(mock.Arguments) { close(disconnected) }).Return()
+
+	srv := newGatewayTestServer(ms)
+	defer srv.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+	ws, err := websocket.Dial(wsURL, "", srv.URL)
+	require.NoError(t, err)
+	defer ws.Close()
+	_ = ws.SetDeadline(time.Now().Add(2 * time.Second))
+
+	receive := func() *app.GatewayMessage {
+		msg := &app.GatewayMessage{}
+		require.NoError(t, websocket.JSON.Receive(ws, msg))
+		return msg
+	}
+
+	require.NoError(t, websocket.JSON.Send(ws, app.GatewayRequest{Type: "subscribe", Channel: "tweet:5"}))
+	assert.Equal(t, &app.GatewayMessage{Type: "subscribed", Channel: "tweet:5"}, receive())
+
+	require.NoError(t, websocket.JSON.Send(ws, app.GatewayRequest{Type: "subscribe", Channel: "dm:1"}))
+	failed := receive()
+	assert.Equal(t, "error", failed.Type)
+	assert.Equal(t, "INVALID_CHANNEL", failed.Code)
+
+	require.NoError(t, websocket.Message.Send(ws, "{壊れたJSON"))
+	assert.Equal(t, "INVALID_JSON_FORMAT", receive().Code)
+
+	events <- &dto.GatewayEventRecord{Channel: "tweet:5", Type: "typing", ActorID: 3}
+	assert.Equal(t, &app.GatewayMessage{Type: "typing", Channel: "tweet:5", ActorID: 3}, receive())
+
+	// サーバー側から閉じると接続も切れ、Disconnect が呼ばれる
+	close(events)
+	select {
+	case <-disconnected:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Disconnect が呼ばれません")
+	}
+	ms.AssertExpectations(t)
+}
+
+func TestGatewayServeDraining(t *testing.T) {
+	ms := new(mockGatewayService)
+	ms.On("Connect", mock.Anything, int64(10)).Return(nil, errcode.ErrServerDraining)
+
+	srv := newGatewayTestServer(ms)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/ws")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func TestGatewayServeOrigin(t *testing.T) {
+	tests := []struct {
+		name    string
+		origin  string
+		wantErr bool
+	}{
+		{name: "【成功】許可リストのオリジン", origin: "https://app.example.com"},
+		{name: "【失敗】許可されていない他サイトのオリジン", origin: "https://evil.example.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &dto.GatewaySession{ID: 1, UserID: 10, Events: make(chan *dto.GatewayEventRecord)}
+			disconnected := make(chan struct{})
+
+			ms := new(mockGatewayService)
+			ms.On("Connect", mock.Anything, int64(10)).Return(session, nil)
+			ms.On("Disconnect", session).Run(func(mock.Arguments) { close(disconnected) }).Return()
+
+			srv := newGatewayTestServer(ms)
+			defer srv.Close()
+
+			wsURL := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+			ws, err := websocket.Dial(wsURL, "", tt.origin)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				ws.Close()
+			}
+
+			select {
+			case <-disconnected:
+			case <-time.After(2 * time.Second):
+				t.Fatal("Disconnect が呼ばれません")
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package api
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ListService interface {
+	CreateList(ctx context.Context, ownerID int64, name, description string, isPrivate bool) (*dto.ListRecord, error)
+	GetList(ctx context.Context, viewerID, listID int64) (*dto.ListRecord, error)
+	ListOwnedLists(ctx context.Context, ownerID int64) ([]*dto.ListRecord, error)
+	DeleteList(ctx context.Context, ownerID, listID int64) error
+	AddMember(ctx context.Context, ownerID, listID, userID int64) error
+	RemoveMember(ctx context.Context, ownerID, listID, userID int64) error
+	ListMembers(ctx context.Context, viewerID, listID int64) ([]*dto.UserSlimRecord, error)
+	GetTimeline(ctx context.Context, viewerID, listID, cursor int64, limit int) ([]*dto.TweetRecord, int64, error)
+}
+
+type ListHandler struct {
+	listService ListService
+}
+
+func NewListHandler(svc ListService) *ListHandler {
+	return &ListHandler{listService: svc}
+}
+
+func (h *ListHandler) Create(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	var req app.CreateListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errcode.FilterBindError(err)
+		c.JSON(errcode.GetStatusCode(appErr), app.Fail(appErr))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	list, err := h.listService.CreateList(c.Request.Context(), auth.UserID, req.Name, req.Description, req.IsPrivate)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, app.Success(list.ToListResponse()))
+}
+
+func (h *ListHandler) Mine(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	records, err := h.listService.ListOwnedLists(c.Request.Context(), auth.UserID)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	lists := make([]*app.ListResponse, len(records))
+	for i, r := range records {
+		lists[i] = r.ToListResponse()
+	}
+
+	c.JSON(http.StatusOK, app.Success(lists))
+}
+
+func (h *ListHandler) Get(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	listID, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(errcode.ErrInvalidListID), app.Fail(errcode.ErrInvalidListID))
+		return
+	}
+
+	list, err := h.listService.GetList(c.Request.Context(), auth.UserID, listID)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.Success(list.ToListResponse()))
+}
+
+func (h *ListHandler) Delete(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	listID, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(errcode.ErrInvalidListID), app.Fail(errcode.ErrInvalidListID))
+		return
+	}
+
+	if err := h.listService.DeleteList(c.Request.Context(), auth.UserID, listID); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.SuccessMsg("リストの削除成功"))
+}
+
+func (h *ListHandler) AddMember(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	listID, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(errcode.ErrInvalidListID), app.Fail(errcode.ErrInvalidListID))
+		return
+	}
+
+	var req app.AddListMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errcode.FilterBindError(err)
+		c.JSON(errcode.GetStatusCode(appErr), app.Fail(appErr))
+		return
+	}
+
+	if err := h.listService.AddMember(c.Request.Context(), auth.UserID, listID, req.UserID); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.SuccessMsg("リストメンバーの追加成功"))
+}
+
+func (h *ListHandler) RemoveMember(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	listID, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(errcode.ErrInvalidListID), app.Fail(errcode.ErrInvalidListID))
+		return
+	}
+	userID, err := GetIDParam(c, "user_id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(errcode.ErrInvalidUserID), app.Fail(errcode.ErrInvalidUserID))
+		return
+	}
+
+	if err := h.listService.RemoveMember(c.Request.Context(), auth.UserID, listID, userID); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.SuccessMsg("リストメンバーの削除成功"))
+}
+
+func (h *ListHandler) Members(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	listID, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(errcode.ErrInvalidListID), app.Fail(errcode.ErrInvalidListID))
+		return
+	}
+
+	records, err := h.listService.ListMembers(c.Request.Context(), auth.UserID, listID)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	members := make([]app.ListMemberResponse, len(records))
+	for i, r := range records {
+		members[i] = app.ListMemberResponse{ID: r.ID, Username: r.Username}
+	}
+
+	c.JSON(http.StatusOK, app.Success(members))
+}
+
+func (h *ListHandler) Timeline(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	listID, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(errcode.ErrInvalidListID), app.Fail(errcode.ErrInvalidListID))
+		return
+	}
+	cursor, err := GetCursorQuery(c, "cursor")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+	limit, err := GetIntQuery(c, "limit", 0)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	records, nextCursor, err := h.listService.GetTimeline(c.Request.Context(), auth.UserID, listID, cursor, limit)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	tweets := make([]*app.TweetResponse, len(records))
+	for i, r := range records {
+		tweets[i] = r.ToTweetResponse()
+	}
+
+	meta := app.CursorMeta{}
+	if nextCursor > 0 {
+		meta.NextCursor = strconv.FormatInt(nextCursor, 10)
+	}
+	c.JSON(http.StatusOK, app.SuccessWithMeta(tweets, meta))
+}
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"aita/internal/contextkeys"
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCreate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(ms *mockListService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "正常系: 前後の空白を除いて作成する",
+			body: `{"name":"  golang  ","description":"Go の話題","is_private":true}`,
+			setupMock: func(ms *mockListService) {
+				ms.On("CreateList", mock.Anything, int64(10), "golang", "Go の話題", true).
+					Return(&dto.ListRecord{ID: 1, OwnerID: 10, Name: "golang", IsPrivate: true}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedCode:   "SUCCESS",
+		},
+		{
+			name:           "空白のみの名前",
+			body:           `{"name":"   "}`,
+			setupMock:      func(ms *mockListService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "REQUIRED_FIELD_MISSING",
+		},
+		{
+			name:           "名前が長すぎる",
+			body:           `{"name":"` + strings.Repeat("あ", 26) + `"}`,
+			setupMock:      func(ms *mockListService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockListService)
+			tt.setupMock(ms)
+			h := NewListHandler(ms)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/lists", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+			h.Create(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedCode != "" {
+				var resp app.Response
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, tt.expectedCode, resp.Code)
+			}
+			ms.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListAddMember(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		setupMock      func(ms *mockListService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "正常系",
+			id:   "3",
+			body: `{"user_id":2}`,
+			setupMock: func(ms *mockListService) {
+				ms.On("AddMember", mock.Anything, int64(10), int64(3), int64(2)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedCode:   "SUCCESS",
+		},
+		{
+			name:           "不正なリストID",
+			id:             "abc",
+			body:           `{"user_id":2}`,
+			setupMock:      func(ms *mockListService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_LIST_ID",
+		},
+		{
+			name: "既にメンバー",
+			id:   "3",
+			body: `{"user_id":2}`,
+			setupMock: func(ms *mockListService) {
+				ms.On("AddMember", mock.Anything, int64(10), int64(3), int64(2)).Return(errcode.ErrAlreadyListMember)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "ALREADY_LIST_MEMBER",
+		},
+		{
+			name: "他人のリスト",
+			id:   "3",
+			body: `{"user_id":2}`,
+			setupMock: func(ms *mockListService) {
+				ms.On("AddMember", mock.Anything, int64(10), int64(3), int64(2)).Return(errcode.ErrForbidden)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockListService)
+			tt.setupMock(ms)
+			h := NewListHandler(ms)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/lists/"+tt.id+"/members", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = gin.Params{{Key: "id", Value: tt.id}}
+			c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+			h.AddMember(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedCode != "" {
+				var resp app.Response
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, tt.expectedCode, resp.Code)
+			}
+			ms.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListTimelineHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("正常系: 次のカーソルを meta に含める", func(t *testing.T) {
+		ms := new(mockListService)
+		ms.On("GetTimeline", mock.Anything, int64(10), int64(3), int64(100), 2).
+			Return([]*dto.TweetRecord{{ID: 99}, {ID: 98}}, int64(98), nil)
+		h := NewListHandler(ms)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/lists/3/timeline?cursor=100&limit=2", nil)
+		c.Params = gin.Params{{Key: "id", Value: "3"}}
+		c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+		h.Timeline(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			Meta app.CursorMeta `json:"meta"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "98", resp.Meta.NextCursor)
+		ms.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 非公開リスト", func(t *testing.T) {
+		ms := new(mockListService)
+		ms.On("GetTimeline", mock.Anything, int64(10), int64(3), int64(0), 0).Return(nil, int64(0), errcode.ErrListNotFound)
+		h := NewListHandler(ms)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/lists/3/timeline", nil)
+		c.Params = gin.Params{{Key: "id", Value: "3"}}
+		c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+		h.Timeline(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipart のヘッダーや境界文字列の分だけ本体サイズの上限に余裕を持たせる
+const multipartOverhead = 1 << 20
+
+type MediaService interface {
+	Upload(ctx context.Context, userID int64, r io.Reader) (*dto.MediaRecord, error)
+}
+
+type MediaHandler struct {
+	mediaService MediaService
+	maxBytes     int64
+}
+
+func NewMediaHandler(svc MediaService, maxBytes int64) *MediaHandler {
+	return &MediaHandler{
+		mediaService: svc,
+		maxBytes:     maxBytes,
+	}
+}
+
+func (h *MediaHandler) Upload(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBytes+multipartOverhead)
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(errcode.GetStatusCode(errcode.ErrMediaTooLarge), app.Fail(errcode.ErrMediaTooLarge))
+			return
+		}
+		c.JSON(errcode.GetStatusCode(errcode.ErrInvalidRequestFormat), app.Fail(errcode.ErrInvalidRequestFormat))
+		return
+	}
+	defer file.Close()
+
+	media, err := h.mediaService.Upload(c.Request.Context(), auth.UserID, file)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, app.Success(media.ToMediaResponse()))
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"aita/internal/contextkeys"
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newMultipartBody(t *testing.T, field string, data []byte) (*bytes.Buffer, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreateFormFile(field, "upload.png")
+	require.NoError(t, err)
+	_, err = part.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return &buf, w.FormDataContentType()
+}
+
+func TestMediaUpload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const maxBytes = 1024
+	tests := []struct {
+		name           string
+		field          string
+		data           []byte
+		setupMock      func(ms *mockMediaService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:  "アップロード成功",
+			field: "file",
+			data:  []byte("\x89PNG\r\n\x1a\n"),
+			setupMock: func(ms *mockMediaService) {
+				ms.On("Upload", mock.Anything, int64(10), mock.Anything).
+					Return(&dto.MediaRecord{ID: 7, URL: "/media/10/a.png", ThumbnailURL: "/media/10/a_thumb.png", ContentType: "image/png", Width: 1, Height: 1}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "fileフィールドがない",
+			field:          "image",
+			data:           []byte("data"),
+			setupMock:      func(ms *mockMediaService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_REQUEST_FORMAT",
+		},
+		{
+			name:           "リクエスト本体が上限を超える",
+			field:          "file",
+			data:           make([]byte, maxBytes+multipartOverhead+1),
+			setupMock:      func(ms *mockMediaService) {},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedCode:   "MEDIA_TOO_LARGE",
+		},
+		{
+			name:  "対応していない形式",
+			field: "file",
+			data:  []byte("plain text"),
+			setupMock: func(ms *mockMediaService) {
+				ms.On("Upload", mock.Anything, int64(10), mock.Anything).Return(nil, errcode.ErrUnsupportedMediaType)
+			},
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedCode:   "UNSUPPORTED_MEDIA_TYPE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockMediaService)
+			tt.setupMock(ms)
+			h := NewMediaHandler(ms, maxBytes)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			body, contentType := newMultipartBody(t, tt.field, tt.data)
+			c.Request = httptest.NewRequest(http.MethodPost, "/media", body)
+			c.Request.Header.Set("Content-Type", contentType)
+			c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+			h.Upload(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var resp app.Response
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			if tt.expectedCode != "" {
+				assert.Equal(t, tt.expectedCode, resp.Code)
+			} else {
+				data := resp.Data.(map[string]any)
+				assert.EqualValues(t, 7, data["id"])
+				assert.Equal(t, "/media/10/a_thumb.png", data["thumbnail_url"])
+			}
+			ms.AssertExpectations(t)
+		})
+	}
+}
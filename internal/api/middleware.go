@@ -6,15 +6,12 @@ import (
 	"aita/internal/errcode"
 	"aita/internal/pkg/app"
 	"context"
-	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type AuthSessionService interface {
 	Validate(ctx context.Context, token string) (*dto.AuthRecord, error)
-	ShouldRefresh(expiresAt, createdAt time.Time) (bool, error)
-	RefreshAsync(token string)
 }
 
 func AuthMiddleware(svc AuthSessionService) gin.HandlerFunc {
@@ -26,21 +23,53 @@ func AuthMiddleware(svc AuthSessionService) gin.HandlerFunc {
 			return
 		}
 
-		response, err := svc.Validate(c.Request.Context(), token)
-		if err != nil {
-			c.AbortWithStatusJSON(errcode.GetStatusCode(err), app.Fail(err))
+		authenticate(c, svc, token)
+	}
+}
+
+type WebSocketAuthService interface {
+	AuthSessionService
+	RedeemGatewayTicket(ctx context.Context, ticket string) (*dto.AuthRecord, error)
+}
+
+// ブラウザの WebSocket API はヘッダーを付けられないため、Authorization がなければ ticket クエリを使う。
+// URL はアクセスログに残るので、アクセストークンではなく事前に発行した使い捨てのチケットだけを受け付ける
+func WebSocketAuthMiddleware(svc WebSocketAuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token, err := extractBearerToken(c.GetHeader("Authorization")); err == nil {
+			authenticate(c, svc, token)
 			return
 		}
 
-		if should, _ := svc.ShouldRefresh(response.ExpiresAt, response.CreatedAt); should {
-			svc.RefreshAsync(token)
+		ticket := c.Query("ticket")
+		if ticket == "" {
+			c.AbortWithStatusJSON(errcode.GetStatusCode(errcode.ErrSessionNotFound), app.Fail(errcode.ErrSessionNotFound))
+			return
 		}
 
-		c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{
-			UserID: response.UserID,
-			Token:  response.Token,
-		})
+		response, err := svc.RedeemGatewayTicket(c.Request.Context(), ticket)
+		if err != nil {
+			c.AbortWithStatusJSON(errcode.GetStatusCode(err), app.Fail(err))
+			return
+		}
+		setAuthContext(c, response)
+	}
+}
 
-		c.Next()
+func authenticate(c *gin.Context, svc AuthSessionService, token string) {
+	response, err := svc.Validate(c.Request.Context(), token)
+	if err != nil {
+		c.AbortWithStatusJSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
 	}
+	setAuthContext(c, response)
+}
+
+func setAuthContext(c *gin.Context, response *dto.AuthRecord) {
+	c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{
+		UserID: response.UserID,
+		Token:  response.Token,
+	})
+
+	c.Next()
 }
@@ -40,33 +40,20 @@ func TestAuthMiddleware(t *testing.T) {
 		expectedAuth   *dto.AuthContext
 	}{
 		{
-			name:       "【成功】有効なBearerトークン&通常認証（リフレッシュ不要）",
+			name:       "【成功】有効なBearerトークン",
 			authHeader: "Bearer valid_token",
 			setupMock: func(m *mockSessionService) {
 				m.On("Validate", mock.Anything, "valid_token").Return(validRespOne, nil)
-				m.On("ShouldRefresh", validRespOne.ExpiresAt, validRespOne.CreatedAt).Return(false, nil)
 
 			},
 			expectedStatus: http.StatusOK,
 			expectedAuth:   AuthOne,
 		},
 		{
-			name:       "【成功】大文字のBEARERでも認識される&リフレッシュが必要な場合（非同期呼び出しを確認）",
+			name:       "【成功】大文字のBEARERでも認識される",
 			authHeader: "BEARER refresh_token",
 			setupMock: func(m *mockSessionService) {
 				m.On("Validate", mock.Anything, "refresh_token").Return(validRespTwo, nil)
-				m.On("ShouldRefresh", validRespTwo.ExpiresAt, validRespTwo.CreatedAt).Return(true, nil)
-				m.On("RefreshAsync", "refresh_token").Return()
-			},
-			expectedStatus: http.StatusOK,
-			expectedAuth:   AuthTwo,
-		},
-		{
-			name:       "【境界値】認証した後、セッションがなくなってしまった",
-			authHeader: "BEARER refresh_token",
-			setupMock: func(m *mockSessionService) {
-				m.On("Validate", mock.Anything, "refresh_token").Return(validRespTwo, nil)
-				m.On("ShouldRefresh", validRespTwo.ExpiresAt, validRespTwo.CreatedAt).Return(false, errcode.ErrSessionNotFound)
 			},
 			expectedStatus: http.StatusOK,
 			expectedAuth:   AuthTwo,
@@ -161,3 +148,76 @@ func TestAuthMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestWebSocketAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	now := time.Now()
+	valid := &dto.AuthRecord{UserID: 123, Token: "ws_token", ExpiresAt: now.Add(time.Hour), CreatedAt: now}
+
+	tests := []struct {
+		name           string
+		authHeader     string
+		query          string
+		setupMock      func(m *mockSessionService)
+		expectedStatus int
+	}{
+		{
+			name:       "【成功】Authorization ヘッダーを優先する",
+			authHeader: "Bearer ws_token",
+			query:      "?ticket=other",
+			setupMock: func(m *mockSessionService) {
+				m.On("Validate", mock.Anything, "ws_token").Return(valid, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "【成功】ヘッダーがなければ ticket クエリのチケットを使う",
+			query: "?ticket=ws_ticket",
+			setupMock: func(m *mockSessionService) {
+				m.On("RedeemGatewayTicket", mock.Anything, "ws_ticket").Return(&dto.AuthRecord{UserID: 123}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "【失敗】使用済みのチケット",
+			query: "?ticket=used_ticket",
+			setupMock: func(m *mockSessionService) {
+				m.On("RedeemGatewayTicket", mock.Anything, "used_ticket").Return(nil, errcode.ErrInvalidGatewayTicket)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "【失敗】アクセストークンはクエリで受け付けない (Serviceは呼ばれない)",
+			query:          "?access_token=ws_token",
+			setupMock:      func(m *mockSessionService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "【失败】トークンがない (Serviceは呼ばれない)",
+			setupMock:      func(m *mockSessionService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockSessionService)
+			tt.setupMock(ms)
+
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.GET("/ws", WebSocketAuthMiddleware(ms), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/ws"+tt.query, nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			ms.AssertExpectations(t)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"aita/internal/dto"
 	"aita/internal/pkg/testutils"
 	"context"
+	"io"
 	"time"
 
 	"github.com/stretchr/testify/mock"
@@ -36,8 +37,8 @@ func (m *mockUserService) ToMyAccount(ctx context.Context, userID int64) (*dto.U
 	return testutils.SafeGet[dto.UserRecord](args, 0), args.Error(1)
 }
 
-func (m *mockSessionService) Issue(ctx context.Context, userID int64) (*dto.AuthRecord, error) {
-	args := m.Called(ctx, userID)
+func (m *mockSessionService) Issue(ctx context.Context, userID int64, client dto.ClientInfo) (*dto.AuthRecord, error) {
+	args := m.Called(ctx, userID, client)
 	return testutils.SafeGet[dto.AuthRecord](args, 0), args.Error(1)
 }
 
@@ -51,17 +52,13 @@ func (m *mockSessionService) Validate(ctx context.Context, token string) (*dto.A
 	return testutils.SafeGet[dto.AuthRecord](args, 0), args.Error(1)
 }
 
-func (m *mockSessionService) ShouldRefresh(expiresAt, createdAt time.Time) (bool, error) {
-	args := m.Called(expiresAt, createdAt)
-	return args.Bool(0), args.Error(1)
-}
-
-func (m *mockSessionService) RefreshAsync(token string) {
-	_ = m.Called(token)
+func (m *mockSessionService) RedeemGatewayTicket(ctx context.Context, ticket string) (*dto.AuthRecord, error) {
+	args := m.Called(ctx, ticket)
+	return testutils.SafeGet[dto.AuthRecord](args, 0), args.Error(1)
 }
 
-func (m *mockTweetService) PostTweet(ctx context.Context, userID int64, content string, imageURL *string) (*dto.TweetRecord, error) {
-	args := m.Called(ctx, userID, content, imageURL)
+func (m *mockTweetService) PostTweet(ctx context.Context, userID int64, content string, media []dto.MediaAttachment, poll *dto.PollInput) (*dto.TweetRecord, error) {
+	args := m.Called(ctx, userID, content, media, poll)
 	return testutils.SafeGet[dto.TweetRecord](args, 0), args.Error(1)
 }
 
@@ -79,3 +76,346 @@ func (m *mockTweetService) RemoveTweet(ctx context.Context, tweetID int64, userI
 	args := m.Called(ctx, tweetID, userID)
 	return args.Error(0)
 }
+
+func (m *mockTweetService) RestoreTweet(ctx context.Context, tweetID int64, userID int64) (*dto.TweetRecord, error) {
+	args := m.Called(ctx, tweetID, userID)
+	return testutils.SafeGet[dto.TweetRecord](args, 0), args.Error(1)
+}
+
+func (m *mockTweetService) FetchHistory(ctx context.Context, tweetID int64) ([]*dto.TweetRevisionRecord, error) {
+	args := m.Called(ctx, tweetID)
+	return testutils.SafeGetSlice[*dto.TweetRevisionRecord](args, 0), args.Error(1)
+}
+
+type mockScheduledTweetService struct {
+	mock.Mock
+}
+
+func (m *mockScheduledTweetService) ScheduleTweet(ctx context.Context, userID int64, content string, media []dto.MediaAttachment, publishAt time.Time) (*dto.ScheduledTweetRecord, error) {
+	args := m.Called(ctx, userID, content, media, publishAt)
+	return testutils.SafeGet[dto.ScheduledTweetRecord](args, 0), args.Error(1)
+}
+
+func (m *mockScheduledTweetService) ListScheduled(ctx context.Context, userID int64) ([]*dto.ScheduledTweetRecord, error) {
+	args := m.Called(ctx, userID)
+	return testutils.SafeGetSlice[*dto.ScheduledTweetRecord](args, 0), args.Error(1)
+}
+
+func (m *mockScheduledTweetService) CancelScheduled(ctx context.Context, scheduledID, userID int64) error {
+	args := m.Called(ctx, scheduledID, userID)
+	return args.Error(0)
+}
+
+type mockMediaService struct {
+	mock.Mock
+}
+
+func (m *mockMediaService) Upload(ctx context.Context, userID int64, r io.Reader) (*dto.MediaRecord, error) {
+	args := m.Called(ctx, userID, r)
+	return testutils.SafeGet[dto.MediaRecord](args, 0), args.Error(1)
+}
+
+type mockPollService struct {
+	mock.Mock
+}
+
+func (m *mockPollService) Vote(ctx context.Context, tweetID, userID, optionID int64) (*dto.PollRecord, error) {
+	args := m.Called(ctx, tweetID, userID, optionID)
+	return testutils.SafeGet[dto.PollRecord](args, 0), args.Error(1)
+}
+
+func (m *mockPollService) GetPoll(ctx context.Context, tweetID, viewerID int64) (*dto.PollRecord, error) {
+	args := m.Called(ctx, tweetID, viewerID)
+	return testutils.SafeGet[dto.PollRecord](args, 0), args.Error(1)
+}
+
+type mockBookmarkService struct {
+	mock.Mock
+}
+
+func (m *mockBookmarkService) AddBookmark(ctx context.Context, userID, tweetID int64) (*dto.BookmarkRecord, error) {
+	args := m.Called(ctx, userID, tweetID)
+	return testutils.SafeGet[dto.BookmarkRecord](args, 0), args.Error(1)
+}
+
+func (m *mockBookmarkService) RemoveBookmark(ctx context.Context, userID, tweetID int64) error {
+	args := m.Called(ctx, userID, tweetID)
+	return args.Error(0)
+}
+
+func (m *mockBookmarkService) ListBookmarks(ctx context.Context, userID, cursor int64, limit int) ([]*dto.TweetRecord, int64, error) {
+	args := m.Called(ctx, userID, cursor, limit)
+	return testutils.SafeGetSlice[*dto.TweetRecord](args, 0), args.Get(1).(int64), args.Error(2)
+}
+
+type mockProfileService struct {
+	mock.Mock
+}
+
+func (m *mockProfileService) PinTweet(ctx context.Context, userID, tweetID int64) error {
+	args := m.Called(ctx, userID, tweetID)
+	return args.Error(0)
+}
+
+func (m *mockProfileService) UnpinTweet(ctx context.Context, userID, tweetID int64) error {
+	args := m.Called(ctx, userID, tweetID)
+	return args.Error(0)
+}
+
+func (m *mockProfileService) GetProfileTweets(ctx context.Context, userID int64, page, size int) ([]*dto.TweetRecord, error) {
+	args := m.Called(ctx, userID, page, size)
+	return testutils.SafeGetSlice[*dto.TweetRecord](args, 0), args.Error(1)
+}
+
+type mockNotificationService struct {
+	mock.Mock
+}
+
+func (m *mockNotificationService) ListNotifications(ctx context.Context, userID, cursor int64, limit int) ([]*dto.NotificationGroupRecord, int64, error) {
+	args := m.Called(ctx, userID, cursor, limit)
+	return testutils.SafeGetSlice[*dto.NotificationGroupRecord](args, 0), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *mockNotificationService) MarkRead(ctx context.Context, userID, cursor int64) error {
+	args := m.Called(ctx, userID, cursor)
+	return args.Error(0)
+}
+
+func (m *mockNotificationService) UnreadCount(ctx context.Context, userID int64) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type mockStreamService struct {
+	mock.Mock
+}
+
+func (m *mockStreamService) Subscribe(ctx context.Context, userID int64, lastEventID string) (<-chan *dto.StreamEventRecord, func(), error) {
+	args := m.Called(ctx, userID, lastEventID)
+	events, _ := args.Get(0).(<-chan *dto.StreamEventRecord)
+	cancel, _ := args.Get(1).(func())
+	return events, cancel, args.Error(2)
+}
+
+type mockGatewayService struct {
+	mock.Mock
+}
+
+func (m *mockGatewayService) Connect(ctx context.Context, userID int64) (*dto.GatewaySession, error) {
+	args := m.Called(ctx, userID)
+	session, _ := args.Get(0).(*dto.GatewaySession)
+	return session, args.Error(1)
+}
+
+func (m *mockGatewayService) Subscribe(ctx context.Context, session *dto.GatewaySession, channel string) error {
+	args := m.Called(ctx, session, channel)
+	return args.Error(0)
+}
+
+func (m *mockGatewayService) Unsubscribe(ctx context.Context, session *dto.GatewaySession, channel string) error {
+	args := m.Called(ctx, session, channel)
+	return args.Error(0)
+}
+
+func (m *mockGatewayService) Typing(ctx context.Context, session *dto.GatewaySession, channel string) error {
+	args := m.Called(ctx, session, channel)
+	return args.Error(0)
+}
+
+func (m *mockGatewayService) Disconnect(session *dto.GatewaySession) {
+	m.Called(session)
+}
+
+type mockDMService struct {
+	mock.Mock
+}
+
+func (m *mockDMService) CreateConversation(ctx context.Context, userID int64, participantIDs []int64) (*dto.DMConversationRecord, error) {
+	args := m.Called(ctx, userID, participantIDs)
+	return testutils.SafeGet[dto.DMConversationRecord](args, 0), args.Error(1)
+}
+
+func (m *mockDMService) ListConversations(ctx context.Context, userID int64, limit int) ([]*dto.DMConversationRecord, error) {
+	args := m.Called(ctx, userID, limit)
+	return testutils.SafeGetSlice[*dto.DMConversationRecord](args, 0), args.Error(1)
+}
+
+func (m *mockDMService) SendMessage(ctx context.Context, userID, conversationID int64, content string) (*dto.DMMessageRecord, error) {
+	args := m.Called(ctx, userID, conversationID, content)
+	return testutils.SafeGet[dto.DMMessageRecord](args, 0), args.Error(1)
+}
+
+func (m *mockDMService) ListMessages(ctx context.Context, userID, conversationID, cursor int64, limit int) ([]*dto.DMMessageRecord, int64, error) {
+	args := m.Called(ctx, userID, conversationID, cursor, limit)
+	return testutils.SafeGetSlice[*dto.DMMessageRecord](args, 0), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *mockDMService) MarkRead(ctx context.Context, userID, conversationID, cursor int64) (int64, error) {
+	args := m.Called(ctx, userID, conversationID, cursor)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockDMService) UpdateDMPolicy(ctx context.Context, userID int64, policy string) error {
+	args := m.Called(ctx, userID, policy)
+	return args.Error(0)
+}
+
+type mockListService struct {
+	mock.Mock
+}
+
+func (m *mockListService) CreateList(ctx context.Context, ownerID int64, name, description string, isPrivate bool) (*dto.ListRecord, error) {
+	args := m.Called(ctx, ownerID, name, description, isPrivate)
+	return testutils.SafeGet[dto.ListRecord](args, 0), args.Error(1)
+}
+
+func (m *mockListService) GetList(ctx context.Context, viewerID, listID int64) (*dto.ListRecord, error) {
+	args := m.Called(ctx, viewerID, listID)
+	return testutils.SafeGet[dto.ListRecord](args, 0), args.Error(1)
+}
+
+func (m *mockListService) ListOwnedLists(ctx context.Context, ownerID int64) ([]*dto.ListRecord, error) {
+	args := m.Called(ctx, ownerID)
+	return testutils.SafeGetSlice[*dto.ListRecord](args, 0), args.Error(1)
+}
+
+func (m *mockListService) DeleteList(ctx context.Context, ownerID, listID int64) error {
+	args := m.Called(ctx, ownerID, listID)
+	return args.Error(0)
+}
+
+func (m *mockListService) AddMember(ctx context.Context, ownerID, listID, userID int64) error {
+	args := m.Called(ctx, ownerID, listID, userID)
+	return args.Error(0)
+}
+
+func (m *mockListService) RemoveMember(ctx context.Context, ownerID, listID, userID int64) error {
+	args := m.Called(ctx, ownerID, listID, userID)
+	return args.Error(0)
+}
+
+func (m *mockListService) ListMembers(ctx context.Context, viewerID, listID int64) ([]*dto.UserSlimRecord, error) {
+	args := m.Called(ctx, viewerID, listID)
+	return testutils.SafeGetSlice[*dto.UserSlimRecord](args, 0), args.Error(1)
+}
+
+func (m *mockListService) GetTimeline(ctx context.Context, viewerID, listID, cursor int64, limit int) ([]*dto.TweetRecord, int64, error) {
+	args := m.Called(ctx, viewerID, listID, cursor, limit)
+	return testutils.SafeGetSlice[*dto.TweetRecord](args, 0), args.Get(1).(int64), args.Error(2)
+}
+
+type mockTrendService struct {
+	mock.Mock
+}
+
+func (m *mockTrendService) GetTrends(ctx context.Context, limit int) ([]*dto.TrendRecord, error) {
+	args := m.Called(ctx, limit)
+	return testutils.SafeGetSlice[*dto.TrendRecord](args, 0), args.Error(1)
+}
+
+type mockViewService struct {
+	mock.Mock
+}
+
+func (m *mockViewService) RecordViews(ctx context.Context, viewer string, tweetIDs []int64) {
+	m.Called(ctx, viewer, tweetIDs)
+}
+
+type mockAnalyticsService struct {
+	mock.Mock
+}
+
+func (m *mockAnalyticsService) GetAnalytics(ctx context.Context, userID int64, from, to string) (*dto.AnalyticsRecord, error) {
+	args := m.Called(ctx, userID, from, to)
+	return testutils.SafeGet[dto.AnalyticsRecord](args, 0), args.Error(1)
+}
+
+type mockPasswordService struct {
+	mock.Mock
+}
+
+func (m *mockPasswordService) RequestPasswordReset(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *mockPasswordService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	args := m.Called(ctx, token, newPassword)
+	return args.Error(0)
+}
+
+type mockEmailVerificationSender struct {
+	mock.Mock
+}
+
+func (m *mockEmailVerificationSender) SendVerification(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+type mockEmailVerificationService struct {
+	mock.Mock
+}
+
+func (m *mockEmailVerificationService) ResendVerification(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *mockEmailVerificationService) VerifyEmail(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *mockEmailVerificationService) CheckAllowed(ctx context.Context, userID int64, action string) error {
+	args := m.Called(ctx, userID, action)
+	return args.Error(0)
+}
+
+type mockAccountService struct {
+	mock.Mock
+}
+
+func (m *mockAccountService) ChangePassword(ctx context.Context, userID int64, currentToken, currentPassword, newPassword string) error {
+	args := m.Called(ctx, userID, currentToken, currentPassword, newPassword)
+	return args.Error(0)
+}
+
+func (m *mockAccountService) ChangeEmail(ctx context.Context, userID int64, email string) (*dto.UserRecord, error) {
+	args := m.Called(ctx, userID, email)
+	return testutils.SafeGet[dto.UserRecord](args, 0), args.Error(1)
+}
+
+func (m *mockAccountService) ChangeUsername(ctx context.Context, userID int64, username string) (*dto.UserRecord, error) {
+	args := m.Called(ctx, userID, username)
+	return testutils.SafeGet[dto.UserRecord](args, 0), args.Error(1)
+}
+
+type mockDeviceSessionService struct {
+	mock.Mock
+}
+
+func (m *mockDeviceSessionService) Refresh(ctx context.Context, refreshToken string) (*dto.AuthRecord, error) {
+	args := m.Called(ctx, refreshToken)
+	return testutils.SafeGet[dto.AuthRecord](args, 0), args.Error(1)
+}
+
+func (m *mockDeviceSessionService) ListSessions(ctx context.Context, userID int64, currentToken string) ([]*dto.SessionRecord, error) {
+	args := m.Called(ctx, userID, currentToken)
+	return testutils.SafeGetSlice[*dto.SessionRecord](args, 0), args.Error(1)
+}
+
+func (m *mockDeviceSessionService) RevokeSession(ctx context.Context, userID int64, sessionID string) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *mockDeviceSessionService) RevokeOthers(ctx context.Context, userID int64, currentToken string) error {
+	args := m.Called(ctx, userID, currentToken)
+	return args.Error(0)
+}
+
+func (m *mockDeviceSessionService) IssueGatewayTicket(ctx context.Context, token string) (*dto.GatewayTicketRecord, error) {
+	args := m.Called(ctx, token)
+	return testutils.SafeGet[dto.GatewayTicketRecord](args, 0), args.Error(1)
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"aita/internal/pkg/utils"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationService interface {
+	ListNotifications(ctx context.Context, userID, cursor int64, limit int) ([]*dto.NotificationGroupRecord, int64, error)
+	MarkRead(ctx context.Context, userID, cursor int64) error
+	UnreadCount(ctx context.Context, userID int64) (int64, error)
+}
+
+type NotificationHandler struct {
+	notificationService NotificationService
+}
+
+func NewNotificationHandler(svc NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: svc}
+}
+
+func (h *NotificationHandler) List(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	cursor, err := GetCursorQuery(c, "cursor")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+	limit, err := GetIntQuery(c, "limit", 0)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	records, nextCursor, err := h.notificationService.ListNotifications(c.Request.Context(), auth.UserID, cursor, limit)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	notifications := make([]*app.NotificationResponse, len(records))
+	for i, r := range records {
+		notifications[i] = r.ToNotificationResponse()
+	}
+
+	meta := app.CursorMeta{}
+	if nextCursor > 0 {
+		meta.NextCursor = strconv.FormatInt(nextCursor, 10)
+	}
+	c.JSON(http.StatusOK, app.SuccessWithMeta(notifications, meta))
+}
+
+// ボディを省略した場合はすべて既読にする
+func (h *NotificationHandler) Read(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	var req app.MarkNotificationsReadRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		appErr := errcode.FilterBindError(err)
+		c.JSON(errcode.GetStatusCode(appErr), app.Fail(appErr))
+		return
+	}
+
+	var cursor int64
+	if req.Cursor != "" {
+		cursor, err = utils.ParseInt64WithErr(req.Cursor)
+		if err != nil || cursor <= 0 {
+			c.JSON(errcode.GetStatusCode(errcode.ErrInvalidCursor), app.Fail(errcode.ErrInvalidCursor))
+			return
+		}
+	}
+
+	if err := h.notificationService.MarkRead(c.Request.Context(), auth.UserID, cursor); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.SuccessMsg("通知の既読化成功"))
+}
+
+func (h *NotificationHandler) UnreadCount(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	count, err := h.notificationService.UnreadCount(c.Request.Context(), auth.UserID)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.Success(app.UnreadCountResponse{UnreadCount: count}))
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"aita/internal/contextkeys"
+	"aita/internal/dto"
+	"aita/internal/pkg/app"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ms := new(mockNotificationService)
+	ms.On("ListNotifications", mock.Anything, int64(10), int64(0), 0).Return([]*dto.NotificationGroupRecord{{}}, int64(42), nil)
+	h := NewNotificationHandler(ms)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/notifications", nil)
+	c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+	h.List(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Meta app.CursorMeta `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "42", resp.Meta.NextCursor)
+	ms.AssertExpectations(t)
+}
+
+func TestNotificationRead(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(ms *mockNotificationService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "ボディなしですべて既読",
+			body: "",
+			setupMock: func(ms *mockNotificationService) {
+				ms.On("MarkRead", mock.Anything, int64(10), int64(0)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedCode:   "SUCCESS",
+		},
+		{
+			name: "カーソルまで既読",
+			body: `{"cursor":"25"}`,
+			setupMock: func(ms *mockNotificationService) {
+				ms.On("MarkRead", mock.Anything, int64(10), int64(25)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedCode:   "SUCCESS",
+		},
+		{
+			name:           "不正なカーソル",
+			body:           `{"cursor":"abc"}`,
+			setupMock:      func(ms *mockNotificationService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_CURSOR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockNotificationService)
+			tt.setupMock(ms)
+			h := NewNotificationHandler(ms)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/notifications/read", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+			h.Read(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var resp app.Response
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			ms.AssertExpectations(t)
+		})
+	}
+}
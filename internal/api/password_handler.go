@@ -0,0 +1,66 @@
+package api
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PasswordService interface {
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+}
+
+type PasswordHandler struct {
+	passwordService PasswordService
+}
+
+func NewPasswordHandler(svc PasswordService) *PasswordHandler {
+	return &PasswordHandler{passwordService: svc}
+}
+
+// 登録されていないメールアドレスでも同じ応答を返す
+func (h *PasswordHandler) Forgot(c *gin.Context) {
+	var req app.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errcode.FilterBindError(err)
+		c.JSON(errcode.GetStatusCode(appErr), app.Fail(appErr))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	if err := h.passwordService.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusAccepted, app.SuccessMsg("登録されているメールアドレスであれば、パスワード再設定の案内を送信しました"))
+}
+
+func (h *PasswordHandler) Reset(c *gin.Context) {
+	var req app.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errcode.FilterBindError(err)
+		c.JSON(errcode.GetStatusCode(appErr), app.Fail(appErr))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	if err := h.passwordService.ResetPassword(c.Request.Context(), req.Token, req.Password); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.SuccessMsg("パスワードを再設定しました。新しいパスワードでログインしてください"))
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordForgot(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(ms *mockPasswordService)
+		expectedStatus int
+	}{
+		{
+			name: "正常系: 受け付けたことだけを返す",
+			body: `{"email":"a@example.com"}`,
+			setupMock: func(ms *mockPasswordService) {
+				ms.On("RequestPasswordReset", mock.Anything, "a@example.com").Return(nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "メールアドレスの形式が不正",
+			body:           `{"email":"not-an-email"}`,
+			setupMock:      func(ms *mockPasswordService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockPasswordService)
+			tt.setupMock(ms)
+			h := NewPasswordHandler(ms)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/password/forgot", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			h.Forgot(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			ms.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPasswordReset(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	token := strings.Repeat("t", 43)
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(ms *mockPasswordService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "正常系: 再設定できる",
+			body: `{"token":"` + token + `","password":"newpassword"}`,
+			setupMock: func(ms *mockPasswordService) {
+				ms.On("ResetPassword", mock.Anything, token, "newpassword").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedCode:   "SUCCESS",
+		},
+		{
+			name: "無効なトークン",
+			body: `{"token":"` + token + `","password":"newpassword"}`,
+			setupMock: func(ms *mockPasswordService) {
+				ms.On("ResetPassword", mock.Anything, token, "newpassword").Return(errcode.ErrInvalidResetToken)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_RESET_TOKEN",
+		},
+		{
+			name:           "パスワードが短すぎる",
+			body:           `{"token":"` + token + `","password":"short"}`,
+			setupMock:      func(ms *mockPasswordService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockPasswordService)
+			tt.setupMock(ms)
+			h := NewPasswordHandler(ms)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/password/reset", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			h.Reset(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedCode != "" {
+				var resp app.Response
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, tt.expectedCode, resp.Code)
+			}
+			ms.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PollService interface {
+	Vote(ctx context.Context, tweetID, userID, optionID int64) (*dto.PollRecord, error)
+	GetPoll(ctx context.Context, tweetID, viewerID int64) (*dto.PollRecord, error)
+}
+
+type PollHandler struct {
+	pollService PollService
+}
+
+func NewPollHandler(svc PollService) *PollHandler {
+	return &PollHandler{pollService: svc}
+}
+
+func (h *PollHandler) Vote(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	tweetID, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	var req app.VotePollRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errcode.FilterBindError(err)
+		c.JSON(errcode.GetStatusCode(appErr), app.Fail(appErr))
+		return
+	}
+
+	poll, err := h.pollService.Vote(c.Request.Context(), tweetID, auth.UserID, req.OptionID)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.Success(poll.ToPollResponse()))
+}
+
+func (h *PollHandler) Get(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	tweetID, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	poll, err := h.pollService.GetPoll(c.Request.Context(), tweetID, auth.UserID)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.Success(poll.ToPollResponse()))
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"aita/internal/contextkeys"
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollVote(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	optionID := int64(12)
+	tests := []struct {
+		name           string
+		tweetID        string
+		requestBody    any
+		setupMock      func(ms *mockPollService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:        "投票成功で集計が返る",
+			tweetID:     "10",
+			requestBody: app.VotePollRequest{OptionID: 12},
+			setupMock: func(ms *mockPollService) {
+				ms.On("Vote", mock.Anything, int64(10), int64(5), int64(12)).Return(&dto.PollRecord{
+					ID:       1,
+					ClosesAt: time.Now().Add(time.Hour),
+					Options: []dto.PollOptionRecord{
+						{ID: 11, Position: 0, Label: "犬", Votes: 2},
+						{ID: 12, Position: 1, Label: "猫", Votes: 3},
+					},
+					VotedOptionID:  &optionID,
+					ResultsVisible: true,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "選択肢IDがない",
+			tweetID:        "10",
+			requestBody:    map[string]any{},
+			setupMock:      func(ms *mockPollService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_REQUEST_FORMAT",
+		},
+		{
+			name:           "ツイートIDが不正",
+			tweetID:        "abc",
+			requestBody:    app.VotePollRequest{OptionID: 12},
+			setupMock:      func(ms *mockPollService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_ID_FORMAT",
+		},
+		{
+			name:        "二重投票",
+			tweetID:     "10",
+			requestBody: app.VotePollRequest{OptionID: 12},
+			setupMock: func(ms *mockPollService) {
+				ms.On("Vote", mock.Anything, int64(10), int64(5), int64(12)).Return(nil, errcode.ErrAlreadyVoted)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "ALREADY_VOTED",
+		},
+		{
+			name:        "締切済みの投票",
+			tweetID:     "10",
+			requestBody: app.VotePollRequest{OptionID: 12},
+			setupMock: func(ms *mockPollService) {
+				ms.On("Vote", mock.Anything, int64(10), int64(5), int64(12)).Return(nil, errcode.ErrPollClosed)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedCode:   "POLL_CLOSED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockPollService)
+			tt.setupMock(ms)
+			h := NewPollHandler(ms)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			body, _ := json.Marshal(tt.requestBody)
+			c.Request = httptest.NewRequest(http.MethodPost, "/tweets/"+tt.tweetID+"/poll/vote", bytes.NewBuffer(body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = gin.Params{{Key: "id", Value: tt.tweetID}}
+			c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 5})
+
+			h.Vote(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var resp app.Response
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			if tt.expectedCode != "" {
+				assert.Equal(t, tt.expectedCode, resp.Code)
+			} else {
+				data := resp.Data.(map[string]any)
+				assert.EqualValues(t, 5, data["total_votes"])
+				assert.EqualValues(t, 12, data["voted_option_id"])
+				options := data["options"].([]any)
+				assert.EqualValues(t, 3, options[1].(map[string]any)["votes"])
+			}
+			ms.AssertExpectations(t)
+		})
+	}
+}
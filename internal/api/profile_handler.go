@@ -0,0 +1,98 @@
+package api
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProfileService interface {
+	PinTweet(ctx context.Context, userID, tweetID int64) error
+	UnpinTweet(ctx context.Context, userID, tweetID int64) error
+	GetProfileTweets(ctx context.Context, userID int64, page, size int) ([]*dto.TweetRecord, error)
+}
+
+type ProfileHandler struct {
+	profileService ProfileService
+}
+
+func NewProfileHandler(svc ProfileService) *ProfileHandler {
+	return &ProfileHandler{profileService: svc}
+}
+
+func (h *ProfileHandler) Pin(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	tweetID, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	if err := h.profileService.PinTweet(c.Request.Context(), auth.UserID, tweetID); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.SuccessMsg("ツイートの固定成功"))
+}
+
+func (h *ProfileHandler) Unpin(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	tweetID, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	if err := h.profileService.UnpinTweet(c.Request.Context(), auth.UserID, tweetID); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.SuccessMsg("ツイートの固定解除成功"))
+}
+
+func (h *ProfileHandler) Tweets(c *gin.Context) {
+	userID, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	page, err := GetIntQuery(c, "page", 0)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+	size, err := GetIntQuery(c, "size", 0)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	records, err := h.profileService.GetProfileTweets(c.Request.Context(), userID, page, size)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	tweets := make([]*app.TweetResponse, len(records))
+	for i, r := range records {
+		tweets[i] = r.ToTweetResponse()
+	}
+	c.JSON(http.StatusOK, app.Success(tweets))
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"aita/internal/contextkeys"
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfilePin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		setupMock      func(ms *mockProfileService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "固定成功",
+			setupMock: func(ms *mockProfileService) {
+				ms.On("PinTweet", mock.Anything, int64(10), int64(5)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedCode:   "SUCCESS",
+		},
+		{
+			name: "他人のツイート",
+			setupMock: func(ms *mockProfileService) {
+				ms.On("PinTweet", mock.Anything, int64(10), int64(5)).Return(errcode.ErrForbidden)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "FORBIDDEN_ACCESS",
+		},
+		{
+			name: "削除済みのツイート",
+			setupMock: func(ms *mockProfileService) {
+				ms.On("PinTweet", mock.Anything, int64(10), int64(5)).Return(errcode.ErrTweetNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   "TWEET_NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockProfileService)
+			tt.setupMock(ms)
+			h := NewProfileHandler(ms)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/tweets/5/pin", nil)
+			c.Params = gin.Params{{Key: "id", Value: "5"}}
+			c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+			h.Pin(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var resp app.Response
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			ms.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProfileTweets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ms := new(mockProfileService)
+	ms.On("GetProfileTweets", mock.Anything, int64(3), 0, 10).
+		Return([]*dto.TweetRecord{{ID: 5, IsPinned: true}, {ID: 9}}, nil)
+	h := NewProfileHandler(ms)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/users/3/tweets?size=10", nil)
+	c.Params = gin.Params{{Key: "id", Value: "3"}}
+
+	h.Tweets(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data []app.TweetResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data, 2)
+	assert.True(t, resp.Data[0].IsPinned)
+	assert.False(t, resp.Data[1].IsPinned)
+	ms.AssertExpectations(t)
+}
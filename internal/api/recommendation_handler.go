@@ -0,0 +1,50 @@
+package api
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecommendationService interface {
+	RecommendUsers(ctx context.Context, userID int64, limit int) ([]*dto.RecommendedUserRecord, error)
+}
+
+type RecommendationHandler struct {
+	recommendationService RecommendationService
+}
+
+func NewRecommendationHandler(svc RecommendationService) *RecommendationHandler {
+	return &RecommendationHandler{recommendationService: svc}
+}
+
+func (h *RecommendationHandler) GetUsers(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	limit, err := GetIntQuery(c, "limit", 0)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	records, err := h.recommendationService.RecommendUsers(c.Request.Context(), auth.UserID, limit)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	users := make([]*app.RecommendedUserResponse, len(records))
+	for i, r := range records {
+		users[i] = r.ToRecommendedUserResponse()
+	}
+
+	c.JSON(http.StatusOK, app.Success(users))
+}
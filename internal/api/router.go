@@ -1,6 +1,8 @@
 package api
 
 import (
+	"aita/internal/dto"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -8,7 +10,23 @@ func SetupRouter(
 	userHandler *UserHandler, 
 	tweetHandler *TweetHandler, 
 	followHandler *FollowHandler,
-	sessionService AuthSessionService ,
+	recommendationHandler *RecommendationHandler,
+	draftHandler *DraftHandler,
+	mediaHandler *MediaHandler,
+	pollHandler *PollHandler,
+	bookmarkHandler *BookmarkHandler,
+	profileHandler *ProfileHandler,
+	notificationHandler *NotificationHandler,
+	streamHandler *StreamHandler,
+	gatewayHandler *GatewayHandler,
+	dmHandler *DMHandler,
+	listHandler *ListHandler,
+	trendHandler *TrendHandler,
+	analyticsHandler *AnalyticsHandler,
+	passwordHandler *PasswordHandler,
+	emailVerificationHandler *EmailVerificationHandler,
+	sessionHandler *SessionHandler,
+	sessionService WebSocketAuthService,
 ) *gin.Engine {
 	router := gin.Default()
 	router.GET("/health", func(c *gin.Context) {
@@ -19,23 +37,48 @@ func SetupRouter(
 	{
 		v1.POST("/signup", userHandler.SignUp)
 		v1.POST("/login", userHandler.Login)
+		v1.POST("/password/forgot", passwordHandler.Forgot)
+		v1.POST("/password/reset", passwordHandler.Reset)
+		v1.POST("/email/verify", emailVerificationHandler.Verify)
+		v1.POST("/token/refresh", sessionHandler.Refresh)
 		v1.GET("/tweets/:id", tweetHandler.Get)
+		v1.GET("/tweets/:id/history", tweetHandler.History)
+		v1.GET("/trends", trendHandler.List)
+		v1.GET("/ws", WebSocketAuthMiddleware(sessionService), gatewayHandler.Serve)
 	
 		protected := v1.Group("/")
 		protected.Use(AuthMiddleware(sessionService))
 		{
 			protected.GET("/me", userHandler.GetMe)
+			protected.PATCH("/me/password", userHandler.ChangePassword)
+			protected.PATCH("/me/email", userHandler.ChangeEmail)
+			protected.PATCH("/me/username", userHandler.ChangeUsername)
+			protected.GET("/me/sessions", sessionHandler.List)
+			protected.DELETE("/me/sessions/:id", sessionHandler.Revoke)
+			protected.POST("/me/sessions/revoke-all", sessionHandler.RevokeOthers)
+			protected.POST("/ws/ticket", sessionHandler.IssueGatewayTicket)
 			protected.POST("/logout", userHandler.Logout)
+			protected.POST("/email/verify/resend", emailVerificationHandler.Resend)
+			protected.GET("/me/analytics", analyticsHandler.Get)
 			tweets := protected.Group("/tweets")
 			{
-				tweets.POST("", tweetHandler.Create)
+				tweets.POST("", emailVerificationHandler.RequireVerified(dto.VerifiedActionTweet), tweetHandler.Create)
+				tweets.GET("/scheduled", tweetHandler.ListScheduled)
+				tweets.DELETE("/scheduled/:id", tweetHandler.CancelScheduled)
 				tweets.PATCH("/:id", tweetHandler.Update)  
                 tweets.DELETE("/:id", tweetHandler.Delete)
+				tweets.POST("/:id/restore", tweetHandler.Restore)
+				tweets.GET("/:id/poll", pollHandler.Get)
+				tweets.POST("/:id/poll/vote", pollHandler.Vote)
+				tweets.POST("/:id/bookmark", bookmarkHandler.Add)
+				tweets.DELETE("/:id/bookmark", bookmarkHandler.Remove)
+				tweets.POST("/:id/pin", profileHandler.Pin)
+				tweets.DELETE("/:id/pin", profileHandler.Unpin)
 				
 			}
 			relation := protected.Group("/relation")
 			{
-				relation.POST("/follow", followHandler.Follow)      
+				relation.POST("/follow", emailVerificationHandler.RequireVerified(dto.VerifiedActionFollow), followHandler.Follow)      
 				relation.POST("/unfollow", followHandler.UnFollow)  
 				relation.GET("/status/:id", followHandler.GetRelation) 
 			}
@@ -44,6 +87,56 @@ func SetupRouter(
 			{
     			users.GET("/followers", followHandler.GetFollowers)
     			users.GET("/followings", followHandler.GetFollowings)
+    			users.GET("/tweets", profileHandler.Tweets)
+			}
+
+			recommendations := protected.Group("/recommendations")
+			{
+				recommendations.GET("/users", recommendationHandler.GetUsers)
+			}
+
+			drafts := protected.Group("/drafts")
+			{
+				drafts.POST("", draftHandler.Create)
+				drafts.GET("", draftHandler.List)
+				drafts.GET("/:id", draftHandler.Get)
+				drafts.PATCH("/:id", draftHandler.Update)
+				drafts.DELETE("/:id", draftHandler.Delete)
+				drafts.POST("/:id/publish", emailVerificationHandler.RequireVerified(dto.VerifiedActionTweet), draftHandler.Publish)
+			}
+
+			protected.POST("/media", mediaHandler.Upload)
+			protected.GET("/bookmarks", bookmarkHandler.List)
+
+			notifications := protected.Group("/notifications")
+			{
+				notifications.GET("", notificationHandler.List)
+				notifications.GET("/unread_count", notificationHandler.UnreadCount)
+				notifications.POST("/read", notificationHandler.Read)
+			}
+
+			protected.GET("/stream", streamHandler.Stream)
+
+			dm := protected.Group("/dm")
+			{
+				dm.POST("/conversations", emailVerificationHandler.RequireVerified(dto.VerifiedActionDM), dmHandler.CreateConversation)
+				dm.GET("/conversations", dmHandler.ListConversations)
+				dm.GET("/conversations/:id/messages", dmHandler.ListMessages)
+				dm.POST("/conversations/:id/messages", emailVerificationHandler.RequireVerified(dto.VerifiedActionDM), dmHandler.SendMessage)
+				dm.POST("/conversations/:id/read", dmHandler.Read)
+				dm.PUT("/settings", dmHandler.UpdateSettings)
+			}
+
+			lists := protected.Group("/lists")
+			{
+				lists.POST("", listHandler.Create)
+				lists.GET("", listHandler.Mine)
+				lists.GET("/:id", listHandler.Get)
+				lists.DELETE("/:id", listHandler.Delete)
+				lists.GET("/:id/members", listHandler.Members)
+				lists.POST("/:id/members", listHandler.AddMember)
+				lists.DELETE("/:id/members/:user_id", listHandler.RemoveMember)
+				lists.GET("/:id/timeline", listHandler.Timeline)
 			}
 		} 
 	}
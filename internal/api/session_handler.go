@@ -0,0 +1,126 @@
+package api
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeviceSessionService interface {
+	Refresh(ctx context.Context, refreshToken string) (*dto.AuthRecord, error)
+	ListSessions(ctx context.Context, userID int64, currentToken string) ([]*dto.SessionRecord, error)
+	RevokeSession(ctx context.Context, userID int64, sessionID string) error
+	RevokeOthers(ctx context.Context, userID int64, currentToken string) error
+	IssueGatewayTicket(ctx context.Context, token string) (*dto.GatewayTicketRecord, error)
+}
+
+type SessionHandler struct {
+	sessionService DeviceSessionService
+}
+
+func NewSessionHandler(svc DeviceSessionService) *SessionHandler {
+	return &SessionHandler{sessionService: svc}
+}
+
+// 使ったリフレッシュトークンは無効になるため、応答の新しいトークンに置き換えてもらう
+func (h *SessionHandler) Refresh(c *gin.Context) {
+	var req app.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errcode.FilterBindError(err)
+		c.JSON(errcode.GetStatusCode(appErr), app.Fail(appErr))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	auth, err := h.sessionService.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.Success(auth.ToTokenResponse()))
+}
+
+func (h *SessionHandler) List(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	sessions, err := h.sessionService.ListSessions(c.Request.Context(), auth.UserID, auth.Token)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	responses := make([]*app.SessionResponse, len(sessions))
+	for i, s := range sessions {
+		responses[i] = s.ToSessionResponse()
+	}
+
+	c.JSON(http.StatusOK, app.Success(responses))
+}
+
+// 現在のセッションを指定した場合はログアウトと同じ扱いになる
+func (h *SessionHandler) Revoke(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	sessionID := strings.TrimSpace(c.Param("id"))
+	if sessionID == "" {
+		c.JSON(errcode.GetStatusCode(errcode.ErrInvalidSessionID), app.Fail(errcode.ErrInvalidSessionID))
+		return
+	}
+
+	if err := h.sessionService.RevokeSession(c.Request.Context(), auth.UserID, sessionID); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.SuccessMsg("セッションを無効にしました"))
+}
+
+func (h *SessionHandler) RevokeOthers(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	if err := h.sessionService.RevokeOthers(c.Request.Context(), auth.UserID, auth.Token); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.SuccessMsg("この端末以外のすべてのセッションからログアウトしました"))
+}
+
+// 発行したチケットは /ws?ticket= で一度だけ使える
+func (h *SessionHandler) IssueGatewayTicket(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	ticket, err := h.sessionService.IssueGatewayTicket(c.Request.Context(), auth.Token)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.Success(ticket.ToGatewayTicketResponse()))
+}
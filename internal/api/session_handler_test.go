@@ -0,0 +1,188 @@
+package api
+
+import (
+	"aita/internal/contextkeys"
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newSessionTestRouter(svc DeviceSessionService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := NewSessionHandler(svc)
+	r := gin.New()
+	r.POST("/token/refresh", h.Refresh)
+	r.Use(func(c *gin.Context) {
+		c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 101, Token: "current_token"})
+	})
+	r.GET("/me/sessions", h.List)
+	r.DELETE("/me/sessions/:id", h.Revoke)
+	r.POST("/me/sessions/revoke-all", h.RevokeOthers)
+	return r
+}
+
+func TestSessionList(t *testing.T) {
+	now := time.Now().UTC()
+	ms := new(mockDeviceSessionService)
+	ms.On("ListSessions", mock.Anything, int64(101), "current_token").Return([]*dto.SessionRecord{
+		{SessionID: "sid-1", TokenHash: "hash_1", IPAddress: "192.0.2.1", UserAgent: "Firefox", CreatedAt: now, LastSeenAt: now, Current: true},
+		{SessionID: "sid-2", TokenHash: "hash_2", IPAddress: "192.0.2.2", UserAgent: "Safari", CreatedAt: now.Add(-time.Hour)},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	newSessionTestRouter(ms).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/me/sessions", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data []app.SessionResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data, 2)
+	assert.True(t, resp.Data[0].Current)
+	assert.Equal(t, "sid-1", resp.Data[0].ID)
+	assert.Equal(t, "Safari", resp.Data[1].UserAgent)
+	assert.False(t, resp.Data[1].Current)
+	assert.True(t, now.Add(-time.Hour).Equal(resp.Data[1].LastSeenAt), "最終利用日時がなければ作成日時で代用するべきです")
+	assert.NotContains(t, w.Body.String(), "hash_1")
+}
+
+func TestSessionRevoke(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		setupMock      func(ms *mockDeviceSessionService)
+		expectedStatus int
+	}{
+		{
+			name:   "正常系: 指定したセッションを無効にする",
+			method: http.MethodDelete,
+			path:   "/me/sessions/sid-2",
+			setupMock: func(ms *mockDeviceSessionService) {
+				ms.On("RevokeSession", mock.Anything, int64(101), "sid-2").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "存在しないセッション",
+			method: http.MethodDelete,
+			path:   "/me/sessions/unknown",
+			setupMock: func(ms *mockDeviceSessionService) {
+				ms.On("RevokeSession", mock.Anything, int64(101), "unknown").Return(errcode.ErrUserSessionNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "正常系: 現在のセッション以外を無効にする",
+			method: http.MethodPost,
+			path:   "/me/sessions/revoke-all",
+			setupMock: func(ms *mockDeviceSessionService) {
+				ms.On("RevokeOthers", mock.Anything, int64(101), "current_token").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockDeviceSessionService)
+			tt.setupMock(ms)
+
+			w := httptest.NewRecorder()
+			newSessionTestRouter(ms).ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			ms.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTokenRefresh(t *testing.T) {
+	refreshToken := strings.Repeat("r", 43)
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(ms *mockDeviceSessionService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "正常系: 新しいトークンの組を返す",
+			body: `{"refresh_token":"` + refreshToken + `"}`,
+			setupMock: func(ms *mockDeviceSessionService) {
+				ms.On("Refresh", mock.Anything, refreshToken).Return(&dto.AuthRecord{UserID: 101, Token: "new_access", RefreshToken: "new_refresh"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "リフレッシュトークンがない",
+			body:           `{}`,
+			setupMock:      func(ms *mockDeviceSessionService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "使用済みのトークンが再利用された",
+			body: `{"refresh_token":"` + refreshToken + `"}`,
+			setupMock: func(ms *mockDeviceSessionService) {
+				ms.On("Refresh", mock.Anything, refreshToken).Return(nil, errcode.ErrRefreshTokenReused)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   "REFRESH_TOKEN_REUSED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockDeviceSessionService)
+			tt.setupMock(ms)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/token/refresh", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			newSessionTestRouter(ms).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Contains(t, w.Body.String(), `"access_token":"new_access"`)
+				assert.Contains(t, w.Body.String(), `"refresh_token":"new_refresh"`)
+			}
+			if tt.expectedCode != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedCode)
+			}
+			ms.AssertExpectations(t)
+		})
+	}
+}
+
+func TestIssueGatewayTicket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	expiresAt := time.Date(2026, 1, 1, 0, 0, 30, 0, time.UTC)
+
+	ms := new(mockDeviceSessionService)
+	ms.On("IssueGatewayTicket", mock.Anything, "current_token").
+		Return(&dto.GatewayTicketRecord{Ticket: "ws_ticket", ExpiresAt: expiresAt}, nil)
+
+	r := gin.New()
+	r.POST("/ws/ticket", func(c *gin.Context) {
+		c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10, Token: "current_token"})
+	}, NewSessionHandler(ms).IssueGatewayTicket)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/ws/ticket", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"ticket":"ws_ticket"`)
+	ms.AssertExpectations(t)
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+type StreamService interface {
+	Subscribe(ctx context.Context, userID int64, lastEventID string) (<-chan *dto.StreamEventRecord, func(), error)
+}
+
+type StreamHandler struct {
+	streamService     StreamService
+	heartbeatInterval time.Duration
+}
+
+func NewStreamHandler(svc StreamService, heartbeatInterval time.Duration) *StreamHandler {
+	return &StreamHandler{
+		streamService:     svc,
+		heartbeatInterval: heartbeatInterval,
+	}
+}
+
+// Server-Sent Events でホームタイムラインの新着ツイートIDと通知を流す。
+// 再接続時は Last-Event-ID ヘッダー以降の取りこぼしを先に送る
+func (h *StreamHandler) Stream(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	ctx := c.Request.Context()
+	events, cancel, err := h.streamService.Subscribe(ctx, auth.UserID, c.GetHeader("Last-Event-ID"))
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+	defer cancel()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// リバースプロキシでのバッファリングを止める
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			err := sse.Encode(c.Writer, sse.Event{
+				Id:    event.ID,
+				Event: event.Type,
+				Data:  event.ToStreamEventResponse(),
+			})
+			if err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case <-heartbeat.C:
+			// コメント行はクライアントのイベントとしては扱われない
+			if _, err := c.Writer.WriteString(": heartbeat\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"aita/internal/contextkeys"
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"aita/internal/pkg/app"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("正常系: イベントを ID 付きで流す", func(t *testing.T) {
+		events := make(chan *dto.StreamEventRecord, 1)
+		events <- &dto.StreamEventRecord{ID: "5-0", Type: models.StreamEventTimeline, TweetID: 9}
+		close(events)
+		canceled := false
+		ms := new(mockStreamService)
+		ms.On("Subscribe", mock.Anything, int64(10), "4-0").
+			Return((<-chan *dto.StreamEventRecord)(events), func() { canceled = true }, nil)
+
+		h := NewStreamHandler(ms, time.Minute)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/stream", nil)
+		c.Request.Header.Set("Last-Event-ID", "4-0")
+		c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+		h.Stream(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+		assert.Equal(t, "id:5-0\nevent:timeline\ndata:{\"tweet_id\":9}\n\n", w.Body.String())
+		assert.True(t, canceled)
+	})
+
+	t.Run("異常系: 接続数の上限", func(t *testing.T) {
+		ms := new(mockStreamService)
+		ms.On("Subscribe", mock.Anything, int64(10), "").Return(nil, nil, errcode.ErrTooManyStreams)
+
+		h := NewStreamHandler(ms, time.Minute)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/stream", nil)
+		c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+		h.Stream(c)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		var resp app.Response
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "TOO_MANY_STREAMS", resp.Code)
+	})
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/app"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrendService interface {
+	GetTrends(ctx context.Context, limit int) ([]*dto.TrendRecord, error)
+}
+
+type TrendHandler struct {
+	trendService TrendService
+}
+
+func NewTrendHandler(svc TrendService) *TrendHandler {
+	return &TrendHandler{trendService: svc}
+}
+
+func (h *TrendHandler) List(c *gin.Context) {
+	limit, err := GetIntQuery(c, "limit", 0)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	records, err := h.trendService.GetTrends(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	trends := make([]*app.TrendResponse, len(records))
+	for i, r := range records {
+		trends[i] = r.ToTrendResponse(i + 1)
+	}
+
+	c.JSON(http.StatusOK, app.Success(trends))
+}
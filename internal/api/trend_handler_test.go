@@ -0,0 +1,70 @@
+package api
+
+import (
+	"aita/internal/dto"
+	"aita/internal/pkg/app"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrendList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(ms *mockTrendService)
+		expectedStatus int
+		expectedLen    int
+	}{
+		{
+			name:  "正常系: 順位を付けて返す",
+			query: "?limit=2",
+			setupMock: func(ms *mockTrendService) {
+				ms.On("GetTrends", mock.Anything, 2).Return([]*dto.TrendRecord{
+					{Hashtag: "go", TweetCount: 20},
+					{Hashtag: "東京", TweetCount: 12},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedLen:    2,
+		},
+		{
+			name:           "不正な limit",
+			query:          "?limit=abc",
+			setupMock:      func(ms *mockTrendService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockTrendService)
+			tt.setupMock(ms)
+			h := NewTrendHandler(ms)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/trends"+tt.query, nil)
+
+			h.List(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp struct {
+					Data []app.TrendResponse `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.Len(t, resp.Data, tt.expectedLen)
+				assert.Equal(t, 1, resp.Data[0].Rank)
+				assert.Equal(t, "東京", resp.Data[1].Hashtag)
+			}
+			ms.AssertExpectations(t)
+		})
+	}
+}
@@ -6,23 +6,42 @@ import (
 	"aita/internal/pkg/app"
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type TweetService interface {
-	PostTweet(ctx context.Context, userID int64, content string, imageURL *string) (*dto.TweetRecord, error)
+	PostTweet(ctx context.Context, userID int64, content string, media []dto.MediaAttachment, poll *dto.PollInput) (*dto.TweetRecord, error)
 	FetchTweet(ctx context.Context, tweetID int64) (*dto.TweetRecord, error)
 	EditTweet(ctx context.Context, newContent string, tweetID int64, userID int64) (*dto.TweetRecord, bool, error)
 	RemoveTweet(ctx context.Context, tweetID int64, userID int64) error
+	FetchHistory(ctx context.Context, tweetID int64) ([]*dto.TweetRevisionRecord, error)
+	RestoreTweet(ctx context.Context, tweetID int64, userID int64) (*dto.TweetRecord, error)
+}
+
+type ScheduledTweetService interface {
+	ScheduleTweet(ctx context.Context, userID int64, content string, media []dto.MediaAttachment, publishAt time.Time) (*dto.ScheduledTweetRecord, error)
+	ListScheduled(ctx context.Context, userID int64) ([]*dto.ScheduledTweetRecord, error)
+	CancelScheduled(ctx context.Context, scheduledID, userID int64) error
+}
+
+type ViewService interface {
+	RecordViews(ctx context.Context, viewer string, tweetIDs []int64)
 }
 
 type TweetHandler struct {
-	tweetService TweetService
+	tweetService          TweetService
+	scheduledTweetService ScheduledTweetService
+	viewService           ViewService
 }
 
-func NewTweetHandler(svc TweetService) *TweetHandler {
-	return &TweetHandler{tweetService: svc}
+func NewTweetHandler(svc TweetService, ss ScheduledTweetService, vs ViewService) *TweetHandler {
+	return &TweetHandler{
+		tweetService:          svc,
+		scheduledTweetService: ss,
+		viewService:           vs,
+	}
 }
 
 func (h *TweetHandler) Create(c *gin.Context) {
@@ -44,11 +63,29 @@ func (h *TweetHandler) Create(c *gin.Context) {
 		return
 	}
 
+	if req.PublishAt != nil {
+		scheduled, err := h.scheduledTweetService.ScheduleTweet(
+			c.Request.Context(),
+			auth.UserID,
+			req.Content,
+			toMediaAttachments(req.Media),
+			*req.PublishAt,
+		)
+		if err != nil {
+			c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+			return
+		}
+
+		c.JSON(http.StatusCreated, app.Success(scheduled.ToScheduledTweetResponse()))
+		return
+	}
+
 	tweet, err := h.tweetService.PostTweet(
 		c.Request.Context(),
 		auth.UserID,
 		req.Content,
-		req.ImageURL,
+		toMediaAttachments(req.Media),
+		toPollInput(req.Poll),
 	)
 	if err != nil {
 		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
@@ -70,9 +107,38 @@ func (h *TweetHandler) Get(c *gin.Context) {
 		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
 		return
 	}
+
+	// 公開エンドポイントなのでログインしていない閲覧者は IP アドレスで区別する
+	var viewerID int64
+	if auth, err := GetAuthContext(c); err == nil {
+		viewerID = auth.UserID
+	}
+	h.viewService.RecordViews(c.Request.Context(), dto.ViewerKey(viewerID, c.ClientIP()), []int64{tweet.ID})
+
 	c.JSON(http.StatusOK, app.Success(tweet.ToTweetResponse()))
 
 }
+func (h *TweetHandler) History(c *gin.Context) {
+	id, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	records, err := h.tweetService.FetchHistory(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	revisions := make([]*app.TweetRevisionResponse, len(records))
+	for i, r := range records {
+		revisions[i] = r.ToTweetRevisionResponse()
+	}
+
+	c.JSON(http.StatusOK, app.Success(revisions))
+}
+
 func (h *TweetHandler) Update(c *gin.Context) {
 	id, err := GetIDParam(c, "id")
 	if err != nil {
@@ -128,3 +194,65 @@ func (h *TweetHandler) Delete(c *gin.Context) {
 
 	c.JSON(http.StatusOK, app.SuccessMsg("ツイートの削除成功"))
 }
+
+func (h *TweetHandler) Restore(c *gin.Context) {
+	id, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	tweet, err := h.tweetService.RestoreTweet(c.Request.Context(), id, auth.UserID)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.Success(tweet.ToTweetResponse()))
+}
+
+func (h *TweetHandler) ListScheduled(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	records, err := h.scheduledTweetService.ListScheduled(c.Request.Context(), auth.UserID)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	scheduled := make([]*app.ScheduledTweetResponse, len(records))
+	for i, r := range records {
+		scheduled[i] = r.ToScheduledTweetResponse()
+	}
+
+	c.JSON(http.StatusOK, app.Success(scheduled))
+}
+
+func (h *TweetHandler) CancelScheduled(c *gin.Context) {
+	id, err := GetIDParam(c, "id")
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	if err := h.scheduledTweetService.CancelScheduled(c.Request.Context(), id, auth.UserID); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.SuccessMsg("予約投稿をキャンセルしました"))
+}
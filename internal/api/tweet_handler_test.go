@@ -42,6 +42,7 @@ func TestTweetCreate(t *testing.T) {
 					int64(10),
 					"AITAの初投稿!",
 					mock.Anything,
+					mock.Anything,
 				).Return(&dto.TweetRecord{
 					ID:        100,
 					Content:   "AITAの初投稿!",
@@ -102,12 +103,130 @@ func TestTweetCreate(t *testing.T) {
 				assert.Equal(t, "REQUIRED_FIELD_MISSING", resp.Code)
 			},
 		},
+		{
+			name: "添付メディア付きの投稿成功",
+			requestBody: app.CreateTweetRequest{
+				Content: "写真です",
+				Media:   []app.MediaAttachmentRequest{{MediaID: 3, AltText: "  夕焼け  "}, {MediaID: 4}},
+			},
+			setupAuth: func(c *gin.Context) {
+				c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+			},
+			setupMock: func(mt *mockTweetService) {
+				mt.On("PostTweet", mock.Anything, int64(10), "写真です",
+					[]dto.MediaAttachment{{MediaID: 3, AltText: "夕焼け"}, {MediaID: 4}},
+					(*dto.PollInput)(nil),
+				).Return(&dto.TweetRecord{
+					ID:      101,
+					UserID:  10,
+					Content: "写真です",
+					Media: []dto.TweetMediaRecord{
+						{MediaID: 3, Position: 0, AltText: "夕焼け", URL: "/media/10/a.png"},
+						{MediaID: 4, Position: 1, URL: "/media/10/b.png"},
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp app.Response
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				data := resp.Data.(map[string]any)
+				media := data["media"].([]any)
+				require.Len(t, media, 2)
+				assert.Equal(t, "夕焼け", media[0].(map[string]any)["alt_text"])
+				assert.EqualValues(t, 1, media[1].(map[string]any)["position"])
+			},
+		},
+		{
+			name: "バリデーションエラー：添付メディアが5件",
+			requestBody: app.CreateTweetRequest{
+				Content: "多すぎ",
+				Media:   []app.MediaAttachmentRequest{{MediaID: 1}, {MediaID: 2}, {MediaID: 3}, {MediaID: 4}, {MediaID: 5}},
+			},
+			setupAuth: func(c *gin.Context) {
+				c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+			},
+			setupMock:      func(mt *mockTweetService) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp app.Response
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.Equal(t, "INVALID_REQUEST_FORMAT", resp.Code)
+			},
+		},
+		{
+			name: "バリデーションエラー：同じメディアの重複",
+			requestBody: app.CreateTweetRequest{
+				Content: "重複",
+				Media:   []app.MediaAttachmentRequest{{MediaID: 1}, {MediaID: 1}},
+			},
+			setupAuth: func(c *gin.Context) {
+				c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+			},
+			setupMock:      func(mt *mockTweetService) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp app.Response
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.Equal(t, "INVALID_MEDIA_ATTACHMENT", resp.Code)
+			},
+		},
+		{
+			name: "投票付きの投稿成功",
+			requestBody: app.CreateTweetRequest{
+				Content: "どっち?",
+				Poll:    &app.PollRequest{Options: []string{" 犬 ", "猫"}, DurationMinutes: 60},
+			},
+			setupAuth: func(c *gin.Context) {
+				c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+			},
+			setupMock: func(mt *mockTweetService) {
+				mt.On("PostTweet", mock.Anything, int64(10), "どっち?", []dto.MediaAttachment(nil),
+					&dto.PollInput{Options: []string{"犬", "猫"}, Duration: time.Hour},
+				).Return(&dto.TweetRecord{
+					ID:      102,
+					UserID:  10,
+					Content: "どっち?",
+					Poll: &dto.PollRecord{
+						ID:       1,
+						ClosesAt: time.Now().Add(time.Hour),
+						Options:  []dto.PollOptionRecord{{ID: 1, Label: "犬"}, {ID: 2, Position: 1, Label: "猫"}},
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp app.Response
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				poll := resp.Data.(map[string]any)["poll"].(map[string]any)
+				assert.Len(t, poll["options"], 2)
+				assert.Nil(t, poll["total_votes"])
+			},
+		},
+		{
+			name: "バリデーションエラー：予約投稿に投票を付ける",
+			requestBody: app.CreateTweetRequest{
+				Content:   "予約",
+				Poll:      &app.PollRequest{Options: []string{"はい", "いいえ"}, DurationMinutes: 60},
+				PublishAt: func() *time.Time { t := time.Now().Add(time.Hour); return &t }(),
+			},
+			setupAuth: func(c *gin.Context) {
+				c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+			},
+			setupMock:      func(mt *mockTweetService) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp app.Response
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.Equal(t, "INVALID_POLL", resp.Code)
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mt := new(mockTweetService)
-			h := NewTweetHandler(mt)
+			h := NewTweetHandler(mt, new(mockScheduledTweetService), new(mockViewService))
 			tt.setupMock(mt)
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
@@ -192,7 +311,9 @@ func TestTweetGet(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mt := new(mockTweetService)
-			h := NewTweetHandler(mt)
+			mv := new(mockViewService)
+			mv.On("RecordViews", mock.Anything, mock.Anything, mock.Anything).Return()
+			h := NewTweetHandler(mt, new(mockScheduledTweetService), mv)
 			tt.setupMock(mt)
 
 			w := httptest.NewRecorder()
@@ -207,6 +328,46 @@ func TestTweetGet(t *testing.T) {
 	}
 }
 
+func TestTweetGetRecordsView(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name       string
+		auth       *dto.AuthContext
+		wantViewer string
+	}{
+		{name: "ログイン中はユーザーIDで数える", auth: &dto.AuthContext{UserID: 7}, wantViewer: "u:7"},
+		{name: "未ログインは IP アドレスで数える", wantViewer: "ip:192.0.2.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mt := new(mockTweetService)
+			mt.On("FetchTweet", mock.Anything, int64(100)).Return(&dto.TweetRecord{ID: 100, ViewCount: 42}, nil)
+			mv := new(mockViewService)
+			mv.On("RecordViews", mock.Anything, tt.wantViewer, []int64{100}).Return().Once()
+			h := NewTweetHandler(mt, new(mockScheduledTweetService), mv)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			c.Params = []gin.Param{{Key: "id", Value: "100"}}
+			if tt.auth != nil {
+				c.Set(contextkeys.AuthPayloadKey, tt.auth)
+			}
+
+			h.Get(c)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			var resp struct {
+				Data app.TweetResponse `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, int64(42), resp.Data.ViewCount)
+			mv.AssertExpectations(t)
+		})
+	}
+}
+
 func TestTweetUpdate(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	tests := []struct {
@@ -268,12 +429,25 @@ func TestTweetUpdate(t *testing.T) {
 			},
 			expectedStatus: http.StatusUnprocessableEntity,
 		},
+		{
+			name:        "エラー：他人のツイートは編集できない",
+			tweetID:     "100",
+			requestBody: app.UpdateTweetRequest{Content: "乗っ取り"},
+			setupAuth: func(c *gin.Context) {
+				c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 999})
+			},
+			setupMock: func(mt *mockTweetService) {
+				mt.On("EditTweet", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, false, errcode.ErrForbidden)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mt := new(mockTweetService)
-			h := NewTweetHandler(mt)
+			h := NewTweetHandler(mt, new(mockScheduledTweetService), new(mockViewService))
 			tt.setupMock(mt)
 
 			w := httptest.NewRecorder()
@@ -331,7 +505,7 @@ func TestTweetDelete(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mt := new(mockTweetService)
-			h := NewTweetHandler(mt)
+			h := NewTweetHandler(mt, new(mockScheduledTweetService), new(mockViewService))
 			tt.setupMock(mt)
 
 			w := httptest.NewRecorder()
@@ -345,3 +519,184 @@ func TestTweetDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestTweetRestore(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		tweetID        string
+		setupAuth      func(c *gin.Context)
+		setupMock      func(mt *mockTweetService)
+		expectedStatus int
+	}{
+		{
+			name:    "復元成功",
+			tweetID: "100",
+			setupAuth: func(c *gin.Context) {
+				c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+			},
+			setupMock: func(mt *mockTweetService) {
+				mt.On("RestoreTweet", mock.Anything, int64(100), int64(10)).
+					Return(&dto.TweetRecord{ID: 100, UserID: 10, Content: "restored"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "エラー：猶予期間を過ぎている",
+			tweetID: "100",
+			setupAuth: func(c *gin.Context) {
+				c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+			},
+			setupMock: func(mt *mockTweetService) {
+				mt.On("RestoreTweet", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errcode.ErrRestorePeriodExpired)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "エラー：認証情報がない",
+			tweetID:        "100",
+			setupAuth:      func(c *gin.Context) {},
+			setupMock:      func(mt *mockTweetService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mt := new(mockTweetService)
+			h := NewTweetHandler(mt, new(mockScheduledTweetService), new(mockViewService))
+			tt.setupMock(mt)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+			c.Params = []gin.Param{{Key: "id", Value: tt.tweetID}}
+
+			tt.setupAuth(c)
+			h.Restore(c)
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mt.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTweetCreateScheduled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	publishAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	tests := []struct {
+		name           string
+		requestBody    any
+		setupMock      func(ms *mockScheduledTweetService)
+		expectedStatus int
+		checkResponse  func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "予約投稿の登録成功",
+			requestBody: app.CreateTweetRequest{Content: "あとで公開", PublishAt: &publishAt},
+			setupMock: func(ms *mockScheduledTweetService) {
+				ms.On("ScheduleTweet", mock.Anything, int64(10), "あとで公開", []dto.MediaAttachment(nil), mock.MatchedBy(func(p time.Time) bool {
+					return p.Equal(publishAt)
+				})).Return(&dto.ScheduledTweetRecord{ID: 5, UserID: 10, Content: "あとで公開", PublishAt: publishAt, Status: "pending"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp struct {
+					Data app.ScheduledTweetResponse `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, int64(5), resp.Data.ID)
+				assert.Equal(t, "pending", resp.Data.Status)
+			},
+		},
+		{
+			name:        "エラー：公開日時が過去",
+			requestBody: app.CreateTweetRequest{Content: "あとで公開", PublishAt: &publishAt},
+			setupMock: func(ms *mockScheduledTweetService) {
+				ms.On("ScheduleTweet", mock.Anything, int64(10), mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errcode.ErrInvalidPublishAt)
+			},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var resp app.Response
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.Equal(t, "INVALID_PUBLISH_AT", resp.Code)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mt := new(mockTweetService)
+			ms := new(mockScheduledTweetService)
+			h := NewTweetHandler(mt, ms, new(mockViewService))
+			tt.setupMock(ms)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			var buf bytes.Buffer
+			json.NewEncoder(&buf).Encode(tt.requestBody)
+			c.Request = httptest.NewRequest(http.MethodPost, "/tweets", &buf)
+			c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+			h.Create(c)
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, w)
+			}
+			mt.AssertNotCalled(t, "PostTweet")
+			ms.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTweetCancelScheduled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		scheduledID    string
+		setupMock      func(ms *mockScheduledTweetService)
+		expectedStatus int
+	}{
+		{
+			name:        "キャンセル成功",
+			scheduledID: "5",
+			setupMock: func(ms *mockScheduledTweetService) {
+				ms.On("CancelScheduled", mock.Anything, int64(5), int64(10)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "エラー：予約投稿が存在しない",
+			scheduledID: "5",
+			setupMock: func(ms *mockScheduledTweetService) {
+				ms.On("CancelScheduled", mock.Anything, int64(5), int64(10)).Return(errcode.ErrScheduledTweetNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "エラー：不正なID",
+			scheduledID:    "abc",
+			setupMock:      func(ms *mockScheduledTweetService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockScheduledTweetService)
+			h := NewTweetHandler(new(mockTweetService), ms, new(mockViewService))
+			tt.setupMock(ms)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodDelete, "/", nil)
+			c.Params = []gin.Param{{Key: "id", Value: tt.scheduledID}}
+			c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 10})
+
+			h.CancelScheduled(c)
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			ms.AssertExpectations(t)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"aita/internal/errcode"
 	"aita/internal/pkg/app"
 	"context"
+	"log/slog"
 
 	"net/http"
 
@@ -18,32 +19,50 @@ type UserService interface {
 }
 
 type SessionManager interface {
-	Issue(ctx context.Context, userID int64) (*dto.AuthRecord, error)
+	Issue(ctx context.Context, userID int64, client dto.ClientInfo) (*dto.AuthRecord, error)
 	Revoke(ctx context.Context, userID int64, token string) error
 }
 
+type EmailVerificationSender interface {
+	SendVerification(ctx context.Context, userID int64) error
+}
+
+type AccountService interface {
+	ChangePassword(ctx context.Context, userID int64, currentToken, currentPassword, newPassword string) error
+	ChangeEmail(ctx context.Context, userID int64, email string) (*dto.UserRecord, error)
+	ChangeUsername(ctx context.Context, userID int64, username string) (*dto.UserRecord, error)
+}
+
 type UserHandler struct {
 	userService    UserService
 	sessionService SessionManager
+	verificationSender EmailVerificationSender
+	accountService AccountService
 }
 
-func NewUserHandler(usvc UserService, sm SessionManager) *UserHandler {
+func NewUserHandler(usvc UserService, sm SessionManager, vs EmailVerificationSender, as AccountService) *UserHandler {
 	return &UserHandler{
 		userService:    usvc,
 		sessionService: sm,
+		verificationSender: vs,
+		accountService: as,
 	}
 }
 
 func (h *UserHandler) respondWithToken(c *gin.Context, user *dto.UserRecord, statusCode int) {
-	response, err := h.sessionService.Issue(c.Request.Context(), user.ID)
+	client := dto.ClientInfo{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+	response, err := h.sessionService.Issue(c.Request.Context(), user.ID, client)
 	if err != nil {
 		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
 		return
 	}
 
 	loginData := app.LoginResponse{
-		SessionToken: response.Token,
-		User:         user.ToUserResponse(),
+		TokenResponse: response.ToTokenResponse(),
+		User:          user.ToUserResponse(),
 	}
 	c.JSON(statusCode, app.Success(loginData))
 }
@@ -67,6 +86,11 @@ func (h *UserHandler) SignUp(c *gin.Context) {
 		return
 	}
 
+	// 確認メールが送れなくても登録は完了させ、再送で対応してもらう
+	if err := h.verificationSender.SendVerification(c.Request.Context(), user.ID); err != nil {
+		slog.Warn("確認メールの送信に失敗しました", "user_id", user.ID, "err", err)
+	}
+
 	h.respondWithToken(c, user, http.StatusCreated)
 }
 
@@ -123,3 +147,86 @@ func (h *UserHandler) Logout(c *gin.Context) {
 
 	c.JSON(http.StatusOK, app.SuccessMsg("ログアウトしました"))
 }
+
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	var req app.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errcode.FilterBindError(err)
+		c.JSON(errcode.GetStatusCode(appErr), app.Fail(appErr))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	if err := h.accountService.ChangePassword(c.Request.Context(), auth.UserID, auth.Token, req.CurrentPassword, req.NewPassword); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.SuccessMsg("パスワードを変更しました。他の端末からはログアウトされました"))
+}
+
+func (h *UserHandler) ChangeEmail(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	var req app.ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errcode.FilterBindError(err)
+		c.JSON(errcode.GetStatusCode(appErr), app.Fail(appErr))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	user, err := h.accountService.ChangeEmail(c.Request.Context(), auth.UserID, req.Email)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.Success(user.ToUserProfile()))
+}
+
+func (h *UserHandler) ChangeUsername(c *gin.Context) {
+	auth, err := GetAuthContext(c)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	var req app.ChangeUsernameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errcode.FilterBindError(err)
+		c.JSON(errcode.GetStatusCode(appErr), app.Fail(appErr))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	user, err := h.accountService.ChangeUsername(c.Request.Context(), auth.UserID, req.Username)
+	if err != nil {
+		c.JSON(errcode.GetStatusCode(err), app.Fail(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, app.Success(user.ToUserProfile()))
+}
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -43,8 +44,9 @@ func TestSignUp(t *testing.T) {
 					CreatedAt:    time.Now().UTC(),
 				}
 				sessionResp := &dto.AuthRecord{
-					UserID: record.ID,
-					Token:  "valid_token_string",
+					UserID:       record.ID,
+					Token:        "valid_token_string",
+					RefreshToken: "valid_refresh_token",
 				}
 				mu.On("Register", mock.Anything, mock.MatchedBy(func(username string) bool {
 					return username == "mock_user"
@@ -53,14 +55,15 @@ func TestSignUp(t *testing.T) {
 				}), mock.MatchedBy(func(password string) bool {
 					return password == "password123"
 				})).Return(record, nil)
-				ms.On("Issue", mock.Anything, record.ID).Return(sessionResp, nil)
+				ms.On("Issue", mock.Anything, record.ID, mock.Anything).Return(sessionResp, nil)
 			},
 			expectedStatus: http.StatusCreated,
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				var resp app.Response
 				json.Unmarshal(w.Body.Bytes(), &resp)
 				data := resp.Data.(map[string]any)
-				assert.Equal(t, "valid_token_string", data["session_token"])
+				assert.Equal(t, "valid_token_string", data["access_token"])
+				assert.Equal(t, "valid_refresh_token", data["refresh_token"])
 			},
 		},
 		{
@@ -117,7 +120,7 @@ func TestSignUp(t *testing.T) {
 				}), mock.MatchedBy(func(password string) bool {
 					return password == "password123"
 				})).Return(record, nil)
-				ms.On("Issue", mock.Anything, int64(50)).Return(nil, errors.New("redis connection failed"))
+				ms.On("Issue", mock.Anything, int64(50), mock.Anything).Return(nil, errors.New("redis connection failed"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -131,7 +134,9 @@ func TestSignUp(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mu, ms := new(mockUserService), new(mockSessionService)
-			h := NewUserHandler(mu, ms)
+			mv := new(mockEmailVerificationSender)
+			mv.On("SendVerification", mock.Anything, mock.Anything).Return(nil).Maybe()
+			h := NewUserHandler(mu, ms, mv, nil)
 			tt.setupMock(mu, ms)
 
 			var buf bytes.Buffer
@@ -155,6 +160,32 @@ func TestSignUp(t *testing.T) {
 	}
 }
 
+func TestSignUpSendsVerification(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	record := &dto.UserRecord{ID: 7, Username: "mock_user", Email: "taro@example.com"}
+
+	mu, ms, mv := new(mockUserService), new(mockSessionService), new(mockEmailVerificationSender)
+	mu.On("Register", mock.Anything, "mock_user", "taro@example.com", "password123").Return(record, nil)
+	// 接続元の情報はセッションに記録するために渡す
+	client := dto.ClientInfo{IPAddress: "192.0.2.1", UserAgent: "aita-test/1.0"}
+	ms.On("Issue", mock.Anything, int64(7), client).Return(&dto.AuthRecord{UserID: 7, Token: "token"}, nil)
+	mv.On("SendVerification", mock.Anything, int64(7)).Return(errors.New("pool overloaded"))
+	h := NewUserHandler(mu, ms, mv, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(`{"username":"mock_user","email":"taro@example.com","password":"password123"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("User-Agent", "aita-test/1.0")
+
+	h.SignUp(c)
+
+	// 確認メールの送信に失敗しても登録は成功する
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mv.AssertExpectations(t)
+	ms.AssertExpectations(t)
+}
+
 func TestLogin(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	tests := []struct {
@@ -177,14 +208,14 @@ func TestLogin(t *testing.T) {
 					Token:  "valid_token_string",
 				}
 				mu.On("Login", mock.Anything, "test@example.com", "password123").Return(user, nil)
-				ms.On("Issue", mock.Anything, user.ID).Return(sessionResp, nil)
+				ms.On("Issue", mock.Anything, user.ID, mock.Anything).Return(sessionResp, nil)
 			},
 			expectedStatus: http.StatusOK,
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				var resp app.Response
 				json.Unmarshal(w.Body.Bytes(), &resp)
 				data := resp.Data.(map[string]any)
-				assert.Equal(t, "valid_token_string", data["session_token"])
+				assert.Equal(t, "valid_token_string", data["access_token"])
 			},
 		},
 		{
@@ -224,7 +255,7 @@ func TestLogin(t *testing.T) {
 			setupMock: func(mu *mockUserService, ms *mockSessionService) {
 				user := &dto.UserRecord{ID: 1, Email: "test@example.com"}
 				mu.On("Login", mock.Anything, "test@example.com", "password123").Return(user, nil)
-				ms.On("Issue", mock.Anything, user.ID).Return(nil, errors.New("internal server error"))
+				ms.On("Issue", mock.Anything, user.ID, mock.Anything).Return(nil, errors.New("internal server error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -238,7 +269,7 @@ func TestLogin(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mu, ms := new(mockUserService), new(mockSessionService)
-			h := NewUserHandler(mu, ms)
+			h := NewUserHandler(mu, ms, nil, nil)
 			tt.setupMock(mu, ms)
 
 			var buf bytes.Buffer
@@ -322,7 +353,7 @@ func TestGetMe(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mu := new(mockUserService)
-			h := NewUserHandler(mu, nil)
+			h := NewUserHandler(mu, nil, nil, nil)
 			tt.setupMock(mu)
 
 			w := httptest.NewRecorder()
@@ -401,7 +432,7 @@ func TestUserHandlerLogout(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 
 			ms := new(mockSessionService)
-			h := NewUserHandler(nil, ms)
+			h := NewUserHandler(nil, ms, nil, nil)
 
 			tt.setupMock(ms)
 
@@ -423,3 +454,129 @@ func TestUserHandlerLogout(t *testing.T) {
 		})
 	}
 }
+
+func TestChangePassword(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(ma *mockAccountService)
+		expectedStatus int
+	}{
+		{
+			name: "正常系: 現在のトークンを渡して変更する",
+			body: `{"current_password":"oldpassword","new_password":"newpassword"}`,
+			setupMock: func(ma *mockAccountService) {
+				ma.On("ChangePassword", mock.Anything, int64(101), "valid_token", "oldpassword", "newpassword").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "新しいパスワードが短い",
+			body:           `{"current_password":"oldpassword","new_password":"short"}`,
+			setupMock:      func(ma *mockAccountService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "現在のパスワードが違う",
+			body: `{"current_password":"wrongpassword","new_password":"newpassword"}`,
+			setupMock: func(ma *mockAccountService) {
+				ma.On("ChangePassword", mock.Anything, int64(101), "valid_token", "wrongpassword", "newpassword").Return(errcode.ErrIncorrectPassword)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ma := new(mockAccountService)
+			tt.setupMock(ma)
+			h := NewUserHandler(nil, nil, nil, ma)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPatch, "/me/password", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 101, Token: "valid_token"})
+
+			h.ChangePassword(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			ma.AssertExpectations(t)
+		})
+	}
+}
+
+func TestChangeEmailAndUsername(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		setupMock      func(ma *mockAccountService)
+		expectedStatus int
+	}{
+		{
+			name: "メールアドレスを変更すると未確認で返る",
+			path: "/me/email",
+			body: `{"email":"new@example.com"}`,
+			setupMock: func(ma *mockAccountService) {
+				ma.On("ChangeEmail", mock.Anything, int64(101), "new@example.com").Return(&dto.UserRecord{ID: 101, Email: "new@example.com"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "メールアドレスの重複",
+			path: "/me/email",
+			body: `{"email":"taken@example.com"}`,
+			setupMock: func(ma *mockAccountService) {
+				ma.On("ChangeEmail", mock.Anything, int64(101), "taken@example.com").Return(nil, errcode.ErrEmailConflict)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "ユーザーネームの重複",
+			path: "/me/username",
+			body: `{"username":"taken_name"}`,
+			setupMock: func(ma *mockAccountService) {
+				ma.On("ChangeUsername", mock.Anything, int64(101), "taken_name").Return(nil, errcode.ErrUsernameConflict)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "ユーザーネームの待機期間中",
+			path: "/me/username",
+			body: `{"username":"new_name"}`,
+			setupMock: func(ma *mockAccountService) {
+				ma.On("ChangeUsername", mock.Anything, int64(101), "new_name").Return(nil, errcode.ErrUsernameChangeTooSoon)
+			},
+			expectedStatus: http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ma := new(mockAccountService)
+			tt.setupMock(ma)
+			h := NewUserHandler(nil, nil, nil, ma)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPatch, tt.path, strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set(contextkeys.AuthPayloadKey, &dto.AuthContext{UserID: 101, Token: "valid_token"})
+
+			if tt.path == "/me/email" {
+				h.ChangeEmail(c)
+			} else {
+				h.ChangeUsername(c)
+			}
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if w.Code == http.StatusOK {
+				assert.Contains(t, w.Body.String(), `"email_verified":false`)
+			}
+			ma.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package cache
+
+import (
+	"aita/internal/models"
+	"aita/internal/pkg/utils"
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 一覧がキャッシュ済みの場合のみ追加する。未キャッシュなら次の読み込みで DB から作り直す
+var addBookmarkLua = redis.NewScript(`
+    if redis.call("EXISTS", KEYS[1]) == 0 then
+        return 0
+    end
+    redis.call("ZADD", KEYS[1], ARGV[1], ARGV[2])
+    redis.call("EXPIRE", KEYS[1], ARGV[3])
+    return 1`)
+
+type redisBookmarkCache struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisBookmarkCache(c *redis.Client) *redisBookmarkCache {
+	return &redisBookmarkCache{
+		client: c,
+		prefix: "bookmark:",
+	}
+}
+
+// スコアはブックマークID、メンバーはツイートID
+func (c *redisBookmarkCache) userKey(userID int64) string {
+	return fmt.Sprintf("%suser:%d", c.prefix, userID)
+}
+
+func (c *redisBookmarkCache) Add(ctx context.Context, userID, tweetID int64, score float64) error {
+	ttl := int(utils.GetRandomExpiration(24*time.Hour, 1*time.Hour).Seconds())
+	_, err := addBookmarkLua.Run(ctx, c.client, []string{c.userKey(userID)}, score, tweetID, ttl).Result()
+	if err != nil {
+		slog.Error("[Redis Lua Error] ブックマークの追加に失敗しました", "user_id", userID, "err", err)
+	}
+	return err
+}
+
+func (c *redisBookmarkCache) AddBookmarks(ctx context.Context, userID int64, sets []*models.CacheMember) error {
+	if len(sets) == 0 {
+		return nil
+	}
+
+	key := c.userKey(userID)
+	zMembers := make([]redis.Z, len(sets))
+	for i, m := range sets {
+		zMembers[i] = redis.Z{Score: m.Score, Member: m.Member}
+	}
+
+	pipe := c.client.Pipeline()
+	pipe.ZAdd(ctx, key, zMembers...)
+	pipe.Expire(ctx, key, utils.GetRandomExpiration(24*time.Hour, 1*time.Hour))
+
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		slog.Error("[Redis Error] ブックマーク一覧のキャッシュ一括追加に失敗しました",
+			"user_id", userID,
+			"count", len(sets),
+			"err", err,
+		)
+	}
+	return err
+}
+
+// before より小さいスコアを新しい順に最大 limit 件返す。before が 0 なら先頭から。
+// 一覧が未キャッシュの場合は redis.Nil を返す
+func (c *redisBookmarkCache) Range(ctx context.Context, userID int64, before int64, limit int) ([]*models.CacheMember, error) {
+	key := c.userKey(userID)
+	max := "+inf"
+	if before > 0 {
+		max = "(" + strconv.FormatInt(before, 10)
+	}
+
+	pipe := c.client.Pipeline()
+	existsCmd := pipe.Exists(ctx, key)
+	rangeCmd := pipe.ZRangeArgsWithScores(ctx, redis.ZRangeArgs{
+		Key:     key,
+		Start:   max,
+		Stop:    "-inf",
+		ByScore: true,
+		Rev:     true,
+		Count:   int64(limit),
+	})
+	if _, err := pipe.Exec(ctx); err != nil {
+		slog.Error("[Redis Error] ブックマーク一覧の取得に失敗しました", "user_id", userID, "err", err)
+		return nil, err
+	}
+	if existsCmd.Val() == 0 {
+		return nil, redis.Nil
+	}
+
+	members := make([]*models.CacheMember, 0, len(rangeCmd.Val()))
+	for _, z := range rangeCmd.Val() {
+		s, _ := z.Member.(string)
+		tweetID, err := utils.ParseInt64WithErr(s)
+		if err != nil {
+			slog.Warn("[Redis Data Error] IDのパースに失敗しました", "value", z.Member, "err", err)
+			continue
+		}
+		members = append(members, &models.CacheMember{Member: tweetID, Score: z.Score})
+	}
+	return members, nil
+}
+
+func (c *redisBookmarkCache) Remove(ctx context.Context, tweetID int64, userIDs ...int64) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	member := strconv.FormatInt(tweetID, 10)
+	pipe := c.client.Pipeline()
+	for _, userID := range userIDs {
+		pipe.ZRem(ctx, c.userKey(userID), member)
+	}
+
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		slog.Error("[Redis Error] ブックマークの削除に失敗しました", "tweet_id", tweetID, "count", len(userIDs), "err", err)
+	}
+	return err
+}
+
+func (c *redisBookmarkCache) Invalidate(ctx context.Context, userID int64) error {
+	err := c.client.Del(ctx, c.userKey(userID)).Err()
+	if err != nil {
+		slog.Error("[Redis Error] ブックマーク一覧のキャッシュ削除に失敗しました", "user_id", userID, "err", err)
+	}
+	return err
+}
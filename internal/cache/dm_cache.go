@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"aita/internal/pkg/utils"
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 未読のない会話しかない場合でもキャッシュ済みと分かるよう、ハッシュに常に置いておくフィールド
+const dmUnreadSentinel = "_"
+
+// 未読数がキャッシュされている場合のみ加算する。なければ次の読み込み時に DB から数え直す
+var incrDMUnreadLua = redis.NewScript(`
+    if redis.call("EXISTS", KEYS[1]) == 0 then
+        return 0
+    end
+    return redis.call("HINCRBY", KEYS[1], ARGV[1], 1)`)
+
+type redisDMCache struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisDMCache(c *redis.Client) *redisDMCache {
+	return &redisDMCache{
+		client: c,
+		prefix: "dm:",
+	}
+}
+
+func (c *redisDMCache) unreadKey(userID int64) string {
+	return fmt.Sprintf("%sunread:%d", c.prefix, userID)
+}
+
+// 会話IDごとの未読数を返す。キャッシュがない場合は redis.Nil を返す
+func (c *redisDMCache) GetUnread(ctx context.Context, userID int64) (map[int64]int64, error) {
+	values, err := c.client.HGetAll(ctx, c.unreadKey(userID)).Result()
+	if err != nil {
+		slog.Error("[Redis Error] DM の未読数の取得に失敗しました", "user_id", userID, "err", err)
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, redis.Nil
+	}
+
+	counts := make(map[int64]int64, len(values))
+	for field, value := range values {
+		if field == dmUnreadSentinel {
+			continue
+		}
+		conversationID, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			continue
+		}
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		counts[conversationID] = count
+	}
+	return counts, nil
+}
+
+func (c *redisDMCache) SetUnread(ctx context.Context, userID int64, counts map[int64]int64) error {
+	key := c.unreadKey(userID)
+	fields := make([]any, 0, 2*len(counts)+2)
+	fields = append(fields, dmUnreadSentinel, 0)
+	for conversationID, count := range counts {
+		fields = append(fields, strconv.FormatInt(conversationID, 10), count)
+	}
+
+	pipe := c.client.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.HSet(ctx, key, fields...)
+	pipe.Expire(ctx, key, utils.GetRandomExpiration(24*time.Hour, 1*time.Hour))
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		slog.Error("[Redis Error] DM の未読数の保存に失敗しました", "user_id", userID, "err", err)
+	}
+	return err
+}
+
+func (c *redisDMCache) IncrUnread(ctx context.Context, conversationID int64, userIDs ...int64) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	field := strconv.FormatInt(conversationID, 10)
+	pipe := c.client.Pipeline()
+	for _, id := range userIDs {
+		incrDMUnreadLua.Eval(ctx, pipe, []string{c.unreadKey(id)}, field)
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		slog.Error("[Redis Lua Error] DM の未読数の加算に失敗しました", "conversation_id", conversationID, "count", len(userIDs), "err", err)
+	}
+	return err
+}
+
+func (c *redisDMCache) InvalidateUnread(ctx context.Context, userIDs ...int64) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		keys[i] = c.unreadKey(id)
+	}
+	err := c.client.Del(ctx, keys...).Err()
+	if err != nil {
+		slog.Error("[Redis Error] DM の未読数の削除に失敗しました", "count", len(userIDs), "err", err)
+	}
+	return err
+}
@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"aita/internal/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 再接続時の再送用に Stream へ残してから、同じ ID を付けて Pub/Sub へ流す
+var publishEventLua = redis.NewScript(`
+    local id = redis.call("XADD", KEYS[1], "MAXLEN", "~", ARGV[1], "*", "data", ARGV[2])
+    redis.call("PUBLISH", ARGV[3], id .. " " .. ARGV[2])
+    return id`)
+
+type redisEventStreamCache struct {
+	client    *redis.Client
+	streamKey string
+	channel   string
+	maxLen    int64
+}
+
+func NewRedisEventStreamCache(c *redis.Client, maxLen int64) *redisEventStreamCache {
+	return &redisEventStreamCache{
+		client:    c,
+		streamKey: "stream:events",
+		channel:   "stream:pubsub",
+		maxLen:    maxLen,
+	}
+}
+
+func (c *redisEventStreamCache) Publish(ctx context.Context, event *models.StreamEvent) (string, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return "", fmt.Errorf("イベントのシリアライズに失敗しました: %w", err)
+	}
+
+	id, err := publishEventLua.Run(ctx, c.client, []string{c.streamKey}, c.maxLen, payload, c.channel).Text()
+	if err != nil {
+		slog.Error("[Redis Lua Error] イベントの配信に失敗しました", "type", event.Type, "err", err)
+		return "", err
+	}
+	return id, nil
+}
+
+// afterID より後のイベントを古い順に最大 count 件返す。保持期間を過ぎたものは返らない
+func (c *redisEventStreamCache) Range(ctx context.Context, afterID string, count int64) ([]*models.StreamEvent, error) {
+	entries, err := c.client.XRangeN(ctx, c.streamKey, "("+afterID, "+", count).Result()
+	if err != nil {
+		slog.Error("[Redis Error] イベントの再送範囲の取得に失敗しました", "after_id", afterID, "err", err)
+		return nil, err
+	}
+
+	events := make([]*models.StreamEvent, 0, len(entries))
+	for _, e := range entries {
+		data, _ := e.Values["data"].(string)
+		event := &models.StreamEvent{}
+		if err := json.Unmarshal([]byte(data), event); err != nil {
+			slog.Warn("壊れたイベントを読み飛ばします", "id", e.ID, "err", err)
+			continue
+		}
+		event.ID = e.ID
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// 購読が確立してから返す。チャネルは close を呼ぶか接続が切れると閉じられる
+func (c *redisEventStreamCache) Subscribe(ctx context.Context) (<-chan *models.StreamEvent, func() error, error) {
+	ps := c.client.Subscribe(ctx, c.channel)
+	if _, err := ps.Receive(ctx); err != nil {
+		_ = ps.Close()
+		slog.Error("[Redis Error] イベントの購読に失敗しました", "channel", c.channel, "err", err)
+		return nil, nil, err
+	}
+
+	events := make(chan *models.StreamEvent, 256)
+	go func() {
+		defer close(events)
+		for msg := range ps.Channel() {
+			id, data, ok := strings.Cut(msg.Payload, " ")
+			if !ok {
+				continue
+			}
+			event := &models.StreamEvent{}
+			if err := json.Unmarshal([]byte(data), event); err != nil {
+				slog.Warn("壊れたイベントを読み飛ばします", "id", id, "err", err)
+				continue
+			}
+			event.ID = id
+			events <- event
+		}
+	}()
+	return events, ps.Close, nil
+}
@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"aita/internal/models"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type redisLinkPreviewCache struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisLinkPreviewCache(c *redis.Client) *redisLinkPreviewCache {
+	return &redisLinkPreviewCache{
+		client: c,
+		prefix: "preview:",
+	}
+}
+
+// URL は長さも文字種も不定なのでハッシュをキーにする
+func (c *redisLinkPreviewCache) previewKey(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return fmt.Sprintf("%surl:%s", c.prefix, hex.EncodeToString(sum[:]))
+}
+
+func (c *redisLinkPreviewCache) Set(ctx context.Context, preview *models.LinkPreview, ttl time.Duration) error {
+	data, err := json.Marshal(preview)
+	if err != nil {
+		slog.Error("[Redis Error] リンクプレビューのシリアライズに失敗しました", "url", preview.URL, "err", err)
+		return err
+	}
+
+	err = c.client.Set(ctx, c.previewKey(preview.URL), data, ttl).Err()
+	if err != nil {
+		slog.Error("[Redis Error] リンクプレビューの保存に失敗しました", "url", preview.URL, "err", err)
+	}
+	return err
+}
+
+func (c *redisLinkPreviewCache) Exists(ctx context.Context, url string) (bool, error) {
+	n, err := c.client.Exists(ctx, c.previewKey(url)).Result()
+	if err != nil {
+		slog.Error("[Redis Error] リンクプレビューの存在確認に失敗しました", "url", url, "err", err)
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// キャッシュにない URL は結果に含めない
+func (c *redisLinkPreviewCache) MultiGet(ctx context.Context, urls []string) (map[string]*models.LinkPreview, error) {
+	results := make(map[string]*models.LinkPreview, len(urls))
+	if len(urls) == 0 {
+		return results, nil
+	}
+
+	keys := make([]string, len(urls))
+	for i, u := range urls {
+		keys[i] = c.previewKey(u)
+	}
+
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		slog.Error("[Redis Error] リンクプレビューの一括取得に失敗しました", "count", len(urls), "err", err)
+		return nil, err
+	}
+
+	for i, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+
+		var preview models.LinkPreview
+		if err := json.Unmarshal([]byte(s), &preview); err != nil {
+			slog.Warn("[Redis Decode Error] リンクプレビューのデコードに失敗しました", "url", urls[i], "err", err)
+			continue
+		}
+		results[urls[i]] = &preview
+	}
+	return results, nil
+}
@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"aita/internal/pkg/utils"
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// メンバーのいないリストもキャッシュ済みと分かるよう、セットに常に入れておくメンバー(ユーザーIDは 1 以上)
+const listMemberSentinel = "0"
+
+// メンバーがキャッシュされている場合のみ追加する。なければ次の読み込み時に DB から作り直す
+var addListMemberLua = redis.NewScript(`
+    if redis.call("EXISTS", KEYS[1]) == 0 then
+        return 0
+    end
+    return redis.call("SADD", KEYS[1], ARGV[1])`)
+
+type redisListCache struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisListCache(c *redis.Client) *redisListCache {
+	return &redisListCache{
+		client: c,
+		prefix: "list:",
+	}
+}
+
+func (c *redisListCache) membersKey(listID int64) string {
+	return fmt.Sprintf("%smembers:%d", c.prefix, listID)
+}
+
+// キャッシュがない場合は redis.Nil を返す。順序は保証しない
+func (c *redisListCache) GetMembers(ctx context.Context, listID int64) ([]int64, error) {
+	members, err := c.client.SMembers(ctx, c.membersKey(listID)).Result()
+	if err != nil {
+		slog.Error("[Redis Error] リストメンバーの取得に失敗しました", "list_id", listID, "err", err)
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, redis.Nil
+	}
+
+	ids := make([]int64, 0, len(members))
+	for _, m := range members {
+		if m == listMemberSentinel {
+			continue
+		}
+		id, err := strconv.ParseInt(m, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (c *redisListCache) SetMembers(ctx context.Context, listID int64, userIDs []int64) error {
+	key := c.membersKey(listID)
+	members := make([]any, 0, len(userIDs)+1)
+	members = append(members, listMemberSentinel)
+	for _, id := range userIDs {
+		members = append(members, id)
+	}
+
+	pipe := c.client.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.SAdd(ctx, key, members...)
+	pipe.Expire(ctx, key, utils.GetRandomExpiration(24*time.Hour, 1*time.Hour))
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		slog.Error("[Redis Error] リストメンバーの保存に失敗しました", "list_id", listID, "count", len(userIDs), "err", err)
+	}
+	return err
+}
+
+func (c *redisListCache) AddMember(ctx context.Context, listID, userID int64) error {
+	_, err := addListMemberLua.Run(ctx, c.client, []string{c.membersKey(listID)}, userID).Result()
+	if err != nil {
+		slog.Error("[Redis Lua Error] リストメンバーの追加に失敗しました", "list_id", listID, "err", err)
+	}
+	return err
+}
+
+func (c *redisListCache) RemoveMember(ctx context.Context, listID, userID int64) error {
+	err := c.client.SRem(ctx, c.membersKey(listID), userID).Err()
+	if err != nil {
+		slog.Error("[Redis Error] リストメンバーの削除に失敗しました", "list_id", listID, "err", err)
+	}
+	return err
+}
+
+func (c *redisListCache) Invalidate(ctx context.Context, listID int64) error {
+	err := c.client.Del(ctx, c.membersKey(listID)).Err()
+	if err != nil {
+		slog.Error("[Redis Error] リストメンバーのキャッシュ削除に失敗しました", "list_id", listID, "err", err)
+	}
+	return err
+}
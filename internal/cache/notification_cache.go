@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"aita/internal/pkg/utils"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 未読数がキャッシュされている場合のみ加算する。なければ次の読み込み時に DB から数え直す
+var incrUnreadLua = redis.NewScript(`
+    if redis.call("EXISTS", KEYS[1]) == 0 then
+        return 0
+    end
+    return redis.call("INCR", KEYS[1])`)
+
+type redisNotificationCache struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisNotificationCache(c *redis.Client) *redisNotificationCache {
+	return &redisNotificationCache{
+		client: c,
+		prefix: "notification:",
+	}
+}
+
+func (c *redisNotificationCache) unreadKey(userID int64) string {
+	return fmt.Sprintf("%sunread:%d", c.prefix, userID)
+}
+
+// キャッシュがない場合は redis.Nil を返す
+func (c *redisNotificationCache) GetUnread(ctx context.Context, userID int64) (int64, error) {
+	count, err := c.client.Get(ctx, c.unreadKey(userID)).Int64()
+	if err != nil && err != redis.Nil {
+		slog.Error("[Redis Error] 未読数の取得に失敗しました", "user_id", userID, "err", err)
+	}
+	return count, err
+}
+
+func (c *redisNotificationCache) SetUnread(ctx context.Context, userID, count int64) error {
+	ttl := utils.GetRandomExpiration(24*time.Hour, 1*time.Hour)
+	err := c.client.Set(ctx, c.unreadKey(userID), count, ttl).Err()
+	if err != nil {
+		slog.Error("[Redis Error] 未読数の保存に失敗しました", "user_id", userID, "err", err)
+	}
+	return err
+}
+
+func (c *redisNotificationCache) IncrUnread(ctx context.Context, userIDs ...int64) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	pipe := c.client.Pipeline()
+	for _, id := range userIDs {
+		incrUnreadLua.Eval(ctx, pipe, []string{c.unreadKey(id)})
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		slog.Error("[Redis Lua Error] 未読数の加算に失敗しました", "count", len(userIDs), "err", err)
+	}
+	return err
+}
+
+func (c *redisNotificationCache) InvalidateUnread(ctx context.Context, userIDs ...int64) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		keys[i] = c.unreadKey(id)
+	}
+	err := c.client.Del(ctx, keys...).Err()
+	if err != nil {
+		slog.Error("[Redis Error] 未読数の削除に失敗しました", "count", len(userIDs), "err", err)
+	}
+	return err
+}
@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"aita/internal/pkg/utils"
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 集計ハッシュがある場合のみ加算し、永続化待ちとして記録する。
+// ハッシュがなければ次の読み込み時に DB から作り直すため何もしない
+var incrTallyLua = redis.NewScript(`
+    if redis.call("EXISTS", KEYS[1]) == 0 then
+        return 0
+    end
+    redis.call("HINCRBY", KEYS[1], ARGV[1], 1)
+    redis.call("SADD", KEYS[2], ARGV[2])
+    return 1`)
+
+type redisPollCache struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisPollCache(c *redis.Client) *redisPollCache {
+	return &redisPollCache{
+		client: c,
+		prefix: "poll:",
+	}
+}
+
+func (c *redisPollCache) tallyKey(pollID int64) string {
+	return fmt.Sprintf("%stally:%d", c.prefix, pollID)
+}
+
+func (c *redisPollCache) dirtyKey() string {
+	return fmt.Sprintf("%sdirty", c.prefix)
+}
+
+// 集計がない場合は redis.Nil を返す
+func (c *redisPollCache) GetTally(ctx context.Context, pollID int64) (map[int64]int64, error) {
+	res, err := c.client.HGetAll(ctx, c.tallyKey(pollID)).Result()
+	if err != nil {
+		slog.Error("[Redis Error] 投票集計の取得に失敗しました", "poll_id", pollID, "err", err)
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, redis.Nil
+	}
+
+	counts := make(map[int64]int64, len(res))
+	for field, value := range res {
+		optionID, err := utils.ParseInt64WithErr(field)
+		if err != nil {
+			slog.Warn("[Redis Data Error] 選択肢IDのパースに失敗しました", "value", field, "err", err)
+			continue
+		}
+		count, err := utils.ParseInt64WithErr(value)
+		if err != nil {
+			slog.Warn("[Redis Data Error] 票数のパースに失敗しました", "value", value, "err", err)
+			continue
+		}
+		counts[optionID] = count
+	}
+	return counts, nil
+}
+
+func (c *redisPollCache) SetTally(ctx context.Context, pollID int64, counts map[int64]int64) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	key := c.tallyKey(pollID)
+	values := make(map[string]any, len(counts))
+	for optionID, count := range counts {
+		values[strconv.FormatInt(optionID, 10)] = count
+	}
+
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.HSet(ctx, key, values)
+		pipe.Expire(ctx, key, utils.GetRandomExpiration(24*time.Hour, 1*time.Hour))
+		return nil
+	})
+	if err != nil {
+		slog.Error("[Redis Error] 投票集計の保存に失敗しました", "poll_id", pollID, "err", err)
+	}
+	return err
+}
+
+func (c *redisPollCache) IncrTally(ctx context.Context, pollID, optionID int64) error {
+	_, err := incrTallyLua.Run(ctx, c.client,
+		[]string{c.tallyKey(pollID), c.dirtyKey()},
+		optionID, pollID,
+	).Result()
+	if err != nil {
+		slog.Error("[Redis Lua Error] 投票集計の加算に失敗しました", "poll_id", pollID, "err", err)
+	}
+	return err
+}
+
+func (c *redisPollCache) DeleteTally(ctx context.Context, pollID int64) error {
+	err := c.client.Del(ctx, c.tallyKey(pollID)).Err()
+	if err != nil {
+		slog.Error("[Redis Error] 投票集計の削除に失敗しました", "poll_id", pollID, "err", err)
+	}
+	return err
+}
+
+// 永続化待ちの投票IDを最大 limit 件取り出す
+func (c *redisPollCache) PopDirty(ctx context.Context, limit int) ([]int64, error) {
+	res, err := c.client.SPopN(ctx, c.dirtyKey(), int64(limit)).Result()
+	if err != nil {
+		slog.Error("[Redis Error] 永続化待ちの投票の取得に失敗しました", "err", err)
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(res))
+	for _, s := range res {
+		id, err := utils.ParseInt64WithErr(s)
+		if err != nil {
+			slog.Warn("[Redis Data Error] IDのパースに失敗しました", "value", s, "err", err)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (c *redisPollCache) MarkDirty(ctx context.Context, pollIDs ...int64) error {
+	if len(pollIDs) == 0 {
+		return nil
+	}
+
+	members := make([]any, len(pollIDs))
+	for i, id := range pollIDs {
+		members[i] = id
+	}
+	err := c.client.SAdd(ctx, c.dirtyKey(), members...).Err()
+	if err != nil {
+		slog.Error("[Redis Error] 永続化待ちの投票の登録に失敗しました", "count", len(pollIDs), "err", err)
+	}
+	return err
+}
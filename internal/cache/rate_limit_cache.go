@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 固定時間枠のカウンタ。枠の最初の1回で期限を付ける
+var rateLimitLua = redis.NewScript(`
+    local count = redis.call("INCR", KEYS[1])
+    if count == 1 then
+        redis.call("PEXPIRE", KEYS[1], ARGV[1])
+    end
+    return count`)
+
+type redisRateLimitCache struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisRateLimitCache(c *redis.Client) *redisRateLimitCache {
+	return &redisRateLimitCache{
+		client: c,
+		prefix: "ratelimit:",
+	}
+}
+
+// window 内で limit 回目までは true を返す
+func (c *redisRateLimitCache) Allow(ctx context.Context, key string, limit int64, window time.Duration) (bool, error) {
+	count, err := rateLimitLua.Run(ctx, c.client, []string{c.prefix + key}, window.Milliseconds()).Int64()
+	if err != nil {
+		return false, fmt.Errorf("回数制限の確認に失敗しました(key:%s): %w", key, err)
+	}
+	return count <= limit, nil
+}
@@ -0,0 +1,112 @@
+package cache
+
+import (
+	"aita/internal/models"
+	"aita/internal/pkg/utils"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type redisRecommendationCache struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisRecommendationCache(c *redis.Client) *redisRecommendationCache {
+	return &redisRecommendationCache{
+		client: c,
+		prefix: "recommend:",
+	}
+}
+
+func (c *redisRecommendationCache) userKey(userID int64) string {
+	return fmt.Sprintf("%suser:%d", c.prefix, userID)
+}
+
+func (c *redisRecommendationCache) popularKey() string {
+	return fmt.Sprintf("%spopular", c.prefix)
+}
+
+func (c *redisRecommendationCache) SetForUser(ctx context.Context, userID int64, members []*models.CacheMember) error {
+	return c.replaceZSet(ctx, c.userKey(userID), members, utils.GetRandomExpiration(24*time.Hour, 3*time.Hour))
+}
+
+func (c *redisRecommendationCache) GetForUser(ctx context.Context, userID int64, limit int) ([]*models.CacheMember, error) {
+	return c.findTop(ctx, c.userKey(userID), limit)
+}
+
+func (c *redisRecommendationCache) SetPopular(ctx context.Context, members []*models.CacheMember) error {
+	return c.replaceZSet(ctx, c.popularKey(), members, utils.GetRandomExpiration(6*time.Hour, 1*time.Hour))
+}
+
+func (c *redisRecommendationCache) GetPopular(ctx context.Context, limit int) ([]*models.CacheMember, error) {
+	return c.findTop(ctx, c.popularKey(), limit)
+}
+
+// 古い候補が混ざらないよう、DELとZADDをMULTIで一括置換する
+func (c *redisRecommendationCache) replaceZSet(ctx context.Context, key string, members []*models.CacheMember, ttl time.Duration) error {
+	pipe := c.client.TxPipeline()
+	pipe.Del(ctx, key)
+
+	if len(members) > 0 {
+		zMembers := make([]redis.Z, len(members))
+		for i, m := range members {
+			zMembers[i] = redis.Z{
+				Score:  m.Score,
+				Member: m.Member,
+			}
+		}
+		pipe.ZAdd(ctx, key, zMembers...)
+		pipe.Expire(ctx, key, ttl)
+	}
+
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		slog.Error("[Redis Error] おすすめユーザーのキャッシュ保存に失敗しました",
+			"key", key,
+			"count", len(members),
+			"err", err,
+		)
+	}
+	return err
+}
+
+func (c *redisRecommendationCache) findTop(ctx context.Context, key string, limit int) ([]*models.CacheMember, error) {
+	if limit <= 0 {
+		return []*models.CacheMember{}, nil
+	}
+
+	res, err := c.client.ZRangeArgsWithScores(ctx, redis.ZRangeArgs{
+		Key:   key,
+		Start: 0,
+		Stop:  int64(limit - 1),
+		Rev:   true,
+	}).Result()
+	if err != nil {
+		slog.Error("[Redis Error] おすすめユーザーの取得に失敗しました", "key", key, "err", err)
+		return nil, err
+	}
+
+	members := make([]*models.CacheMember, 0, len(res))
+	for _, z := range res {
+		s, ok := z.Member.(string)
+		if !ok {
+			continue
+		}
+		id, err := utils.ParseInt64WithErr(s)
+		if err != nil {
+			slog.Warn("[Redis Data Error] IDのパースに失敗しました", "value", s, "err", err)
+			continue
+		}
+		members = append(members, &models.CacheMember{
+			Member: id,
+			Score:  z.Score,
+		})
+	}
+
+	return members, nil
+}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"aita/internal/models"
+	"aita/internal/pkg/utils"
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type redisScheduleCache struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisScheduleCache(c *redis.Client) *redisScheduleCache {
+	return &redisScheduleCache{
+		client: c,
+		prefix: "schedule:",
+	}
+}
+
+func (c *redisScheduleCache) dueKey() string {
+	return fmt.Sprintf("%sdue", c.prefix)
+}
+
+func (c *redisScheduleCache) Add(ctx context.Context, scheduledID int64, publishAt time.Time) error {
+	err := c.client.ZAdd(ctx, c.dueKey(), redis.Z{
+		Score:  float64(publishAt.Unix()),
+		Member: scheduledID,
+	}).Err()
+	if err != nil {
+		slog.Error("[Redis Error] 予約投稿の登録に失敗しました", "scheduled_id", scheduledID, "err", err)
+	}
+	return err
+}
+
+func (c *redisScheduleCache) AddBatch(ctx context.Context, members []*models.CacheMember) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	zMembers := make([]redis.Z, len(members))
+	for i, m := range members {
+		zMembers[i] = redis.Z{Score: m.Score, Member: m.Member}
+	}
+
+	err := c.client.ZAdd(ctx, c.dueKey(), zMembers...).Err()
+	if err != nil {
+		slog.Error("[Redis Error] 予約投稿の一括登録に失敗しました", "count", len(members), "err", err)
+	}
+	return err
+}
+
+func (c *redisScheduleCache) Remove(ctx context.Context, scheduledID int64) error {
+	err := c.client.ZRem(ctx, c.dueKey(), strconv.FormatInt(scheduledID, 10)).Err()
+	if err != nil {
+		slog.Error("[Redis Error] 予約投稿の削除に失敗しました", "scheduled_id", scheduledID, "err", err)
+	}
+	return err
+}
+
+func (c *redisScheduleCache) GetDue(ctx context.Context, now time.Time, limit int) ([]int64, error) {
+	res, err := c.client.ZRangeArgs(ctx, redis.ZRangeArgs{
+		Key:     c.dueKey(),
+		Start:   "-inf",
+		Stop:    strconv.FormatInt(now.Unix(), 10),
+		ByScore: true,
+		Count:   int64(limit),
+	}).Result()
+	if err != nil {
+		slog.Error("[Redis Error] 公開予定の予約投稿の取得に失敗しました", "err", err)
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(res))
+	for _, s := range res {
+		id, err := utils.ParseInt64WithErr(s)
+		if err != nil {
+			slog.Warn("[Redis Data Error] IDのパースに失敗しました", "value", s, "err", err)
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
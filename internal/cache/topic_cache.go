@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"aita/internal/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var errTopicNotListening = errors.New("トピックの購読が開始されていません")
+
+// プロセス全体で1本の Pub/Sub 接続を共有し、ローカルに購読者がいるトピックだけを SUBSCRIBE する
+type redisTopicCache struct {
+	client *redis.Client
+	prefix string
+
+	mu     sync.Mutex
+	pubsub *redis.PubSub
+}
+
+func NewRedisTopicCache(c *redis.Client) *redisTopicCache {
+	return &redisTopicCache{
+		client: c,
+		prefix: "ws:topic:",
+	}
+}
+
+func (c *redisTopicCache) channel(topic string) string {
+	return c.prefix + topic
+}
+
+func (c *redisTopicCache) Publish(ctx context.Context, message *models.TopicMessage) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("メッセージのシリアライズに失敗しました: %w", err)
+	}
+
+	if err := c.client.Publish(ctx, c.channel(message.Topic), payload).Err(); err != nil {
+		slog.Error("[Redis Error] トピックへの配信に失敗しました", "topic", message.Topic, "err", err)
+		return err
+	}
+	return nil
+}
+
+// 共有の Pub/Sub 接続を開く。切断時は go-redis が購読中のトピックを再購読する。
+// チャネルは Close を呼ぶまで閉じられない
+func (c *redisTopicCache) Listen(ctx context.Context) (<-chan *models.TopicMessage, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pubsub != nil {
+		_ = c.pubsub.Close()
+	}
+	c.pubsub = c.client.Subscribe(ctx)
+
+	messages := make(chan *models.TopicMessage, 256)
+	ps := c.pubsub
+	go func() {
+		defer close(messages)
+		for msg := range ps.Channel() {
+			message := &models.TopicMessage{}
+			if err := json.Unmarshal([]byte(msg.Payload), message); err != nil {
+				slog.Warn("壊れたメッセージを読み飛ばします", "channel", msg.Channel, "err", err)
+				continue
+			}
+			messages <- message
+		}
+	}()
+	return messages, nil
+}
+
+func (c *redisTopicCache) Join(ctx context.Context, topics ...string) error {
+	return c.update(ctx, topics, true)
+}
+
+func (c *redisTopicCache) Leave(ctx context.Context, topics ...string) error {
+	return c.update(ctx, topics, false)
+}
+
+func (c *redisTopicCache) update(ctx context.Context, topics []string, join bool) error {
+	if len(topics) == 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	ps := c.pubsub
+	c.mu.Unlock()
+	if ps == nil {
+		return errTopicNotListening
+	}
+
+	channels := make([]string, len(topics))
+	for i, t := range topics {
+		channels[i] = c.channel(t)
+	}
+
+	var err error
+	if join {
+		err = ps.Subscribe(ctx, channels...)
+	} else {
+		err = ps.Unsubscribe(ctx, channels...)
+	}
+	if err != nil {
+		slog.Error("[Redis Error] トピックの購読状態の更新に失敗しました", "join", join, "count", len(topics), "err", err)
+	}
+	return err
+}
+
+func (c *redisTopicCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pubsub == nil {
+		return nil
+	}
+	err := c.pubsub.Close()
+	c.pubsub = nil
+	return err
+}
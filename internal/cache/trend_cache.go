@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"aita/internal/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type redisTrendCache struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisTrendCache(c *redis.Client) *redisTrendCache {
+	return &redisTrendCache{
+		client: c,
+		prefix: "trend:",
+	}
+}
+
+func (c *redisTrendCache) bucketKey(bucket int64) string {
+	return fmt.Sprintf("%sbucket:%d", c.prefix, bucket)
+}
+
+func (c *redisTrendCache) topKey() string {
+	return c.prefix + "top"
+}
+
+// 時間枠ごとのソート済みセットにハッシュタグの出現数を加算する。古い枠は ttl で消える
+func (c *redisTrendCache) IncrHashtags(ctx context.Context, bucket int64, hashtags []string, ttl time.Duration) error {
+	if len(hashtags) == 0 {
+		return nil
+	}
+
+	key := c.bucketKey(bucket)
+	pipe := c.client.Pipeline()
+	for _, tag := range hashtags {
+		pipe.ZIncrBy(ctx, key, 1, tag)
+	}
+	pipe.Expire(ctx, key, ttl)
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		slog.Error("[Redis Error] ハッシュタグの集計に失敗しました", "bucket", bucket, "count", len(hashtags), "err", err)
+	}
+	return err
+}
+
+// 指定した枠の出現数をハッシュタグごとに合算する。期限切れの枠は 0 として扱う
+func (c *redisTrendCache) SumBuckets(ctx context.Context, buckets []int64) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	if len(buckets) == 0 {
+		return counts, nil
+	}
+
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.ZSliceCmd, len(buckets))
+	for i, b := range buckets {
+		cmds[i] = pipe.ZRangeWithScores(ctx, c.bucketKey(b), 0, -1)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		slog.Error("[Redis Error] ハッシュタグの集計の取得に失敗しました", "buckets", len(buckets), "err", err)
+		return nil, err
+	}
+
+	for _, cmd := range cmds {
+		for _, z := range cmd.Val() {
+			tag, ok := z.Member.(string)
+			if !ok {
+				continue
+			}
+			counts[tag] += int64(z.Score)
+		}
+	}
+	return counts, nil
+}
+
+func (c *redisTrendCache) SetTop(ctx context.Context, trends []*models.Trend, ttl time.Duration) error {
+	data, err := json.Marshal(trends)
+	if err != nil {
+		slog.Error("[Redis Error] トレンドのシリアライズに失敗しました", "err", err)
+		return err
+	}
+
+	err = c.client.Set(ctx, c.topKey(), data, ttl).Err()
+	if err != nil {
+		slog.Error("[Redis Error] トレンドの保存に失敗しました", "err", err)
+	}
+	return err
+}
+
+// まだ集計されていない場合は nil を返す
+func (c *redisTrendCache) GetTop(ctx context.Context) ([]*models.Trend, error) {
+	data, err := c.client.Get(ctx, c.topKey()).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		slog.Error("[Redis Error] トレンドの取得に失敗しました", "err", err)
+		return nil, err
+	}
+
+	var trends []*models.Trend
+	if err := json.Unmarshal(data, &trends); err != nil {
+		slog.Error("[Redis Error] トレンドのデシリアライズに失敗しました", "err", err)
+		return nil, err
+	}
+	return trends, nil
+}
@@ -0,0 +1,207 @@
+package cache
+
+import (
+	"aita/internal/pkg/utils"
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 時間枠内で初めての閲覧者の場合のみ、永続化待ちの増分とキャッシュ済みの合計に加算する。
+// HyperLogLog の推定なので、まれに新しい閲覧者でも数えられないことがある
+var recordViewLua = redis.NewScript(`
+    if redis.call("PFADD", KEYS[1], ARGV[1]) == 0 then
+        return 0
+    end
+    if redis.call("TTL", KEYS[1]) < 0 then
+        redis.call("EXPIRE", KEYS[1], ARGV[3])
+    end
+    redis.call("HINCRBY", KEYS[3], ARGV[2], 1)
+    if redis.call("EXISTS", KEYS[2]) == 1 then
+        redis.call("INCR", KEYS[2])
+    end
+    return 1`)
+
+// 永続化待ちの増分を取り出し、同時に加算された分を取りこぼさないよう同じスクリプト内で消す
+var popPendingViewsLua = redis.NewScript(`
+    local values = redis.call("HGETALL", KEYS[1])
+    redis.call("DEL", KEYS[1])
+    return values`)
+
+type redisViewCache struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisViewCache(c *redis.Client) *redisViewCache {
+	return &redisViewCache{
+		client: c,
+		prefix: "views:",
+	}
+}
+
+func (c *redisViewCache) hllKey(tweetID, window int64) string {
+	return fmt.Sprintf("%shll:%d:%d", c.prefix, tweetID, window)
+}
+
+func (c *redisViewCache) countKey(tweetID int64) string {
+	return fmt.Sprintf("%scount:%d", c.prefix, tweetID)
+}
+
+func (c *redisViewCache) pendingKey() string {
+	return c.prefix + "pending"
+}
+
+// 新しく数えた閲覧の件数を返す
+func (c *redisViewCache) RecordViews(ctx context.Context, viewer string, window int64, tweetIDs []int64, ttl time.Duration) (int64, error) {
+	if len(tweetIDs) == 0 {
+		return 0, nil
+	}
+
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.Cmd, len(tweetIDs))
+	for i, id := range tweetIDs {
+		cmds[i] = recordViewLua.Eval(ctx, pipe,
+			[]string{c.hllKey(id, window), c.countKey(id), c.pendingKey()},
+			viewer, id, int64(ttl/time.Second),
+		)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		slog.Error("[Redis Lua Error] 閲覧の記録に失敗しました", "count", len(tweetIDs), "err", err)
+		return 0, err
+	}
+
+	var recorded int64
+	for _, cmd := range cmds {
+		n, _ := cmd.Int64()
+		recorded += n
+	}
+	return recorded, nil
+}
+
+// キャッシュにないツイートは結果に含めない
+func (c *redisViewCache) GetCounts(ctx context.Context, tweetIDs []int64) (map[int64]int64, error) {
+	counts := make(map[int64]int64, len(tweetIDs))
+	if len(tweetIDs) == 0 {
+		return counts, nil
+	}
+
+	keys := make([]string, len(tweetIDs))
+	for i, id := range tweetIDs {
+		keys[i] = c.countKey(id)
+	}
+
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		slog.Error("[Redis Error] 閲覧数の一括取得に失敗しました", "count", len(tweetIDs), "err", err)
+		return nil, err
+	}
+
+	for i, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		count, err := utils.ParseInt64WithErr(s)
+		if err != nil {
+			slog.Warn("[Redis Data Error] 閲覧数のパースに失敗しました", "value", s, "err", err)
+			continue
+		}
+		counts[tweetIDs[i]] = count
+	}
+	return counts, nil
+}
+
+// 読み込み中に加算された合計を上書きしないよう、キャッシュがない場合のみ保存する
+func (c *redisViewCache) SetCounts(ctx context.Context, counts map[int64]int64) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	pipe := c.client.Pipeline()
+	for id, count := range counts {
+		pipe.SetNX(ctx, c.countKey(id), count, utils.GetRandomExpiration(1*time.Hour, 10*time.Minute))
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		slog.Error("[Redis Error] 閲覧数の保存に失敗しました", "count", len(counts), "err", err)
+	}
+	return err
+}
+
+// 永続化待ちの増分のうち指定したツイートの分を返す。増分がなければ結果に含めない
+func (c *redisViewCache) GetPending(ctx context.Context, tweetIDs []int64) (map[int64]int64, error) {
+	pending := make(map[int64]int64, len(tweetIDs))
+	if len(tweetIDs) == 0 {
+		return pending, nil
+	}
+
+	fields := make([]string, len(tweetIDs))
+	for i, id := range tweetIDs {
+		fields[i] = strconv.FormatInt(id, 10)
+	}
+
+	values, err := c.client.HMGet(ctx, c.pendingKey(), fields...).Result()
+	if err != nil {
+		slog.Error("[Redis Error] 永続化待ちの閲覧数の取得に失敗しました", "count", len(tweetIDs), "err", err)
+		return nil, err
+	}
+
+	for i, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		delta, err := utils.ParseInt64WithErr(s)
+		if err != nil {
+			continue
+		}
+		pending[tweetIDs[i]] = delta
+	}
+	return pending, nil
+}
+
+func (c *redisViewCache) PopPending(ctx context.Context) (map[int64]int64, error) {
+	res, err := popPendingViewsLua.Run(ctx, c.client, []string{c.pendingKey()}).StringSlice()
+	if err != nil {
+		slog.Error("[Redis Lua Error] 永続化待ちの閲覧数の取り出しに失敗しました", "err", err)
+		return nil, err
+	}
+
+	deltas := make(map[int64]int64, len(res)/2)
+	for i := 0; i+1 < len(res); i += 2 {
+		tweetID, err := utils.ParseInt64WithErr(res[i])
+		if err != nil {
+			slog.Warn("[Redis Data Error] IDのパースに失敗しました", "value", res[i], "err", err)
+			continue
+		}
+		delta, err := utils.ParseInt64WithErr(res[i+1])
+		if err != nil {
+			slog.Warn("[Redis Data Error] 閲覧数のパースに失敗しました", "value", res[i+1], "err", err)
+			continue
+		}
+		deltas[tweetID] = delta
+	}
+	return deltas, nil
+}
+
+// 永続化に失敗した増分を戻す
+func (c *redisViewCache) RestorePending(ctx context.Context, deltas map[int64]int64) error {
+	if len(deltas) == 0 {
+		return nil
+	}
+
+	pipe := c.client.Pipeline()
+	for id, delta := range deltas {
+		pipe.HIncrBy(ctx, c.pendingKey(), strconv.FormatInt(id, 10), delta)
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		slog.Error("[Redis Error] 永続化待ちの閲覧数の復元に失敗しました", "count", len(deltas), "err", err)
+	}
+	return err
+}
@@ -45,6 +45,8 @@ type Config struct{
 	RedisPassword    	string
 	TweetStream      	string 
     FanoutGroup      	string 
+    PreviewGroup     	string 
+    TrendGroup       	string 
     ConsumerName    	string 
 
 	DBMaxOpenConns    	int 
@@ -56,6 +58,60 @@ type Config struct{
     WorkerPoolSize   	int 
     BackfillPoolSize 	int 
 
+	RecommendationInterval int
+
+	TweetEditWindow  	int
+	TweetMaxEdits    	int
+	TweetRestoreGrace   int
+	TweetRetention      int
+	TweetPurgeInterval  int
+	TweetSchedulerInterval int
+
+	MediaDir            string
+	MediaBaseURL        string
+	MediaMaxBytes       int
+	MediaMaxPixels      int
+	MediaThumbnailSize  int
+	MediaOrphanTTL      int
+	MediaGCInterval     int
+
+	PollTallyInterval   int
+	PollCloseInterval   int
+
+	LinkPreviewTimeout  int
+	LinkPreviewMaxBytes int
+
+	StreamMaxConnsPerUser   int
+	StreamHeartbeatInterval int
+	StreamBacklogSize       int
+
+	GatewayPingInterval     int
+	// 同一オリジン以外に WebSocket 接続を許可するオリジン(カンマ区切り)
+	GatewayAllowedOrigins   string
+
+	TrendInterval           int
+	TrendMinAccountAge      int
+
+	ViewFlushInterval       int
+
+	AnalyticsRollupInterval int
+
+	AppBaseURL              string
+	MailDriver              string
+	MailFrom                string
+	MailDir                 string
+	SMTPHost                string
+	SMTPPort                int
+	SMTPUsername            string
+	SMTPPassword            string
+	PasswordResetTTL        int
+	EmailVerificationTTL    int
+	UnverifiedRestrictions  string
+	UsernameChangeCooldown  int
+	AccessTokenTTL          int
+	RefreshTokenTTL         int
+	SessionMaxLife          int
+
     //BackfillDBLimit 	int 
 }
 
@@ -91,6 +147,8 @@ func LoadConfig() *Config{
 		RedisPassword: 		os.Getenv("REDIS_PASSWORD"),
 		TweetStream:      	os.Getenv("TWEET_STREAM"),
         FanoutGroup:      	os.Getenv("FANOUT_GROUP"),
+        PreviewGroup:     	os.Getenv("PREVIEW_GROUP"),
+        TrendGroup:       	os.Getenv("TREND_GROUP"),
         ConsumerName:     	os.Getenv("CONSUMER_NAME"),
 		DBMaxOpenConns:    	getEnvInt("DB_MAX_OPEN", 300),
         DBMaxIdleConns:    	getEnvInt("DB_MAX_IDLE", 50),
@@ -99,6 +157,48 @@ func LoadConfig() *Config{
 		RedisMinIdleConns:  getEnvInt("REDIS_MIN_IDLE",20),
 		BackfillPoolSize: 	getEnvInt("BACKFILL_POOL_SIZE", 500),
 		WorkerPoolSize: 	getEnvInt("WORKER_POOL_SIZE", 2000),
+		RecommendationInterval: getEnvInt("RECOMMENDATION_INTERVAL", 60),
+		TweetEditWindow: 	getEnvInt("TWEET_EDIT_WINDOW", 10),
+		TweetMaxEdits: 		getEnvInt("TWEET_MAX_EDITS", 5),
+		TweetRestoreGrace: 	getEnvInt("TWEET_RESTORE_GRACE", 30),
+		TweetRetention: 	getEnvInt("TWEET_RETENTION", 720),
+		TweetPurgeInterval: getEnvInt("TWEET_PURGE_INTERVAL", 60),
+		TweetSchedulerInterval: getEnvInt("TWEET_SCHEDULER_INTERVAL", 5),
+		MediaDir:           os.Getenv("MEDIA_DIR"),
+		MediaBaseURL:       os.Getenv("MEDIA_BASE_URL"),
+		MediaMaxBytes:      getEnvInt("MEDIA_MAX_BYTES", 5<<20),
+		MediaMaxPixels:     getEnvInt("MEDIA_MAX_PIXELS", 40_000_000),
+		MediaThumbnailSize: getEnvInt("MEDIA_THUMBNAIL_SIZE", 320),
+		MediaOrphanTTL:     getEnvInt("MEDIA_ORPHAN_TTL", 24),
+		MediaGCInterval:    getEnvInt("MEDIA_GC_INTERVAL", 60),
+		PollTallyInterval:  getEnvInt("POLL_TALLY_INTERVAL", 30),
+		PollCloseInterval:  getEnvInt("POLL_CLOSE_INTERVAL", 30),
+		LinkPreviewTimeout: getEnvInt("LINK_PREVIEW_TIMEOUT", 5),
+		LinkPreviewMaxBytes: getEnvInt("LINK_PREVIEW_MAX_BYTES", 512<<10),
+		StreamMaxConnsPerUser:   getEnvInt("STREAM_MAX_CONNS_PER_USER", 3),
+		StreamHeartbeatInterval: getEnvInt("STREAM_HEARTBEAT_INTERVAL", 15),
+		StreamBacklogSize:       getEnvInt("STREAM_BACKLOG_SIZE", 10000),
+		GatewayPingInterval:     getEnvInt("GATEWAY_PING_INTERVAL", 30),
+		GatewayAllowedOrigins:   os.Getenv("GATEWAY_ALLOWED_ORIGINS"),
+		TrendInterval:           getEnvInt("TREND_INTERVAL", 60),
+		TrendMinAccountAge:      getEnvInt("TREND_MIN_ACCOUNT_AGE", 72),
+		ViewFlushInterval:       getEnvInt("VIEW_FLUSH_INTERVAL", 60),
+		AnalyticsRollupInterval: getEnvInt("ANALYTICS_ROLLUP_INTERVAL", 10),
+		AppBaseURL:              os.Getenv("APP_BASE_URL"),
+		MailDriver:              os.Getenv("MAIL_DRIVER"),
+		MailFrom:                os.Getenv("MAIL_FROM"),
+		MailDir:                 os.Getenv("MAIL_DIR"),
+		SMTPHost:                os.Getenv("SMTP_HOST"),
+		SMTPPort:                getEnvInt("SMTP_PORT", 587),
+		SMTPUsername:            os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:            os.Getenv("SMTP_PASSWORD"),
+		PasswordResetTTL:        getEnvInt("PASSWORD_RESET_TTL", 30),
+		EmailVerificationTTL:    getEnvInt("EMAIL_VERIFICATION_TTL", 24),
+		UnverifiedRestrictions:  os.Getenv("UNVERIFIED_RESTRICTIONS"),
+		UsernameChangeCooldown:  getEnvInt("USERNAME_CHANGE_COOLDOWN", 30),
+		AccessTokenTTL:          getEnvInt("ACCESS_TOKEN_TTL", 15),
+		RefreshTokenTTL:         getEnvInt("REFRESH_TOKEN_TTL", 24),
+		SessionMaxLife:          getEnvInt("SESSION_MAX_LIFE", 168),
 		//BackfillDBLimit:	getEnvInt("BACKFILL_DB_LIMIT",70),	
 	}
 
@@ -108,10 +208,35 @@ func LoadConfig() *Config{
     if cfg.FanoutGroup == "" { 
 		cfg.FanoutGroup = "aita:fanout:group" 
 	}
+    if cfg.PreviewGroup == "" { 
+		cfg.PreviewGroup = "aita:preview:group" 
+	}
+    if cfg.TrendGroup == "" { 
+		cfg.TrendGroup = "aita:trend:group" 
+	}
     if cfg.ConsumerName == "" {
 		hostname, _ := os.Hostname()
         cfg.ConsumerName = "api-node-" + hostname
     }
+	if cfg.MediaDir == "" {
+		cfg.MediaDir = GetPath("uploads")
+	}
+	if cfg.MediaBaseURL == "" {
+		cfg.MediaBaseURL = "/media"
+	}
+	if cfg.AppBaseURL == "" {
+		cfg.AppBaseURL = "http://localhost:8080"
+	}
+	// smtp 以外はログ出力のみで実際には送らない
+	if cfg.MailDriver == "" {
+		cfg.MailDriver = "log"
+	}
+	if cfg.MailFrom == "" {
+		cfg.MailFrom = "no-reply@aita.local"
+	}
+	if cfg.UnverifiedRestrictions == "" {
+		cfg.UnverifiedRestrictions = "tweet"
+	}
 
 
 
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"aita/internal/models"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const dailyStatsColumns = `user_id, day, followers_gained, followers_lost, tweets_posted, impressions`
+
+const dayLayout = "2006-01-02"
+
+type postgresAnalyticsStore struct {
+	BaseStore
+}
+
+func NewPostgresAnalyticsStore(db *sqlx.DB) *postgresAnalyticsStore {
+	return &postgresAnalyticsStore{
+		BaseStore: BaseStore{
+			database: db,
+		},
+	}
+}
+
+// from から to の前日までの日別集計を元のテーブルから作り直し、作成した行数を返す。
+// 削除されたツイートなどで値が減る場合もあるため、対象の日は一度消してから入れ直す
+func (s *postgresAnalyticsStore) RollupDailyStats(ctx context.Context, from, to time.Time) (int64, error) {
+	fromDay := from.UTC().Format(dayLayout)
+	toDay := to.UTC().Format(dayLayout)
+
+	var rows int64
+	err := s.withTx(ctx, func(ctx context.Context) error {
+		deleteQuery := `DELETE FROM user_daily_stats WHERE day >= $1::DATE AND day < $2::DATE`
+		if _, err := s.BaseStore.conn(ctx).ExecContext(ctx, deleteQuery, fromDay, toDay); err != nil {
+			return err
+		}
+
+		insertQuery := `
+			INSERT INTO user_daily_stats(` + dailyStatsColumns + `, updated_at)
+			SELECT user_id, day, SUM(gained), SUM(lost), SUM(posted), SUM(impressions), NOW()
+			FROM (
+				SELECT following_id AS user_id, (created_at AT TIME ZONE 'UTC')::DATE AS day,
+					COUNT(*) FILTER (WHERE action = 'follow') AS gained,
+					COUNT(*) FILTER (WHERE action = 'unfollow') AS lost,
+					0 AS posted, 0 AS impressions
+				FROM follow_events
+				WHERE created_at >= $1::DATE AT TIME ZONE 'UTC' AND created_at < $2::DATE AT TIME ZONE 'UTC'
+				GROUP BY 1, 2
+				UNION ALL
+				SELECT user_id, (created_at AT TIME ZONE 'UTC')::DATE, 0, 0, COUNT(*), 0
+				FROM tweets
+				WHERE created_at >= $1::DATE AT TIME ZONE 'UTC' AND created_at < $2::DATE AT TIME ZONE 'UTC'
+					AND deleted_at IS NULL
+				GROUP BY 1, 2
+				UNION ALL
+				SELECT t.user_id, v.day, 0, 0, 0, SUM(v.view_count)
+				FROM tweet_daily_views v JOIN tweets t ON t.id = v.tweet_id
+				WHERE v.day >= $1::DATE AND v.day < $2::DATE
+				GROUP BY 1, 2
+			) s
+			WHERE EXISTS (SELECT 1 FROM users u WHERE u.id = s.user_id)
+			GROUP BY user_id, day`
+		res, err := s.BaseStore.conn(ctx).ExecContext(ctx, insertQuery, fromDay, toDay)
+		if err != nil {
+			return err
+		}
+		rows, err = res.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("日別集計の作成に失敗しました(%s〜%s): %w", fromDay, toDay, err)
+	}
+	return rows, nil
+}
+
+// from から to までの日別集計を日付順に返す。動きのなかった日は含まない
+func (s *postgresAnalyticsStore) GetDailyStats(ctx context.Context, userID int64, from, to time.Time) ([]*models.DailyStats, error) {
+	stats := []*models.DailyStats{}
+	query := `SELECT ` + dailyStatsColumns + ` FROM user_daily_stats
+		WHERE user_id = $1 AND day >= $2::DATE AND day <= $3::DATE
+		ORDER BY day`
+	err := s.BaseStore.conn(ctx).SelectContext(ctx, &stats, query, userID, from.UTC().Format(dayLayout), to.UTC().Format(dayLayout))
+	if err != nil {
+		return nil, fmt.Errorf("日別集計の取得に失敗しました(user_id:%d): %w", userID, err)
+	}
+	return stats, nil
+}
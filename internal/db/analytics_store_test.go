@@ -0,0 +1,67 @@
+package db
+
+import (
+	"aita/internal/models"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollupDailyStats(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	u := setupUsers(t, ctx, 3)
+	author, fan1, fan2 := u[0], u[1], u[2]
+
+	_, err := testFollowStore.Create(ctx, &models.Follow{FollowerID: fan1.ID, FollowingID: author.ID})
+	require.NoError(t, err)
+	_, err = testFollowStore.Create(ctx, &models.Follow{FollowerID: fan2.ID, FollowingID: author.ID})
+	require.NoError(t, err)
+	require.NoError(t, testFollowStore.Delete(ctx, fan2.ID, author.ID))
+
+	a, err := testTweetStore.CreateTweet(ctx, &models.Tweet{UserID: author.ID, Content: "a"})
+	require.NoError(t, err)
+	_, err = testTweetStore.CreateTweet(ctx, &models.Tweet{UserID: author.ID, Content: "b"})
+	require.NoError(t, err)
+	require.NoError(t, testViewStore.IncrementViewCounts(ctx, map[int64]int64{a.ID: 7}))
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	t.Run("正常系: 今日の増減・投稿数・閲覧数が集計されること", func(t *testing.T) {
+		rows, err := testAnalyticsStore.RollupDailyStats(ctx, today, tomorrow)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, rows)
+
+		stats, err := testAnalyticsStore.GetDailyStats(ctx, author.ID, today.AddDate(0, 0, -7), today)
+		require.NoError(t, err)
+		require.Len(t, stats, 1)
+		assert.Equal(t, today.Format("2006-01-02"), stats[0].Day.UTC().Format("2006-01-02"))
+		assert.EqualValues(t, 2, stats[0].FollowersGained)
+		assert.EqualValues(t, 1, stats[0].FollowersLost)
+		assert.EqualValues(t, 2, stats[0].TweetsPosted)
+		assert.EqualValues(t, 7, stats[0].Impressions)
+	})
+
+	t.Run("正常系: 作り直しても行が重複しないこと", func(t *testing.T) {
+		require.NoError(t, testViewStore.IncrementViewCounts(ctx, map[int64]int64{a.ID: 3}))
+		_, err := testAnalyticsStore.RollupDailyStats(ctx, today, tomorrow)
+		require.NoError(t, err)
+
+		stats, err := testAnalyticsStore.GetDailyStats(ctx, author.ID, today, today)
+		require.NoError(t, err)
+		require.Len(t, stats, 1)
+		assert.EqualValues(t, 10, stats[0].Impressions)
+	})
+
+	t.Run("正常系: 動きのないユーザーは空であること", func(t *testing.T) {
+		stats, err := testAnalyticsStore.GetDailyStats(ctx, fan2.ID, today, today)
+		require.NoError(t, err)
+		assert.Empty(t, stats)
+	})
+}
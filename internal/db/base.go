@@ -31,6 +31,25 @@ func (b *BaseStore) conn(ctx context.Context) Execer {
 	return b.database
 }
 
+// 呼び出し元のトランザクションがあればそれに参加し、なければ新しく開始する
+func (b *BaseStore) withTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if extractTx(ctx) != nil {
+		return fn(ctx)
+	}
+
+	tx, err := b.database.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(injectTx(ctx, tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
 type txKey struct{}
 
 var activeTxKey = txKey{}
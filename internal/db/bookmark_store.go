@@ -0,0 +1,96 @@
+package db
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const bookmarkColumns = `id, user_id, tweet_id, created_at`
+
+type postgresBookmarkStore struct {
+	BaseStore
+}
+
+func NewPostgresBookmarkStore(db *sqlx.DB) *postgresBookmarkStore {
+	return &postgresBookmarkStore{
+		BaseStore: BaseStore{
+			database: db,
+		},
+	}
+}
+
+// 削除済みのツイートはブックマークできない
+func (s *postgresBookmarkStore) CreateBookmark(ctx context.Context, userID, tweetID int64) (*models.Bookmark, error) {
+	query := `
+		INSERT INTO bookmarks(user_id, tweet_id)
+		SELECT $1, id FROM tweets WHERE id = $2 AND deleted_at IS NULL
+		RETURNING ` + bookmarkColumns
+	var created models.Bookmark
+	err := s.BaseStore.conn(ctx).GetContext(ctx, &created, query, userID, tweetID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errcode.ErrTweetNotFound
+		}
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			if pqErr.Code == errCodeUniqueViolation && pqErr.Constraint == constraintUniqueBookmark {
+				return nil, errcode.ErrAlreadyBookmarked
+			}
+			if pqErr.Code == errCodeForeignKeyViolation && pqErr.Constraint == constraintBookmarkUserFK {
+				return nil, errcode.ErrUserNotFound
+			}
+		}
+		return nil, fmt.Errorf("ブックマークの挿入に失敗しました(tweet_id:%d): %w", tweetID, err)
+	}
+
+	created.CreatedAt = created.CreatedAt.UTC()
+	return &created, nil
+}
+
+func (s *postgresBookmarkStore) DeleteBookmark(ctx context.Context, userID, tweetID int64) error {
+	query := `DELETE FROM bookmarks WHERE user_id = $1 AND tweet_id = $2`
+	result, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, userID, tweetID)
+	if err != nil {
+		return fmt.Errorf("ブックマークの削除に失敗しました(tweet_id:%d): %w", tweetID, err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ブックマーク削除結果の確認に失敗しました(tweet_id:%d): %w", tweetID, err)
+	}
+	if rows == 0 {
+		return errcode.ErrBookmarkNotFound
+	}
+	return nil
+}
+
+// 新しい順。キャッシュの再構築に使う
+func (s *postgresBookmarkStore) GetBookmarksByUser(ctx context.Context, userID int64) ([]*models.Bookmark, error) {
+	bookmarks := []*models.Bookmark{}
+	query := `SELECT ` + bookmarkColumns + ` FROM bookmarks WHERE user_id = $1 ORDER BY id DESC`
+	if err := s.BaseStore.conn(ctx).SelectContext(ctx, &bookmarks, query, userID); err != nil {
+		return nil, fmt.Errorf("ブックマーク一覧の取得に失敗しました(user_id:%d): %w", userID, err)
+	}
+
+	for _, b := range bookmarks {
+		b.CreatedAt = b.CreatedAt.UTC()
+	}
+	return bookmarks, nil
+}
+
+// ツイートに付いたブックマークをすべて削除し、ブックマークしていたユーザーのIDを返す
+func (s *postgresBookmarkStore) DeleteBookmarksByTweet(ctx context.Context, tweetID int64) ([]int64, error) {
+	userIDs := []int64{}
+	query := `DELETE FROM bookmarks WHERE tweet_id = $1 RETURNING user_id`
+	if err := s.BaseStore.conn(ctx).SelectContext(ctx, &userIDs, query, tweetID); err != nil {
+		return nil, fmt.Errorf("ツイートのブックマーク削除に失敗しました(tweet_id:%d): %w", tweetID, err)
+	}
+	return userIDs, nil
+}
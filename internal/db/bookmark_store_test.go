@@ -0,0 +1,66 @@
+package db
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookmarkLifecycle(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	u := setupUsers(t, ctx, 2)
+	first, err := testTweetStore.CreateTweet(ctx, &models.Tweet{UserID: u[0].ID, Content: "1件目"})
+	require.NoError(t, err)
+	second, err := testTweetStore.CreateTweet(ctx, &models.Tweet{UserID: u[0].ID, Content: "2件目"})
+	require.NoError(t, err)
+
+	t.Run("正常系: ブックマークは新しい順に取得できること", func(t *testing.T) {
+		_, err := testBookmarkStore.CreateBookmark(ctx, u[1].ID, first.ID)
+		require.NoError(t, err)
+		_, err = testBookmarkStore.CreateBookmark(ctx, u[1].ID, second.ID)
+		require.NoError(t, err)
+
+		bookmarks, err := testBookmarkStore.GetBookmarksByUser(ctx, u[1].ID)
+		require.NoError(t, err)
+		require.Len(t, bookmarks, 2)
+		assert.Equal(t, second.ID, bookmarks[0].TweetID)
+		assert.Equal(t, first.ID, bookmarks[1].TweetID)
+	})
+
+	t.Run("異常系: 同じツイートは2回ブックマークできないこと", func(t *testing.T) {
+		_, err := testBookmarkStore.CreateBookmark(ctx, u[1].ID, first.ID)
+		assert.ErrorIs(t, err, errcode.ErrAlreadyBookmarked)
+	})
+
+	t.Run("異常系: 存在しないブックマークは削除できないこと", func(t *testing.T) {
+		err := testBookmarkStore.DeleteBookmark(ctx, u[0].ID, first.ID)
+		assert.ErrorIs(t, err, errcode.ErrBookmarkNotFound)
+	})
+
+	t.Run("正常系: ツイート単位で削除するとブックマークしていたユーザーを返すこと", func(t *testing.T) {
+		_, err := testBookmarkStore.CreateBookmark(ctx, u[0].ID, first.ID)
+		require.NoError(t, err)
+
+		userIDs, err := testBookmarkStore.DeleteBookmarksByTweet(ctx, first.ID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []int64{u[0].ID, u[1].ID}, userIDs)
+
+		bookmarks, err := testBookmarkStore.GetBookmarksByUser(ctx, u[1].ID)
+		require.NoError(t, err)
+		require.Len(t, bookmarks, 1)
+		assert.Equal(t, second.ID, bookmarks[0].TweetID)
+	})
+
+	t.Run("異常系: 削除済みのツイートはブックマークできないこと", func(t *testing.T) {
+		require.NoError(t, testTweetStore.DeleteTweet(ctx, second.ID))
+		_, err := testBookmarkStore.CreateBookmark(ctx, u[0].ID, second.ID)
+		assert.ErrorIs(t, err, errcode.ErrTweetNotFound)
+	})
+}
@@ -8,9 +8,21 @@ const (
 	constraintSessionUserFK          = "sessions_user_id_fkey"
 	constraintTokenHashUnique        = "sessions_token_hash_key"
 	constraintTweetUserFK            = "tweets_user_id_fkey"
+	constraintScheduledTweetUserFK   = "scheduled_tweets_user_id_fkey"
+	constraintDraftUserFK            = "drafts_user_id_fkey"
+	constraintMediaUserFK            = "media_user_id_fkey"
 	constraintUsernameK              = "users_username_key"
 	constraintUseremailK             = "users_email_key"
 	constraintTokenhashK             = "sessions_token_hash_key"
 	constraintUniqueFollow           = "unique_follow"
 	constraintNoSelfFollow           = "no_self_follow"
+	constraintUniqueTweetMedia       = "unique_tweet_media_media_id"
+	constraintUniquePollVote         = "unique_poll_vote"
+	constraintPollVoteUserFK         = "poll_votes_user_id_fkey"
+	constraintUniqueBookmark         = "unique_bookmark"
+	constraintBookmarkUserFK         = "bookmarks_user_id_fkey"
+	constraintListOwnerFK            = "lists_owner_id_fkey"
+	constraintListMemberUserFK       = "list_members_user_id_fkey"
+	constraintUserTokenUserFK        = "user_tokens_user_id_fkey"
+	constraintUserTokenHashK         = "user_tokens_token_hash_key"
 )
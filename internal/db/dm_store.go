@@ -0,0 +1,270 @@
+package db
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	dmConversationColumns = `id, is_group, direct_key, created_by, created_at, last_message_at`
+	dmMessageColumns      = `id, conversation_id, sender_id, content, created_at`
+)
+
+type postgresDMStore struct {
+	BaseStore
+}
+
+func NewPostgresDMStore(db *sqlx.DB) *postgresDMStore {
+	return &postgresDMStore{
+		BaseStore: BaseStore{
+			database: db,
+		},
+	}
+}
+
+// 会話と参加者(作成者を含む)を作成する。1対1の会話が既にある場合は作成せずそれを返す
+func (s *postgresDMStore) CreateConversation(ctx context.Context, conv *models.DMConversation, participantIDs []int64) (*models.DMConversation, error) {
+	created := &models.DMConversation{}
+	err := s.BaseStore.withTx(ctx, func(ctx context.Context) error {
+		query := `
+			INSERT INTO dm_conversations(is_group, direct_key, created_by)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (direct_key) DO NOTHING
+			RETURNING ` + dmConversationColumns
+		err := s.BaseStore.conn(ctx).GetContext(ctx, created, query, conv.IsGroup, conv.DirectKey, conv.CreatedBy)
+		if errors.Is(err, sql.ErrNoRows) && conv.DirectKey != nil {
+			query = `SELECT ` + dmConversationColumns + ` FROM dm_conversations WHERE direct_key = $1`
+			if err := s.BaseStore.conn(ctx).GetContext(ctx, created, query, *conv.DirectKey); err != nil {
+				return fmt.Errorf("既存の会話の取得に失敗しました(direct_key:%s): %w", *conv.DirectKey, err)
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("会話の作成に失敗しました(created_by:%d): %w", conv.CreatedBy, err)
+		}
+
+		query = `
+			INSERT INTO dm_participants(conversation_id, user_id)
+			SELECT $1, u.id FROM users u WHERE u.id = ANY($2::BIGINT[])`
+		res, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, created.ID, pq.Array(participantIDs))
+		if err != nil {
+			return fmt.Errorf("参加者の追加に失敗しました(conversation_id:%d): %w", created.ID, err)
+		}
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("影響を受けた行数の取得に失敗しました: %w", err)
+		}
+		if rows != int64(len(participantIDs)) {
+			return errcode.ErrUserNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created.CreatedAt = created.CreatedAt.UTC()
+	return created, nil
+}
+
+// 参加している会話を最後のメッセージが新しい順に返す
+func (s *postgresDMStore) GetConversationsByUser(ctx context.Context, userID int64, limit int) ([]*models.DMConversation, error) {
+	conversations := []*models.DMConversation{}
+	query := `
+		SELECT c.id, c.is_group, c.direct_key, c.created_by, c.created_at, c.last_message_at
+		FROM dm_participants p
+		JOIN dm_conversations c ON c.id = p.conversation_id
+		WHERE p.user_id = $1
+		ORDER BY COALESCE(c.last_message_at, c.created_at) DESC, c.id DESC
+		LIMIT $2`
+	if err := s.BaseStore.conn(ctx).SelectContext(ctx, &conversations, query, userID, limit); err != nil {
+		return nil, fmt.Errorf("会話一覧の取得に失敗しました(user_id:%d): %w", userID, err)
+	}
+
+	for _, c := range conversations {
+		c.CreatedAt = c.CreatedAt.UTC()
+	}
+	return conversations, nil
+}
+
+// userID が参加していない会話は存在しないものとして扱う
+func (s *postgresDMStore) GetConversation(ctx context.Context, conversationID, userID int64) (*models.DMConversation, error) {
+	conv := &models.DMConversation{}
+	query := `
+		SELECT c.id, c.is_group, c.direct_key, c.created_by, c.created_at, c.last_message_at
+		FROM dm_conversations c
+		JOIN dm_participants p ON p.conversation_id = c.id AND p.user_id = $2
+		WHERE c.id = $1`
+	if err := s.BaseStore.conn(ctx).GetContext(ctx, conv, query, conversationID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errcode.ErrConversationNotFound
+		}
+		return nil, fmt.Errorf("会話の取得に失敗しました(conversation_id:%d): %w", conversationID, err)
+	}
+
+	conv.CreatedAt = conv.CreatedAt.UTC()
+	return conv, nil
+}
+
+func (s *postgresDMStore) GetParticipants(ctx context.Context, conversationIDs []int64) ([]*models.DMParticipant, error) {
+	participants := []*models.DMParticipant{}
+	if len(conversationIDs) == 0 {
+		return participants, nil
+	}
+
+	query := `
+		SELECT conversation_id, user_id, last_read_message_id, joined_at
+		FROM dm_participants
+		WHERE conversation_id = ANY($1::BIGINT[])
+		ORDER BY conversation_id, joined_at, user_id`
+	if err := s.BaseStore.conn(ctx).SelectContext(ctx, &participants, query, pq.Array(conversationIDs)); err != nil {
+		return nil, fmt.Errorf("参加者の取得に失敗しました(count:%d): %w", len(conversationIDs), err)
+	}
+
+	for _, p := range participants {
+		p.JoinedAt = p.JoinedAt.UTC()
+	}
+	return participants, nil
+}
+
+func (s *postgresDMStore) IsParticipant(ctx context.Context, conversationID, userID int64) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM dm_participants WHERE conversation_id = $1 AND user_id = $2)`
+	var exists bool
+	if err := s.BaseStore.conn(ctx).GetContext(ctx, &exists, query, conversationID, userID); err != nil {
+		return false, fmt.Errorf("参加者の確認に失敗しました(conversation_id:%d): %w", conversationID, err)
+	}
+	return exists, nil
+}
+
+// 送信者が参加していない会話には書き込めない。送信したメッセージまでは送信者の既読とする
+func (s *postgresDMStore) CreateMessage(ctx context.Context, msg *models.DMMessage) (*models.DMMessage, error) {
+	created := &models.DMMessage{}
+	err := s.BaseStore.withTx(ctx, func(ctx context.Context) error {
+		query := `
+			INSERT INTO dm_messages(conversation_id, sender_id, content)
+			SELECT $1, $2, $3
+			WHERE EXISTS(SELECT 1 FROM dm_participants WHERE conversation_id = $1 AND user_id = $2)
+			RETURNING ` + dmMessageColumns
+		if err := s.BaseStore.conn(ctx).GetContext(ctx, created, query, msg.ConversationID, msg.SenderID, msg.Content); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errcode.ErrConversationNotFound
+			}
+			return fmt.Errorf("メッセージの挿入に失敗しました(conversation_id:%d): %w", msg.ConversationID, err)
+		}
+
+		query = `UPDATE dm_conversations SET last_message_at = $2 WHERE id = $1`
+		if _, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, created.ConversationID, created.CreatedAt); err != nil {
+			return fmt.Errorf("会話の更新に失敗しました(conversation_id:%d): %w", created.ConversationID, err)
+		}
+
+		query = `UPDATE dm_participants SET last_read_message_id = $3 WHERE conversation_id = $1 AND user_id = $2`
+		if _, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, created.ConversationID, created.SenderID, created.ID); err != nil {
+			return fmt.Errorf("既読位置の更新に失敗しました(conversation_id:%d): %w", created.ConversationID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created.CreatedAt = created.CreatedAt.UTC()
+	return created, nil
+}
+
+// before より古いメッセージを新しい順に最大 limit 件返す。before が 0 なら最新から
+func (s *postgresDMStore) GetMessages(ctx context.Context, conversationID, before int64, limit int) ([]*models.DMMessage, error) {
+	messages := []*models.DMMessage{}
+	query := `SELECT ` + dmMessageColumns + ` FROM dm_messages
+		WHERE conversation_id = $1 AND ($2 = 0 OR id < $2)
+		ORDER BY id DESC
+		LIMIT $3`
+	if err := s.BaseStore.conn(ctx).SelectContext(ctx, &messages, query, conversationID, before, limit); err != nil {
+		return nil, fmt.Errorf("メッセージの取得に失敗しました(conversation_id:%d): %w", conversationID, err)
+	}
+
+	for _, m := range messages {
+		m.CreatedAt = m.CreatedAt.UTC()
+	}
+	return messages, nil
+}
+
+// cursor 以下のメッセージを既読にし、更新後の既読位置を返す。cursor が 0 なら最新まで。既読位置は後退させない
+func (s *postgresDMStore) UpdateReadCursor(ctx context.Context, conversationID, userID, cursor int64) (int64, error) {
+	query := `
+		UPDATE dm_participants SET last_read_message_id = GREATEST(last_read_message_id, (
+			SELECT CASE WHEN $3 = 0 THEN COALESCE(MAX(id), 0) ELSE LEAST($3, COALESCE(MAX(id), 0)) END
+			FROM dm_messages WHERE conversation_id = $1
+		))
+		WHERE conversation_id = $1 AND user_id = $2
+		RETURNING last_read_message_id`
+	var readID int64
+	if err := s.BaseStore.conn(ctx).GetContext(ctx, &readID, query, conversationID, userID, cursor); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errcode.ErrConversationNotFound
+		}
+		return 0, fmt.Errorf("既読位置の更新に失敗しました(conversation_id:%d): %w", conversationID, err)
+	}
+	return readID, nil
+}
+
+// 未読がある会話のみ返す。自分が送ったメッセージは数えない
+func (s *postgresDMStore) CountUnread(ctx context.Context, userID int64) ([]*models.DMUnreadCount, error) {
+	counts := []*models.DMUnreadCount{}
+	query := `
+		SELECT p.conversation_id, COUNT(m.id) AS count
+		FROM dm_participants p
+		JOIN dm_messages m ON m.conversation_id = p.conversation_id
+			AND m.id > p.last_read_message_id AND m.sender_id <> p.user_id
+		WHERE p.user_id = $1
+		GROUP BY p.conversation_id`
+	if err := s.BaseStore.conn(ctx).SelectContext(ctx, &counts, query, userID); err != nil {
+		return nil, fmt.Errorf("未読数の取得に失敗しました(user_id:%d): %w", userID, err)
+	}
+	return counts, nil
+}
+
+// senderID からの DM を受け付けない受信者のIDを返す。存在しないユーザーは含めない
+func (s *postgresDMStore) GetDeniedRecipients(ctx context.Context, senderID int64, recipientIDs []int64) ([]int64, error) {
+	denied := []int64{}
+	if len(recipientIDs) == 0 {
+		return denied, nil
+	}
+
+	query := `
+		SELECT u.id FROM users u
+		WHERE u.id = ANY($2::BIGINT[])
+		AND (
+			u.dm_policy = 'nobody'
+			OR (u.dm_policy = 'following' AND NOT EXISTS(
+				SELECT 1 FROM follows f WHERE f.follower_id = u.id AND f.following_id = $1
+			))
+		)`
+	if err := s.BaseStore.conn(ctx).SelectContext(ctx, &denied, query, senderID, pq.Array(recipientIDs)); err != nil {
+		return nil, fmt.Errorf("DM 設定の確認に失敗しました(sender_id:%d): %w", senderID, err)
+	}
+	return denied, nil
+}
+
+func (s *postgresDMStore) UpdateDMPolicy(ctx context.Context, userID int64, policy string) error {
+	query := `UPDATE users SET dm_policy = $2 WHERE id = $1`
+	res, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, userID, policy)
+	if err != nil {
+		return fmt.Errorf("DM 設定の更新に失敗しました(user_id:%d): %w", userID, err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("影響を受けた行数の取得に失敗しました: %w", err)
+	}
+	if rows == 0 {
+		return errcode.ErrUserNotFound
+	}
+	return nil
+}
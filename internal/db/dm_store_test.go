@@ -0,0 +1,115 @@
+package db
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDMLifecycle(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	u := setupUsers(t, ctx, 3)
+	key := fmt.Sprintf("%d:%d", u[0].ID, u[1].ID)
+
+	var conv *models.DMConversation
+	t.Run("正常系: 1対1の会話は重複して作成されないこと", func(t *testing.T) {
+		var err error
+		conv, err = testDMStore.CreateConversation(ctx, &models.DMConversation{DirectKey: &key, CreatedBy: u[0].ID}, []int64{u[0].ID, u[1].ID})
+		require.NoError(t, err)
+
+		again, err := testDMStore.CreateConversation(ctx, &models.DMConversation{DirectKey: &key, CreatedBy: u[1].ID}, []int64{u[1].ID, u[0].ID})
+		require.NoError(t, err)
+		assert.Equal(t, conv.ID, again.ID)
+
+		participants, err := testDMStore.GetParticipants(ctx, []int64{conv.ID})
+		require.NoError(t, err)
+		assert.Len(t, participants, 2)
+	})
+
+	t.Run("異常系: 参加していない会話", func(t *testing.T) {
+		_, err := testDMStore.GetConversation(ctx, conv.ID, u[2].ID)
+		assert.ErrorIs(t, err, errcode.ErrConversationNotFound)
+
+		_, err = testDMStore.CreateMessage(ctx, &models.DMMessage{ConversationID: conv.ID, SenderID: u[2].ID, Content: "hi"})
+		assert.ErrorIs(t, err, errcode.ErrConversationNotFound)
+
+		ok, err := testDMStore.IsParticipant(ctx, conv.ID, u[2].ID)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("正常系: 自分が送ったメッセージは未読に数えないこと", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			_, err := testDMStore.CreateMessage(ctx, &models.DMMessage{ConversationID: conv.ID, SenderID: u[0].ID, Content: "hello"})
+			require.NoError(t, err)
+		}
+
+		counts, err := testDMStore.CountUnread(ctx, u[1].ID)
+		require.NoError(t, err)
+		require.Len(t, counts, 1)
+		assert.Equal(t, int64(3), counts[0].Count)
+
+		counts, err = testDMStore.CountUnread(ctx, u[0].ID)
+		require.NoError(t, err)
+		assert.Empty(t, counts)
+
+		got, err := testDMStore.GetConversation(ctx, conv.ID, u[1].ID)
+		require.NoError(t, err)
+		assert.NotNil(t, got.LastMessageAt)
+	})
+
+	t.Run("正常系: 新しい順にカーソルで取得できること", func(t *testing.T) {
+		first, err := testDMStore.GetMessages(ctx, conv.ID, 0, 2)
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+		assert.Greater(t, first[0].ID, first[1].ID)
+
+		rest, err := testDMStore.GetMessages(ctx, conv.ID, first[1].ID, 10)
+		require.NoError(t, err)
+		assert.Len(t, rest, 1)
+	})
+
+	t.Run("正常系: 既読位置は後退しないこと", func(t *testing.T) {
+		messages, err := testDMStore.GetMessages(ctx, conv.ID, 0, 10)
+		require.NoError(t, err)
+
+		readID, err := testDMStore.UpdateReadCursor(ctx, conv.ID, u[1].ID, messages[1].ID)
+		require.NoError(t, err)
+		assert.Equal(t, messages[1].ID, readID)
+
+		readID, err = testDMStore.UpdateReadCursor(ctx, conv.ID, u[1].ID, messages[2].ID)
+		require.NoError(t, err)
+		assert.Equal(t, messages[1].ID, readID)
+
+		readID, err = testDMStore.UpdateReadCursor(ctx, conv.ID, u[1].ID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, messages[0].ID, readID)
+
+		_, err = testDMStore.UpdateReadCursor(ctx, conv.ID, u[2].ID, 0)
+		assert.ErrorIs(t, err, errcode.ErrConversationNotFound)
+	})
+
+	t.Run("正常系: DM 設定に応じて受信を拒否すること", func(t *testing.T) {
+		require.NoError(t, testDMStore.UpdateDMPolicy(ctx, u[1].ID, models.DMPolicyFollowing))
+		require.NoError(t, testDMStore.UpdateDMPolicy(ctx, u[2].ID, models.DMPolicyNobody))
+
+		denied, err := testDMStore.GetDeniedRecipients(ctx, u[0].ID, []int64{u[1].ID, u[2].ID})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []int64{u[1].ID, u[2].ID}, denied)
+
+		follow(t, ctx, u[1], u[0])
+		denied, err = testDMStore.GetDeniedRecipients(ctx, u[0].ID, []int64{u[1].ID, u[2].ID})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{u[2].ID}, denied)
+
+		assert.ErrorIs(t, testDMStore.UpdateDMPolicy(ctx, 999999, models.DMPolicyNobody), errcode.ErrUserNotFound)
+	})
+}
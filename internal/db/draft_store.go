@@ -0,0 +1,131 @@
+package db
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const draftColumns = `id, user_id, content, media, created_at, updated_at`
+
+type postgresDraftStore struct {
+	BaseStore
+}
+
+func NewPostgresDraftStore(db *sqlx.DB) *postgresDraftStore {
+	return &postgresDraftStore{
+		BaseStore: BaseStore{
+			database: db,
+		},
+	}
+}
+
+func (s *postgresDraftStore) CreateDraft(ctx context.Context, draft *models.Draft) (*models.Draft, error) {
+	query := `
+		INSERT INTO drafts(user_id, content, media)
+		SELECT $1::BIGINT, $2::TEXT, $3::JSONB
+		WHERE ` + ownedMediaCondition + `
+		RETURNING ` + draftColumns
+	var created models.Draft
+	err := s.BaseStore.conn(ctx).GetContext(ctx, &created, query, draft.UserID, draft.Content, draft.Media)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errcode.ErrMediaNotFound
+		}
+		return nil, mapDraftWriteError(err, "下書きの保存に失敗しました")
+	}
+
+	normalizeDraftTime(&created)
+	return &created, nil
+}
+
+func (s *postgresDraftStore) GetDraft(ctx context.Context, draftID, userID int64) (*models.Draft, error) {
+	query := `SELECT ` + draftColumns + ` FROM drafts WHERE id = $1 AND user_id = $2`
+	var draft models.Draft
+	err := s.BaseStore.conn(ctx).GetContext(ctx, &draft, query, draftID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errcode.ErrDraftNotFound
+		}
+		return nil, fmt.Errorf("下書きの取得に失敗しました: %w", err)
+	}
+
+	normalizeDraftTime(&draft)
+	return &draft, nil
+}
+
+func (s *postgresDraftStore) GetDraftsByUser(ctx context.Context, userID int64) ([]*models.Draft, error) {
+	drafts := []*models.Draft{}
+	query := `SELECT ` + draftColumns + ` FROM drafts WHERE user_id = $1 ORDER BY updated_at DESC, id DESC`
+	if err := s.BaseStore.conn(ctx).SelectContext(ctx, &drafts, query, userID); err != nil {
+		return nil, fmt.Errorf("下書き一覧の取得に失敗しました(user_id:%d): %w", userID, err)
+	}
+
+	for _, d := range drafts {
+		normalizeDraftTime(d)
+	}
+	return drafts, nil
+}
+
+func (s *postgresDraftStore) UpdateDraft(ctx context.Context, draft *models.Draft) (*models.Draft, error) {
+	query := `UPDATE drafts 
+		SET content = $2, media = $3::JSONB, updated_at = NOW()
+		WHERE id = $4 AND user_id = $1 AND ` + ownedMediaCondition + `
+		RETURNING ` + draftColumns
+	var updated models.Draft
+	err := s.BaseStore.conn(ctx).GetContext(ctx, &updated, query, draft.UserID, draft.Content, draft.Media, draft.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			if len(draft.Media) > 0 {
+				if _, getErr := s.GetDraft(ctx, draft.ID, draft.UserID); getErr == nil {
+					return nil, errcode.ErrMediaNotFound
+				}
+			}
+			return nil, errcode.ErrDraftNotFound
+		}
+		return nil, mapDraftWriteError(err, "下書きの更新に失敗しました")
+	}
+
+	normalizeDraftTime(&updated)
+	return &updated, nil
+}
+
+// 削除した行を返すので、公開処理ではトランザクション内で下書きの確保を兼ねる
+func (s *postgresDraftStore) DeleteDraft(ctx context.Context, draftID, userID int64) (*models.Draft, error) {
+	query := `DELETE FROM drafts WHERE id = $1 AND user_id = $2 RETURNING ` + draftColumns
+	var deleted models.Draft
+	err := s.BaseStore.conn(ctx).GetContext(ctx, &deleted, query, draftID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errcode.ErrDraftNotFound
+		}
+		return nil, fmt.Errorf("下書きの削除に失敗しました: %w", err)
+	}
+
+	normalizeDraftTime(&deleted)
+	return &deleted, nil
+}
+
+func mapDraftWriteError(err error, msg string) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		if pqErr.Code == errCodeForeignKeyViolation && pqErr.Constraint == constraintDraftUserFK {
+			return errcode.ErrUserNotFound
+		}
+		if pqErr.Code == errCodeStringDataRightTruncation {
+			return errcode.ErrValueTooLong
+		}
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+func normalizeDraftTime(d *models.Draft) {
+	d.CreatedAt = d.CreatedAt.UTC()
+	d.UpdatedAt = d.UpdatedAt.UTC()
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDraftCRUD(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	u := setupUsers(t, ctx, 2)
+
+	created, err := testDraftStore.CreateDraft(ctx, &models.Draft{UserID: u[0].ID, Content: "書きかけ"})
+	require.NoError(t, err)
+	assert.Equal(t, "書きかけ", created.Content)
+
+	t.Run("正常系: 自分の下書きを取得できること", func(t *testing.T) {
+		draft, err := testDraftStore.GetDraft(ctx, created.ID, u[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, draft.ID)
+
+		drafts, err := testDraftStore.GetDraftsByUser(ctx, u[0].ID)
+		require.NoError(t, err)
+		assert.Len(t, drafts, 1)
+	})
+
+	t.Run("異常系: 他人の下書きは取得・更新・削除できないこと", func(t *testing.T) {
+		_, err := testDraftStore.GetDraft(ctx, created.ID, u[1].ID)
+		assert.ErrorIs(t, err, errcode.ErrDraftNotFound)
+
+		_, err = testDraftStore.UpdateDraft(ctx, &models.Draft{ID: created.ID, UserID: u[1].ID, Content: "乗っ取り"})
+		assert.ErrorIs(t, err, errcode.ErrDraftNotFound)
+
+		_, err = testDraftStore.DeleteDraft(ctx, created.ID, u[1].ID)
+		assert.ErrorIs(t, err, errcode.ErrDraftNotFound)
+	})
+
+	t.Run("異常系: contentが長すぎ", func(t *testing.T) {
+		_, err := testDraftStore.UpdateDraft(ctx, &models.Draft{ID: created.ID, UserID: u[0].ID, Content: strings.Repeat("a", 1001)})
+		assert.ErrorIs(t, err, errcode.ErrValueTooLong)
+	})
+
+	t.Run("正常系: 下書きを更新できること", func(t *testing.T) {
+		updated, err := testDraftStore.UpdateDraft(ctx, &models.Draft{ID: created.ID, UserID: u[0].ID, Content: "書き直し"})
+		require.NoError(t, err)
+		assert.Equal(t, "書き直し", updated.Content)
+		assert.True(t, updated.UpdatedAt.After(created.UpdatedAt) || updated.UpdatedAt.Equal(created.UpdatedAt))
+	})
+
+	t.Run("正常系: トランザクションがロールバックされると下書きは残ること", func(t *testing.T) {
+		errAbort := errors.New("abort")
+		err := NewTransactor(testContext.TestDB).Exec(ctx, func(txCtx context.Context) error {
+			_, err := testDraftStore.DeleteDraft(txCtx, created.ID, u[0].ID)
+			require.NoError(t, err)
+			return errAbort
+		})
+		assert.ErrorIs(t, err, errAbort)
+
+		_, err = testDraftStore.GetDraft(ctx, created.ID, u[0].ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("正常系: 下書きを削除できること", func(t *testing.T) {
+		deleted, err := testDraftStore.DeleteDraft(ctx, created.ID, u[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, "書き直し", deleted.Content)
+
+		_, err = testDraftStore.GetDraft(ctx, created.ID, u[0].ID)
+		assert.ErrorIs(t, err, errcode.ErrDraftNotFound)
+	})
+}
@@ -24,9 +24,16 @@ func NewPostgresFollowStore(db *sqlx.DB) *postgresFollowStore {
 }
 
 func (s *postgresFollowStore)Create(ctx context.Context, follow *models.Follow) (*models.Follow, error) {
-	query := `INSERT INTO follows(follower_id, following_id)
-			  VALUES($1, $2)
-			  RETURNING id, follower_id, following_id, created_at`
+	// 解除後もフォロワーの増減を数えられるよう、同じ文で履歴にも記録する
+	query := `WITH f AS (
+				INSERT INTO follows(follower_id, following_id)
+				VALUES($1, $2)
+				RETURNING id, follower_id, following_id, created_at
+			  ), e AS (
+				INSERT INTO follow_events(follower_id, following_id, action, created_at)
+				SELECT follower_id, following_id, 'follow', created_at FROM f
+			  )
+			  SELECT id, follower_id, following_id, created_at FROM f`
 	var newFollow models.Follow
 	err := s.BaseStore.conn(ctx).QueryRowContext(
 		ctx, 
@@ -124,8 +131,13 @@ func (s *postgresFollowStore) GetRelationship(ctx context.Context, userA, userB
 }
 
 func (s *postgresFollowStore) Delete(ctx context.Context, followerID, followingID int64) error {
-    query := `DELETE FROM follows WHERE follower_id = $1 AND following_id = $2`
-    _, err := s.database.ExecContext(ctx, query, followerID, followingID)
+    query := `WITH d AS (
+                DELETE FROM follows WHERE follower_id = $1 AND following_id = $2
+                RETURNING follower_id, following_id
+              )
+              INSERT INTO follow_events(follower_id, following_id, action)
+              SELECT follower_id, following_id, 'unfollow' FROM d`
+    _, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, followerID, followingID)
     if err != nil {
         return fmt.Errorf("フォロー解除に失敗しました: %w", err)
     }
@@ -0,0 +1,164 @@
+package db
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const listColumns = `l.id, l.owner_id, l.name, l.description, l.is_private, l.created_at, l.updated_at,
+	(SELECT COUNT(*) FROM list_members m WHERE m.list_id = l.id) AS member_count`
+
+type postgresListStore struct {
+	BaseStore
+}
+
+func NewPostgresListStore(db *sqlx.DB) *postgresListStore {
+	return &postgresListStore{
+		BaseStore: BaseStore{
+			database: db,
+		},
+	}
+}
+
+func (s *postgresListStore) CreateList(ctx context.Context, list *models.List) (*models.List, error) {
+	query := `
+		INSERT INTO lists(owner_id, name, description, is_private)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, owner_id, name, description, is_private, created_at, updated_at`
+	var created models.List
+	err := s.BaseStore.conn(ctx).GetContext(ctx, &created, query, list.OwnerID, list.Name, list.Description, list.IsPrivate)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == errCodeForeignKeyViolation && pqErr.Constraint == constraintListOwnerFK {
+			return nil, errcode.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("リストの作成に失敗しました(owner_id:%d): %w", list.OwnerID, err)
+	}
+
+	normalizeListTime(&created)
+	return &created, nil
+}
+
+func (s *postgresListStore) GetList(ctx context.Context, listID int64) (*models.List, error) {
+	query := `SELECT ` + listColumns + ` FROM lists l WHERE l.id = $1`
+	var list models.List
+	if err := s.BaseStore.conn(ctx).GetContext(ctx, &list, query, listID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errcode.ErrListNotFound
+		}
+		return nil, fmt.Errorf("リストの取得に失敗しました(list_id:%d): %w", listID, err)
+	}
+
+	normalizeListTime(&list)
+	return &list, nil
+}
+
+// 新しく作成した順に返す
+func (s *postgresListStore) GetListsByOwner(ctx context.Context, ownerID int64) ([]*models.List, error) {
+	lists := []*models.List{}
+	query := `SELECT ` + listColumns + ` FROM lists l WHERE l.owner_id = $1 ORDER BY l.id DESC`
+	if err := s.BaseStore.conn(ctx).SelectContext(ctx, &lists, query, ownerID); err != nil {
+		return nil, fmt.Errorf("リスト一覧の取得に失敗しました(owner_id:%d): %w", ownerID, err)
+	}
+
+	for _, l := range lists {
+		normalizeListTime(l)
+	}
+	return lists, nil
+}
+
+func (s *postgresListStore) DeleteList(ctx context.Context, listID, ownerID int64) error {
+	query := `DELETE FROM lists WHERE id = $1 AND owner_id = $2`
+	res, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, listID, ownerID)
+	if err != nil {
+		return fmt.Errorf("リストの削除に失敗しました(list_id:%d): %w", listID, err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("影響を受けた行数の取得に失敗しました: %w", err)
+	}
+	if rows == 0 {
+		return errcode.ErrListNotFound
+	}
+	return nil
+}
+
+// 同時に追加されても上限を超えないよう、リストの行をロックしてから数える
+func (s *postgresListStore) AddMember(ctx context.Context, listID, userID int64, maxMembers int) error {
+	return s.BaseStore.withTx(ctx, func(ctx context.Context) error {
+		var count int
+		query := `
+			SELECT (SELECT COUNT(*) FROM list_members WHERE list_id = l.id)
+			FROM lists l WHERE l.id = $1
+			FOR UPDATE`
+		if err := s.BaseStore.conn(ctx).GetContext(ctx, &count, query, listID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errcode.ErrListNotFound
+			}
+			return fmt.Errorf("リストの取得に失敗しました(list_id:%d): %w", listID, err)
+		}
+		if count >= maxMembers {
+			return errcode.ErrListMemberLimit
+		}
+
+		query = `INSERT INTO list_members(list_id, user_id) VALUES ($1, $2)`
+		if _, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, listID, userID); err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) {
+				if pqErr.Code == errCodeUniqueViolation {
+					return errcode.ErrAlreadyListMember
+				}
+				if pqErr.Code == errCodeForeignKeyViolation && pqErr.Constraint == constraintListMemberUserFK {
+					return errcode.ErrUserNotFound
+				}
+			}
+			return fmt.Errorf("リストメンバーの追加に失敗しました(list_id:%d): %w", listID, err)
+		}
+
+		query = `UPDATE lists SET updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+		if _, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, listID); err != nil {
+			return fmt.Errorf("リストの更新に失敗しました(list_id:%d): %w", listID, err)
+		}
+		return nil
+	})
+}
+
+func (s *postgresListStore) RemoveMember(ctx context.Context, listID, userID int64) error {
+	query := `DELETE FROM list_members WHERE list_id = $1 AND user_id = $2`
+	res, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, listID, userID)
+	if err != nil {
+		return fmt.Errorf("リストメンバーの削除に失敗しました(list_id:%d): %w", listID, err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("影響を受けた行数の取得に失敗しました: %w", err)
+	}
+	if rows == 0 {
+		return errcode.ErrListMemberNotFound
+	}
+	return nil
+}
+
+// 追加した順に返す
+func (s *postgresListStore) GetMemberIDs(ctx context.Context, listID int64) ([]int64, error) {
+	ids := []int64{}
+	query := `SELECT user_id FROM list_members WHERE list_id = $1 ORDER BY created_at, user_id`
+	if err := s.BaseStore.conn(ctx).SelectContext(ctx, &ids, query, listID); err != nil {
+		return nil, fmt.Errorf("リストメンバーの取得に失敗しました(list_id:%d): %w", listID, err)
+	}
+	return ids, nil
+}
+
+func normalizeListTime(l *models.List) {
+	l.CreatedAt = l.CreatedAt.UTC()
+	l.UpdatedAt = l.UpdatedAt.UTC()
+}
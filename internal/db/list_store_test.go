@@ -0,0 +1,79 @@
+package db
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListLifecycle(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	u := setupUsers(t, ctx, 3)
+
+	list, err := testListStore.CreateList(ctx, &models.List{OwnerID: u[0].ID, Name: "friends", IsPrivate: true})
+	require.NoError(t, err)
+
+	t.Run("正常系: メンバーを追加した順に取得できること", func(t *testing.T) {
+		require.NoError(t, testListStore.AddMember(ctx, list.ID, u[2].ID, 10))
+		require.NoError(t, testListStore.AddMember(ctx, list.ID, u[1].ID, 10))
+
+		ids, err := testListStore.GetMemberIDs(ctx, list.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{u[2].ID, u[1].ID}, ids)
+
+		got, err := testListStore.GetList(ctx, list.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), got.MemberCount)
+		assert.True(t, got.IsPrivate)
+	})
+
+	t.Run("異常系: 追加済み・上限・存在しないユーザー", func(t *testing.T) {
+		assert.ErrorIs(t, testListStore.AddMember(ctx, list.ID, u[1].ID, 10), errcode.ErrAlreadyListMember)
+		assert.ErrorIs(t, testListStore.AddMember(ctx, list.ID, u[0].ID, 2), errcode.ErrListMemberLimit)
+		assert.ErrorIs(t, testListStore.AddMember(ctx, list.ID, 999999, 10), errcode.ErrUserNotFound)
+		assert.ErrorIs(t, testListStore.AddMember(ctx, 999999, u[1].ID, 10), errcode.ErrListNotFound)
+	})
+
+	t.Run("正常系: メンバーを削除できること", func(t *testing.T) {
+		require.NoError(t, testListStore.RemoveMember(ctx, list.ID, u[2].ID))
+		assert.ErrorIs(t, testListStore.RemoveMember(ctx, list.ID, u[2].ID), errcode.ErrListMemberNotFound)
+	})
+
+	t.Run("正常系: 作成者以外は削除できないこと", func(t *testing.T) {
+		assert.ErrorIs(t, testListStore.DeleteList(ctx, list.ID, u[1].ID), errcode.ErrListNotFound)
+		require.NoError(t, testListStore.DeleteList(ctx, list.ID, u[0].ID))
+
+		lists, err := testListStore.GetListsByOwner(ctx, u[0].ID)
+		require.NoError(t, err)
+		assert.Empty(t, lists)
+	})
+}
+
+func TestGetTweetIDsByAuthors(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	u := setupUsers(t, ctx, 3)
+	var ids []int64
+	for i := 0; i < 4; i++ {
+		tweet, err := testTweetStore.CreateTweet(ctx, &models.Tweet{UserID: u[i%3].ID, Content: "hello"})
+		require.NoError(t, err)
+		ids = append(ids, tweet.ID)
+	}
+
+	first, err := testTweetStore.GetTweetIDsByAuthors(ctx, []int64{u[0].ID, u[1].ID}, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{ids[3], ids[1]}, first)
+
+	rest, err := testTweetStore.GetTweetIDsByAuthors(ctx, []int64{u[0].ID, u[1].ID}, first[1], 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{ids[0]}, rest)
+}
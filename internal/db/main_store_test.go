@@ -13,6 +13,18 @@ var (
     testSessionStore *redisSessionStore
     testTweetStore   *postgresTweetStore
 	testFollowStore  *postgresFollowStore
+	testRecommendationStore *postgresRecommendationStore
+	testScheduledTweetStore *postgresScheduledTweetStore
+	testDraftStore          *postgresDraftStore
+	testMediaStore          *postgresMediaStore
+	testPollStore           *postgresPollStore
+	testBookmarkStore       *postgresBookmarkStore
+	testNotificationStore   *postgresNotificationStore
+	testDMStore             *postgresDMStore
+	testListStore           *postgresListStore
+	testViewStore           *postgresViewStore
+	testAnalyticsStore      *postgresAnalyticsStore
+	testUserTokenStore      *postgresUserTokenStore
     testContext      *testConfig.TestContext 
 )
 
@@ -24,6 +36,18 @@ func TestMain(m *testing.M) {
 	testSessionStore = NewRedisSessionStore(testContext.TestRDB)
 	testTweetStore = NewPostgresTweetStore(testContext.TestDB)
 	testFollowStore = NewPostgresFollowStore(testContext.TestDB)
+	testRecommendationStore = NewPostgresRecommendationStore(testContext.TestDB)
+	testScheduledTweetStore = NewPostgresScheduledTweetStore(testContext.TestDB)
+	testDraftStore = NewPostgresDraftStore(testContext.TestDB)
+	testMediaStore = NewPostgresMediaStore(testContext.TestDB)
+	testPollStore = NewPostgresPollStore(testContext.TestDB)
+	testBookmarkStore = NewPostgresBookmarkStore(testContext.TestDB)
+	testNotificationStore = NewPostgresNotificationStore(testContext.TestDB)
+	testDMStore = NewPostgresDMStore(testContext.TestDB)
+	testListStore = NewPostgresListStore(testContext.TestDB)
+	testViewStore = NewPostgresViewStore(testContext.TestDB)
+	testAnalyticsStore = NewPostgresAnalyticsStore(testContext.TestDB)
+	testUserTokenStore = NewPostgresUserTokenStore(testContext.TestDB)
 	testContext.CleanupTestDB()
 	exitCode := m.Run()
 	teardown()
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const mediaColumns = `id, user_id, storage_key, thumbnail_key, url, thumbnail_url, content_type, size_bytes, width, height, created_at`
+
+// 下書き・予約投稿の INSERT/UPDATE で使う添付メディアの所有者チェック。
+// $1 に user_id、$3 に添付の JSON 配列を渡すこと。全件が本人の未使用メディアであれば真になる
+const ownedMediaCondition = `NOT EXISTS (
+	SELECT 1 FROM jsonb_array_elements($3::JSONB) a
+	WHERE NOT EXISTS (
+		SELECT 1 FROM media m
+		WHERE m.id = (a->>'media_id')::BIGINT AND m.user_id = $1
+			AND NOT EXISTS (SELECT 1 FROM tweet_media tm WHERE tm.media_id = m.id)))`
+
+type postgresMediaStore struct {
+	BaseStore
+}
+
+func NewPostgresMediaStore(db *sqlx.DB) *postgresMediaStore {
+	return &postgresMediaStore{
+		BaseStore: BaseStore{
+			database: db,
+		},
+	}
+}
+
+func (s *postgresMediaStore) CreateMedia(ctx context.Context, media *models.Media) (*models.Media, error) {
+	query := `
+		INSERT INTO media(user_id, storage_key, thumbnail_key, url, thumbnail_url, content_type, size_bytes, width, height)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING ` + mediaColumns
+	var created models.Media
+	err := s.BaseStore.conn(ctx).GetContext(ctx, &created, query,
+		media.UserID,
+		media.StorageKey,
+		media.ThumbnailKey,
+		media.URL,
+		media.ThumbnailURL,
+		media.ContentType,
+		media.SizeBytes,
+		media.Width,
+		media.Height,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			if pqErr.Code == errCodeForeignKeyViolation && pqErr.Constraint == constraintMediaUserFK {
+				return nil, errcode.ErrUserNotFound
+			}
+			if pqErr.Code == errCodeStringDataRightTruncation {
+				return nil, errcode.ErrValueTooLong
+			}
+		}
+		return nil, fmt.Errorf("メディアの挿入に失敗しました: %w", err)
+	}
+
+	created.CreatedAt = created.CreatedAt.UTC()
+	return &created, nil
+}
+
+// ツイートに紐付かず、下書きや未公開の予約投稿からも参照されていないメディアを削除し、削除した行を返す。
+// 実ファイルの削除は呼び出し側が返り値の storage_key をもとに行う
+func (s *postgresMediaStore) PurgeOrphanedMedia(ctx context.Context, olderThan time.Duration, limit int) ([]*models.Media, error) {
+	purged := []*models.Media{}
+	query := `DELETE FROM media
+		WHERE id IN (
+			SELECT m.id FROM media m
+			WHERE NOT EXISTS (SELECT 1 FROM tweet_media tm WHERE tm.media_id = m.id)
+				AND m.created_at < NOW() - make_interval(secs => $1)
+				AND NOT EXISTS (SELECT 1 FROM drafts d
+					WHERE d.media @> jsonb_build_array(jsonb_build_object('media_id', m.id)))
+				AND NOT EXISTS (SELECT 1 FROM scheduled_tweets st
+					WHERE st.status = 'pending' AND st.media @> jsonb_build_array(jsonb_build_object('media_id', m.id)))
+			ORDER BY m.created_at
+			LIMIT $2
+		) AND NOT EXISTS (SELECT 1 FROM tweet_media tm WHERE tm.media_id = media.id)
+		RETURNING ` + mediaColumns
+
+	if err := s.BaseStore.conn(ctx).SelectContext(ctx, &purged, query, olderThan.Seconds(), limit); err != nil {
+		return nil, fmt.Errorf("未使用メディアの削除に失敗しました: %w", err)
+	}
+
+	for _, m := range purged {
+		m.CreatedAt = m.CreatedAt.UTC()
+	}
+	return purged, nil
+}
@@ -0,0 +1,117 @@
+package db
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestMedia(t *testing.T, ctx context.Context, userID int64, name string) *models.Media {
+	t.Helper()
+	key := fmt.Sprintf("%d/%s.png", userID, name)
+	thumbKey := fmt.Sprintf("%d/%s_thumb.png", userID, name)
+	media, err := testMediaStore.CreateMedia(ctx, &models.Media{
+		UserID:       userID,
+		StorageKey:   key,
+		ThumbnailKey: thumbKey,
+		URL:          "/media/" + key,
+		ThumbnailURL: "/media/" + thumbKey,
+		ContentType:  "image/png",
+		SizeBytes:    1024,
+		Width:        640,
+		Height:       480,
+	})
+	require.NoError(t, err)
+	return media
+}
+
+func TestCreateTweetWithMedia(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	u := setupUsers(t, ctx, 2)
+	first := createTestMedia(t, ctx, u[0].ID, "a")
+	second := createTestMedia(t, ctx, u[0].ID, "b")
+
+	t.Run("異常系: 他人のメディアは添付できないこと", func(t *testing.T) {
+		_, err := testTweetStore.CreateTweet(ctx, &models.Tweet{UserID: u[1].ID, Content: "盗用", Media: []models.TweetMedia{{MediaID: first.ID}}})
+		assert.ErrorIs(t, err, errcode.ErrMediaNotFound)
+	})
+
+	var created *models.Tweet
+	t.Run("正常系: 指定した順に代替テキスト付きで添付されること", func(t *testing.T) {
+		var err error
+		created, err = testTweetStore.CreateTweet(ctx, &models.Tweet{
+			UserID:  u[0].ID,
+			Content: "画像付き",
+			Media: []models.TweetMedia{
+				{MediaID: second.ID, AltText: "2枚目"},
+				{MediaID: first.ID, AltText: "1枚目"},
+			},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, created.ImageURL)
+		assert.Equal(t, second.URL, *created.ImageURL)
+		require.Len(t, created.Media, 2)
+		assert.Equal(t, second.ID, created.Media[0].MediaID)
+		assert.Equal(t, "2枚目", created.Media[0].AltText)
+		assert.Equal(t, 1, created.Media[1].Position)
+	})
+
+	t.Run("正常系: 取得時に添付メディアが読み込まれること", func(t *testing.T) {
+		found, err := testTweetStore.GetTweetByTweetID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, created.Media, found.Media)
+
+		tweets, err := testTweetStore.GetTweetsByTweetIDs(ctx, []int64{created.ID})
+		require.NoError(t, err)
+		require.Len(t, tweets, 1)
+		assert.Equal(t, created.Media, tweets[0].Media)
+	})
+
+	t.Run("異常系: 使用済みのメディアは再利用できず、ツイートも作成されないこと", func(t *testing.T) {
+		_, err := testTweetStore.CreateTweet(ctx, &models.Tweet{UserID: u[0].ID, Content: "再利用", Media: []models.TweetMedia{{MediaID: first.ID}}})
+		assert.ErrorIs(t, err, errcode.ErrMediaNotFound)
+
+		ids, err := testTweetStore.GetTweetIDsByAuthor(ctx, u[0].ID, 0, 10)
+		require.NoError(t, err)
+		assert.Len(t, ids, 1)
+	})
+}
+
+func TestPurgeOrphanedMedia(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	u := setupUsers(t, ctx, 1)
+	attached := createTestMedia(t, ctx, u[0].ID, "attached")
+	inDraft := createTestMedia(t, ctx, u[0].ID, "draft")
+	orphan := createTestMedia(t, ctx, u[0].ID, "orphan")
+
+	_, err := testTweetStore.CreateTweet(ctx, &models.Tweet{UserID: u[0].ID, Content: "添付", Media: []models.TweetMedia{{MediaID: attached.ID}}})
+	require.NoError(t, err)
+	_, err = testDraftStore.CreateDraft(ctx, &models.Draft{UserID: u[0].ID, Content: "下書き", Media: models.MediaAttachments{{MediaID: inDraft.ID}}})
+	require.NoError(t, err)
+
+	t.Run("正常系: 猶予期間内のメディアは削除されないこと", func(t *testing.T) {
+		purged, err := testMediaStore.PurgeOrphanedMedia(ctx, time.Hour, 10)
+		require.NoError(t, err)
+		assert.Empty(t, purged)
+	})
+
+	t.Run("正常系: ツイートにも下書きにも使われていないメディアだけ削除されること", func(t *testing.T) {
+		purged, err := testMediaStore.PurgeOrphanedMedia(ctx, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, purged, 1)
+		assert.Equal(t, orphan.ID, purged[0].ID)
+		assert.Equal(t, orphan.StorageKey, purged[0].StorageKey)
+	})
+}
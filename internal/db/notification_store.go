@@ -0,0 +1,134 @@
+package db
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const notificationColumns = `id, user_id, actor_id, type, tweet_id, created_at`
+
+type postgresNotificationStore struct {
+	BaseStore
+}
+
+func NewPostgresNotificationStore(db *sqlx.DB) *postgresNotificationStore {
+	return &postgresNotificationStore{
+		BaseStore: BaseStore{
+			database: db,
+		},
+	}
+}
+
+// 自分自身への通知、既に同じ通知がある場合、受信者が存在しない場合は挿入しない。
+// 実際に挿入できた通知の受信者IDを返す
+func (s *postgresNotificationStore) CreateNotifications(ctx context.Context, notifications []*models.Notification) ([]int64, error) {
+	if len(notifications) == 0 {
+		return []int64{}, nil
+	}
+
+	userIDs := make([]int64, len(notifications))
+	actorIDs := make([]int64, len(notifications))
+	types := make([]string, len(notifications))
+	tweetIDs := make([]sql.NullInt64, len(notifications))
+	for i, n := range notifications {
+		userIDs[i] = n.UserID
+		actorIDs[i] = n.ActorID
+		types[i] = n.Type
+		if n.TweetID != nil {
+			tweetIDs[i] = sql.NullInt64{Int64: *n.TweetID, Valid: true}
+		}
+	}
+
+	query := `
+		INSERT INTO notifications(user_id, actor_id, type, tweet_id)
+		SELECT c.user_id, c.actor_id, c.type, c.tweet_id
+		FROM unnest($1::BIGINT[], $2::BIGINT[], $3::VARCHAR[], $4::BIGINT[]) AS c(user_id, actor_id, type, tweet_id)
+		JOIN users u ON u.id = c.user_id
+		WHERE c.user_id <> c.actor_id
+		ON CONFLICT DO NOTHING
+		RETURNING user_id`
+	recipientIDs := []int64{}
+	err := s.BaseStore.conn(ctx).SelectContext(ctx, &recipientIDs, query,
+		pq.Array(userIDs), pq.Array(actorIDs), pq.Array(types), pq.Array(tweetIDs))
+	if err != nil {
+		return nil, fmt.Errorf("通知の挿入に失敗しました(count:%d): %w", len(notifications), err)
+	}
+	return recipientIDs, nil
+}
+
+// before より古い通知を新しい順に最大 limit 件返す。before が 0 なら最新から
+func (s *postgresNotificationStore) GetNotifications(ctx context.Context, userID, before int64, limit int) ([]*models.Notification, error) {
+	notifications := []*models.Notification{}
+	query := `SELECT ` + notificationColumns + ` FROM notifications
+		WHERE user_id = $1 AND ($2 = 0 OR id < $2)
+		ORDER BY id DESC
+		LIMIT $3`
+	if err := s.BaseStore.conn(ctx).SelectContext(ctx, &notifications, query, userID, before, limit); err != nil {
+		return nil, fmt.Errorf("通知の取得に失敗しました(user_id:%d): %w", userID, err)
+	}
+
+	for _, n := range notifications {
+		n.CreatedAt = n.CreatedAt.UTC()
+	}
+	return notifications, nil
+}
+
+// この ID 以下の通知は既読として扱う
+func (s *postgresNotificationStore) GetReadCursor(ctx context.Context, userID int64) (int64, error) {
+	query := `SELECT notifications_read_id FROM users WHERE id = $1`
+	var cursor int64
+	if err := s.BaseStore.conn(ctx).GetContext(ctx, &cursor, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errcode.ErrUserNotFound
+		}
+		return 0, fmt.Errorf("既読位置の取得に失敗しました(user_id:%d): %w", userID, err)
+	}
+	return cursor, nil
+}
+
+// cursor 以下の通知を既読にする。cursor が 0 なら全件。既読位置は後退させない
+func (s *postgresNotificationStore) UpdateReadCursor(ctx context.Context, userID, cursor int64) error {
+	query := `
+		UPDATE users SET notifications_read_id = GREATEST(notifications_read_id, (
+			SELECT CASE WHEN $2 = 0 THEN COALESCE(MAX(id), 0) ELSE LEAST($2, COALESCE(MAX(id), 0)) END
+			FROM notifications WHERE user_id = $1
+		))
+		WHERE id = $1`
+	res, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, userID, cursor)
+	if err != nil {
+		return fmt.Errorf("既読位置の更新に失敗しました(user_id:%d): %w", userID, err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("影響を受けた行数の取得に失敗しました: %w", err)
+	}
+	if rows == 0 {
+		return errcode.ErrUserNotFound
+	}
+	return nil
+}
+
+func (s *postgresNotificationStore) CountUnread(ctx context.Context, userID int64) (int64, error) {
+	query := `
+		SELECT COUNT(n.id)
+		FROM users u
+		LEFT JOIN notifications n ON n.user_id = u.id AND n.id > u.notifications_read_id
+		WHERE u.id = $1
+		GROUP BY u.id`
+	var count int64
+	if err := s.BaseStore.conn(ctx).GetContext(ctx, &count, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errcode.ErrUserNotFound
+		}
+		return 0, fmt.Errorf("未読数の取得に失敗しました(user_id:%d): %w", userID, err)
+	}
+	return count, nil
+}
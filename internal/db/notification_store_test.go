@@ -0,0 +1,84 @@
+package db
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationLifecycle(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	u := setupUsers(t, ctx, 3)
+	tweet, err := testTweetStore.CreateTweet(ctx, &models.Tweet{UserID: u[0].ID, Content: "@user"})
+	require.NoError(t, err)
+
+	t.Run("正常系: 自分宛てと重複を除いて挿入すること", func(t *testing.T) {
+		recipients, err := testNotificationStore.CreateNotifications(ctx, []*models.Notification{
+			{UserID: u[1].ID, ActorID: u[0].ID, Type: models.NotificationTypeFollow},
+			{UserID: u[1].ID, ActorID: u[0].ID, Type: models.NotificationTypeFollow},
+			{UserID: u[0].ID, ActorID: u[0].ID, Type: models.NotificationTypeFollow},
+			{UserID: u[1].ID, ActorID: u[0].ID, Type: models.NotificationTypeMention, TweetID: &tweet.ID},
+			{UserID: u[2].ID, ActorID: u[0].ID, Type: models.NotificationTypeMention, TweetID: &tweet.ID},
+		})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []int64{u[1].ID, u[1].ID, u[2].ID}, recipients)
+
+		again, err := testNotificationStore.CreateNotifications(ctx, []*models.Notification{
+			{UserID: u[1].ID, ActorID: u[0].ID, Type: models.NotificationTypeFollow},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, again)
+	})
+
+	t.Run("正常系: 新しい順にカーソルで取得できること", func(t *testing.T) {
+		first, err := testNotificationStore.GetNotifications(ctx, u[1].ID, 0, 1)
+		require.NoError(t, err)
+		require.Len(t, first, 1)
+		assert.Equal(t, models.NotificationTypeMention, first[0].Type)
+
+		rest, err := testNotificationStore.GetNotifications(ctx, u[1].ID, first[0].ID, 10)
+		require.NoError(t, err)
+		require.Len(t, rest, 1)
+		assert.Equal(t, models.NotificationTypeFollow, rest[0].Type)
+		assert.Nil(t, rest[0].TweetID)
+	})
+
+	t.Run("正常系: 既読位置までの通知は未読に数えないこと", func(t *testing.T) {
+		count, err := testNotificationStore.CountUnread(ctx, u[1].ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		list, err := testNotificationStore.GetNotifications(ctx, u[1].ID, 0, 10)
+		require.NoError(t, err)
+		require.NoError(t, testNotificationStore.UpdateReadCursor(ctx, u[1].ID, list[1].ID))
+		count, err = testNotificationStore.CountUnread(ctx, u[1].ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		require.NoError(t, testNotificationStore.UpdateReadCursor(ctx, u[1].ID, 0))
+		cursor, err := testNotificationStore.GetReadCursor(ctx, u[1].ID)
+		require.NoError(t, err)
+		assert.Equal(t, list[0].ID, cursor)
+	})
+
+	t.Run("正常系: 既読位置は後退しないこと", func(t *testing.T) {
+		before, err := testNotificationStore.GetReadCursor(ctx, u[1].ID)
+		require.NoError(t, err)
+		require.NoError(t, testNotificationStore.UpdateReadCursor(ctx, u[1].ID, 1))
+		after, err := testNotificationStore.GetReadCursor(ctx, u[1].ID)
+		require.NoError(t, err)
+		assert.Equal(t, before, after)
+	})
+
+	t.Run("異常系: 存在しないユーザー", func(t *testing.T) {
+		_, err := testNotificationStore.CountUnread(ctx, 99999)
+		assert.ErrorIs(t, err, errcode.ErrUserNotFound)
+	})
+}
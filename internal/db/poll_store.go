@@ -0,0 +1,279 @@
+package db
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const pollColumns = `id, tweet_id, closes_at, closed_at, created_at`
+const pollOptionColumns = `id, poll_id, position, label, vote_count`
+
+type postgresPollStore struct {
+	BaseStore
+}
+
+func NewPostgresPollStore(db *sqlx.DB) *postgresPollStore {
+	return &postgresPollStore{
+		BaseStore: BaseStore{
+			database: db,
+		},
+	}
+}
+
+// トランザクション内で呼ぶこと。選択肢は渡された順に position 0 から並べる
+func insertPoll(ctx context.Context, conn Execer, tweetID int64, poll *models.Poll) (*models.Poll, error) {
+	query := `INSERT INTO polls(tweet_id, closes_at) VALUES($1, $2) RETURNING ` + pollColumns
+	var created models.Poll
+	if err := conn.GetContext(ctx, &created, query, tweetID, poll.ClosesAt); err != nil {
+		return nil, err
+	}
+
+	optionQuery := `INSERT INTO poll_options(poll_id, position, label) VALUES($1, $2, $3) RETURNING ` + pollOptionColumns
+	created.Options = make([]models.PollOption, 0, len(poll.Options))
+	for i, o := range poll.Options {
+		var option models.PollOption
+		if err := conn.GetContext(ctx, &option, optionQuery, created.ID, i, o.Label); err != nil {
+			return nil, err
+		}
+		created.Options = append(created.Options, option)
+	}
+
+	normalizePollTime(&created)
+	return &created, nil
+}
+
+// 複数ツイートの投票を選択肢ごとまとめて読み込む。
+// ツイートはキャッシュされるため得票数は読み込まない
+func loadTweetPolls(ctx context.Context, conn Execer, tweets ...*models.Tweet) error {
+	if len(tweets) == 0 {
+		return nil
+	}
+
+	tweetByID := make(map[int64]*models.Tweet, len(tweets))
+	tweetIDs := make([]int64, 0, len(tweets))
+	for _, t := range tweets {
+		tweetByID[t.ID] = t
+		tweetIDs = append(tweetIDs, t.ID)
+	}
+
+	var polls []*models.Poll
+	query := `SELECT ` + pollColumns + ` FROM polls WHERE tweet_id = ANY($1)`
+	if err := conn.SelectContext(ctx, &polls, query, pq.Array(tweetIDs)); err != nil {
+		return fmt.Errorf("投票の取得に失敗しました(count:%d): %w", len(tweetIDs), err)
+	}
+	if len(polls) == 0 {
+		return nil
+	}
+
+	pollByID := make(map[int64]*models.Poll, len(polls))
+	pollIDs := make([]int64, 0, len(polls))
+	for _, p := range polls {
+		normalizePollTime(p)
+		pollByID[p.ID] = p
+		pollIDs = append(pollIDs, p.ID)
+		tweetByID[p.TweetID].Poll = p
+	}
+
+	var options []models.PollOption
+	optionQuery := `SELECT id, poll_id, position, label FROM poll_options
+		WHERE poll_id = ANY($1)
+		ORDER BY poll_id, position`
+	if err := conn.SelectContext(ctx, &options, optionQuery, pq.Array(pollIDs)); err != nil {
+		return fmt.Errorf("投票の選択肢の取得に失敗しました(count:%d): %w", len(pollIDs), err)
+	}
+
+	for _, o := range options {
+		p := pollByID[o.PollID]
+		p.Options = append(p.Options, o)
+	}
+	return nil
+}
+
+// 削除済みツイートの投票は存在しないものとして扱う。選択肢の得票数は永続化済みの値
+func (s *postgresPollStore) GetPollByTweetID(ctx context.Context, tweetID int64) (*models.Poll, error) {
+	query := `SELECT p.id, p.tweet_id, p.closes_at, p.closed_at, p.created_at
+		FROM polls p
+		JOIN tweets t ON t.id = p.tweet_id
+		WHERE p.tweet_id = $1 AND t.deleted_at IS NULL`
+	var poll models.Poll
+	if err := s.BaseStore.conn(ctx).GetContext(ctx, &poll, query, tweetID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errcode.ErrPollNotFound
+		}
+		return nil, fmt.Errorf("投票の取得に失敗しました(tweet_id:%d): %w", tweetID, err)
+	}
+
+	optionQuery := `SELECT ` + pollOptionColumns + ` FROM poll_options WHERE poll_id = $1 ORDER BY position`
+	if err := s.BaseStore.conn(ctx).SelectContext(ctx, &poll.Options, optionQuery, poll.ID); err != nil {
+		return nil, fmt.Errorf("投票の選択肢の取得に失敗しました(poll_id:%d): %w", poll.ID, err)
+	}
+
+	normalizePollTime(&poll)
+	return &poll, nil
+}
+
+// 1ユーザー1票は unique_poll_vote 制約で保証する。
+// 締切済みの投票には ErrPollClosed、投票に属さない選択肢には ErrPollOptionNotFound を返す
+func (s *postgresPollStore) CreateVote(ctx context.Context, pollID, optionID, userID int64) error {
+	query := `
+		INSERT INTO poll_votes(poll_id, option_id, user_id)
+		SELECT p.id, o.id, $3
+		FROM polls p
+		JOIN poll_options o ON o.poll_id = p.id
+		WHERE p.id = $1 AND o.id = $2 AND p.closed_at IS NULL AND p.closes_at > NOW()`
+	result, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, pollID, optionID, userID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			if pqErr.Code == errCodeUniqueViolation && pqErr.Constraint == constraintUniquePollVote {
+				return errcode.ErrAlreadyVoted
+			}
+			if pqErr.Code == errCodeForeignKeyViolation && pqErr.Constraint == constraintPollVoteUserFK {
+				return errcode.ErrUserNotFound
+			}
+		}
+		return fmt.Errorf("投票の挿入に失敗しました(poll_id:%d): %w", pollID, err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("投票結果の確認に失敗しました(poll_id:%d): %w", pollID, err)
+	}
+	if rows > 0 {
+		return nil
+	}
+
+	var open bool
+	openQuery := `SELECT closed_at IS NULL AND closes_at > NOW() FROM polls WHERE id = $1`
+	if err := s.BaseStore.conn(ctx).GetContext(ctx, &open, openQuery, pollID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errcode.ErrPollNotFound
+		}
+		return fmt.Errorf("投票状態の取得に失敗しました(poll_id:%d): %w", pollID, err)
+	}
+	if !open {
+		return errcode.ErrPollClosed
+	}
+	return errcode.ErrPollOptionNotFound
+}
+
+// 未投票の場合は ok=false を返す
+func (s *postgresPollStore) GetVotedOptionID(ctx context.Context, pollID, userID int64) (int64, bool, error) {
+	query := `SELECT option_id FROM poll_votes WHERE poll_id = $1 AND user_id = $2`
+	var optionID int64
+	if err := s.BaseStore.conn(ctx).GetContext(ctx, &optionID, query, pollID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("投票済み選択肢の取得に失敗しました(poll_id:%d): %w", pollID, err)
+	}
+	return optionID, true, nil
+}
+
+// poll_votes から選択肢ごとの票数を数え直す。票のない選択肢も 0 で含める
+func (s *postgresPollStore) CountVotes(ctx context.Context, pollID int64) (map[int64]int64, error) {
+	query := `
+		SELECT o.id, COUNT(v.option_id)
+		FROM poll_options o
+		LEFT JOIN poll_votes v ON v.option_id = o.id
+		WHERE o.poll_id = $1
+		GROUP BY o.id`
+	rows, err := s.BaseStore.conn(ctx).QueryContext(ctx, query, pollID)
+	if err != nil {
+		return nil, fmt.Errorf("票数の集計に失敗しました(poll_id:%d): %w", pollID, err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int64]int64)
+	for rows.Next() {
+		var optionID, count int64
+		if err := rows.Scan(&optionID, &count); err != nil {
+			return nil, fmt.Errorf("票数の読み込みに失敗しました(poll_id:%d): %w", pollID, err)
+		}
+		counts[optionID] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("票数の読み込みに失敗しました(poll_id:%d): %w", pollID, err)
+	}
+	return counts, nil
+}
+
+// Redis の集計値を vote_count に書き戻す。締切処理で確定した投票は上書きしない
+func (s *postgresPollStore) UpdateVoteCounts(ctx context.Context, pollID int64, counts map[int64]int64) error {
+	optionIDs := make([]int64, 0, len(counts))
+	values := make([]int64, 0, len(counts))
+	for optionID, count := range counts {
+		optionIDs = append(optionIDs, optionID)
+		values = append(values, count)
+	}
+
+	query := `
+		UPDATE poll_options o SET vote_count = c.vote_count
+		FROM unnest($2::BIGINT[], $3::BIGINT[]) AS c(id, vote_count), polls p
+		WHERE o.id = c.id AND o.poll_id = $1 AND p.id = o.poll_id AND p.closed_at IS NULL`
+	if _, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, pollID, pq.Array(optionIDs), pq.Array(values)); err != nil {
+		return fmt.Errorf("票数の保存に失敗しました(poll_id:%d): %w", pollID, err)
+	}
+	return nil
+}
+
+// 締切時刻を過ぎてまだ確定していない投票のID
+func (s *postgresPollStore) GetExpiredPollIDs(ctx context.Context, limit int) ([]int64, error) {
+	pollIDs := []int64{}
+	query := `SELECT id FROM polls
+		WHERE closed_at IS NULL AND closes_at <= NOW()
+		ORDER BY closes_at
+		LIMIT $1`
+	if err := s.BaseStore.conn(ctx).SelectContext(ctx, &pollIDs, query, limit); err != nil {
+		return nil, fmt.Errorf("締切済み投票の取得に失敗しました: %w", err)
+	}
+	return pollIDs, nil
+}
+
+// poll_votes から票数を数え直して確定させる。既に確定済みであれば ErrPollNotFound を返す
+func (s *postgresPollStore) ClosePoll(ctx context.Context, pollID int64) error {
+	err := s.BaseStore.withTx(ctx, func(txCtx context.Context) error {
+		conn := s.BaseStore.conn(txCtx)
+
+		var id int64
+		lockQuery := `SELECT id FROM polls WHERE id = $1 AND closed_at IS NULL FOR UPDATE`
+		if err := conn.GetContext(txCtx, &id, lockQuery, pollID); err != nil {
+			return err
+		}
+
+		countQuery := `
+			UPDATE poll_options o
+			SET vote_count = (SELECT COUNT(*) FROM poll_votes v WHERE v.option_id = o.id)
+			WHERE o.poll_id = $1`
+		if _, err := conn.ExecContext(txCtx, countQuery, pollID); err != nil {
+			return err
+		}
+
+		closeQuery := `UPDATE polls SET closed_at = NOW() WHERE id = $1`
+		_, err := conn.ExecContext(txCtx, closeQuery, pollID)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errcode.ErrPollNotFound
+		}
+		return fmt.Errorf("投票の締切に失敗しました(poll_id:%d): %w", pollID, err)
+	}
+	return nil
+}
+
+func normalizePollTime(p *models.Poll) {
+	p.ClosesAt = p.ClosesAt.UTC()
+	p.CreatedAt = p.CreatedAt.UTC()
+	if p.ClosedAt != nil {
+		closedAt := p.ClosedAt.UTC()
+		p.ClosedAt = &closedAt
+	}
+}
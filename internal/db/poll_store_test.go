@@ -0,0 +1,129 @@
+package db
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestPollTweet(t *testing.T, ctx context.Context, userID int64, closesAt time.Time) *models.Tweet {
+	t.Helper()
+	tweet, err := testTweetStore.CreateTweet(ctx, &models.Tweet{
+		UserID:  userID,
+		Content: "どっち?",
+		Poll: &models.Poll{
+			ClosesAt: closesAt,
+			Options:  []models.PollOption{{Label: "犬"}, {Label: "猫"}},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, tweet.Poll)
+	return tweet
+}
+
+func TestCreateTweetWithPoll(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	u := setupUsers(t, ctx, 1)
+	created := createTestPollTweet(t, ctx, u[0].ID, time.Now().Add(time.Hour))
+	require.Len(t, created.Poll.Options, 2)
+	assert.Equal(t, "猫", created.Poll.Options[1].Label)
+	assert.Equal(t, 1, created.Poll.Options[1].Position)
+
+	found, err := testTweetStore.GetTweetByTweetID(ctx, created.ID)
+	require.NoError(t, err)
+	require.NotNil(t, found.Poll)
+	assert.Equal(t, created.Poll.ID, found.Poll.ID)
+	assert.Len(t, found.Poll.Options, 2)
+
+	t.Run("異常系: 選択肢が長すぎる場合はツイートも作成されないこと", func(t *testing.T) {
+		_, err := testTweetStore.CreateTweet(ctx, &models.Tweet{
+			UserID:  u[0].ID,
+			Content: "長い",
+			Poll: &models.Poll{
+				ClosesAt: time.Now().Add(time.Hour),
+				Options:  []models.PollOption{{Label: "あ"}, {Label: "この選択肢は二十五文字を超えているので保存できないはずです"}},
+			},
+		})
+		assert.ErrorIs(t, err, errcode.ErrValueTooLong)
+
+		ids, err := testTweetStore.GetTweetIDsByAuthor(ctx, u[0].ID, 0, 10)
+		require.NoError(t, err)
+		assert.Len(t, ids, 1)
+	})
+}
+
+func TestPollVoteAndClose(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	u := setupUsers(t, ctx, 3)
+	tweet := createTestPollTweet(t, ctx, u[0].ID, time.Now().Add(time.Hour))
+	poll := tweet.Poll
+	dog, cat := poll.Options[0].ID, poll.Options[1].ID
+
+	t.Run("正常系: 投票と投票済み選択肢の取得", func(t *testing.T) {
+		require.NoError(t, testPollStore.CreateVote(ctx, poll.ID, cat, u[1].ID))
+		require.NoError(t, testPollStore.CreateVote(ctx, poll.ID, cat, u[2].ID))
+
+		optionID, voted, err := testPollStore.GetVotedOptionID(ctx, poll.ID, u[1].ID)
+		require.NoError(t, err)
+		assert.True(t, voted)
+		assert.Equal(t, cat, optionID)
+
+		_, voted, err = testPollStore.GetVotedOptionID(ctx, poll.ID, u[0].ID)
+		require.NoError(t, err)
+		assert.False(t, voted)
+	})
+
+	t.Run("異常系: 同じユーザーは2回投票できないこと", func(t *testing.T) {
+		err := testPollStore.CreateVote(ctx, poll.ID, dog, u[1].ID)
+		assert.ErrorIs(t, err, errcode.ErrAlreadyVoted)
+	})
+
+	t.Run("異常系: 別の投票の選択肢は指定できないこと", func(t *testing.T) {
+		other := createTestPollTweet(t, ctx, u[1].ID, time.Now().Add(time.Hour))
+		err := testPollStore.CreateVote(ctx, poll.ID, other.Poll.Options[0].ID, u[0].ID)
+		assert.ErrorIs(t, err, errcode.ErrPollOptionNotFound)
+	})
+
+	t.Run("正常系: 票数の集計と書き戻し", func(t *testing.T) {
+		counts, err := testPollStore.CountVotes(ctx, poll.ID)
+		require.NoError(t, err)
+		assert.Equal(t, map[int64]int64{dog: 0, cat: 2}, counts)
+
+		require.NoError(t, testPollStore.UpdateVoteCounts(ctx, poll.ID, map[int64]int64{dog: 1, cat: 2}))
+		found, err := testPollStore.GetPollByTweetID(ctx, tweet.ID)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, found.Options[0].VoteCount)
+	})
+
+	t.Run("正常系: 締切で票数が確定し、以降は投票できないこと", func(t *testing.T) {
+		_, err := testContext.TestDB.ExecContext(ctx, `UPDATE polls SET closes_at = NOW() - INTERVAL '1 minute' WHERE id = $1`, poll.ID)
+		require.NoError(t, err)
+
+		ids, err := testPollStore.GetExpiredPollIDs(ctx, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{poll.ID}, ids)
+
+		require.NoError(t, testPollStore.ClosePoll(ctx, poll.ID))
+		assert.ErrorIs(t, testPollStore.ClosePoll(ctx, poll.ID), errcode.ErrPollNotFound)
+
+		found, err := testPollStore.GetPollByTweetID(ctx, tweet.ID)
+		require.NoError(t, err)
+		require.NotNil(t, found.ClosedAt)
+		assert.EqualValues(t, 0, found.Options[0].VoteCount)
+		assert.EqualValues(t, 2, found.Options[1].VoteCount)
+
+		err = testPollStore.CreateVote(ctx, poll.ID, dog, u[0].ID)
+		assert.ErrorIs(t, err, errcode.ErrPollClosed)
+	})
+}
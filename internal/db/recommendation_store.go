@@ -0,0 +1,83 @@
+package db
+
+import (
+	"aita/internal/models"
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type postgresRecommendationStore struct {
+	BaseStore
+}
+
+func NewPostgresRecommendationStore(db *sqlx.DB) *postgresRecommendationStore {
+	return &postgresRecommendationStore{
+		BaseStore: BaseStore{
+			database: db,
+		},
+	}
+}
+
+// 自分がフォローしている人たちが多くフォローしているユーザーを共通フォロー数の多い順に返す
+func (s *postgresRecommendationStore) GetFriendsOfFriends(ctx context.Context, userID int64, limit int) ([]*models.Recommendation, error) {
+	recommendations := []*models.Recommendation{}
+	query := `
+		SELECT f2.following_id AS user_id, COUNT(*) AS score
+		FROM follows f1
+		JOIN follows f2 ON f2.follower_id = f1.following_id
+		WHERE f1.follower_id = $1
+		  AND f2.following_id <> $1
+		  AND NOT EXISTS (
+			SELECT 1 FROM follows f3
+			WHERE f3.follower_id = $1 AND f3.following_id = f2.following_id
+		  )
+		GROUP BY f2.following_id
+		ORDER BY score DESC, f2.following_id ASC
+		LIMIT $2
+	`
+
+	err := s.BaseStore.conn(ctx).SelectContext(ctx, &recommendations, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("おすすめユーザーの集計に失敗しました(user_id:%d): %w", userID, err)
+	}
+
+	return recommendations, nil
+}
+
+func (s *postgresRecommendationStore) GetPopularUsers(ctx context.Context, limit int) ([]*models.Recommendation, error) {
+	recommendations := []*models.Recommendation{}
+	query := `
+		SELECT id AS user_id, follower_count AS score
+		FROM users
+		ORDER BY follower_count DESC, id ASC
+		LIMIT $1
+	`
+
+	err := s.BaseStore.conn(ctx).SelectContext(ctx, &recommendations, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("人気ユーザーの取得に失敗しました: %w", err)
+	}
+
+	return recommendations, nil
+}
+
+// 誰かをフォローしているユーザーのIDをカーソル(afterID)以降から昇順で返す
+func (s *postgresRecommendationStore) GetActiveUserIDs(ctx context.Context, afterID int64, limit int) ([]int64, error) {
+	ids := []int64{}
+	query := `
+		SELECT DISTINCT follower_id
+		FROM follows
+		WHERE follower_id > $1
+		ORDER BY follower_id ASC
+		LIMIT $2
+	`
+
+	err := s.BaseStore.conn(ctx).SelectContext(ctx, &ids, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("対象ユーザーIDの取得に失敗しました(after:%d): %w", afterID, err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"aita/internal/models"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupUsers(t *testing.T, ctx context.Context, n int) []*models.User {
+	users := make([]*models.User, n)
+	for i := 0; i < n; i++ {
+		u, err := testUserStore.Create(ctx, &models.User{
+			Username:     fmt.Sprintf("user%03d", i),
+			Email:        fmt.Sprintf("user%03d@example.com", i),
+			PasswordHash: "passwordhash",
+		})
+		require.NoError(t, err)
+		users[i] = u
+	}
+	return users
+}
+
+func follow(t *testing.T, ctx context.Context, follower, following *models.User) {
+	_, err := testFollowStore.Create(ctx, &models.Follow{
+		FollowerID:  follower.ID,
+		FollowingID: following.ID,
+	})
+	require.NoError(t, err)
+}
+
+func TestGetFriendsOfFriends(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	u := setupUsers(t, ctx, 5)
+	me, friendA, friendB, target, followed := u[0], u[1], u[2], u[3], u[4]
+
+	follow(t, ctx, me, friendA)
+	follow(t, ctx, me, friendB)
+	follow(t, ctx, me, followed)
+	follow(t, ctx, friendA, target)
+	follow(t, ctx, friendB, target)
+	follow(t, ctx, friendA, followed)
+	follow(t, ctx, friendA, me)
+
+	t.Run("正常系: 共通フォロー数の多い順に、自分とフォロー済みを除外して返すこと", func(t *testing.T) {
+		recs, err := testRecommendationStore.GetFriendsOfFriends(ctx, me.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, recs, 1)
+		assert.Equal(t, target.ID, recs[0].UserID)
+		assert.Equal(t, int64(2), recs[0].Score)
+	})
+
+	t.Run("正常系: フォローがないユーザーは空リストを返すこと", func(t *testing.T) {
+		recs, err := testRecommendationStore.GetFriendsOfFriends(ctx, target.ID, 10)
+		require.NoError(t, err)
+		assert.Empty(t, recs)
+	})
+}
+
+func TestGetPopularUsersAndActiveIDs(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	u := setupUsers(t, ctx, 3)
+	require.NoError(t, testUserStore.IncreaseFollowerCount(ctx, u[2].ID, 10))
+	require.NoError(t, testUserStore.IncreaseFollowerCount(ctx, u[1].ID, 5))
+	follow(t, ctx, u[0], u[1])
+	follow(t, ctx, u[2], u[1])
+
+	t.Run("正常系: フォロワー数の多い順に返すこと", func(t *testing.T) {
+		recs, err := testRecommendationStore.GetPopularUsers(ctx, 2)
+		require.NoError(t, err)
+		require.Len(t, recs, 2)
+		assert.Equal(t, u[2].ID, recs[0].UserID)
+		assert.Equal(t, u[1].ID, recs[1].UserID)
+	})
+
+	t.Run("正常系: カーソル以降のフォロワーIDを昇順で返すこと", func(t *testing.T) {
+		ids, err := testRecommendationStore.GetActiveUserIDs(ctx, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{u[0].ID, u[2].ID}, ids)
+
+		ids, err = testRecommendationStore.GetActiveUserIDs(ctx, u[0].ID, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{u[2].ID}, ids)
+	})
+}
@@ -32,6 +32,35 @@ func (s *redisSessionStore) hashKey(userID int64) string {
 	return fmt.Sprintf("%shash:%d", s.prefix, userID)
 }
 
+// トークンファミリー(=端末ごとのセッション)の現在のアクセストークンのハッシュを指す
+func (s *redisSessionStore) familyKey(sessionID string) string {
+	return fmt.Sprintf("%sfamily:%s", s.prefix, sessionID)
+}
+
+func (s *redisSessionStore) refreshKey(refreshHash string) string {
+	return fmt.Sprintf("%srefresh:%s", s.prefix, refreshHash)
+}
+
+// WebSocket 接続用の使い捨てチケット。発行したセッションのアクセストークンのハッシュを持つ
+func (s *redisSessionStore) ticketKey(ticketHash string) string {
+	return fmt.Sprintf("%sticket:%s", s.prefix, ticketHash)
+}
+
+// 現在のアクセストークンが old のときだけ新しいトークンの組に差し替える
+var rotateScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+redis.call("DEL", KEYS[2])
+redis.call("SREM", KEYS[4], ARGV[1])
+redis.call("SET", KEYS[3], ARGV[3], "PX", ARGV[4])
+redis.call("SADD", KEYS[4], ARGV[2])
+redis.call("PEXPIRE", KEYS[4], ARGV[5])
+redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[4])
+redis.call("SET", KEYS[5], ARGV[6], "PX", ARGV[4])
+return 1
+`)
+
 func (s *redisSessionStore) Create(ctx context.Context, session *models.Session) (*models.Session, error) {
 	dKey := s.dataKey(session.TokenHash)
 	hKey := s.hashKey(session.UserID)
@@ -42,7 +71,7 @@ func (s *redisSessionStore) Create(ctx context.Context, session *models.Session)
 		return nil, fmt.Errorf("セッションのシリアライズに失敗しました: %w", err)
 	}
 
-	ttl := time.Until(session.ExpiresAt)
+	ttl := time.Until(session.RetainUntil())
 	if ttl <= 0 {
 		return nil, errcode.ErrSessionExpired
 	}
@@ -51,6 +80,14 @@ func (s *redisSessionStore) Create(ctx context.Context, session *models.Session)
 	setCmd := pipe.SetArgs(ctx, dKey, data, redis.SetArgs{Mode: "NX", TTL: ttl})
 	pipe.SAdd(ctx, hKey, session.TokenHash)
 	pipe.Expire(ctx, hKey, ttl+24*time.Hour)
+	if session.SessionID != "" && session.RefreshHash != "" {
+		ref, err := json.Marshal(&models.RefreshTokenRef{UserID: session.UserID, SessionID: session.SessionID})
+		if err != nil {
+			return nil, fmt.Errorf("リフレッシュトークンのシリアライズに失敗しました: %w", err)
+		}
+		pipe.Set(ctx, s.familyKey(session.SessionID), session.TokenHash, ttl)
+		pipe.Set(ctx, s.refreshKey(session.RefreshHash), ref, ttl)
+	}
 
 	_, err = pipe.Exec(ctx)
 
@@ -105,14 +142,14 @@ func (s *redisSessionStore) Update(ctx context.Context, session *models.Session)
 		return fmt.Errorf("セッションのシリアライズに失敗しました: %w", err)
 	}
 
-	ttl := time.Until(session.ExpiresAt)
+	ttl := time.Until(session.RetainUntil())
 	if ttl <= 0 {
 		return errcode.ErrSessionExpired
 	}
 
 	pipe := s.client.Pipeline()
 
-	setCmd := pipe.SetArgs(ctx, dKey, data, redis.SetArgs{Mode: "XX", Get: false, ExpireAt: session.ExpiresAt})
+	setCmd := pipe.SetArgs(ctx, dKey, data, redis.SetArgs{Mode: "XX", Get: false, ExpireAt: session.RetainUntil()})
 	pipe.SAdd(ctx, hKey, session.TokenHash)
 	pipe.Expire(ctx, hKey, ttl+24*time.Hour)
 
@@ -140,6 +177,9 @@ func (s *redisSessionStore) Delete(ctx context.Context, session *models.Session)
 
     delCmd := pipe.Unlink(ctx, dKey)
     pipe.SRem(ctx, uKey, session.TokenHash)
+    if session.SessionID != "" {
+        pipe.Unlink(ctx, s.familyKey(session.SessionID))
+    }
 
     _, err := pipe.Exec(ctx)
 	
@@ -185,4 +225,208 @@ func (s *redisSessionStore) DeleteByUserID(ctx context.Context, userID int64) er
     }
 
     return nil
-}
\ No newline at end of file
+}
+// keepHash のセッションだけを残して、ユーザーの他のセッションを削除する
+func (s *redisSessionStore) DeleteOthersByUserID(ctx context.Context, userID int64, keepHash string) error {
+	uKey := s.hashKey(userID)
+
+	hashes, err := s.client.SMembers(ctx, uKey).Result()
+	if err != nil {
+		return fmt.Errorf("インデックスの取得に失敗しました: %w", err)
+	}
+
+	pipe := s.client.Pipeline()
+	count := 0
+	for _, hash := range hashes {
+		if hash == keepHash {
+			continue
+		}
+		pipe.Del(ctx, s.dataKey(hash))
+		pipe.SRem(ctx, uKey, hash)
+		count++
+	}
+	if count == 0 {
+		return nil
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("一括削除に失敗しました: %w", err)
+	}
+
+	return nil
+}
+
+// 期限切れで消えたセッションはインデックスからも取り除く
+func (s *redisSessionStore) ListByUserID(ctx context.Context, userID int64) ([]*models.Session, error) {
+	uKey := s.hashKey(userID)
+
+	hashes, err := s.client.SMembers(ctx, uKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("インデックスの取得に失敗しました: %w", err)
+	}
+	if len(hashes) == 0 {
+		return []*models.Session{}, nil
+	}
+
+	keys := make([]string, len(hashes))
+	for i, hash := range hashes {
+		keys[i] = s.dataKey(hash)
+	}
+
+	vals, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("Redisからのセッション一覧の取得に失敗しました: %w", err)
+	}
+
+	now := time.Now()
+	sessions := make([]*models.Session, 0, len(vals))
+	stale := make([]interface{}, 0)
+	for i, val := range vals {
+		str, ok := val.(string)
+		if !ok {
+			stale = append(stale, hashes[i])
+			continue
+		}
+
+		var session models.Session
+		if err := json.Unmarshal([]byte(str), &session); err != nil {
+			return nil, fmt.Errorf("セッションのデシリアライズに失敗しました: %w", err)
+		}
+		if session.RetainUntil().Before(now) {
+			continue
+		}
+		sessions = append(sessions, &session)
+	}
+
+	// 掃除に失敗しても一覧は返せるため、エラーは無視する
+	if len(stale) > 0 {
+		_ = s.client.SRem(ctx, uKey, stale...).Err()
+	}
+
+	return sessions, nil
+}
+
+// ローテーション済みのトークンも期限までは見つかる
+func (s *redisSessionStore) GetRefreshRef(ctx context.Context, refreshHash string) (*models.RefreshTokenRef, error) {
+	val, err := s.client.Get(ctx, s.refreshKey(refreshHash)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, errcode.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("Redisからのリフレッシュトークンの取得に失敗しました: %w", err)
+	}
+
+	var ref models.RefreshTokenRef
+	if err := json.Unmarshal([]byte(val), &ref); err != nil {
+		return nil, fmt.Errorf("リフレッシュトークンのデシリアライズに失敗しました: %w", err)
+	}
+	return &ref, nil
+}
+
+// アクセストークンの期限は見ずに、ファミリーの現在のセッションを返す
+func (s *redisSessionStore) GetByFamily(ctx context.Context, sessionID string) (*models.Session, error) {
+	tokenHash, err := s.client.Get(ctx, s.familyKey(sessionID)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, errcode.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("Redisからのトークンファミリーの取得に失敗しました: %w", err)
+	}
+
+	val, err := s.client.Get(ctx, s.dataKey(tokenHash)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, errcode.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("Redisからの取得に失敗しました: %w", err)
+	}
+
+	var session models.Session
+	if err := json.Unmarshal([]byte(val), &session); err != nil {
+		return nil, fmt.Errorf("セッションのデシリアライズに失敗しました: %w", err)
+	}
+	if session.RetainUntil().Before(time.Now()) {
+		return nil, errcode.ErrSessionExpired
+	}
+	return &session, nil
+}
+
+// 同じトークンで同時にリフレッシュされた場合、後から来た方は ErrTokenConflict になる
+func (s *redisSessionStore) Rotate(ctx context.Context, current, next *models.Session) error {
+	data, err := json.Marshal(next)
+	if err != nil {
+		return fmt.Errorf("セッションのシリアライズに失敗しました: %w", err)
+	}
+	ref, err := json.Marshal(&models.RefreshTokenRef{UserID: next.UserID, SessionID: next.SessionID})
+	if err != nil {
+		return fmt.Errorf("リフレッシュトークンのシリアライズに失敗しました: %w", err)
+	}
+
+	ttl := time.Until(next.RetainUntil())
+	if ttl <= 0 {
+		return errcode.ErrSessionExpired
+	}
+
+	keys := []string{
+		s.familyKey(next.SessionID),
+		s.dataKey(current.TokenHash),
+		s.dataKey(next.TokenHash),
+		s.hashKey(next.UserID),
+		s.refreshKey(next.RefreshHash),
+	}
+	rotated, err := rotateScript.Run(ctx, s.client, keys,
+		current.TokenHash, next.TokenHash, data, ttl.Milliseconds(), (ttl + 24*time.Hour).Milliseconds(), ref,
+	).Int()
+	if err != nil {
+		return fmt.Errorf("トークンのローテーションに失敗しました: %w", err)
+	}
+	if rotated == 0 {
+		return errcode.ErrTokenConflict
+	}
+	return nil
+}
+
+// 再利用を検知したときなど、ファミリーのセッションをまとめて無効にする
+func (s *redisSessionStore) DeleteFamily(ctx context.Context, userID int64, sessionID string) error {
+	fKey := s.familyKey(sessionID)
+
+	tokenHash, err := s.client.Get(ctx, fKey).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("Redisからのトークンファミリーの取得に失敗しました: %w", err)
+	}
+
+	pipe := s.client.Pipeline()
+	pipe.Unlink(ctx, fKey)
+	if tokenHash != "" {
+		pipe.Unlink(ctx, s.dataKey(tokenHash))
+		pipe.SRem(ctx, s.hashKey(userID), tokenHash)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("トークンファミリーの削除に失敗しました: %w", err)
+	}
+
+	return nil
+}
+
+func (s *redisSessionStore) CreateTicket(ctx context.Context, ticketHash, tokenHash string, ttl time.Duration) error {
+	ok, err := s.client.SetNX(ctx, s.ticketKey(ticketHash), tokenHash, ttl).Result()
+	if err != nil {
+		return fmt.Errorf("チケットの保存に失敗しました: %w", err)
+	}
+	if !ok {
+		return errcode.ErrTokenConflict
+	}
+	return nil
+}
+
+// 取得と同時に削除し、同じチケットを二度使えないようにする
+func (s *redisSessionStore) ConsumeTicket(ctx context.Context, ticketHash string) (string, error) {
+	tokenHash, err := s.client.GetDel(ctx, s.ticketKey(ticketHash)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", errcode.ErrSessionNotFound
+		}
+		return "", fmt.Errorf("Redisからのチケットの取得に失敗しました: %w", err)
+	}
+	return tokenHash, nil
+}
@@ -0,0 +1,179 @@
+package db
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const scheduledTweetColumns = `id, user_id, content, media, publish_at, status, tweet_id, created_at`
+
+type postgresScheduledTweetStore struct {
+	BaseStore
+}
+
+func NewPostgresScheduledTweetStore(db *sqlx.DB) *postgresScheduledTweetStore {
+	return &postgresScheduledTweetStore{
+		BaseStore: BaseStore{
+			database: db,
+		},
+	}
+}
+
+// 添付メディアは投稿者本人の未使用のものに限る
+func (s *postgresScheduledTweetStore) CreateScheduledTweet(ctx context.Context, st *models.ScheduledTweet) (*models.ScheduledTweet, error) {
+	query := `
+		INSERT INTO scheduled_tweets(user_id, content, media, publish_at)
+		SELECT $1::BIGINT, $2::TEXT, $3::JSONB, $4::TIMESTAMPTZ
+		WHERE ` + ownedMediaCondition + `
+		RETURNING ` + scheduledTweetColumns
+	var created models.ScheduledTweet
+	err := s.BaseStore.conn(ctx).GetContext(ctx, &created, query, st.UserID, st.Content, st.Media, st.PublishAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errcode.ErrMediaNotFound
+		}
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			if pqErr.Code == errCodeForeignKeyViolation && pqErr.Constraint == constraintScheduledTweetUserFK {
+				return nil, errcode.ErrUserNotFound
+			}
+			if pqErr.Code == errCodeStringDataRightTruncation {
+				return nil, errcode.ErrValueTooLong
+			}
+		}
+		return nil, fmt.Errorf("予約投稿の挿入に失敗しました: %w", err)
+	}
+
+	normalizeScheduledTweetTime(&created)
+	return &created, nil
+}
+
+func (s *postgresScheduledTweetStore) GetPendingByUser(ctx context.Context, userID int64) ([]*models.ScheduledTweet, error) {
+	scheduled := []*models.ScheduledTweet{}
+	query := `SELECT ` + scheduledTweetColumns + `
+		FROM scheduled_tweets
+		WHERE user_id = $1 AND status = 'pending'
+		ORDER BY publish_at ASC, id ASC`
+
+	if err := s.BaseStore.conn(ctx).SelectContext(ctx, &scheduled, query, userID); err != nil {
+		return nil, fmt.Errorf("予約投稿一覧の取得に失敗しました(user_id:%d): %w", userID, err)
+	}
+
+	for _, st := range scheduled {
+		normalizeScheduledTweetTime(st)
+	}
+	return scheduled, nil
+}
+
+// ZSET の再構築用。Postgres 上の未公開予約を ID 順に返す
+func (s *postgresScheduledTweetStore) GetPendingSchedules(ctx context.Context, afterID int64, limit int) ([]*models.ScheduledTweet, error) {
+	scheduled := []*models.ScheduledTweet{}
+	query := `SELECT ` + scheduledTweetColumns + `
+		FROM scheduled_tweets
+		WHERE status = 'pending' AND id > $1
+		ORDER BY id ASC
+		LIMIT $2`
+
+	if err := s.BaseStore.conn(ctx).SelectContext(ctx, &scheduled, query, afterID, limit); err != nil {
+		return nil, fmt.Errorf("未公開の予約投稿の取得に失敗しました: %w", err)
+	}
+
+	for _, st := range scheduled {
+		normalizeScheduledTweetTime(st)
+	}
+	return scheduled, nil
+}
+
+func (s *postgresScheduledTweetStore) CancelScheduledTweet(ctx context.Context, scheduledID, userID int64) error {
+	query := `UPDATE scheduled_tweets SET status = 'canceled' 
+		WHERE id = $1 AND user_id = $2 AND status = 'pending'`
+	result, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, scheduledID, userID)
+	if err != nil {
+		return fmt.Errorf("予約投稿のキャンセルに失敗しました: %w", err)
+	}
+
+	row, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("影響を受けた行数の取得に失敗しました: %w", err)
+	}
+
+	if row == 0 {
+		return errcode.ErrScheduledTweetNotFound
+	}
+
+	return nil
+}
+
+// 予約行を SKIP LOCKED で確保してからツイートを作成するため、複数ワーカーが同じ予約を処理しても公開は一度だけになる。
+// 再試行しても公開できない場合は failed にして ErrScheduledTweetFailed を返す
+func (s *postgresScheduledTweetStore) PublishScheduledTweet(ctx context.Context, scheduledID int64) (*models.Tweet, error) {
+	var published models.Tweet
+	err := s.BaseStore.withTx(ctx, func(txCtx context.Context) error {
+		var scheduled models.ScheduledTweet
+		lockQuery := `SELECT ` + scheduledTweetColumns + `
+			FROM scheduled_tweets
+			WHERE id = $1 AND status = 'pending' AND publish_at <= NOW()
+			FOR UPDATE SKIP LOCKED`
+		if err := s.BaseStore.conn(txCtx).GetContext(txCtx, &scheduled, lockQuery, scheduledID); err != nil {
+			return err
+		}
+
+		var tweet *models.Tweet
+		var err error
+		if len(scheduled.Media) > 0 {
+			tweet, err = insertTweetWithMedia(txCtx, s.BaseStore.conn(txCtx), scheduled.UserID, scheduled.Content, scheduled.Media.ToTweetMedia())
+		} else {
+			tweet, err = insertTweet(txCtx, s.BaseStore.conn(txCtx), scheduled.UserID, scheduled.Content, nil)
+		}
+		if err != nil {
+			if errors.Is(err, errcode.ErrMediaNotFound) {
+				return err
+			}
+			return mapTweetInsertError(err)
+		}
+		published = *tweet
+
+		updateQuery := `UPDATE scheduled_tweets SET status = 'published', tweet_id = $1 WHERE id = $2`
+		_, err = s.BaseStore.conn(txCtx).ExecContext(txCtx, updateQuery, published.ID, scheduledID)
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errcode.ErrScheduledTweetNotFound
+		}
+		if isPermanentPublishError(err) {
+			return nil, s.markScheduledTweetFailed(ctx, scheduledID, err)
+		}
+		return nil, fmt.Errorf("予約投稿の公開に失敗しました(scheduled_id:%d): %w", scheduledID, err)
+	}
+
+	normalizeTweetTime(&published)
+	return &published, nil
+}
+
+func isPermanentPublishError(err error) bool {
+	return errors.Is(err, errcode.ErrMediaNotFound) ||
+		errors.Is(err, errcode.ErrUserNotFound) ||
+		errors.Is(err, errcode.ErrValueTooLong)
+}
+
+func (s *postgresScheduledTweetStore) markScheduledTweetFailed(ctx context.Context, scheduledID int64, cause error) error {
+	query := `UPDATE scheduled_tweets SET status = 'failed' WHERE id = $1 AND status = 'pending'`
+	if _, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, scheduledID); err != nil {
+		return fmt.Errorf("予約投稿を失敗として記録できませんでした(scheduled_id:%d): %w", scheduledID, errors.Join(cause, err))
+	}
+	return fmt.Errorf("%w(scheduled_id:%d): %w", errcode.ErrScheduledTweetFailed, scheduledID, cause)
+}
+
+func normalizeScheduledTweetTime(st *models.ScheduledTweet) {
+	st.PublishAt = st.PublishAt.UTC()
+	st.CreatedAt = st.CreatedAt.UTC()
+}
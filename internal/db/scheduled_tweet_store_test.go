@@ -0,0 +1,151 @@
+package db
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledTweetLifecycle(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	u := setupUsers(t, ctx, 2)
+
+	future, err := testScheduledTweetStore.CreateScheduledTweet(ctx, &models.ScheduledTweet{
+		UserID:    u[0].ID,
+		Content:   "未来の投稿",
+		PublishAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.ScheduleStatusPending, future.Status)
+
+	t.Run("正常系: 未公開の予約一覧を取得できること", func(t *testing.T) {
+		scheduled, err := testScheduledTweetStore.GetPendingByUser(ctx, u[0].ID)
+		require.NoError(t, err)
+		require.Len(t, scheduled, 1)
+		assert.Equal(t, future.ID, scheduled[0].ID)
+	})
+
+	t.Run("異常系: 公開日時前の予約は公開されないこと", func(t *testing.T) {
+		tweet, err := testScheduledTweetStore.PublishScheduledTweet(ctx, future.ID)
+		assert.ErrorIs(t, err, errcode.ErrScheduledTweetNotFound)
+		assert.Nil(t, tweet)
+	})
+
+	t.Run("異常系: 他人の予約はキャンセルできないこと", func(t *testing.T) {
+		err := testScheduledTweetStore.CancelScheduledTweet(ctx, future.ID, u[1].ID)
+		assert.ErrorIs(t, err, errcode.ErrScheduledTweetNotFound)
+	})
+
+	t.Run("正常系: 予約をキャンセルできること", func(t *testing.T) {
+		require.NoError(t, testScheduledTweetStore.CancelScheduledTweet(ctx, future.ID, u[0].ID))
+
+		scheduled, err := testScheduledTweetStore.GetPendingByUser(ctx, u[0].ID)
+		require.NoError(t, err)
+		assert.Empty(t, scheduled)
+	})
+}
+
+func TestPublishScheduledTweetOnlyOnce(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	u := setupUsers(t, ctx, 1)
+
+	due, err := testScheduledTweetStore.CreateScheduledTweet(ctx, &models.ScheduledTweet{
+		UserID:    u[0].ID,
+		Content:   "公開時刻を過ぎた投稿",
+		PublishAt: time.Now().Add(-time.Second),
+	})
+	require.NoError(t, err)
+
+	pending, err := testScheduledTweetStore.GetPendingSchedules(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+
+	var (
+		wg        sync.WaitGroup
+		succeeded atomic.Int32
+		published *models.Tweet
+		mu        sync.Mutex
+	)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tweet, err := testScheduledTweetStore.PublishScheduledTweet(ctx, due.ID)
+			if err == nil {
+				succeeded.Add(1)
+				mu.Lock()
+				published = tweet
+				mu.Unlock()
+				return
+			}
+			assert.ErrorIs(t, err, errcode.ErrScheduledTweetNotFound)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), succeeded.Load())
+	require.NotNil(t, published)
+	assert.Equal(t, due.Content, published.Content)
+
+	tweet, err := testTweetStore.GetTweetByTweetID(ctx, published.ID)
+	require.NoError(t, err)
+	assert.Equal(t, u[0].ID, tweet.UserID)
+
+	pending, err = testScheduledTweetStore.GetPendingSchedules(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestPublishScheduledTweetFailsPermanently(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	u := setupUsers(t, ctx, 1)
+	media := createTestMedia(t, ctx, u[0].ID, "scheduled")
+
+	due, err := testScheduledTweetStore.CreateScheduledTweet(ctx, &models.ScheduledTweet{
+		UserID:    u[0].ID,
+		Content:   "画像付きの予約",
+		Media:     models.MediaAttachments{{MediaID: media.ID}},
+		PublishAt: time.Now().Add(-time.Second),
+	})
+	require.NoError(t, err)
+
+	// 予約後に同じ画像を別のツイートで使うと、予約は公開できなくなる
+	_, err = testTweetStore.CreateTweet(ctx, &models.Tweet{
+		UserID:  u[0].ID,
+		Content: "先に投稿",
+		Media:   []models.TweetMedia{{MediaID: media.ID}},
+	})
+	require.NoError(t, err)
+
+	tweet, err := testScheduledTweetStore.PublishScheduledTweet(ctx, due.ID)
+	assert.ErrorIs(t, err, errcode.ErrScheduledTweetFailed)
+	assert.ErrorIs(t, err, errcode.ErrMediaNotFound)
+	assert.Nil(t, tweet)
+
+	var status string
+	require.NoError(t, testContext.TestDB.GetContext(ctx, &status, `SELECT status FROM scheduled_tweets WHERE id = $1`, due.ID))
+	assert.Equal(t, models.ScheduleStatusFailed, status)
+
+	pending, err := testScheduledTweetStore.GetPendingSchedules(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	_, err = testScheduledTweetStore.PublishScheduledTweet(ctx, due.ID)
+	assert.ErrorIs(t, err, errcode.ErrScheduledTweetNotFound)
+}
@@ -7,11 +7,14 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 )
 
+const tweetColumns = `id, user_id, content, image_url, created_at, updated_at, is_edited, edit_count, last_edited_at, deleted_at`
+
 type postgresTweetStore struct {
 	BaseStore
 }
@@ -24,49 +27,166 @@ func NewPostgresTweetStore(db *sqlx.DB) *postgresTweetStore {
 	}
 }
 
+// 添付メディアや投票がある場合はツイートの作成と同一トランザクションで行う。
+// メディアが投稿者のものでない、または既に別のツイートに使われている場合は ErrMediaNotFound を返す
 func (s *postgresTweetStore) CreateTweet(ctx context.Context, tweet *models.Tweet) (*models.Tweet, error) {
+	var newTweet *models.Tweet
+	var err error
+	if len(tweet.Media) == 0 && tweet.Poll == nil {
+		newTweet, err = insertTweet(ctx, s.BaseStore.conn(ctx), tweet.UserID, tweet.Content, tweet.ImageURL)
+	} else {
+		err = s.BaseStore.withTx(ctx, func(txCtx context.Context) error {
+			conn := s.BaseStore.conn(txCtx)
+			var txErr error
+			if len(tweet.Media) > 0 {
+				newTweet, txErr = insertTweetWithMedia(txCtx, conn, tweet.UserID, tweet.Content, tweet.Media)
+			} else {
+				newTweet, txErr = insertTweet(txCtx, conn, tweet.UserID, tweet.Content, tweet.ImageURL)
+			}
+			if txErr != nil || tweet.Poll == nil {
+				return txErr
+			}
+
+			newTweet.Poll, txErr = insertPoll(txCtx, conn, newTweet.ID, tweet.Poll)
+			return txErr
+		})
+	}
+	if err != nil {
+		if errors.Is(err, errcode.ErrMediaNotFound) {
+			return nil, err
+		}
+		return nil, mapTweetInsertError(err)
+	}
+
+	normalizeTweetTime(newTweet)
+	return newTweet, nil
+}
+
+func insertTweet(ctx context.Context, conn Execer, userID int64, content string, imageURL *string) (*models.Tweet, error) {
 	query := `
 		INSERT INTO tweets(user_id, content, image_url)
 		VALUES($1, $2, $3)
-		RETURNING id, user_id, content, image_url, created_at, updated_at, is_edited`
+		RETURNING ` + tweetColumns
 	var newTweet models.Tweet
-	err := s.BaseStore.conn(ctx).QueryRowContext(
-		ctx,
-		query,
-		tweet.UserID,
-		tweet.Content,
-		tweet.ImageURL,
-	).Scan(
-		&newTweet.ID,
-		&newTweet.UserID,
-		&newTweet.Content,
-		&newTweet.ImageURL,
-		&newTweet.CreatedAt,
-		&newTweet.UpdatedAt,
-		&newTweet.IsEdited, 
-	)
+	if err := conn.GetContext(ctx, &newTweet, query, userID, content, imageURL); err != nil {
+		return nil, err
+	}
+	return &newTweet, nil
+}
 
+// トランザクション内で呼ぶこと。添付は渡された順に position 0 から並べる。
+// image_url には互換性のため先頭の添付の URL を入れる
+func insertTweetWithMedia(ctx context.Context, conn Execer, userID int64, content string, attachments []models.TweetMedia) (*models.Tweet, error) {
+	mediaIDs := make([]int64, 0, len(attachments))
+	for _, a := range attachments {
+		mediaIDs = append(mediaIDs, a.MediaID)
+	}
+
+	var owned []*models.Media
+	lockQuery := `SELECT ` + mediaColumns + ` FROM media m
+		WHERE m.id = ANY($1) AND m.user_id = $2
+			AND NOT EXISTS (SELECT 1 FROM tweet_media tm WHERE tm.media_id = m.id)
+		FOR UPDATE`
+	if err := conn.SelectContext(ctx, &owned, lockQuery, pq.Array(mediaIDs), userID); err != nil {
+		return nil, err
+	}
+
+	mediaByID := make(map[int64]*models.Media, len(owned))
+	for _, m := range owned {
+		mediaByID[m.ID] = m
+	}
+	if len(mediaByID) != len(attachments) {
+		return nil, errcode.ErrMediaNotFound
+	}
+
+	newTweet, err := insertTweet(ctx, conn, userID, content, &mediaByID[attachments[0].MediaID].URL)
 	if err != nil {
-		var pqErr *pq.Error
-		if errors.As(err, &pqErr) {
-			if pqErr.Code == errCodeForeignKeyViolation && pqErr.Constraint == constraintTweetUserFK {
-				return nil, errcode.ErrUserNotFound
-			}
-			if pqErr.Code == errCodeStringDataRightTruncation {
-				return nil, errcode.ErrValueTooLong
+		return nil, err
+	}
+
+	attachQuery := `INSERT INTO tweet_media(tweet_id, media_id, position, alt_text) VALUES($1, $2, $3, $4)`
+	newTweet.Media = make([]models.TweetMedia, 0, len(attachments))
+	for i, a := range attachments {
+		if _, err := conn.ExecContext(ctx, attachQuery, newTweet.ID, a.MediaID, i, a.AltText); err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == errCodeUniqueViolation && pqErr.Constraint == constraintUniqueTweetMedia {
+				return nil, errcode.ErrMediaNotFound
 			}
+			return nil, err
 		}
 
-		return nil, fmt.Errorf("ツイートの挿入に失敗しました: %w", err)
+		m := mediaByID[a.MediaID]
+		newTweet.Media = append(newTweet.Media, models.TweetMedia{
+			TweetID:      newTweet.ID,
+			MediaID:      m.ID,
+			Position:     i,
+			AltText:      a.AltText,
+			URL:          m.URL,
+			ThumbnailURL: m.ThumbnailURL,
+			ContentType:  m.ContentType,
+			Width:        m.Width,
+			Height:       m.Height,
+		})
 	}
+	return newTweet, nil
+}
 
-	newTweet.CreatedAt = newTweet.CreatedAt.UTC()
-	newTweet.UpdatedAt = newTweet.UpdatedAt.UTC()
-	return &newTweet, nil
+// 添付メディアと投票をまとめて読み込む
+func loadTweetDetails(ctx context.Context, conn Execer, tweets ...*models.Tweet) error {
+	if err := loadTweetMedia(ctx, conn, tweets...); err != nil {
+		return err
+	}
+	return loadTweetPolls(ctx, conn, tweets...)
+}
+
+// 複数ツイートの添付メディアを1クエリでまとめて読み込む
+func loadTweetMedia(ctx context.Context, conn Execer, tweets ...*models.Tweet) error {
+	if len(tweets) == 0 {
+		return nil
+	}
+
+	tweetByID := make(map[int64]*models.Tweet, len(tweets))
+	tweetIDs := make([]int64, 0, len(tweets))
+	for _, t := range tweets {
+		tweetByID[t.ID] = t
+		tweetIDs = append(tweetIDs, t.ID)
+	}
+
+	var attachments []models.TweetMedia
+	query := `
+		SELECT tm.tweet_id, tm.media_id, tm.position, tm.alt_text,
+			m.url, m.thumbnail_url, m.content_type, m.width, m.height
+		FROM tweet_media tm
+		JOIN media m ON m.id = tm.media_id
+		WHERE tm.tweet_id = ANY($1)
+		ORDER BY tm.tweet_id, tm.position`
+	if err := conn.SelectContext(ctx, &attachments, query, pq.Array(tweetIDs)); err != nil {
+		return fmt.Errorf("添付メディアの取得に失敗しました(count:%d): %w", len(tweetIDs), err)
+	}
+
+	for _, a := range attachments {
+		t := tweetByID[a.TweetID]
+		t.Media = append(t.Media, a)
+	}
+	return nil
+}
+
+func mapTweetInsertError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		if pqErr.Code == errCodeForeignKeyViolation && pqErr.Constraint == constraintTweetUserFK {
+			return errcode.ErrUserNotFound
+		}
+		if pqErr.Code == errCodeStringDataRightTruncation {
+			return errcode.ErrValueTooLong
+		}
+	}
+
+	return fmt.Errorf("ツイートの挿入に失敗しました: %w", err)
 }
 
 func (s *postgresTweetStore) GetTweetByTweetID(ctx context.Context, tweetID int64) (*models.Tweet, error) {
-	query := `SELECT user_id, content, image_url, created_at, updated_at, is_edited FROM tweets WHERE id = $1`
+	query := `SELECT ` + tweetColumns + ` FROM tweets WHERE id = $1 AND deleted_at IS NULL`
 	var wantedTweet models.Tweet
 	err := s.BaseStore.conn(ctx).GetContext(ctx, &wantedTweet, query, tweetID)
 	if err != nil {
@@ -75,22 +195,61 @@ func (s *postgresTweetStore) GetTweetByTweetID(ctx context.Context, tweetID int6
 		}
 		return nil, fmt.Errorf("ツイートの取得に失敗しました: %w", err)
 	}
-	wantedTweet.CreatedAt = wantedTweet.CreatedAt.UTC()
-	wantedTweet.UpdatedAt = wantedTweet.UpdatedAt.UTC()
+	normalizeTweetTime(&wantedTweet)
+	if err := loadTweetDetails(ctx, s.BaseStore.conn(ctx), &wantedTweet); err != nil {
+		return nil, err
+	}
 	return &wantedTweet, nil
 }
 
-func (s *postgresTweetStore) UpdateContent(ctx context.Context, newContent string, tweetID int64) (*models.Tweet, error) {
-	query := `UPDATE tweets 
-		SET content = $1, is_edited = true
-		WHERE id = $2 AND content <> $1 
-		RETURNING id, user_id, content, image_url, created_at, updated_at, is_edited`
+// 内容の更新と版(tweet_revisions)の記録を同一トランザクションで行う。
+// 初回編集時は元の投稿を revision 0 として保存する
+// 編集期間と回数の判定はロックした行に対する UPDATE の WHERE 句で行い、同時編集でも上限を超えないようにする
+func (s *postgresTweetStore) UpdateContent(ctx context.Context, newContent string, tweetID int64, editWindow time.Duration, maxEdits int) (*models.Tweet, error) {
 	var updatedTweet models.Tweet
-	err :=s.BaseStore.conn(ctx).GetContext(ctx, &updatedTweet, query, newContent, tweetID)
+	err := s.BaseStore.withTx(ctx, func(txCtx context.Context) error {
+		var current models.Tweet
+		lockQuery := `SELECT ` + tweetColumns + ` FROM tweets WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+		if err := s.BaseStore.conn(txCtx).GetContext(txCtx, &current, lockQuery, tweetID); err != nil {
+			return err
+		}
+
+		updateQuery := `UPDATE tweets 
+			SET content = $1, is_edited = true, edit_count = edit_count + 1, last_edited_at = NOW()
+			WHERE id = $2 AND content <> $1 
+				AND created_at > NOW() - make_interval(secs => $3)
+				AND edit_count < $4
+			RETURNING ` + tweetColumns
+		err := s.BaseStore.conn(txCtx).GetContext(txCtx, &updatedTweet, updateQuery, newContent, tweetID, editWindow.Seconds(), maxEdits)
+		if errors.Is(err, sql.ErrNoRows) {
+			switch {
+			case current.EditCount >= maxEdits:
+				return errcode.ErrEditLimitExceeded
+			case time.Since(current.CreatedAt) > editWindow:
+				return errcode.ErrEditTimeExpired
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		revisionQuery := `INSERT INTO tweet_revisions(tweet_id, revision, content, created_at) VALUES($1, $2, $3, $4)`
+		if current.EditCount == 0 {
+			if _, err := s.BaseStore.conn(txCtx).ExecContext(txCtx, revisionQuery, tweetID, 0, current.Content, current.CreatedAt); err != nil {
+				return err
+			}
+		}
+		_, err = s.BaseStore.conn(txCtx).ExecContext(txCtx, revisionQuery, tweetID, updatedTweet.EditCount, updatedTweet.Content, updatedTweet.LastEditedAt)
+		return err
+	})
+
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errcode.ErrTweetNotFound
 		}
+		if errors.Is(err, errcode.ErrEditLimitExceeded) || errors.Is(err, errcode.ErrEditTimeExpired) {
+			return nil, err
+		}
 		var pqErr *pq.Error
 		if errors.As(err, &pqErr) {
 			if pqErr.Code == errCodeStringDataRightTruncation {
@@ -100,28 +259,100 @@ func (s *postgresTweetStore) UpdateContent(ctx context.Context, newContent strin
 		return nil, fmt.Errorf("ツイートの更新に失敗しました: %w", err)
 	}
 
-	updatedTweet.CreatedAt = updatedTweet.CreatedAt.UTC()
-	updatedTweet.UpdatedAt = updatedTweet.UpdatedAt.UTC()
+	normalizeTweetTime(&updatedTweet)
+	if err := loadTweetDetails(ctx, s.BaseStore.conn(ctx), &updatedTweet); err != nil {
+		return nil, err
+	}
 	return &updatedTweet, nil
 }
 
+// 論理削除。物理削除は保持期間経過後に PurgeDeletedTweets が行う
+// 固定ツイートに設定されていれば同じトランザクションで解除する
 func (s *postgresTweetStore) DeleteTweet(ctx context.Context, tweetID int64) error {
-	query := `DELETE FROM tweets WHERE id = $1`
-	result, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, tweetID)
+	return s.BaseStore.withTx(ctx, func(txCtx context.Context) error {
+		conn := s.BaseStore.conn(txCtx)
+
+		query := `UPDATE tweets SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+		result, err := conn.ExecContext(txCtx, query, tweetID)
+		if err != nil {
+			return fmt.Errorf("ツイートの削除に失敗しました: %w", err)
+		}
+
+		row, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("影響を受けた行数の取得に失敗しました: %w", err)
+		}
+
+		if row == 0 {
+			return errcode.ErrTweetNotFound
+		}
+
+		pinQuery := `UPDATE users SET pinned_tweet_id = NULL WHERE pinned_tweet_id = $1`
+		if _, err := conn.ExecContext(txCtx, pinQuery, tweetID); err != nil {
+			return fmt.Errorf("固定ツイートの解除に失敗しました: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *postgresTweetStore) GetDeletedTweet(ctx context.Context, tweetID int64) (*models.Tweet, error) {
+	query := `SELECT ` + tweetColumns + ` FROM tweets WHERE id = $1 AND deleted_at IS NOT NULL`
+	var deletedTweet models.Tweet
+	err := s.BaseStore.conn(ctx).GetContext(ctx, &deletedTweet, query, tweetID)
 	if err != nil {
-		return fmt.Errorf("ツイートの削除に失敗しました: %w", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errcode.ErrTweetNotFound
+		}
+		return nil, fmt.Errorf("削除済みツイートの取得に失敗しました: %w", err)
+	}
+	normalizeTweetTime(&deletedTweet)
+	if err := loadTweetDetails(ctx, s.BaseStore.conn(ctx), &deletedTweet); err != nil {
+		return nil, err
 	}
+	return &deletedTweet, nil
+}
 
-	row, err := result.RowsAffected()
+// 猶予期間内の削除済みツイートのみ復元する
+func (s *postgresTweetStore) RestoreTweet(ctx context.Context, tweetID int64, gracePeriod time.Duration) (*models.Tweet, error) {
+	query := `UPDATE tweets SET deleted_at = NULL
+		WHERE id = $1 AND deleted_at IS NOT NULL
+			AND deleted_at > NOW() - make_interval(secs => $2)
+		RETURNING ` + tweetColumns
+	var restoredTweet models.Tweet
+	err := s.BaseStore.conn(ctx).GetContext(ctx, &restoredTweet, query, tweetID, gracePeriod.Seconds())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errcode.ErrTweetNotFound
+		}
+		return nil, fmt.Errorf("ツイートの復元に失敗しました: %w", err)
+	}
+	normalizeTweetTime(&restoredTweet)
+	if err := loadTweetDetails(ctx, s.BaseStore.conn(ctx), &restoredTweet); err != nil {
+		return nil, err
+	}
+	return &restoredTweet, nil
+}
+
+func (s *postgresTweetStore) PurgeDeletedTweets(ctx context.Context, retention time.Duration, limit int) (int64, error) {
+	query := `DELETE FROM tweets 
+		WHERE id IN (
+			SELECT id FROM tweets 
+			WHERE deleted_at < NOW() - make_interval(secs => $1)
+			ORDER BY deleted_at
+			LIMIT $2
+		)`
+	result, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, retention.Seconds(), limit)
 	if err != nil {
-		return fmt.Errorf("影響を受けた行数の取得に失敗しました: %w", err)
+		return 0, fmt.Errorf("削除済みツイートの物理削除に失敗しました: %w", err)
 	}
 
-	if row == 0 {
-		return errcode.ErrTweetNotFound
+	row, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("影響を受けた行数の取得に失敗しました: %w", err)
 	}
 
-	return nil
+	return row, nil
 }
 
 
@@ -134,15 +365,9 @@ func (s *postgresTweetStore) GetTweetsByTweetIDs(ctx context.Context, tweetIDs [
 		return nil, fmt.Errorf("tweetIDsが大きすぎます(count:%d)", len(tweetIDs))
 	}
 
-	query := `SELECT id, 
-		user_id, 
-		content, 
-		image_url, 
-		created_at, 
-		updated_at, 
-		is_edited
+	query := `SELECT ` + tweetColumns + `
 		FROM tweets
-		WHERE id = ANY($1)`
+		WHERE id = ANY($1) AND deleted_at IS NULL`
 	
 	var tweets []*models.Tweet
 
@@ -152,12 +377,18 @@ func (s *postgresTweetStore) GetTweetsByTweetIDs(ctx context.Context, tweetIDs [
 		return nil, fmt.Errorf("IDによるtweets取得に失敗しました(count:%d); %w", len(tweetIDs),err)
 	}
 
+	for _, t := range tweets {
+		normalizeTweetTime(t)
+	}
+	if err := loadTweetDetails(ctx, s.BaseStore.conn(ctx), tweets...); err != nil {
+		return nil, err
+	}
 	return tweets, nil
 }
 
 func (s *postgresTweetStore) GetTweetIDsByAuthor(ctx context.Context, authorID int64, page, size int) ([]int64, error) {
 	offset := page * size
-	query := `SELCT id FROM tweets WHERE user_id = ? ORERDED BY id DESC LIMIT $2 OFFSET $3`
+	query := `SELECT id FROM tweets WHERE user_id = $1 AND deleted_at IS NULL ORDER BY id DESC LIMIT $2 OFFSET $3`
 	rows, err := s.BaseStore.conn(ctx).QueryContext(ctx, query, authorID, size, offset)
 	if err != nil {
 		return nil, fmt.Errorf("%dのツイートの取得に失敗しました: %w", authorID, err)
@@ -177,4 +408,54 @@ func (s *postgresTweetStore) GetTweetIDsByAuthor(ctx context.Context, authorID i
     }
 
     return ids, nil
-}
\ No newline at end of file
+}
+
+// 複数の投稿者のツイートを新しい順に最大 limit 件返す。before が 0 なら最新から
+func (s *postgresTweetStore) GetTweetIDsByAuthors(ctx context.Context, authorIDs []int64, before int64, limit int) ([]int64, error) {
+	ids := []int64{}
+	if len(authorIDs) == 0 {
+		return ids, nil
+	}
+
+	query := `SELECT id FROM tweets
+		WHERE user_id = ANY($1) AND deleted_at IS NULL AND ($2 = 0 OR id < $2)
+		ORDER BY id DESC
+		LIMIT $3`
+	if err := s.BaseStore.conn(ctx).SelectContext(ctx, &ids, query, pq.Array(authorIDs), before, limit); err != nil {
+		return nil, fmt.Errorf("投稿者一覧のツイートの取得に失敗しました(count:%d): %w", len(authorIDs), err)
+	}
+	return ids, nil
+}
+
+func (s *postgresTweetStore) GetRevisions(ctx context.Context, tweetID int64) ([]*models.TweetRevision, error) {
+	revisions := []*models.TweetRevision{}
+	query := `
+		SELECT id, tweet_id, revision, content, created_at
+		FROM tweet_revisions
+		WHERE tweet_id = $1
+		ORDER BY revision ASC
+	`
+
+	err := s.BaseStore.conn(ctx).SelectContext(ctx, &revisions, query, tweetID)
+	if err != nil {
+		return nil, fmt.Errorf("ツイートの編集履歴の取得に失敗しました(tweet_id:%d): %w", tweetID, err)
+	}
+
+	for i := range revisions {
+		revisions[i].CreatedAt = revisions[i].CreatedAt.UTC()
+	}
+	return revisions, nil
+}
+
+func normalizeTweetTime(t *models.Tweet) {
+	t.CreatedAt = t.CreatedAt.UTC()
+	t.UpdatedAt = t.UpdatedAt.UTC()
+	if t.LastEditedAt != nil {
+		editedAt := t.LastEditedAt.UTC()
+		t.LastEditedAt = &editedAt
+	}
+	if t.DeletedAt != nil {
+		deletedAt := t.DeletedAt.UTC()
+		t.DeletedAt = &deletedAt
+	}
+}
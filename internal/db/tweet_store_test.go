@@ -183,6 +183,11 @@ func TestGetTweetByTweetIDWhileErr(t *testing.T) {
 	})
 }
 
+const (
+	testEditWindow = 10 * time.Minute
+	testMaxEdits   = 5
+)
+
 func TestUpdateContent(t *testing.T) {
 	testContext.CleanupTestDB()
 	defer testContext.CleanupTestDB()
@@ -207,7 +212,7 @@ func TestUpdateContent(t *testing.T) {
 
 	t.Run("正常系:ツイートの更新に成功したこと", func(t *testing.T) {
 		newContent := "Contentが更新しました"
-		updatedTweet, err := testTweetStore.UpdateContent(ctx, newContent, createdTweet.ID)
+		updatedTweet, err := testTweetStore.UpdateContent(ctx, newContent, createdTweet.ID, testEditWindow, testMaxEdits)
 		require.NoError(t, err)
 		require.NotNil(t, updatedTweet)
 		assert.Equal(t, createdTweet.ID, updatedTweet.ID)
@@ -217,6 +222,27 @@ func TestUpdateContent(t *testing.T) {
 		assert.NotEqual(t, updatedTweet.CreatedAt, updatedTweet.UpdatedAt)
 		assert.WithinDuration(t, time.Now(), updatedTweet.UpdatedAt, 2*time.Second)
 		assert.Equal(t, true, updatedTweet.IsEdited)
+		assert.Equal(t, 1, updatedTweet.EditCount)
+		require.NotNil(t, updatedTweet.LastEditedAt)
+		assert.WithinDuration(t, time.Now(), *updatedTweet.LastEditedAt, 2*time.Second)
+	})
+
+	t.Run("正常系:編集のたびに履歴が保存されること", func(t *testing.T) {
+		secondContent := "二回目の更新です"
+		updatedTweet, err := testTweetStore.UpdateContent(ctx, secondContent, createdTweet.ID, testEditWindow, testMaxEdits)
+		require.NoError(t, err)
+		assert.Equal(t, 2, updatedTweet.EditCount)
+
+		revisions, err := testTweetStore.GetRevisions(ctx, createdTweet.ID)
+		require.NoError(t, err)
+		require.Len(t, revisions, 3)
+		assert.Equal(t, createdTweet.Content, revisions[0].Content)
+		assert.Equal(t, "Contentが更新しました", revisions[1].Content)
+		assert.Equal(t, secondContent, revisions[2].Content)
+		for i, rev := range revisions {
+			assert.Equal(t, i, rev.Revision)
+			assert.Equal(t, createdTweet.ID, rev.TweetID)
+		}
 	})
 }
 
@@ -244,18 +270,32 @@ func TestUpdateContentWhileErr(t *testing.T) {
 
 	t.Run("異常系:contentが長すぎ", func(t *testing.T) {
 		newContent := strings.Repeat("a", 1001)
-		updatedTweet, err := testTweetStore.UpdateContent(ctx, newContent, createdTweet.ID)
+		updatedTweet, err := testTweetStore.UpdateContent(ctx, newContent, createdTweet.ID, testEditWindow, testMaxEdits)
 		assert.ErrorIs(t, err, errcode.ErrValueTooLong)
 		assert.Nil(t, updatedTweet)
 	})
 
 	t.Run("異常系:TweetIDがないこと", func(t *testing.T) {
 		newContent := "Contentが更新しました"
-		updatedTweet, err := testTweetStore.UpdateContent(ctx, newContent, int64(999))
+		updatedTweet, err := testTweetStore.UpdateContent(ctx, newContent, int64(999), testEditWindow, testMaxEdits)
 		assert.ErrorIs(t, err, errcode.ErrTweetNotFound)
 		assert.Nil(t, updatedTweet)
 	})
 
+	t.Run("異常系:編集回数の上限に達したこと", func(t *testing.T) {
+		newContent := "Contentが更新しました"
+		updatedTweet, err := testTweetStore.UpdateContent(ctx, newContent, createdTweet.ID, testEditWindow, 0)
+		assert.ErrorIs(t, err, errcode.ErrEditLimitExceeded)
+		assert.Nil(t, updatedTweet)
+	})
+
+	t.Run("異常系:編集可能期間を過ぎたこと", func(t *testing.T) {
+		newContent := "Contentが更新しました"
+		updatedTweet, err := testTweetStore.UpdateContent(ctx, newContent, createdTweet.ID, time.Nanosecond, testMaxEdits)
+		assert.ErrorIs(t, err, errcode.ErrEditTimeExpired)
+		assert.Nil(t, updatedTweet)
+	})
+
 	t.Run("異常系:データベース切断時の時、ラップされたエラーを返すこと", func(t *testing.T) {
 		tempDB, err := testConfig.OpenDB(testContext.DSN)
 		require.NoError(t, err)
@@ -263,7 +303,7 @@ func TestUpdateContentWhileErr(t *testing.T) {
 		tempDB.Close()
 
 		newContent := "Contentが更新しました"
-		updatedTweet, err := tempTweetStore.UpdateContent(ctx, newContent, createdTweet.ID)
+		updatedTweet, err := tempTweetStore.UpdateContent(ctx, newContent, createdTweet.ID, testEditWindow, testMaxEdits)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "ツイートの更新に失敗しました")
 		t.Logf("エラーは: %v\n", err)
@@ -298,6 +338,99 @@ func TestDeleteTweet(t *testing.T) {
 		res, err := testTweetStore.GetTweetByTweetID(ctx, createdTweet.ID)
 		require.Error(t, err)
 		require.Nil(t, res)
+
+		tweets, err := testTweetStore.GetTweetsByTweetIDs(ctx, []int64{createdTweet.ID})
+		require.NoError(t, err)
+		assert.Empty(t, tweets)
+
+		deleted, err := testTweetStore.GetDeletedTweet(ctx, createdTweet.ID)
+		require.NoError(t, err)
+		require.NotNil(t, deleted.DeletedAt)
+	})
+
+	t.Run("異常系: 削除済みのツイートは再削除できないこと", func(t *testing.T) {
+		err := testTweetStore.DeleteTweet(ctx, createdTweet.ID)
+		assert.ErrorIs(t, err, errcode.ErrTweetNotFound)
+	})
+}
+
+func TestRestoreTweet(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	initUser := &models.User{
+		Username:     "henry",
+		Email:        "text@example.com",
+		PasswordHash: "passwordHash",
+	}
+	createdUser, err := testUserStore.Create(ctx, initUser)
+	require.NoError(t, err)
+
+	createdTweet, err := testTweetStore.CreateTweet(ctx, &models.Tweet{UserID: createdUser.ID, Content: "復元テスト"})
+	require.NoError(t, err)
+	require.NoError(t, testTweetStore.DeleteTweet(ctx, createdTweet.ID))
+
+	t.Run("異常系: 猶予期間を過ぎたツイートは復元できないこと", func(t *testing.T) {
+		restored, err := testTweetStore.RestoreTweet(ctx, createdTweet.ID, time.Nanosecond)
+		assert.ErrorIs(t, err, errcode.ErrTweetNotFound)
+		assert.Nil(t, restored)
+	})
+
+	t.Run("正常系: 猶予期間内のツイートが復元されること", func(t *testing.T) {
+		restored, err := testTweetStore.RestoreTweet(ctx, createdTweet.ID, time.Hour)
+		require.NoError(t, err)
+		assert.Nil(t, restored.DeletedAt)
+		assert.Equal(t, createdTweet.Content, restored.Content)
+
+		res, err := testTweetStore.GetTweetByTweetID(ctx, createdTweet.ID)
+		require.NoError(t, err)
+		assert.Equal(t, createdTweet.ID, res.ID)
+	})
+
+	t.Run("異常系: 削除されていないツイートは復元できないこと", func(t *testing.T) {
+		restored, err := testTweetStore.RestoreTweet(ctx, createdTweet.ID, time.Hour)
+		assert.ErrorIs(t, err, errcode.ErrTweetNotFound)
+		assert.Nil(t, restored)
+	})
+}
+
+func TestPurgeDeletedTweets(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	initUser := &models.User{
+		Username:     "henry",
+		Email:        "text@example.com",
+		PasswordHash: "passwordHash",
+	}
+	createdUser, err := testUserStore.Create(ctx, initUser)
+	require.NoError(t, err)
+
+	liveTweet, err := testTweetStore.CreateTweet(ctx, &models.Tweet{UserID: createdUser.ID, Content: "残るツイート"})
+	require.NoError(t, err)
+	deletedTweet, err := testTweetStore.CreateTweet(ctx, &models.Tweet{UserID: createdUser.ID, Content: "消えるツイート"})
+	require.NoError(t, err)
+	require.NoError(t, testTweetStore.DeleteTweet(ctx, deletedTweet.ID))
+
+	t.Run("正常系: 保持期間内の削除済みツイートは残ること", func(t *testing.T) {
+		purged, err := testTweetStore.PurgeDeletedTweets(ctx, time.Hour, 100)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), purged)
+	})
+
+	t.Run("正常系: 保持期間を過ぎた削除済みツイートのみ物理削除されること", func(t *testing.T) {
+		purged, err := testTweetStore.PurgeDeletedTweets(ctx, time.Nanosecond, 100)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), purged)
+
+		_, err = testTweetStore.GetDeletedTweet(ctx, deletedTweet.ID)
+		assert.ErrorIs(t, err, errcode.ErrTweetNotFound)
+
+		res, err := testTweetStore.GetTweetByTweetID(ctx, liveTweet.ID)
+		require.NoError(t, err)
+		assert.Equal(t, liveTweet.ID, res.ID)
 	})
 }
 
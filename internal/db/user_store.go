@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
@@ -28,7 +29,7 @@ func NewPostgresUserStore(db *sqlx.DB) *postgresUserStore {
 func (s *postgresUserStore) Create(ctx context.Context, user *models.User) (*models.User, error) {
 	query := `INSERT INTO users(username, email, password_hash) 
 			  VALUES ($1, $2, $3) 
-			  RETURNING id, username, email, password_hash, created_at, follower_count, following_count`
+			  RETURNING id, username, email, password_hash, created_at, follower_count, following_count, email_verified_at`
 
 	var newUser models.User
 	err := s.BaseStore.conn(ctx).QueryRowContext(
@@ -45,6 +46,7 @@ func (s *postgresUserStore) Create(ctx context.Context, user *models.User) (*mod
 		&newUser.CreatedAt,
 		&newUser.FollowerCount,
 		&newUser.FollowingCount,
+		&newUser.EmailVerifiedAt,
 	)
 
 	if err != nil {
@@ -71,7 +73,7 @@ func (s *postgresUserStore) Create(ctx context.Context, user *models.User) (*mod
 
 func (s *postgresUserStore) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	var newUser models.User
-	query := `SELECT id, username, email, password_hash, created_at, follower_count, following_count FROM users WHERE email = $1`
+	query := `SELECT id, username, email, password_hash, created_at, follower_count, following_count, email_verified_at, username_changed_at FROM users WHERE email = $1`
 	err := s.BaseStore.conn(ctx).GetContext(ctx, &newUser, query, email)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -86,7 +88,7 @@ func (s *postgresUserStore) GetByEmail(ctx context.Context, email string) (*mode
 
 func (s *postgresUserStore) GetFullByID(ctx context.Context, userID int64) (*models.User, error) {
 	var newUser models.User
-	query := `SELECT id, username, email, password_hash, created_at, follower_count, following_count FROM users WHERE id = $1`
+	query := `SELECT id, username, email, password_hash, created_at, follower_count, following_count, email_verified_at, username_changed_at FROM users WHERE id = $1`
 	err := s.BaseStore.conn(ctx).GetContext(ctx, &newUser, query, userID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -147,4 +149,184 @@ func (s *postgresUserStore) GetNamesByIDs(ctx context.Context, userIDs []int64)
 		return nil, fmt.Errorf("IDによるusers取得に失敗しました(count:%d); %w", len(userIDs),err)
 	}
 	return rows, nil 
-}
\ No newline at end of file
+}
+// 本人の削除されていないツイートのみ固定できる。
+// 他人のツイートには ErrForbidden、存在しないツイートには ErrTweetNotFound を返す
+func (s *postgresUserStore) SetPinnedTweet(ctx context.Context, userID, tweetID int64) error {
+	query := `UPDATE users SET pinned_tweet_id = $2
+		WHERE id = $1
+		AND EXISTS (SELECT 1 FROM tweets WHERE id = $2 AND user_id = $1 AND deleted_at IS NULL)`
+	res, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, userID, tweetID)
+	if err != nil {
+		return fmt.Errorf("固定ツイートの設定に失敗しました(user_id:%d): %w", userID, err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("影響を受けた行数の取得に失敗しました: %w", err)
+	}
+	if rows > 0 {
+		return nil
+	}
+
+	var ownerID int64
+	ownerQuery := `SELECT user_id FROM tweets WHERE id = $1 AND deleted_at IS NULL`
+	if err := s.BaseStore.conn(ctx).GetContext(ctx, &ownerID, ownerQuery, tweetID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errcode.ErrTweetNotFound
+		}
+		return fmt.Errorf("ツイート所有者の取得に失敗しました(tweet_id:%d): %w", tweetID, err)
+	}
+	if ownerID != userID {
+		return errcode.ErrForbidden
+	}
+	return errcode.ErrUserNotFound
+}
+
+// 指定したツイートが固定されていなければ ErrPinnedTweetNotFound を返す
+func (s *postgresUserStore) ClearPinnedTweet(ctx context.Context, userID, tweetID int64) error {
+	query := `UPDATE users SET pinned_tweet_id = NULL WHERE id = $1 AND pinned_tweet_id = $2`
+	res, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, userID, tweetID)
+	if err != nil {
+		return fmt.Errorf("固定ツイートの解除に失敗しました(user_id:%d): %w", userID, err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("影響を受けた行数の取得に失敗しました: %w", err)
+	}
+	if rows == 0 {
+		return errcode.ErrPinnedTweetNotFound
+	}
+	return nil
+}
+
+// 固定していない場合は 0 を返す
+func (s *postgresUserStore) GetPinnedTweetID(ctx context.Context, userID int64) (int64, error) {
+	query := `SELECT COALESCE(pinned_tweet_id, 0) FROM users WHERE id = $1`
+	var tweetID int64
+	if err := s.BaseStore.conn(ctx).GetContext(ctx, &tweetID, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errcode.ErrUserNotFound
+		}
+		return 0, fmt.Errorf("固定ツイートの取得に失敗しました(user_id:%d): %w", userID, err)
+	}
+	return tweetID, nil
+}
+
+// メンションの解決用。存在しないユーザー名は結果に含めない
+func (s *postgresUserStore) GetIDsByUsernames(ctx context.Context, usernames []string) ([]*models.UserInfo, error) {
+	if len(usernames) == 0 {
+		return []*models.UserInfo{}, nil
+	}
+
+	query := `SELECT id, username FROM users WHERE username = ANY($1)`
+	var rows []*models.UserInfo
+	if err := s.BaseStore.conn(ctx).SelectContext(ctx, &rows, query, pq.Array(usernames)); err != nil {
+		return nil, fmt.Errorf("ユーザー名によるusers取得に失敗しました(count:%d): %w", len(usernames), err)
+	}
+	return rows, nil
+}
+
+func (s *postgresUserStore) UpdatePassword(ctx context.Context, userID int64, passwordHash string) error {
+	query := `UPDATE users SET password_hash = $2 WHERE id = $1`
+	res, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, userID, passwordHash)
+	if err != nil {
+		return fmt.Errorf("パスワードの更新に失敗しました(user_id:%d): %w", userID, err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("影響を受けた行数の取得に失敗しました: %w", err)
+	}
+	if rows == 0 {
+		return errcode.ErrUserNotFound
+	}
+	return nil
+}
+
+// 確認済みの場合は最初に確認した日時を残す
+func (s *postgresUserStore) MarkEmailVerified(ctx context.Context, userID int64) error {
+	query := `UPDATE users SET email_verified_at = COALESCE(email_verified_at, NOW()) WHERE id = $1`
+	res, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("メールアドレスの確認状態の更新に失敗しました(user_id:%d): %w", userID, err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("影響を受けた行数の取得に失敗しました: %w", err)
+	}
+	if rows == 0 {
+		return errcode.ErrUserNotFound
+	}
+	return nil
+}
+
+// メールアドレスを変更したら確認をやり直してもらう
+func (s *postgresUserStore) UpdateEmail(ctx context.Context, userID int64, email string) error {
+	query := `UPDATE users SET email = $2, email_verified_at = NULL WHERE id = $1`
+	res, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, userID, email)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code {
+			case errCodeUniqueViolation:
+				if pqErr.Constraint == constraintUseremailK {
+					return errcode.ErrEmailConflict
+				}
+			case errCodeStringDataRightTruncation:
+				return errcode.ErrValueTooLong
+			}
+		}
+		return fmt.Errorf("メールアドレスの更新に失敗しました(user_id:%d): %w", userID, err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("影響を受けた行数の取得に失敗しました: %w", err)
+	}
+	if rows == 0 {
+		return errcode.ErrUserNotFound
+	}
+	return nil
+}
+
+// changedBefore より後に変更済みの場合は更新せず ErrUsernameChangeTooSoon を返す
+func (s *postgresUserStore) UpdateUsername(ctx context.Context, userID int64, username string, changedBefore time.Time) error {
+	query := `UPDATE users SET username = $2, username_changed_at = NOW()
+			  WHERE id = $1 AND (username_changed_at IS NULL OR username_changed_at <= $3)`
+	res, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, userID, username, changedBefore)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code {
+			case errCodeUniqueViolation:
+				if pqErr.Constraint == constraintUsernameK {
+					return errcode.ErrUsernameConflict
+				}
+			case errCodeStringDataRightTruncation:
+				return errcode.ErrValueTooLong
+			}
+		}
+		return fmt.Errorf("ユーザーネームの更新に失敗しました(user_id:%d): %w", userID, err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("影響を受けた行数の取得に失敗しました: %w", err)
+	}
+	if rows > 0 {
+		return nil
+	}
+
+	var exists bool
+	err = s.BaseStore.conn(ctx).GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)`, userID)
+	if err != nil {
+		return fmt.Errorf("ユーザーの存在確認に失敗しました(user_id:%d): %w", userID, err)
+	}
+	if !exists {
+		return errcode.ErrUserNotFound
+	}
+	return errcode.ErrUsernameChangeTooSoon
+}
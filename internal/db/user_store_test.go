@@ -464,3 +464,118 @@ func TestIncreFollowingCountWhileErr(t *testing.T) {
 		t.Logf("エラーは: %v\n", err)
 	})
 }
+
+func TestPinnedTweet(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	u := setupUsers(t, ctx, 2)
+	tweet, err := testTweetStore.CreateTweet(ctx, &models.Tweet{UserID: u[0].ID, Content: "固定する"})
+	require.NoError(t, err)
+
+	t.Run("異常系: 他人のツイートは固定できないこと", func(t *testing.T) {
+		err := testUserStore.SetPinnedTweet(ctx, u[1].ID, tweet.ID)
+		assert.ErrorIs(t, err, errcode.ErrForbidden)
+	})
+
+	t.Run("正常系: 固定したツイートを取得できること", func(t *testing.T) {
+		require.NoError(t, testUserStore.SetPinnedTweet(ctx, u[0].ID, tweet.ID))
+
+		pinnedID, err := testUserStore.GetPinnedTweetID(ctx, u[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, tweet.ID, pinnedID)
+	})
+
+	t.Run("正常系: ツイートを削除すると固定も解除されること", func(t *testing.T) {
+		require.NoError(t, testTweetStore.DeleteTweet(ctx, tweet.ID))
+
+		pinnedID, err := testUserStore.GetPinnedTweetID(ctx, u[0].ID)
+		require.NoError(t, err)
+		assert.Zero(t, pinnedID)
+
+		err = testUserStore.SetPinnedTweet(ctx, u[0].ID, tweet.ID)
+		assert.ErrorIs(t, err, errcode.ErrTweetNotFound)
+	})
+
+	t.Run("異常系: 固定していないツイートは解除できないこと", func(t *testing.T) {
+		err := testUserStore.ClearPinnedTweet(ctx, u[0].ID, tweet.ID)
+		assert.ErrorIs(t, err, errcode.ErrPinnedTweetNotFound)
+	})
+}
+
+func TestMarkEmailVerified(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+	createdUser, err := testUserStore.Create(ctx, &models.User{
+		Username:     "verify_user",
+		Email:        "verify@example.com",
+		PasswordHash: "passwordHash",
+	})
+	require.NoError(t, err)
+	assert.Nil(t, createdUser.EmailVerifiedAt, "新規ユーザーは未確認であるべきです")
+
+	require.NoError(t, testUserStore.MarkEmailVerified(ctx, createdUser.ID))
+	foundUser, err := testUserStore.GetFullByID(ctx, createdUser.ID)
+	require.NoError(t, err)
+	require.NotNil(t, foundUser.EmailVerifiedAt, "確認日時が記録されるべきです")
+	firstVerifiedAt := *foundUser.EmailVerifiedAt
+
+	require.NoError(t, testUserStore.MarkEmailVerified(ctx, createdUser.ID), "二回目の確認もエラーを返すべきではありません")
+	foundUser, err = testUserStore.GetFullByID(ctx, createdUser.ID)
+	require.NoError(t, err)
+	assert.True(t, firstVerifiedAt.Equal(*foundUser.EmailVerifiedAt), "最初の確認日時が維持されるべきです")
+
+	err = testUserStore.MarkEmailVerified(ctx, createdUser.ID+1000)
+	assert.ErrorIs(t, err, errcode.ErrUserNotFound)
+}
+
+func TestUpdateEmail(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+	user, err := testUserStore.Create(ctx, &models.User{Username: "email_user", Email: "before@example.com", PasswordHash: "hash"})
+	require.NoError(t, err)
+	_, err = testUserStore.Create(ctx, &models.User{Username: "other_user", Email: "taken@example.com", PasswordHash: "hash"})
+	require.NoError(t, err)
+	require.NoError(t, testUserStore.MarkEmailVerified(ctx, user.ID))
+
+	require.NoError(t, testUserStore.UpdateEmail(ctx, user.ID, "after@example.com"))
+	found, err := testUserStore.GetFullByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "after@example.com", found.Email)
+	assert.Nil(t, found.EmailVerifiedAt, "変更後は未確認に戻るべきです")
+
+	err = testUserStore.UpdateEmail(ctx, user.ID, "taken@example.com")
+	assert.ErrorIs(t, err, errcode.ErrEmailConflict)
+
+	err = testUserStore.UpdateEmail(ctx, user.ID+1000, "nobody@example.com")
+	assert.ErrorIs(t, err, errcode.ErrUserNotFound)
+}
+
+func TestUpdateUsername(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+	user, err := testUserStore.Create(ctx, &models.User{Username: "before_name", Email: "name@example.com", PasswordHash: "hash"})
+	require.NoError(t, err)
+	_, err = testUserStore.Create(ctx, &models.User{Username: "taken_name", Email: "taken@example.com", PasswordHash: "hash"})
+	require.NoError(t, err)
+
+	cooldown := time.Now().Add(-30 * 24 * time.Hour)
+	require.NoError(t, testUserStore.UpdateUsername(ctx, user.ID, "after_name", cooldown), "初回の変更はエラーを返すべきではありません")
+	found, err := testUserStore.GetFullByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "after_name", found.Username)
+	require.NotNil(t, found.UsernameChangedAt, "変更日時が記録されるべきです")
+
+	err = testUserStore.UpdateUsername(ctx, user.ID, "again_name", cooldown)
+	assert.ErrorIs(t, err, errcode.ErrUsernameChangeTooSoon, "待機期間中は変更できないべきです")
+
+	err = testUserStore.UpdateUsername(ctx, user.ID, "taken_name", time.Now().Add(time.Minute))
+	assert.ErrorIs(t, err, errcode.ErrUsernameConflict)
+
+	err = testUserStore.UpdateUsername(ctx, user.ID+1000, "ghost_name", cooldown)
+	assert.ErrorIs(t, err, errcode.ErrUserNotFound)
+}
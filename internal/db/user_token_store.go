@@ -0,0 +1,86 @@
+package db
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const userTokenColumns = `id, user_id, purpose, token_hash, expires_at, used_at, created_at`
+
+type postgresUserTokenStore struct {
+	BaseStore
+}
+
+func NewPostgresUserTokenStore(db *sqlx.DB) *postgresUserTokenStore {
+	return &postgresUserTokenStore{
+		BaseStore: BaseStore{
+			database: db,
+		},
+	}
+}
+
+// 同じ用途の未使用トークンは取り消し、最後に発行したものだけを有効にする
+func (s *postgresUserTokenStore) CreateToken(ctx context.Context, token *models.UserToken) (*models.UserToken, error) {
+	var created models.UserToken
+	err := s.withTx(ctx, func(ctx context.Context) error {
+		deleteQuery := `DELETE FROM user_tokens WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL`
+		if _, err := s.BaseStore.conn(ctx).ExecContext(ctx, deleteQuery, token.UserID, token.Purpose); err != nil {
+			return err
+		}
+
+		insertQuery := `INSERT INTO user_tokens(user_id, purpose, token_hash, expires_at)
+			VALUES($1, $2, $3, $4)
+			RETURNING ` + userTokenColumns
+		return s.BaseStore.conn(ctx).GetContext(ctx, &created, insertQuery, token.UserID, token.Purpose, token.TokenHash, token.ExpiresAt)
+	})
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			if pqErr.Code == errCodeForeignKeyViolation && pqErr.Constraint == constraintUserTokenUserFK {
+				return nil, errcode.ErrUserNotFound
+			}
+			if pqErr.Code == errCodeUniqueViolation && pqErr.Constraint == constraintUserTokenHashK {
+				return nil, errcode.ErrTokenConflict
+			}
+		}
+		return nil, fmt.Errorf("トークンの保存に失敗しました(user_id:%d): %w", token.UserID, err)
+	}
+
+	created.ExpiresAt = created.ExpiresAt.UTC()
+	created.CreatedAt = created.CreatedAt.UTC()
+	return &created, nil
+}
+
+// 有効なトークンを使用済みにして返す。使用済み・期限切れ・存在しない場合は ErrUserTokenNotFound を返す
+func (s *postgresUserTokenStore) ConsumeToken(ctx context.Context, purpose, tokenHash string) (*models.UserToken, error) {
+	query := `UPDATE user_tokens SET used_at = NOW()
+		WHERE purpose = $1 AND token_hash = $2 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING ` + userTokenColumns
+	var token models.UserToken
+	if err := s.BaseStore.conn(ctx).GetContext(ctx, &token, query, purpose, tokenHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errcode.ErrUserTokenNotFound
+		}
+		return nil, fmt.Errorf("トークンの使用に失敗しました(purpose:%s): %w", purpose, err)
+	}
+
+	token.ExpiresAt = token.ExpiresAt.UTC()
+	token.CreatedAt = token.CreatedAt.UTC()
+	return &token, nil
+}
+
+// 用途ごとに未使用のトークンをすべて取り消す
+func (s *postgresUserTokenStore) DeleteTokens(ctx context.Context, userID int64, purpose string) error {
+	query := `DELETE FROM user_tokens WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL`
+	if _, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, userID, purpose); err != nil {
+		return fmt.Errorf("トークンの取り消しに失敗しました(user_id:%d): %w", userID, err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserTokens(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	u := setupUsers(t, ctx, 1)
+	newToken := func(hash string, expiresAt time.Time) *models.UserToken {
+		return &models.UserToken{UserID: u[0].ID, Purpose: models.TokenPurposePasswordReset, TokenHash: hash, ExpiresAt: expiresAt}
+	}
+
+	t.Run("正常系: 1回だけ使えること", func(t *testing.T) {
+		_, err := testUserTokenStore.CreateToken(ctx, newToken("once", time.Now().Add(time.Hour)))
+		require.NoError(t, err)
+
+		token, err := testUserTokenStore.ConsumeToken(ctx, models.TokenPurposePasswordReset, "once")
+		require.NoError(t, err)
+		assert.Equal(t, u[0].ID, token.UserID)
+		assert.NotNil(t, token.UsedAt)
+
+		_, err = testUserTokenStore.ConsumeToken(ctx, models.TokenPurposePasswordReset, "once")
+		assert.ErrorIs(t, err, errcode.ErrUserTokenNotFound)
+	})
+
+	t.Run("新しく発行すると古い未使用のトークンは使えないこと", func(t *testing.T) {
+		_, err := testUserTokenStore.CreateToken(ctx, newToken("old", time.Now().Add(time.Hour)))
+		require.NoError(t, err)
+		_, err = testUserTokenStore.CreateToken(ctx, newToken("new", time.Now().Add(time.Hour)))
+		require.NoError(t, err)
+
+		_, err = testUserTokenStore.ConsumeToken(ctx, models.TokenPurposePasswordReset, "old")
+		assert.ErrorIs(t, err, errcode.ErrUserTokenNotFound)
+		_, err = testUserTokenStore.ConsumeToken(ctx, models.TokenPurposePasswordReset, "new")
+		assert.NoError(t, err)
+	})
+
+	t.Run("期限切れ・用途違いのトークンは使えないこと", func(t *testing.T) {
+		_, err := testUserTokenStore.CreateToken(ctx, newToken("expired", time.Now().Add(-time.Minute)))
+		require.NoError(t, err)
+		_, err = testUserTokenStore.ConsumeToken(ctx, models.TokenPurposePasswordReset, "expired")
+		assert.ErrorIs(t, err, errcode.ErrUserTokenNotFound)
+
+		_, err = testUserTokenStore.CreateToken(ctx, newToken("purpose", time.Now().Add(time.Hour)))
+		require.NoError(t, err)
+		_, err = testUserTokenStore.ConsumeToken(ctx, "other", "purpose")
+		assert.ErrorIs(t, err, errcode.ErrUserTokenNotFound)
+	})
+
+	t.Run("異常系: 存在しないユーザー", func(t *testing.T) {
+		_, err := testUserTokenStore.CreateToken(ctx, &models.UserToken{UserID: 999999, Purpose: models.TokenPurposePasswordReset, TokenHash: "x", ExpiresAt: time.Now().Add(time.Hour)})
+		assert.ErrorIs(t, err, errcode.ErrUserNotFound)
+	})
+}
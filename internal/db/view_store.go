@@ -0,0 +1,75 @@
+package db
+
+import (
+	"aita/internal/models"
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type postgresViewStore struct {
+	BaseStore
+}
+
+func NewPostgresViewStore(db *sqlx.DB) *postgresViewStore {
+	return &postgresViewStore{
+		BaseStore: BaseStore{
+			database: db,
+		},
+	}
+}
+
+// 存在しないツイートは結果に含めない
+func (s *postgresViewStore) GetViewCounts(ctx context.Context, tweetIDs []int64) ([]*models.TweetViewCount, error) {
+	counts := []*models.TweetViewCount{}
+	if len(tweetIDs) == 0 {
+		return counts, nil
+	}
+
+	query := `
+		SELECT t.id, COALESCE(v.view_count, 0) AS view_count
+		FROM tweets t
+		LEFT JOIN tweet_view_counts v ON v.tweet_id = t.id
+		WHERE t.id = ANY($1)`
+	if err := s.BaseStore.conn(ctx).SelectContext(ctx, &counts, query, pq.Array(tweetIDs)); err != nil {
+		return nil, fmt.Errorf("閲覧数の取得に失敗しました: %w", err)
+	}
+	return counts, nil
+}
+
+// ツイートIDごとの増分を加算し、日別の集計用に今日(UTC)の増分としても記録する。
+// 削除されたツイートの分は捨てる
+func (s *postgresViewStore) IncrementViewCounts(ctx context.Context, deltas map[int64]int64) error {
+	if len(deltas) == 0 {
+		return nil
+	}
+
+	tweetIDs := make([]int64, 0, len(deltas))
+	values := make([]int64, 0, len(deltas))
+	for tweetID, delta := range deltas {
+		tweetIDs = append(tweetIDs, tweetID)
+		values = append(values, delta)
+	}
+
+	query := `
+		WITH d AS (
+			SELECT d.id, d.delta
+			FROM unnest($1::BIGINT[], $2::BIGINT[]) AS d(id, delta)
+			JOIN tweets t ON t.id = d.id
+		), total AS (
+			INSERT INTO tweet_view_counts(tweet_id, view_count)
+			SELECT id, delta FROM d
+			ON CONFLICT (tweet_id) DO UPDATE
+			SET view_count = tweet_view_counts.view_count + EXCLUDED.view_count
+		)
+		INSERT INTO tweet_daily_views(tweet_id, day, view_count)
+		SELECT id, (NOW() AT TIME ZONE 'UTC')::DATE, delta FROM d
+		ON CONFLICT (tweet_id, day) DO UPDATE
+		SET view_count = tweet_daily_views.view_count + EXCLUDED.view_count`
+	if _, err := s.BaseStore.conn(ctx).ExecContext(ctx, query, pq.Array(tweetIDs), pq.Array(values)); err != nil {
+		return fmt.Errorf("閲覧数の加算に失敗しました(count:%d): %w", len(deltas), err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package db
+
+import (
+	"aita/internal/models"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewCounts(t *testing.T) {
+	testContext.CleanupTestDB()
+	defer testContext.CleanupTestDB()
+	ctx := context.Background()
+
+	u := setupUsers(t, ctx, 1)
+	a, err := testTweetStore.CreateTweet(ctx, &models.Tweet{UserID: u[0].ID, Content: "a"})
+	require.NoError(t, err)
+	b, err := testTweetStore.CreateTweet(ctx, &models.Tweet{UserID: u[0].ID, Content: "b"})
+	require.NoError(t, err)
+
+	t.Run("正常系: 増分を加算できること。存在しないツイートは無視されること", func(t *testing.T) {
+		require.NoError(t, testViewStore.IncrementViewCounts(ctx, map[int64]int64{a.ID: 3, 999999: 5}))
+		require.NoError(t, testViewStore.IncrementViewCounts(ctx, map[int64]int64{a.ID: 2, b.ID: 1}))
+
+		counts, err := testViewStore.GetViewCounts(ctx, []int64{a.ID, b.ID, 999999})
+		require.NoError(t, err)
+		got := make(map[int64]int64, len(counts))
+		for _, c := range counts {
+			got[c.TweetID] = c.ViewCount
+		}
+		assert.Equal(t, map[int64]int64{a.ID: 5, b.ID: 1}, got)
+	})
+
+	t.Run("正常系: 閲覧数の加算でツイートの更新日時が変わらないこと", func(t *testing.T) {
+		before, err := testTweetStore.GetTweetByTweetID(ctx, a.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, testViewStore.IncrementViewCounts(ctx, map[int64]int64{a.ID: 1}))
+
+		after, err := testTweetStore.GetTweetByTweetID(ctx, a.ID)
+		require.NoError(t, err)
+		assert.True(t, before.UpdatedAt.Equal(after.UpdatedAt))
+	})
+}
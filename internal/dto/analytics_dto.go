@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"aita/internal/models"
+	"aita/internal/pkg/app"
+	"time"
+)
+
+const DayLayout = "2006-01-02"
+
+// 期間内の日別集計。Days は動きのなかった日も含めて from から to まで並ぶ
+type AnalyticsRecord struct {
+	From   time.Time
+	To     time.Time
+	Days   []*DailyStatsRecord
+	Totals AnalyticsTotalsRecord
+}
+
+type AnalyticsTotalsRecord struct {
+	FollowersGained int64
+	FollowersLost   int64
+	TweetsPosted    int64
+	Impressions     int64
+}
+
+type DailyStatsRecord struct {
+	Day             time.Time
+	FollowersGained int64
+	FollowersLost   int64
+	TweetsPosted    int64
+	Impressions     int64
+}
+
+func NewDailyStatsRecord(s *models.DailyStats) *DailyStatsRecord {
+	if s == nil {
+		return nil
+	}
+
+	return &DailyStatsRecord{
+		Day:             s.Day,
+		FollowersGained: s.FollowersGained,
+		FollowersLost:   s.FollowersLost,
+		TweetsPosted:    s.TweetsPosted,
+		Impressions:     s.Impressions,
+	}
+}
+
+func (r *DailyStatsRecord) ToDailyStatsResponse() *app.DailyStatsResponse {
+	return &app.DailyStatsResponse{
+		Day:             r.Day.UTC().Format(DayLayout),
+		FollowersGained: r.FollowersGained,
+		FollowersLost:   r.FollowersLost,
+		TweetsPosted:    r.TweetsPosted,
+		Impressions:     r.Impressions,
+	}
+}
+
+func (r *AnalyticsRecord) ToAnalyticsResponse() *app.AnalyticsResponse {
+	days := make([]*app.DailyStatsResponse, len(r.Days))
+	for i, d := range r.Days {
+		days[i] = d.ToDailyStatsResponse()
+	}
+
+	return &app.AnalyticsResponse{
+		From: r.From.UTC().Format(DayLayout),
+		To:   r.To.UTC().Format(DayLayout),
+		Days: days,
+		Totals: &app.AnalyticsTotalsResponse{
+			FollowersGained: r.Totals.FollowersGained,
+			FollowersLost:   r.Totals.FollowersLost,
+			NetFollowers:    r.Totals.FollowersGained - r.Totals.FollowersLost,
+			TweetsPosted:    r.Totals.TweetsPosted,
+			Impressions:     r.Totals.Impressions,
+		},
+	}
+}
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"aita/internal/models"
+	"aita/internal/pkg/app"
+	"time"
+)
+
+type BookmarkRecord struct {
+	ID          int64
+	UserID      int64
+	TweetID     int64
+	CreatedAt   time.Time
+}
+
+func NewBookmarkRecord(bookmark *models.Bookmark) *BookmarkRecord {
+	if bookmark == nil {
+		return nil
+	}
+
+	return &BookmarkRecord{
+		ID:        bookmark.ID,
+		UserID:    bookmark.UserID,
+		TweetID:   bookmark.TweetID,
+		CreatedAt: bookmark.CreatedAt,
+	}
+}
+
+func (r *BookmarkRecord) ToBookmarkResponse() *app.BookmarkResponse {
+	return &app.BookmarkResponse{
+		TweetID:   r.TweetID,
+		CreatedAt: r.CreatedAt,
+	}
+}
@@ -0,0 +1,121 @@
+package dto
+
+import (
+	"aita/internal/models"
+	"aita/internal/pkg/app"
+	"time"
+)
+
+type DMConversationRecord struct {
+	ID              int64
+	IsGroup         bool
+	CreatedBy       int64
+	CreatedAt       time.Time
+	LastMessageAt  *time.Time
+	Participants    []*DMParticipantRecord
+	UnreadCount     int64
+}
+
+type DMParticipantRecord struct {
+	UserID            int64
+	Username          string
+	LastReadMessageID int64
+}
+
+type DMMessageRecord struct {
+	ID              int64
+	ConversationID  int64
+	SenderID        int64
+	Content         string
+	CreatedAt       time.Time
+}
+
+func NewDMConversationRecord(c *models.DMConversation) *DMConversationRecord {
+	if c == nil {
+		return nil
+	}
+
+	return &DMConversationRecord{
+		ID:            c.ID,
+		IsGroup:       c.IsGroup,
+		CreatedBy:     c.CreatedBy,
+		CreatedAt:     c.CreatedAt,
+		LastMessageAt: c.LastMessageAt,
+	}
+}
+
+func NewDMParticipantRecord(p *models.DMParticipant) *DMParticipantRecord {
+	if p == nil {
+		return nil
+	}
+
+	return &DMParticipantRecord{
+		UserID:            p.UserID,
+		LastReadMessageID: p.LastReadMessageID,
+	}
+}
+
+func NewDMMessageRecord(m *models.DMMessage) *DMMessageRecord {
+	if m == nil {
+		return nil
+	}
+
+	return &DMMessageRecord{
+		ID:             m.ID,
+		ConversationID: m.ConversationID,
+		SenderID:       m.SenderID,
+		Content:        m.Content,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+func (r *DMMessageRecord) ToModel() *models.DMMessage {
+	return &models.DMMessage{
+		ID:             r.ID,
+		ConversationID: r.ConversationID,
+		SenderID:       r.SenderID,
+		Content:        r.Content,
+		CreatedAt:      r.CreatedAt,
+	}
+}
+
+// userID 以外の参加者のID
+func (r *DMConversationRecord) OtherParticipantIDs(userID int64) []int64 {
+	ids := make([]int64, 0, len(r.Participants))
+	for _, p := range r.Participants {
+		if p.UserID != userID {
+			ids = append(ids, p.UserID)
+		}
+	}
+	return ids
+}
+
+func (r *DMConversationRecord) ToDMConversationResponse() *app.DMConversationResponse {
+	participants := make([]app.DMParticipantResponse, 0, len(r.Participants))
+	for _, p := range r.Participants {
+		participants = append(participants, app.DMParticipantResponse{
+			ID:                p.UserID,
+			Username:          p.Username,
+			LastReadMessageID: p.LastReadMessageID,
+		})
+	}
+
+	return &app.DMConversationResponse{
+		ID:            r.ID,
+		IsGroup:       r.IsGroup,
+		Participants:  participants,
+		UnreadCount:   r.UnreadCount,
+		CreatedAt:     r.CreatedAt,
+		LastMessageAt: r.LastMessageAt,
+	}
+}
+
+func (r *DMMessageRecord) ToDMMessageResponse() *app.DMMessageResponse {
+	return &app.DMMessageResponse{
+		ID:             r.ID,
+		ConversationID: r.ConversationID,
+		SenderID:       r.SenderID,
+		Content:        r.Content,
+		CreatedAt:      r.CreatedAt,
+	}
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"aita/internal/models"
+	"aita/internal/pkg/app"
+	"time"
+)
+
+type DraftRecord struct {
+	ID            int64
+	UserID        int64
+	Content       string
+	Media         []MediaAttachment
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (dr *DraftRecord) ToModel() *models.Draft {
+	if dr == nil {
+		return &models.Draft{}
+	}
+
+	return &models.Draft{
+		ID:        dr.ID,
+		UserID:    dr.UserID,
+		Content:   dr.Content,
+		Media:     ToMediaAttachmentModels(dr.Media),
+		CreatedAt: dr.CreatedAt,
+		UpdatedAt: dr.UpdatedAt,
+	}
+}
+
+func NewDraftRecord(draft *models.Draft) *DraftRecord {
+	if draft == nil {
+		return &DraftRecord{}
+	}
+
+	return &DraftRecord{
+		ID:        draft.ID,
+		UserID:    draft.UserID,
+		Content:   draft.Content,
+		Media:     NewMediaAttachments(draft.Media),
+		CreatedAt: draft.CreatedAt,
+		UpdatedAt: draft.UpdatedAt,
+	}
+}
+
+func (dr *DraftRecord) ToDraftResponse() *app.DraftResponse {
+	return &app.DraftResponse{
+		ID:        dr.ID,
+		Content:   dr.Content,
+		Media:     ToMediaAttachmentResponses(dr.Media),
+		CreatedAt: dr.CreatedAt,
+		UpdatedAt: dr.UpdatedAt,
+	}
+}
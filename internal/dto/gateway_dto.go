@@ -0,0 +1,92 @@
+package dto
+
+import (
+	"aita/internal/models"
+	"aita/internal/pkg/app"
+)
+
+const (
+	GatewayEventReconnect = "reconnect"
+)
+
+// WebSocket 接続1本分。Events はサーバーから切断すると閉じられる
+type GatewaySession struct {
+	ID     uint64
+	UserID int64
+	Events <-chan *GatewayEventRecord
+}
+
+type GatewayEventRecord struct {
+	Channel          string
+	Type             string
+	ID               string
+	TweetID          int64
+	ActorID          int64
+	NotificationType string
+	MessageID        int64
+}
+
+func NewGatewayEventFromStream(channel string, e *StreamEventRecord) *GatewayEventRecord {
+	return &GatewayEventRecord{
+		Channel:          channel,
+		Type:             e.Type,
+		ID:               e.ID,
+		TweetID:          e.TweetID,
+		ActorID:          e.ActorID,
+		NotificationType: e.NotificationType,
+	}
+}
+
+func NewGatewayEventFromTopic(m *TopicMessageRecord) *GatewayEventRecord {
+	return &GatewayEventRecord{
+		Channel:   m.Topic,
+		Type:      m.Type,
+		TweetID:   m.TweetID,
+		ActorID:   m.ActorID,
+		MessageID: m.MessageID,
+	}
+}
+
+func (r *GatewayEventRecord) ToGatewayMessage() *app.GatewayMessage {
+	return &app.GatewayMessage{
+		Type:             r.Type,
+		Channel:          r.Channel,
+		ID:               r.ID,
+		TweetID:          r.TweetID,
+		ActorID:          r.ActorID,
+		NotificationType: r.NotificationType,
+		MessageID:        r.MessageID,
+	}
+}
+
+type TopicMessageRecord struct {
+	Topic     string
+	Type      string
+	ActorID   int64
+	TweetID   int64
+	MessageID int64
+}
+
+func NewTopicMessageRecord(m *models.TopicMessage) *TopicMessageRecord {
+	if m == nil {
+		return nil
+	}
+
+	return &TopicMessageRecord{
+		Topic:     m.Topic,
+		Type:      m.Type,
+		ActorID:   m.ActorID,
+		TweetID:   m.TweetID,
+		MessageID: m.MessageID,
+	}
+}
+
+func (r *TopicMessageRecord) ToModel() *models.TopicMessage {
+	return &models.TopicMessage{
+		Topic:     r.Topic,
+		Type:      r.Type,
+		ActorID:   r.ActorID,
+		TweetID:   r.TweetID,
+		MessageID: r.MessageID,
+	}
+}
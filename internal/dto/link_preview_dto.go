@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"aita/internal/models"
+	"aita/internal/pkg/app"
+	"aita/internal/pkg/unfurl"
+	"time"
+)
+
+type LinkPreviewRecord struct {
+	URL           string
+	Title         string
+	Description   string
+	ImageURL      string
+	FetchedAt     time.Time
+}
+
+func NewLinkPreviewRecord(preview *models.LinkPreview) *LinkPreviewRecord {
+	if preview == nil {
+		return nil
+	}
+
+	return &LinkPreviewRecord{
+		URL:         preview.URL,
+		Title:       preview.Title,
+		Description: preview.Description,
+		ImageURL:    preview.ImageURL,
+		FetchedAt:   preview.FetchedAt,
+	}
+}
+
+func NewLinkPreviewRecordFromUnfurl(preview *unfurl.Preview, fetchedAt time.Time) *LinkPreviewRecord {
+	if preview == nil {
+		return nil
+	}
+
+	return &LinkPreviewRecord{
+		URL:         preview.URL,
+		Title:       preview.Title,
+		Description: preview.Description,
+		ImageURL:    preview.ImageURL,
+		FetchedAt:   fetchedAt,
+	}
+}
+
+func (r *LinkPreviewRecord) ToModel() *models.LinkPreview {
+	if r == nil {
+		return nil
+	}
+
+	return &models.LinkPreview{
+		URL:         r.URL,
+		Title:       r.Title,
+		Description: r.Description,
+		ImageURL:    r.ImageURL,
+		FetchedAt:   r.FetchedAt,
+	}
+}
+
+func (r *LinkPreviewRecord) ToLinkPreviewResponse() *app.LinkPreviewResponse {
+	if r == nil {
+		return nil
+	}
+
+	return &app.LinkPreviewResponse{
+		URL:         r.URL,
+		Title:       r.Title,
+		Description: r.Description,
+		ImageURL:    r.ImageURL,
+	}
+}
+
+// プレビュー対象となる本文中の最初の URL。なければ空文字
+func (tr *TweetRecord) FirstURL() string {
+	if tr == nil {
+		return ""
+	}
+	return unfurl.FirstURL(tr.Content)
+}
@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"aita/internal/models"
+	"aita/internal/pkg/app"
+	"time"
+)
+
+type ListRecord struct {
+	ID            int64
+	OwnerID       int64
+	Name          string
+	Description   string
+	IsPrivate     bool
+	MemberCount   int64
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func NewListRecord(l *models.List) *ListRecord {
+	if l == nil {
+		return nil
+	}
+
+	return &ListRecord{
+		ID:          l.ID,
+		OwnerID:     l.OwnerID,
+		Name:        l.Name,
+		Description: l.Description,
+		IsPrivate:   l.IsPrivate,
+		MemberCount: l.MemberCount,
+		CreatedAt:   l.CreatedAt,
+		UpdatedAt:   l.UpdatedAt,
+	}
+}
+
+func (r *ListRecord) ToModel() *models.List {
+	return &models.List{
+		ID:          r.ID,
+		OwnerID:     r.OwnerID,
+		Name:        r.Name,
+		Description: r.Description,
+		IsPrivate:   r.IsPrivate,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+// 非公開のリストは作成者のみ閲覧できる
+func (r *ListRecord) VisibleTo(userID int64) bool {
+	return !r.IsPrivate || r.OwnerID == userID
+}
+
+func (r *ListRecord) ToListResponse() *app.ListResponse {
+	return &app.ListResponse{
+		ID:          r.ID,
+		OwnerID:     r.OwnerID,
+		Name:        r.Name,
+		Description: r.Description,
+		IsPrivate:   r.IsPrivate,
+		MemberCount: r.MemberCount,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
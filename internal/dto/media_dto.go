@@ -0,0 +1,114 @@
+package dto
+
+import (
+	"aita/internal/models"
+	"aita/internal/pkg/app"
+	"time"
+)
+
+type MediaRecord struct {
+	ID            int64
+	UserID        int64
+	StorageKey    string
+	ThumbnailKey  string
+	URL           string
+	ThumbnailURL  string
+	ContentType   string
+	SizeBytes     int64
+	Width         int
+	Height        int
+	CreatedAt     time.Time
+}
+
+func (mr *MediaRecord) ToModel() *models.Media {
+	if mr == nil {
+		return &models.Media{}
+	}
+
+	return &models.Media{
+		ID:           mr.ID,
+		UserID:       mr.UserID,
+		StorageKey:   mr.StorageKey,
+		ThumbnailKey: mr.ThumbnailKey,
+		URL:          mr.URL,
+		ThumbnailURL: mr.ThumbnailURL,
+		ContentType:  mr.ContentType,
+		SizeBytes:    mr.SizeBytes,
+		Width:        mr.Width,
+		Height:       mr.Height,
+		CreatedAt:    mr.CreatedAt,
+	}
+}
+
+func NewMediaRecord(media *models.Media) *MediaRecord {
+	if media == nil {
+		return &MediaRecord{}
+	}
+
+	return &MediaRecord{
+		ID:           media.ID,
+		UserID:       media.UserID,
+		StorageKey:   media.StorageKey,
+		ThumbnailKey: media.ThumbnailKey,
+		URL:          media.URL,
+		ThumbnailURL: media.ThumbnailURL,
+		ContentType:  media.ContentType,
+		SizeBytes:    media.SizeBytes,
+		Width:        media.Width,
+		Height:       media.Height,
+		CreatedAt:    media.CreatedAt,
+	}
+}
+
+func (mr *MediaRecord) ToMediaResponse() *app.MediaResponse {
+	return &app.MediaResponse{
+		ID:           mr.ID,
+		URL:          mr.URL,
+		ThumbnailURL: mr.ThumbnailURL,
+		ContentType:  mr.ContentType,
+		SizeBytes:    mr.SizeBytes,
+		Width:        mr.Width,
+		Height:       mr.Height,
+		CreatedAt:    mr.CreatedAt,
+	}
+}
+
+type MediaPolicy struct {
+	MaxBytes      int64
+	MaxPixels     int
+	ThumbnailSize int
+	OrphanTTL     time.Duration
+}
+
+type MediaAttachment struct {
+	MediaID       int64
+	AltText       string
+}
+
+func ToMediaAttachmentModels(attachments []MediaAttachment) models.MediaAttachments {
+	media := make(models.MediaAttachments, 0, len(attachments))
+	for _, a := range attachments {
+		media = append(media, models.MediaAttachment{MediaID: a.MediaID, AltText: a.AltText})
+	}
+	return media
+}
+
+func NewMediaAttachments(media models.MediaAttachments) []MediaAttachment {
+	if len(media) == 0 {
+		return nil
+	}
+
+	attachments := make([]MediaAttachment, 0, len(media))
+	for _, m := range media {
+		attachments = append(attachments, MediaAttachment{MediaID: m.MediaID, AltText: m.AltText})
+	}
+	return attachments
+}
+
+func ToMediaAttachmentResponses(attachments []MediaAttachment) []app.MediaAttachmentResponse {
+	responses := make([]app.MediaAttachmentResponse, 0, len(attachments))
+	for _, a := range attachments {
+		responses = append(responses, app.MediaAttachmentResponse{MediaID: a.MediaID, AltText: a.AltText})
+	}
+	return responses
+}
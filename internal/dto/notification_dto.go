@@ -0,0 +1,111 @@
+package dto
+
+import (
+	"aita/internal/models"
+	"aita/internal/pkg/app"
+	"fmt"
+	"time"
+)
+
+type NotificationRecord struct {
+	ID            int64
+	UserID        int64
+	ActorID       int64
+	Type          string
+	TweetID      *int64
+	CreatedAt     time.Time
+}
+
+// 同じ種類・同じツイートへの通知をまとめたもの。ID はまとめた中で最新の通知ID
+type NotificationGroupRecord struct {
+	ID            int64
+	Type          string
+	TweetID      *int64
+	// 新しい順。表示用に最大 maxGroupActors 人まで
+	Actors        []*UserSlimRecord
+	ActorCount    int
+	CreatedAt     time.Time
+	IsRead        bool
+}
+
+func NewNotificationRecord(n *models.Notification) *NotificationRecord {
+	if n == nil {
+		return nil
+	}
+
+	return &NotificationRecord{
+		ID:        n.ID,
+		UserID:    n.UserID,
+		ActorID:   n.ActorID,
+		Type:      n.Type,
+		TweetID:   n.TweetID,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+func (r *NotificationRecord) ToModel() *models.Notification {
+	return &models.Notification{
+		ID:        r.ID,
+		UserID:    r.UserID,
+		ActorID:   r.ActorID,
+		Type:      r.Type,
+		TweetID:   r.TweetID,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+// フォロー・いいね・リツイートは複数人分をまとめて表示する
+func (r *NotificationRecord) GroupKey() string {
+	switch r.Type {
+	case models.NotificationTypeFollow:
+		return r.Type
+	case models.NotificationTypeLike, models.NotificationTypeRetweet:
+		if r.TweetID != nil {
+			return fmt.Sprintf("%s:%d", r.Type, *r.TweetID)
+		}
+	}
+	return fmt.Sprintf("%s:id:%d", r.Type, r.ID)
+}
+
+func (g *NotificationGroupRecord) Message() string {
+	if len(g.Actors) == 0 {
+		return ""
+	}
+
+	subject := g.Actors[0].Username + "さん"
+	if g.ActorCount > 1 {
+		subject = fmt.Sprintf("%s他%d人", subject, g.ActorCount-1)
+	}
+
+	switch g.Type {
+	case models.NotificationTypeFollow:
+		return subject + "があなたをフォローしました"
+	case models.NotificationTypeMention:
+		return subject + "があなたをメンションしました"
+	case models.NotificationTypeLike:
+		return subject + "があなたのツイートにいいねしました"
+	case models.NotificationTypeReply:
+		return subject + "があなたのツイートに返信しました"
+	case models.NotificationTypeRetweet:
+		return subject + "があなたのツイートをリツイートしました"
+	}
+	return ""
+}
+
+func (g *NotificationGroupRecord) ToNotificationResponse() *app.NotificationResponse {
+	actors := make([]app.NotificationActorResponse, 0, len(g.Actors))
+	for _, a := range g.Actors {
+		actors = append(actors, app.NotificationActorResponse{ID: a.ID, Username: a.Username})
+	}
+
+	return &app.NotificationResponse{
+		ID:         g.ID,
+		Type:       g.Type,
+		TweetID:    g.TweetID,
+		Actors:     actors,
+		ActorCount: g.ActorCount,
+		Message:    g.Message(),
+		CreatedAt:  g.CreatedAt,
+		IsRead:     g.IsRead,
+	}
+}
@@ -0,0 +1,139 @@
+package dto
+
+import (
+	"aita/internal/models"
+	"aita/internal/pkg/app"
+	"time"
+)
+
+type PollInput struct {
+	Options       []string
+	Duration      time.Duration
+}
+
+type PollRecord struct {
+	ID            int64
+	TweetID       int64
+	ClosesAt      time.Time
+	ClosedAt     *time.Time
+	CreatedAt     time.Time
+	// position 順
+	Options       []PollOptionRecord
+
+	// 閲覧者ごとの表示用。ResultsVisible が false の場合は得票数を返さない
+	VotedOptionID *int64
+	ResultsVisible bool
+}
+
+type PollOptionRecord struct {
+	ID            int64
+	Position      int
+	Label         string
+	Votes         int64
+}
+
+// 締切処理が済んでいなくても締切時刻を過ぎていれば締切として扱う
+func (pr *PollRecord) IsClosed(now time.Time) bool {
+	if pr == nil {
+		return true
+	}
+
+	return pr.ClosedAt != nil || !now.Before(pr.ClosesAt)
+}
+
+// tally は選択肢IDごとの票数。含まれない選択肢は 0 票とする
+func (pr *PollRecord) ApplyTally(tally map[int64]int64) {
+	if pr == nil {
+		return
+	}
+
+	for i := range pr.Options {
+		pr.Options[i].Votes = tally[pr.Options[i].ID]
+	}
+}
+
+func (pr *PollRecord) ToModel() *models.Poll {
+	if pr == nil {
+		return nil
+	}
+
+	options := make([]models.PollOption, 0, len(pr.Options))
+	for _, o := range pr.Options {
+		options = append(options, models.PollOption{
+			ID:        o.ID,
+			PollID:    pr.ID,
+			Position:  o.Position,
+			Label:     o.Label,
+			VoteCount: o.Votes,
+		})
+	}
+
+	return &models.Poll{
+		ID:        pr.ID,
+		TweetID:   pr.TweetID,
+		ClosesAt:  pr.ClosesAt,
+		ClosedAt:  pr.ClosedAt,
+		CreatedAt: pr.CreatedAt,
+		Options:   options,
+	}
+}
+
+func NewPollRecord(poll *models.Poll) *PollRecord {
+	if poll == nil {
+		return nil
+	}
+
+	options := make([]PollOptionRecord, 0, len(poll.Options))
+	for _, o := range poll.Options {
+		options = append(options, PollOptionRecord{
+			ID:       o.ID,
+			Position: o.Position,
+			Label:    o.Label,
+			Votes:    o.VoteCount,
+		})
+	}
+
+	return &PollRecord{
+		ID:        poll.ID,
+		TweetID:   poll.TweetID,
+		ClosesAt:  poll.ClosesAt,
+		ClosedAt:  poll.ClosedAt,
+		CreatedAt: poll.CreatedAt,
+		Options:   options,
+	}
+}
+
+func (pr *PollRecord) ToPollResponse() *app.PollResponse {
+	if pr == nil {
+		return nil
+	}
+
+	var total int64
+	options := make([]app.PollOptionResponse, 0, len(pr.Options))
+	for _, o := range pr.Options {
+		option := app.PollOptionResponse{
+			ID:       o.ID,
+			Position: o.Position,
+			Label:    o.Label,
+		}
+		if pr.ResultsVisible {
+			votes := o.Votes
+			option.Votes = &votes
+			total += votes
+		}
+		options = append(options, option)
+	}
+
+	res := &app.PollResponse{
+		ID:             pr.ID,
+		Options:        options,
+		ClosesAt:       pr.ClosesAt,
+		IsClosed:       pr.IsClosed(time.Now()),
+		ResultsVisible: pr.ResultsVisible,
+		VotedOptionID:  pr.VotedOptionID,
+	}
+	if pr.ResultsVisible {
+		res.TotalVotes = &total
+	}
+	return res
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"aita/internal/models"
+	"aita/internal/pkg/app"
+)
+
+type RecommendationRecord struct {
+	UserID int64
+	Score  int64
+}
+
+type RecommendedUserRecord struct {
+	ID          int64
+	Username    string
+	MutualCount int64
+}
+
+func NewRecommendationRecord(m *models.CacheMember) *RecommendationRecord {
+	if m == nil {
+		return nil
+	}
+
+	return &RecommendationRecord{
+		UserID: m.Member,
+		Score:  int64(m.Score),
+	}
+}
+
+func (r *RecommendedUserRecord) ToRecommendedUserResponse() *app.RecommendedUserResponse {
+	return &app.RecommendedUserResponse{
+		ID:          r.ID,
+		Username:    r.Username,
+		MutualCount: r.MutualCount,
+	}
+}
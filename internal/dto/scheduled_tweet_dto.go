@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"aita/internal/models"
+	"aita/internal/pkg/app"
+	"time"
+)
+
+type ScheduledTweetRecord struct {
+	ID            int64
+	UserID        int64
+	Content       string
+	Media         []MediaAttachment
+	PublishAt     time.Time
+	Status        string
+	TweetID      *int64
+	CreatedAt     time.Time
+}
+
+func (sr *ScheduledTweetRecord) ToModel() *models.ScheduledTweet {
+	if sr == nil {
+		return &models.ScheduledTweet{}
+	}
+
+	return &models.ScheduledTweet{
+		ID:        sr.ID,
+		UserID:    sr.UserID,
+		Content:   sr.Content,
+		Media:     ToMediaAttachmentModels(sr.Media),
+		PublishAt: sr.PublishAt,
+		Status:    sr.Status,
+		TweetID:   sr.TweetID,
+		CreatedAt: sr.CreatedAt,
+	}
+}
+
+func NewScheduledTweetRecord(st *models.ScheduledTweet) *ScheduledTweetRecord {
+	if st == nil {
+		return &ScheduledTweetRecord{}
+	}
+
+	return &ScheduledTweetRecord{
+		ID:        st.ID,
+		UserID:    st.UserID,
+		Content:   st.Content,
+		Media:     NewMediaAttachments(st.Media),
+		PublishAt: st.PublishAt,
+		Status:    st.Status,
+		TweetID:   st.TweetID,
+		CreatedAt: st.CreatedAt,
+	}
+}
+
+func (sr *ScheduledTweetRecord) ToScheduledTweetResponse() *app.ScheduledTweetResponse {
+	return &app.ScheduledTweetResponse{
+		ID:        sr.ID,
+		UserID:    sr.UserID,
+		Content:   sr.Content,
+		Media:     ToMediaAttachmentResponses(sr.Media),
+		PublishAt: sr.PublishAt,
+		Status:    sr.Status,
+		CreatedAt: sr.CreatedAt,
+	}
+}
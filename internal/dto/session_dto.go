@@ -2,14 +2,73 @@ package dto
 
 import (
 	"aita/internal/models"
+	"aita/internal/pkg/app"
 	"time"
 )
 
+const (
+	DefaultAccessTokenTTL  = 15 * time.Minute
+	DefaultRefreshTokenTTL = 24 * time.Hour
+	DefaultMaxSessionLife  = 7 * 24 * time.Hour
+)
+
+// RefreshTTL はリフレッシュのたびに延長され、MaxLife はログインからの絶対的な上限
+type SessionPolicy struct {
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+	MaxLife    time.Duration
+}
+
+func NewSessionPolicy(accessTTL, refreshTTL, maxLife time.Duration) SessionPolicy {
+	if accessTTL <= 0 {
+		accessTTL = DefaultAccessTokenTTL
+	}
+	if refreshTTL <= 0 {
+		refreshTTL = DefaultRefreshTokenTTL
+	}
+	if maxLife <= 0 {
+		maxLife = DefaultMaxSessionLife
+	}
+	if refreshTTL > maxLife {
+		refreshTTL = maxLife
+	}
+
+	return SessionPolicy{AccessTTL: accessTTL, RefreshTTL: refreshTTL, MaxLife: maxLife}
+}
+
+// ログインからの上限を超えないようにリフレッシュトークンの期限を決める
+func (p SessionPolicy) RefreshExpiry(now, createdAt time.Time) time.Time {
+	expiry := now.Add(p.RefreshTTL)
+	if maxExpiry := createdAt.Add(p.MaxLife); expiry.After(maxExpiry) {
+		expiry = maxExpiry
+	}
+	return expiry
+}
+
 type SessionRecord struct {
+	UserID     int64
+	TokenHash  string
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	SessionID  string
+	IPAddress  string
+	UserAgent  string
+	LastSeenAt time.Time
+	RefreshHash      string
+	RefreshExpiresAt time.Time
+	// 一覧でリクエスト中のセッションに印を付けるためだけに使い、保存はしない
+	Current          bool
+}
+
+type RefreshTokenRefRecord struct {
 	UserID    int64
-	TokenHash string
-	ExpiresAt time.Time
-	CreatedAt time.Time
+	SessionID string
+}
+
+// ログイン時に記録する接続元の情報
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
 }
 
 type AuthRecord struct {
@@ -17,6 +76,8 @@ type AuthRecord struct {
 	Token     string
 	ExpiresAt time.Time
 	CreatedAt time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
 }
 
 type AuthContext struct {
@@ -31,10 +92,16 @@ func (sr *SessionRecord) ToModel() *models.Session {
 	}
 
 	return &models.Session{
-		UserID:    sr.UserID,
-		TokenHash: sr.TokenHash,
-		ExpiresAt: sr.ExpiresAt,
-		CreatedAt: sr.CreatedAt,
+		UserID:     sr.UserID,
+		TokenHash:  sr.TokenHash,
+		ExpiresAt:  sr.ExpiresAt,
+		CreatedAt:  sr.CreatedAt,
+		SessionID:  sr.SessionID,
+		IPAddress:  sr.IPAddress,
+		UserAgent:  sr.UserAgent,
+		LastSeenAt: sr.LastSeenAt,
+		RefreshHash:      sr.RefreshHash,
+		RefreshExpiresAt: sr.RefreshExpiresAt,
 	}
 }
 
@@ -44,10 +111,16 @@ func ToSessionRecord(ms *models.Session) *SessionRecord {
 	}
 
 	return &SessionRecord{
-		UserID:    ms.UserID,
-		TokenHash: ms.TokenHash,
-		ExpiresAt: ms.ExpiresAt,
-		CreatedAt: ms.CreatedAt,
+		UserID:     ms.UserID,
+		TokenHash:  ms.TokenHash,
+		ExpiresAt:  ms.ExpiresAt,
+		CreatedAt:  ms.CreatedAt,
+		SessionID:  ms.SessionID,
+		IPAddress:  ms.IPAddress,
+		UserAgent:  ms.UserAgent,
+		LastSeenAt: ms.LastSeenAt,
+		RefreshHash:      ms.RefreshHash,
+		RefreshExpiresAt: ms.RefreshExpiresAt,
 	}
 }
 
@@ -61,5 +134,54 @@ func ToAuthRecord(s *SessionRecord, token string) *AuthRecord {
 		Token:     token,
 		ExpiresAt: s.ExpiresAt,
 		CreatedAt: s.CreatedAt,
+		RefreshExpiresAt: s.RefreshExpiresAt,
+	}
+}
+
+// 発行・ローテーション直後だけ平文のリフレッシュトークンを持たせる
+func ToIssuedAuthRecord(s *SessionRecord, token, refreshToken string) *AuthRecord {
+	record := ToAuthRecord(s, token)
+	if record != nil {
+		record.RefreshToken = refreshToken
+	}
+	return record
+}
+
+func (ar *AuthRecord) ToTokenResponse() app.TokenResponse {
+	return app.TokenResponse{
+		AccessToken:           ar.Token,
+		AccessTokenExpiresAt:  ar.ExpiresAt,
+		RefreshToken:          ar.RefreshToken,
+		RefreshTokenExpiresAt: ar.RefreshExpiresAt,
+	}
+}
+
+type GatewayTicketRecord struct {
+	Ticket    string
+	ExpiresAt time.Time
+}
+
+func (tr *GatewayTicketRecord) ToGatewayTicketResponse() *app.GatewayTicketResponse {
+	return &app.GatewayTicketResponse{
+		Ticket:    tr.Ticket,
+		ExpiresAt: tr.ExpiresAt,
+	}
+}
+
+// 最終利用日時を記録する前に作られたセッションは作成日時で代用する
+func (sr *SessionRecord) ToSessionResponse() *app.SessionResponse {
+	lastSeen := sr.LastSeenAt
+	if lastSeen.IsZero() {
+		lastSeen = sr.CreatedAt
+	}
+
+	return &app.SessionResponse{
+		ID:         sr.SessionID,
+		IPAddress:  sr.IPAddress,
+		UserAgent:  sr.UserAgent,
+		CreatedAt:  sr.CreatedAt,
+		LastSeenAt: lastSeen,
+		ExpiresAt:  sr.ToModel().RetainUntil(),
+		Current:    sr.Current,
 	}
 }
@@ -0,0 +1,90 @@
+package dto
+
+import (
+	"aita/internal/models"
+	"aita/internal/pkg/app"
+	"strconv"
+	"strings"
+)
+
+type StreamEventRecord struct {
+	ID               string
+	Type             string
+	UserIDs          []int64
+	TweetID          int64
+	ActorID          int64
+	NotificationType string
+}
+
+func NewStreamEventRecord(e *models.StreamEvent) *StreamEventRecord {
+	if e == nil {
+		return nil
+	}
+
+	return &StreamEventRecord{
+		ID:               e.ID,
+		Type:             e.Type,
+		UserIDs:          e.UserIDs,
+		TweetID:          e.TweetID,
+		ActorID:          e.ActorID,
+		NotificationType: e.NotificationType,
+	}
+}
+
+func (r *StreamEventRecord) ToModel() *models.StreamEvent {
+	return &models.StreamEvent{
+		ID:               r.ID,
+		Type:             r.Type,
+		UserIDs:          r.UserIDs,
+		TweetID:          r.TweetID,
+		ActorID:          r.ActorID,
+		NotificationType: r.NotificationType,
+	}
+}
+
+func (r *StreamEventRecord) IsFor(userID int64) bool {
+	for _, id := range r.UserIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// ID が lastID より新しいか。lastID が空なら常に true
+func (r *StreamEventRecord) IsAfter(lastID string) bool {
+	if lastID == "" {
+		return true
+	}
+	ms, seq, ok := ParseStreamID(r.ID)
+	lastMs, lastSeq, lastOk := ParseStreamID(lastID)
+	if !ok || !lastOk {
+		return false
+	}
+	return ms > lastMs || (ms == lastMs && seq > lastSeq)
+}
+
+func (r *StreamEventRecord) ToStreamEventResponse() *app.StreamEventResponse {
+	return &app.StreamEventResponse{
+		TweetID:          r.TweetID,
+		ActorID:          r.ActorID,
+		NotificationType: r.NotificationType,
+	}
+}
+
+// Redis Stream のエントリID("ミリ秒-連番")を分解する
+func ParseStreamID(id string) (uint64, uint64, bool) {
+	msPart, seqPart, found := strings.Cut(id, "-")
+	if !found {
+		return 0, 0, false
+	}
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	seq, err := strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return ms, seq, true
+}
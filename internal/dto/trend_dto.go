@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"aita/internal/models"
+	"aita/internal/pkg/app"
+)
+
+type TrendRecord struct {
+	Hashtag    string
+	TweetCount int64
+	Score      float64
+}
+
+func NewTrendRecord(t *models.Trend) *TrendRecord {
+	if t == nil {
+		return nil
+	}
+
+	return &TrendRecord{
+		Hashtag:    t.Hashtag,
+		TweetCount: t.TweetCount,
+		Score:      t.Score,
+	}
+}
+
+func (r *TrendRecord) ToModel() *models.Trend {
+	if r == nil {
+		return nil
+	}
+
+	return &models.Trend{
+		Hashtag:    r.Hashtag,
+		TweetCount: r.TweetCount,
+		Score:      r.Score,
+	}
+}
+
+func (r *TrendRecord) ToTrendResponse(rank int) *app.TrendResponse {
+	return &app.TrendResponse{
+		Rank:       rank,
+		Hashtag:    r.Hashtag,
+		TweetCount: r.TweetCount,
+	}
+}
@@ -7,9 +7,31 @@ import (
 )
 
 const(
-	editwindow = 10 * time.Minute
+	DefaultEditWindow = 10 * time.Minute
+	DefaultMaxEdits   = 5
 )
 
+type DeletionPolicy struct {
+	GracePeriod time.Duration
+	Retention   time.Duration
+}
+
+type EditPolicy struct {
+	Window      time.Duration
+	MaxEdits    int
+}
+
+func NewEditPolicy(window time.Duration, maxEdits int) EditPolicy {
+	if window <= 0 {
+		window = DefaultEditWindow
+	}
+	if maxEdits <= 0 {
+		maxEdits = DefaultMaxEdits
+	}
+
+	return EditPolicy{Window: window, MaxEdits: maxEdits}
+}
+
 
 
 
@@ -22,6 +44,41 @@ type TweetRecord struct {
 	CreatedAt     time.Time   
 	UpdatedAt     time.Time   
 	IsEdited      bool         
+	EditCount     int
+	LastEditedAt  *time.Time
+	DeletedAt     *time.Time
+
+	// 投稿時は MediaID と AltText のみ指定する。position 順
+	Media         []TweetMediaRecord
+	// 投稿時は選択肢のラベルと ClosesAt のみ指定する。ツイートには得票数を載せない
+	Poll         *PollRecord
+	// 本文中の最初の URL のプレビュー。取得済みの場合のみ
+	LinkPreview  *LinkPreviewRecord
+
+	// プロフィールの先頭に固定表示する場合のみ true
+	IsPinned      bool
+	// 閲覧者ごとに重複を除いた概算の閲覧数
+	ViewCount     int64
+
+	EditWindowRemaining time.Duration
+	RemainingEdits      int
+}
+
+type TweetMediaRecord struct {
+	MediaID       int64
+	Position      int
+	AltText       string
+	URL           string
+	ThumbnailURL  string
+	ContentType   string
+	Width         int
+	Height        int
+}
+
+type TweetRevisionRecord struct {
+	Revision      int
+	Content       string
+	CreatedAt     time.Time
 }
 
 
@@ -35,17 +92,49 @@ func (tr TweetRecord) ToTweetResponse() *app.TweetResponse {
 		CreatedAt:  tr.CreatedAt,
 		UpdatedAt:  tr.UpdatedAt,
 		IsEdited:   tr.IsEdited,
+		EditCount:  tr.EditCount,
+		LastEditedAt: tr.LastEditedAt,
+		EditWindowRemaining: int64(tr.EditWindowRemaining.Seconds()),
+		RemainingEdits: tr.RemainingEdits,
+		Media:      toTweetMediaResponses(tr.Media),
+		Poll:       tr.Poll.ToPollResponse(),
+		IsPinned:   tr.IsPinned,
+		LinkPreview: tr.LinkPreview.ToLinkPreviewResponse(),
+		ViewCount:  tr.ViewCount,
 	}
 }
 
-func(tr *TweetRecord) CanBeUpdated() bool {
+func (tr *TweetRecord) IsEditWindowExpired(policy EditPolicy) bool {
 	if tr == nil {
-		return false
+		return true
 	}
 
 	duration := time.Now().UTC().Sub(tr.CreatedAt.UTC())
 	
-	return duration <= editwindow
+	return duration > policy.Window
+}
+
+func (tr *TweetRecord) HasReachedEditLimit(policy EditPolicy) bool {
+	if tr == nil {
+		return true
+	}
+
+	return tr.EditCount >= policy.MaxEdits
+}
+
+// 残りの編集可能時間と編集回数をレスポンス用に埋める
+func (tr *TweetRecord) ApplyEditPolicy(policy EditPolicy) {
+	if tr == nil {
+		return
+	}
+
+	remaining := policy.Window - time.Now().UTC().Sub(tr.CreatedAt.UTC())
+	if remaining < 0 {
+		remaining = 0
+	}
+	tr.EditWindowRemaining = remaining
+
+	tr.RemainingEdits = max(policy.MaxEdits-tr.EditCount, 0)
 }
 
 func (tr *TweetRecord) ToModel() *models.Tweet {
@@ -61,6 +150,11 @@ func (tr *TweetRecord) ToModel() *models.Tweet {
 		CreatedAt: tr.CreatedAt,
 		UpdatedAt: tr.UpdatedAt,
 		IsEdited: tr.IsEdited,
+		EditCount: tr.EditCount,
+		LastEditedAt: tr.LastEditedAt,
+		DeletedAt: tr.DeletedAt,
+		Media: toTweetMediaModels(tr.Media),
+		Poll: tr.Poll.ToModel(),
 	}
 }
 
@@ -77,5 +171,90 @@ func NewTweetRecord(tweet *models.Tweet) *TweetRecord {
 		CreatedAt: tweet.CreatedAt,
 		UpdatedAt: tweet.UpdatedAt,
 		IsEdited: tweet.IsEdited,
+		EditCount: tweet.EditCount,
+		LastEditedAt: tweet.LastEditedAt,
+		DeletedAt: tweet.DeletedAt,
+		Media: newTweetMediaRecords(tweet.Media),
+		Poll: NewPollRecord(tweet.Poll),
+	}
+}
+
+func toTweetMediaModels(records []TweetMediaRecord) []models.TweetMedia {
+	if len(records) == 0 {
+		return nil
+	}
+
+	media := make([]models.TweetMedia, 0, len(records))
+	for _, r := range records {
+		media = append(media, models.TweetMedia{
+			MediaID:      r.MediaID,
+			Position:     r.Position,
+			AltText:      r.AltText,
+			URL:          r.URL,
+			ThumbnailURL: r.ThumbnailURL,
+			ContentType:  r.ContentType,
+			Width:        r.Width,
+			Height:       r.Height,
+		})
+	}
+	return media
+}
+
+func newTweetMediaRecords(media []models.TweetMedia) []TweetMediaRecord {
+	if len(media) == 0 {
+		return nil
+	}
+
+	records := make([]TweetMediaRecord, 0, len(media))
+	for _, m := range media {
+		records = append(records, TweetMediaRecord{
+			MediaID:      m.MediaID,
+			Position:     m.Position,
+			AltText:      m.AltText,
+			URL:          m.URL,
+			ThumbnailURL: m.ThumbnailURL,
+			ContentType:  m.ContentType,
+			Width:        m.Width,
+			Height:       m.Height,
+		})
+	}
+	return records
+}
+
+// 添付がない場合も空配列で返す
+func toTweetMediaResponses(records []TweetMediaRecord) []app.TweetMediaResponse {
+	responses := make([]app.TweetMediaResponse, 0, len(records))
+	for _, r := range records {
+		responses = append(responses, app.TweetMediaResponse{
+			MediaID:      r.MediaID,
+			Position:     r.Position,
+			AltText:      r.AltText,
+			URL:          r.URL,
+			ThumbnailURL: r.ThumbnailURL,
+			ContentType:  r.ContentType,
+			Width:        r.Width,
+			Height:       r.Height,
+		})
+	}
+	return responses
+}
+
+func NewTweetRevisionRecord(revision *models.TweetRevision) *TweetRevisionRecord {
+	if revision == nil {
+		return nil
+	}
+
+	return &TweetRevisionRecord{
+		Revision: revision.Revision,
+		Content: revision.Content,
+		CreatedAt: revision.CreatedAt,
+	}
+}
+
+func (r *TweetRevisionRecord) ToTweetRevisionResponse() *app.TweetRevisionResponse {
+	return &app.TweetRevisionResponse{
+		Revision: r.Revision,
+		Content: r.Content,
+		CreatedAt: r.CreatedAt,
 	}
 }
\ No newline at end of file
@@ -15,6 +15,7 @@ type UserRecord struct {
 	CreatedAt     	time.Time    	
 	FollowerCount 	int64        	
 	FollowingCount  int64         
+	EmailVerifiedAt *time.Time
 }
 
 type UserPageRecord struct {
@@ -37,6 +38,7 @@ type UserProfile struct {
 	Email     		string    `json:"email"` 	       	
 	FollowerCount 	int64     `json:"follower_count"`   	
 	FollowingCount  int64     `json:"following_count"`  
+	EmailVerified   bool      `json:"email_verified"`
 	CreatedAt 		time.Time `json:"created_at"`
 }
 
@@ -59,6 +61,7 @@ func (u *UserRecord)ToUserResponse() *app.UserResponse {
 		ID:        u.ID,
 		Username:  u.Username,
 		Email:     u.Email,
+		EmailVerified: u.IsEmailVerified(),
 		CreatedAt: u.CreatedAt,
 	}
 }
@@ -76,6 +79,7 @@ func (ur *UserRecord)ToUserModel() *models.User {
 		CreatedAt: ur.CreatedAt,
 		FollowerCount: ur.FollowerCount,
 		FollowingCount: ur.FollowingCount,
+		EmailVerifiedAt: ur.EmailVerifiedAt,
 	}
 }
 
@@ -90,10 +94,15 @@ func (u *UserRecord)ToUserProfile() *UserProfile {
 		Email:          u.Email,
 		FollowerCount: 	u.FollowerCount,
 		FollowingCount: u.FollowingCount,
+		EmailVerified:  u.IsEmailVerified(),
 		CreatedAt:      u.CreatedAt,
 	}
 }
 
+func (u *UserRecord) IsEmailVerified() bool {
+	return u != nil && u.EmailVerifiedAt != nil
+}
+
 func NewUserRecord(user *models.User) *UserRecord {
 	if user == nil {
 		return nil
@@ -107,6 +116,7 @@ func NewUserRecord(user *models.User) *UserRecord {
 		CreatedAt: user.CreatedAt,
 		FollowerCount: user.FollowerCount,
 		FollowingCount: user.FollowingCount,
+		EmailVerifiedAt: user.EmailVerifiedAt,
 	}
 }
 
@@ -0,0 +1,71 @@
+package dto
+
+import (
+	"aita/internal/models"
+	"strings"
+	"time"
+)
+
+type UserTokenRecord struct {
+	ID        int64
+	UserID    int64
+	Purpose   string
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+func NewUserTokenRecord(t *models.UserToken) *UserTokenRecord {
+	if t == nil {
+		return nil
+	}
+
+	return &UserTokenRecord{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		Purpose:   t.Purpose,
+		TokenHash: t.TokenHash,
+		ExpiresAt: t.ExpiresAt,
+	}
+}
+
+func (r *UserTokenRecord) ToModel() *models.UserToken {
+	if r == nil {
+		return nil
+	}
+
+	return &models.UserToken{
+		ID:        r.ID,
+		UserID:    r.UserID,
+		Purpose:   r.Purpose,
+		TokenHash: r.TokenHash,
+		ExpiresAt: r.ExpiresAt,
+	}
+}
+
+// メールアドレスを確認していないユーザーに制限できる操作
+const (
+	VerifiedActionTweet  = "tweet"
+	VerifiedActionDM     = "dm"
+	VerifiedActionFollow = "follow"
+)
+
+// メールアドレスの確認が必要な操作の一覧
+type VerificationPolicy struct {
+	RequiredFor map[string]bool
+}
+
+// actions は "tweet,dm" のようなカンマ区切り。空なら何も制限しない
+func NewVerificationPolicy(actions string) VerificationPolicy {
+	required := make(map[string]bool)
+	for _, a := range strings.Split(actions, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			required[a] = true
+		}
+	}
+
+	return VerificationPolicy{RequiredFor: required}
+}
+
+func (p VerificationPolicy) Requires(action string) bool {
+	return p.RequiredFor[action]
+}
@@ -0,0 +1,14 @@
+package dto
+
+import "strconv"
+
+// 閲覧の重複を判定するための閲覧者の識別子。ログインしていなければ IP アドレスで代用する
+func ViewerKey(userID int64, clientIP string) string {
+	if userID > 0 {
+		return "u:" + strconv.FormatInt(userID, 10)
+	}
+	if clientIP == "" {
+		return ""
+	}
+	return "ip:" + clientIP
+}
@@ -51,32 +51,89 @@ var errorToMetadata = map[error]errorMetadata{
 	ErrInvalidSessionID:      {http.StatusBadRequest, "INVALID_SESSION_ID"},
 	ErrInvalidTokenFormat:    {http.StatusBadRequest, "INVALID_TOKEN_FORMAT"},
 	ErrInvalidTweetID:        {http.StatusBadRequest, "INVALID_TWEET_ID"},
+	ErrInvalidDraftID:        {http.StatusBadRequest, "INVALID_DRAFT_ID"},
 	ErrInvalidUrlFormat:      {http.StatusBadRequest, "INVALID_URL_FORMAT"},
 	ErrInvalidContentFormat:  {http.StatusBadRequest, "INVALID_CONTENT_FORMAT"},
 	ErrValueTooLong:          {http.StatusBadRequest, "VALUE_TOO_LONG"},
 	ErrAlreadyFollowing:      {http.StatusBadRequest, "ALREADY_FOLLOWING"}, 
 	ErrCannotFollowSelf:      {http.StatusBadRequest, "CANNOT_FOLLOW_SELF"}, 
 	ErrNotFollowing:          {http.StatusBadRequest, "NOT_FOLLOWING"},    
+	ErrInvalidPublishAt:      {http.StatusBadRequest, "INVALID_PUBLISH_AT"},
+	ErrInvalidMediaAttachment: {http.StatusBadRequest, "INVALID_MEDIA_ATTACHMENT"},
+	ErrInvalidPoll:           {http.StatusBadRequest, "INVALID_POLL"},
+	ErrInvalidCursor:         {http.StatusBadRequest, "INVALID_CURSOR"},
+	ErrInvalidLastEventID:    {http.StatusBadRequest, "INVALID_LAST_EVENT_ID"},
+	ErrInvalidChannel:        {http.StatusBadRequest, "INVALID_CHANNEL"},
+	ErrNotSubscribed:         {http.StatusBadRequest, "NOT_SUBSCRIBED"},
+	ErrInvalidConversationID: {http.StatusBadRequest, "INVALID_CONVERSATION_ID"},
+	ErrInvalidDMParticipants: {http.StatusBadRequest, "INVALID_DM_PARTICIPANTS"},
+	ErrInvalidListID:         {http.StatusBadRequest, "INVALID_LIST_ID"},
+	ErrInvalidDateRange:      {http.StatusBadRequest, "INVALID_DATE_RANGE"},
+	ErrInvalidResetToken:     {http.StatusBadRequest, "INVALID_RESET_TOKEN"},
+	ErrInvalidVerificationToken: {http.StatusBadRequest, "INVALID_VERIFICATION_TOKEN"},
+	ErrIncorrectPassword:     {http.StatusBadRequest, "INCORRECT_PASSWORD"},
+	ErrUnchangedValue:        {http.StatusBadRequest, "UNCHANGED_VALUE"},
 
 	// 401 Unauthorized
 	ErrInvalidCredentials: {http.StatusUnauthorized, "INVALID_CREDENTIALS"},
 	ErrSessionExpired:     {http.StatusUnauthorized, "SESSION_EXPIRED"},
 	ErrSessionNotFound:    {http.StatusUnauthorized, "SESSION_NOT_FOUND"},
+	ErrInvalidRefreshToken: {http.StatusUnauthorized, "INVALID_REFRESH_TOKEN"},
+	ErrRefreshTokenReused:  {http.StatusUnauthorized, "REFRESH_TOKEN_REUSED"},
+	ErrInvalidGatewayTicket: {http.StatusUnauthorized, "INVALID_GATEWAY_TICKET"},
 
 	// 403 Forbidden
 	ErrForbidden: {http.StatusForbidden, "FORBIDDEN_ACCESS"},
+	ErrDMNotAllowed: {http.StatusForbidden, "DM_NOT_ALLOWED"},
+	ErrEmailNotVerified: {http.StatusForbidden, "EMAIL_NOT_VERIFIED"},
 
 	// 404 Not Found
 	ErrUserNotFound:  {http.StatusNotFound, "USER_NOT_FOUND"},
 	ErrTweetNotFound: {http.StatusNotFound, "TWEET_NOT_FOUND"},
+	ErrScheduledTweetNotFound: {http.StatusNotFound, "SCHEDULED_TWEET_NOT_FOUND"},
+	ErrScheduledTweetFailed:   {http.StatusUnprocessableEntity, "SCHEDULED_TWEET_FAILED"},
+	ErrDraftNotFound: {http.StatusNotFound, "DRAFT_NOT_FOUND"},
+	ErrMediaNotFound: {http.StatusNotFound, "MEDIA_NOT_FOUND"},
+	ErrPollNotFound:  {http.StatusNotFound, "POLL_NOT_FOUND"},
+	ErrPollOptionNotFound: {http.StatusNotFound, "POLL_OPTION_NOT_FOUND"},
+	ErrBookmarkNotFound: {http.StatusNotFound, "BOOKMARK_NOT_FOUND"},
+	ErrPinnedTweetNotFound: {http.StatusNotFound, "PINNED_TWEET_NOT_FOUND"},
+	ErrConversationNotFound: {http.StatusNotFound, "CONVERSATION_NOT_FOUND"},
+	ErrListNotFound: {http.StatusNotFound, "LIST_NOT_FOUND"},
+	ErrListMemberNotFound: {http.StatusNotFound, "LIST_MEMBER_NOT_FOUND"},
+	ErrUserTokenNotFound: {http.StatusNotFound, "USER_TOKEN_NOT_FOUND"},
+	ErrUserSessionNotFound: {http.StatusNotFound, "USER_SESSION_NOT_FOUND"},
 
 	// 409 Conflict
 	ErrUsernameConflict: {http.StatusConflict, "USERNAME_CONFLICT"},
 	ErrEmailConflict:    {http.StatusConflict, "EMAIL_CONFLICT"},
 	ErrTokenConflict:    {http.StatusConflict, "TOKEN_CONFLICT"},
+	ErrAlreadyVoted:     {http.StatusConflict, "ALREADY_VOTED"},
+	ErrAlreadyBookmarked: {http.StatusConflict, "ALREADY_BOOKMARKED"},
+	ErrAlreadyListMember: {http.StatusConflict, "ALREADY_LIST_MEMBER"},
+	ErrEmailAlreadyVerified: {http.StatusConflict, "EMAIL_ALREADY_VERIFIED"},
+
+	// 413 Request Entity Too Large
+	ErrMediaTooLarge: {http.StatusRequestEntityTooLarge, "MEDIA_TOO_LARGE"},
+
+	// 415 Unsupported Media Type
+	ErrUnsupportedMediaType: {http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE"},
 
 	// 422 Unprocessable Entity
 	ErrEditTimeExpired: {http.StatusUnprocessableEntity, "EDIT_TIME_EXPIRED"},
+	ErrEditLimitExceeded: {http.StatusUnprocessableEntity, "EDIT_LIMIT_EXCEEDED"},
+	ErrRestorePeriodExpired: {http.StatusUnprocessableEntity, "RESTORE_PERIOD_EXPIRED"},
+	ErrPollClosed: {http.StatusUnprocessableEntity, "POLL_CLOSED"},
+	ErrListMemberLimit: {http.StatusUnprocessableEntity, "LIST_MEMBER_LIMIT"},
+
+	// 429 Too Many Requests
+	ErrTooManyStreams: {http.StatusTooManyRequests, "TOO_MANY_STREAMS"},
+	ErrTooManyChannels: {http.StatusTooManyRequests, "TOO_MANY_CHANNELS"},
+	ErrTooManyRequests: {http.StatusTooManyRequests, "TOO_MANY_REQUESTS"},
+	ErrUsernameChangeTooSoon: {http.StatusTooManyRequests, "USERNAME_CHANGE_TOO_SOON"},
+
+	// 503 Service Unavailable
+	ErrServerDraining: {http.StatusServiceUnavailable, "SERVER_DRAINING"},
 }
 
 func GetStatusCode(err error) int {
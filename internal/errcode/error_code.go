@@ -6,7 +6,39 @@ var (
 	ErrInvalidRequestFormat  = errors.New("リクエスト形式が正しくありません")
 	ErrInvalidJSON           = errors.New("JSONの構文が正しくありません")
 	ErrInvalidIDFormat       = errors.New("IDの形式が正しくありません")
-	ErrEditTimeExpired       = errors.New("投稿から10分以上経過したツイートは編集できません")
+	ErrEditTimeExpired       = errors.New("編集可能期間を過ぎたツイートは編集できません")
+	ErrEditLimitExceeded     = errors.New("編集回数の上限に達したツイートは編集できません")
+	ErrRestorePeriodExpired  = errors.New("復元可能期間を過ぎたツイートは復元できません")
+	ErrInvalidPublishAt      = errors.New("公開日時は現在から1年以内の未来の日時を指定してください")
+	ErrMediaTooLarge         = errors.New("ファイルサイズまたは画像サイズが上限を超えています")
+	ErrUnsupportedMediaType  = errors.New("対応していないファイル形式です(JPEG/PNG/GIF)")
+	ErrInvalidMediaAttachment = errors.New("添付メディアの指定が正しくありません(最大4件・重複不可・代替テキストは最大1000文字)")
+	ErrInvalidPoll           = errors.New("投票の指定が正しくありません(選択肢2〜4件・各25文字以内・重複不可・期間5分〜7日、予約投稿には付けられません)")
+	ErrAlreadyVoted          = errors.New("既にこの投票に投票しています")
+	ErrPollClosed            = errors.New("締め切られた投票には投票できません")
+	ErrAlreadyBookmarked     = errors.New("既にこのツイートをブックマークしています")
+	ErrInvalidCursor         = errors.New("カーソルの形式が正しくありません")
+	ErrInvalidLastEventID    = errors.New("Last-Event-ID の形式が正しくありません")
+	ErrTooManyStreams        = errors.New("同時に接続できるストリーム数の上限に達しています")
+	ErrInvalidChannel        = errors.New("チャンネルの指定が正しくありません")
+	ErrNotSubscribed         = errors.New("購読していないチャンネルです")
+	ErrTooManyChannels       = errors.New("同時に購読できるチャンネル数の上限に達しています")
+	ErrServerDraining        = errors.New("サーバーが停止処理中です。再接続してください")
+	ErrInvalidConversationID = errors.New("無効な会話IDです")
+	ErrInvalidDMParticipants = errors.New("参加者の指定が正しくありません(自分以外に1〜9人・重複不可)")
+	ErrDMNotAllowed          = errors.New("このユーザーにはメッセージを送信できません")
+	ErrInvalidListID         = errors.New("無効なリストIDです")
+	ErrAlreadyListMember     = errors.New("既にこのユーザーはリストに追加されています")
+	ErrListMemberLimit       = errors.New("リストに追加できるメンバー数の上限に達しています")
+	ErrInvalidResetToken     = errors.New("パスワード再設定のトークンが無効か期限切れです")
+	ErrInvalidVerificationToken = errors.New("メールアドレス確認のトークンが無効か期限切れです")
+	ErrEmailAlreadyVerified  = errors.New("メールアドレスは既に確認済みです")
+	ErrEmailNotVerified      = errors.New("この操作にはメールアドレスの確認が必要です")
+	ErrTooManyRequests       = errors.New("リクエストが多すぎます。しばらくしてから再度お試しください")
+	ErrUsernameChangeTooSoon = errors.New("ユーザーネームは前回の変更から一定期間が経つまで変更できません")
+	ErrIncorrectPassword     = errors.New("現在のパスワードが正しくありません")
+	ErrUnchangedValue        = errors.New("現在と同じ値には変更できません")
+	ErrInvalidDateRange      = errors.New("期間の指定が正しくありません(YYYY-MM-DD形式・開始日は終了日以前・最大90日)")
 	ErrForbidden             = errors.New("指定された操作を行う権限がありません")
 	ErrRequiredFieldMissing  = errors.New("必要な項目が不足しています")
 	ErrInvalidUsernameFormat = errors.New("ユーザーネームの形式が正しくありません(4〜50文字)")
@@ -17,6 +49,7 @@ var (
 	ErrInvalidTokenFormat    = errors.New("有効トークンを入力してください(最大255文字)")
 	ErrInvalidUrlFormat      = errors.New("Will be written")
 	ErrInvalidTweetID        = errors.New("無効なツイートIDです")
+	ErrInvalidDraftID        = errors.New("無効な下書きIDです")
 	ErrInvalidContentFormat  = errors.New("contentの形式が正しくありません(最大1000文字)")
 	ErrAlreadyFollowing      = errors.New("既にこのユーザーをフォローしています")
 	ErrCannotFollowSelf      = errors.New("自分自身をフォローすることはできません")
@@ -27,8 +60,24 @@ var (
 	ErrUserNotFound    = errors.New("ユーザーデータが存在しません")
 	ErrSessionNotFound = errors.New("セッションが見つかりません")
 	ErrTweetNotFound   = errors.New("ツイートが見つかりません")
+	ErrScheduledTweetNotFound = errors.New("予約投稿が見つかりません")
+	ErrScheduledTweetFailed   = errors.New("予約投稿を公開できないため、失敗として記録しました")
+	ErrDraftNotFound   = errors.New("下書きが見つかりません")
+	ErrMediaNotFound   = errors.New("メディアが見つかりません")
+	ErrPollNotFound    = errors.New("投票が見つかりません")
+	ErrPollOptionNotFound = errors.New("投票の選択肢が見つかりません")
+	ErrBookmarkNotFound = errors.New("ブックマークが見つかりません")
+	ErrPinnedTweetNotFound = errors.New("固定されたツイートが見つかりません")
+	ErrConversationNotFound = errors.New("会話が見つかりません")
+	ErrListNotFound    = errors.New("リストが見つかりません")
+	ErrListMemberNotFound = errors.New("リストのメンバーが見つかりません")
+	ErrUserTokenNotFound = errors.New("トークンが見つかりません")
+	ErrUserSessionNotFound = errors.New("指定されたセッションが見つかりません")
 
 	ErrSessionExpired   = errors.New("セッションが期限切れです")
+	ErrInvalidRefreshToken = errors.New("リフレッシュトークンが無効か期限切れです。再度ログインしてください")
+	ErrRefreshTokenReused  = errors.New("使用済みのリフレッシュトークンが使われたため、セッションを無効にしました。再度ログインしてください")
+	ErrInvalidGatewayTicket = errors.New("接続用のチケットが無効か期限切れです。発行し直してください")
 	ErrUsernameConflict = errors.New("ユーザーネームは既に使用されています")
 	ErrEmailConflict    = errors.New("メールのアドレスは既に使用されています")
 	ErrTokenConflict    = errors.New("トークンは既に存在します")
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// 投稿者の1日(UTC)分の集計
+type DailyStats struct {
+	UserID          int64     `db:"user_id"`
+	Day             time.Time `db:"day"`
+	FollowersGained int64     `db:"followers_gained"`
+	FollowersLost   int64     `db:"followers_lost"`
+	TweetsPosted    int64     `db:"tweets_posted"`
+	Impressions     int64     `db:"impressions"`
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type Bookmark struct {
+	ID              int64       `db:"id"`
+	UserID          int64       `db:"user_id"`
+	TweetID         int64       `db:"tweet_id"`
+	CreatedAt       time.Time   `db:"created_at"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+const (
+	DMPolicyEveryone  = "everyone"
+	DMPolicyFollowing = "following"
+	DMPolicyNobody    = "nobody"
+)
+
+type DMConversation struct {
+	ID              int64       `db:"id"`
+	IsGroup         bool        `db:"is_group"`
+	DirectKey      *string      `db:"direct_key"`
+	CreatedBy       int64       `db:"created_by"`
+	CreatedAt       time.Time   `db:"created_at"`
+	LastMessageAt  *time.Time   `db:"last_message_at"`
+}
+
+type DMParticipant struct {
+	ConversationID    int64     `db:"conversation_id"`
+	UserID            int64     `db:"user_id"`
+	LastReadMessageID int64     `db:"last_read_message_id"`
+	JoinedAt          time.Time `db:"joined_at"`
+}
+
+type DMMessage struct {
+	ID              int64       `db:"id"`
+	ConversationID  int64       `db:"conversation_id"`
+	SenderID        int64       `db:"sender_id"`
+	Content         string      `db:"content"`
+	CreatedAt       time.Time   `db:"created_at"`
+}
+
+// 会話ごとの未読数
+type DMUnreadCount struct {
+	ConversationID  int64       `db:"conversation_id"`
+	Count           int64       `db:"count"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import (
+	"time"
+)
+
+type Draft struct {
+	ID            int64        `db:"id"`
+	UserID        int64        `db:"user_id"`
+	Content       string       `db:"content"`
+	Media         MediaAttachments `db:"media"`
+	CreatedAt     time.Time    `db:"created_at"`
+	UpdatedAt     time.Time    `db:"updated_at"`
+}
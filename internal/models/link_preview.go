@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Redis にのみ保存する。取得に失敗した URL も再取得を避けるため内容が空のまま保存する
+type LinkPreview struct {
+	URL             string      `json:"url"`
+	Title           string      `json:"title"`
+	Description     string      `json:"description"`
+	ImageURL        string      `json:"image_url"`
+	FetchedAt       time.Time   `json:"fetched_at"`
+}
+
+func (p *LinkPreview) IsEmpty() bool {
+	return p == nil || (p.Title == "" && p.Description == "" && p.ImageURL == "")
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+type List struct {
+	ID              int64       `db:"id"`
+	OwnerID         int64       `db:"owner_id"`
+	Name            string      `db:"name"`
+	Description     string      `db:"description"`
+	IsPrivate       bool        `db:"is_private"`
+	CreatedAt       time.Time   `db:"created_at"`
+	UpdatedAt       time.Time   `db:"updated_at"`
+	MemberCount     int64       `db:"member_count"`
+}
+
+type ListMember struct {
+	ListID          int64       `db:"list_id"`
+	UserID          int64       `db:"user_id"`
+	CreatedAt       time.Time   `db:"created_at"`
+}
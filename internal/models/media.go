@@ -0,0 +1,63 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type Media struct {
+	ID            int64        `db:"id"`
+	UserID        int64        `db:"user_id"`
+	StorageKey    string       `db:"storage_key"`
+	ThumbnailKey  string       `db:"thumbnail_key"`
+	URL           string       `db:"url"`
+	ThumbnailURL  string       `db:"thumbnail_url"`
+	ContentType   string       `db:"content_type"`
+	SizeBytes     int64        `db:"size_bytes"`
+	Width         int          `db:"width"`
+	Height        int          `db:"height"`
+	CreatedAt     time.Time    `db:"created_at"`
+}
+
+// 公開前の下書き・予約投稿が持つ添付メディアの指定。JSONB カラムに保存する
+type MediaAttachment struct {
+	MediaID       int64        `json:"media_id"`
+	AltText       string       `json:"alt_text"`
+}
+
+type MediaAttachments []MediaAttachment
+
+func (a MediaAttachments) Value() (driver.Value, error) {
+	if a == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (a *MediaAttachments) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, a)
+	case string:
+		return json.Unmarshal([]byte(v), a)
+	default:
+		return fmt.Errorf("MediaAttachments に変換できない型です: %T", src)
+	}
+}
+
+func (a MediaAttachments) ToTweetMedia() []TweetMedia {
+	media := make([]TweetMedia, 0, len(a))
+	for _, m := range a {
+		media = append(media, TweetMedia{MediaID: m.MediaID, AltText: m.AltText})
+	}
+	return media
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+const (
+	NotificationTypeFollow  = "follow"
+	NotificationTypeMention = "mention"
+	NotificationTypeLike    = "like"
+	NotificationTypeReply   = "reply"
+	NotificationTypeRetweet = "retweet"
+)
+
+type Notification struct {
+	ID              int64       `db:"id"`
+	// 通知を受け取るユーザー
+	UserID          int64       `db:"user_id"`
+	ActorID         int64       `db:"actor_id"`
+	Type            string      `db:"type"`
+	TweetID        *int64       `db:"tweet_id"`
+	CreatedAt       time.Time   `db:"created_at"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+)
+
+type Poll struct {
+	ID            int64        `db:"id"`
+	TweetID       int64        `db:"tweet_id"`
+	ClosesAt      time.Time    `db:"closes_at"`
+	ClosedAt     *time.Time    `db:"closed_at"`
+	CreatedAt     time.Time    `db:"created_at"`
+
+	// poll_options から別クエリで読み込む。position 順
+	Options       []PollOption `db:"-"`
+}
+
+type PollOption struct {
+	ID            int64        `db:"id"`
+	PollID        int64        `db:"poll_id"`
+	Position      int          `db:"position"`
+	Label         string       `db:"label"`
+	VoteCount     int64        `db:"vote_count"`
+}
+
+type PollVote struct {
+	PollID        int64        `db:"poll_id"`
+	UserID        int64        `db:"user_id"`
+	OptionID      int64        `db:"option_id"`
+	CreatedAt     time.Time    `db:"created_at"`
+}
@@ -0,0 +1,6 @@
+package models
+
+type Recommendation struct {
+	UserID int64 `db:"user_id"`
+	Score  int64 `db:"score"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	ScheduleStatusPending   = "pending"
+	ScheduleStatusPublished = "published"
+	ScheduleStatusCanceled  = "canceled"
+	// 添付メディアが使えなくなったなど、再試行しても公開できない予約
+	ScheduleStatusFailed    = "failed"
+)
+
+type ScheduledTweet struct {
+	ID            int64        `db:"id"`
+	UserID        int64        `db:"user_id"`
+	Content       string       `db:"content"`
+	Media         MediaAttachments `db:"media"`
+	PublishAt     time.Time    `db:"publish_at"`
+	Status        string       `db:"status"`
+	TweetID      *int64        `db:"tweet_id"`
+	CreatedAt     time.Time    `db:"created_at"`
+}
@@ -11,5 +11,25 @@ type Session struct{
 	TokenHash     string       `db:"token_hash" json:"hash"`
 	ExpiresAt     time.Time    `db:"expires_at" json:"exp"`
 	CreatedAt     time.Time    `db:"created_at" json:"iat"`
+	SessionID     string       `db:"session_id" json:"sid"`
+	IPAddress     string       `db:"ip_address" json:"ip"`
+	UserAgent     string       `db:"user_agent" json:"ua"`
+	LastSeenAt    time.Time    `db:"last_seen_at" json:"seen"`
+	RefreshHash   string       `db:"refresh_hash" json:"rhash"`
+	RefreshExpiresAt time.Time `db:"refresh_expires_at" json:"rexp"`
+}
+
+// ローテーション済みのリフレッシュトークンも、再利用を検知するために期限まで残す
+type RefreshTokenRef struct {
+	UserID        int64        `json:"uid"`
+	SessionID     string       `json:"sid"`
+}
+
+// アクセストークンが切れても、リフレッシュトークンが有効な間はセッションを残す
+func (s *Session) RetainUntil() time.Time {
+	if s.RefreshExpiresAt.After(s.ExpiresAt) {
+		return s.RefreshExpiresAt
+	}
+	return s.ExpiresAt
 }
 
@@ -0,0 +1,19 @@
+package models
+
+const (
+	StreamEventTimeline     = "timeline"
+	StreamEventNotification = "notification"
+	// 取りこぼしを再送しきれなかったため、クライアントに再取得を促す
+	StreamEventResync       = "resync"
+)
+
+// リアルタイム配信するイベント。ID は Redis Stream のエントリID
+type StreamEvent struct {
+	ID               string   `json:"-"`
+	Type             string   `json:"type"`
+	// 配信先のユーザー
+	UserIDs          []int64  `json:"user_ids"`
+	TweetID          int64    `json:"tweet_id,omitempty"`
+	ActorID          int64    `json:"actor_id,omitempty"`
+	NotificationType string   `json:"notification_type,omitempty"`
+}
@@ -0,0 +1,17 @@
+package models
+
+const (
+	TopicMessageTyping = "typing"
+	TopicMessageDM     = "dm_message"
+	TopicMessageDMRead = "dm_read"
+)
+
+// WebSocket のチャンネル(ツイート・DM など)に流すメッセージ。全 API ノードへ Pub/Sub で配る
+type TopicMessage struct {
+	Topic   string `json:"topic"`
+	Type    string `json:"type"`
+	ActorID int64  `json:"actor_id"`
+	TweetID int64  `json:"tweet_id,omitempty"`
+	// DM の場合はメッセージID(既読の場合は既読位置)
+	MessageID int64 `json:"message_id,omitempty"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// Redis にのみ保存する。集計のたびに上位のハッシュタグをまとめて置き換える
+type Trend struct {
+	Hashtag    string   `json:"hashtag"`
+	TweetCount int64    `json:"tweet_count"`
+	Score      float64  `json:"score"`
+}
@@ -14,6 +14,34 @@ type Tweet struct{
 	CreatedAt     time.Time    `db:"created_at"` 
 	UpdatedAt     time.Time    `db:"updated_at"`
 	IsEdited      bool         `db:"is_edited"`
+	EditCount     int          `db:"edit_count"`
+	LastEditedAt  *time.Time   `db:"last_edited_at"`
+	DeletedAt     *time.Time   `db:"deleted_at"`
+
+	// tweet_media から別クエリで読み込む。position 順
+	Media         []TweetMedia `db:"-"`
+	// polls から別クエリで読み込む。得票数はキャッシュに載せないため常に 0
+	Poll          *Poll        `db:"-"`
+}
+
+type TweetMedia struct {
+	TweetID       int64        `db:"tweet_id"`
+	MediaID       int64        `db:"media_id"`
+	Position      int          `db:"position"`
+	AltText       string       `db:"alt_text"`
+	URL           string       `db:"url"`
+	ThumbnailURL  string       `db:"thumbnail_url"`
+	ContentType   string       `db:"content_type"`
+	Width         int          `db:"width"`
+	Height        int          `db:"height"`
+}
+
+type TweetRevision struct {
+	ID            int64        `db:"id"`
+	TweetID       int64        `db:"tweet_id"`
+	Revision      int          `db:"revision"`
+	Content       string       `db:"content"`
+	CreatedAt     time.Time    `db:"created_at"`
 }
 
 
@@ -0,0 +1,6 @@
+package models
+
+type TweetViewCount struct {
+	TweetID       int64        `db:"id"`
+	ViewCount     int64        `db:"view_count"`
+}
@@ -12,6 +12,8 @@ type User struct{
 	CreatedAt     	time.Time    	`db:"created_at"`
 	FollowerCount 	int64        	`db:"follower_count"`
 	FollowingCount  int64           `db:"following_count"`
+	EmailVerifiedAt *time.Time      `db:"email_verified_at"`
+	UsernameChangedAt *time.Time    `db:"username_changed_at"`
 } 
 
 type UserInfo struct {
@@ -4,6 +4,7 @@ import (
 	"aita/internal/errcode"
 	"aita/internal/pkg/utils"
 	"strings"
+	"time"
 	"unicode/utf8"
 )
 
@@ -18,6 +19,36 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required,min=8,max=72"`
 }
 
+type ForgotPasswordRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
+}
+
+type VerifyEmailRequest struct {
+	Token    string `json:"token" binding:"required"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8,max=72"`
+}
+
+type ChangeEmailRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+}
+
+type ChangeUsernameRequest struct {
+	Username string `json:"username" binding:"required,min=4,max=50"`
+}
+
 type FollowRequest struct {
 	TargetID int64 `json:"target_id" binding:"required,gt=0"`
 }
@@ -28,7 +59,61 @@ type UnFollowRequest struct {
 
 type CreateTweetRequest struct {
 	Content		 string        `json:"content" binding:"max=1000"`
-	ImageURL    *string        `json:"image_url" binding:"omitempty,url"`
+	Media       []MediaAttachmentRequest `json:"media" binding:"omitempty,max=4,dive"`
+	Poll        *PollRequest   `json:"poll"`
+	PublishAt   *time.Time     `json:"publish_at"`
+}
+
+type PollRequest struct {
+	Options      []string      `json:"options" binding:"required,min=2,max=4,dive,max=25"`
+	DurationMinutes int        `json:"duration_minutes" binding:"required,min=5,max=10080"`
+}
+
+type VotePollRequest struct {
+	OptionID     int64         `json:"option_id" binding:"required,gt=0"`
+}
+
+// Cursor を省略した場合はすべて既読にする
+type MarkNotificationsReadRequest struct {
+	Cursor       string        `json:"cursor"`
+}
+
+// 自分以外の参加者を指定する。1人なら1対1、2人以上ならグループの会話になる
+type CreateConversationRequest struct {
+	ParticipantIDs []int64     `json:"participant_ids" binding:"required,min=1,max=9,dive,gt=0"`
+}
+
+type SendDMRequest struct {
+	Content      string        `json:"content" binding:"required,max=1000"`
+}
+
+// Cursor を省略した場合は最新のメッセージまで既読にする
+type MarkDMReadRequest struct {
+	Cursor       string        `json:"cursor"`
+}
+
+type DMSettingsRequest struct {
+	Policy       string        `json:"policy" binding:"required,oneof=everyone following nobody"`
+}
+
+type CreateListRequest struct {
+	Name         string        `json:"name" binding:"required,max=25"`
+	Description  string        `json:"description" binding:"max=100"`
+	IsPrivate    bool          `json:"is_private"`
+}
+
+type AddListMemberRequest struct {
+	UserID       int64         `json:"user_id" binding:"required,gt=0"`
+}
+
+type DraftRequest struct {
+	Content		 string        `json:"content" binding:"max=1000"`
+	Media       []MediaAttachmentRequest `json:"media" binding:"omitempty,max=4,dive"`
+}
+
+type MediaAttachmentRequest struct {
+	MediaID      int64         `json:"media_id" binding:"required,gt=0"`
+	AltText      string        `json:"alt_text" binding:"max=1000"`
 }
 
 type UpdateTweetRequest struct {
@@ -70,26 +155,111 @@ func (r *LoginRequest) Validate() error {
 	return nil
 }
 
+func (r *ForgotPasswordRequest) Validate() error {
+	r.Email = strings.TrimSpace(r.Email)
+	if r.Email == "" {
+		return errcode.ErrRequiredFieldMissing
+	}
+	if !utils.IsValidEmail(r.Email) || len(r.Email) > 255 {
+		return errcode.ErrInvalidEmailFormat
+	}
+	return nil
+}
+
+func (r *ResetPasswordRequest) Validate() error {
+	r.Token = strings.TrimSpace(r.Token)
+	r.Password = strings.TrimSpace(r.Password)
+	if r.Token == "" || r.Password == "" {
+		return errcode.ErrRequiredFieldMissing
+	}
+	if len(r.Password) < 8 || len(r.Password) > 72 {
+		return errcode.ErrInvalidPasswordFormat
+	}
+	return nil
+}
+
+func (r *VerifyEmailRequest) Validate() error {
+	r.Token = strings.TrimSpace(r.Token)
+	if r.Token == "" {
+		return errcode.ErrRequiredFieldMissing
+	}
+	return nil
+}
+
+func (r *RefreshTokenRequest) Validate() error {
+	r.RefreshToken = strings.TrimSpace(r.RefreshToken)
+	if r.RefreshToken == "" {
+		return errcode.ErrRequiredFieldMissing
+	}
+	return nil
+}
+
+func (r *ChangePasswordRequest) Validate() error {
+	r.CurrentPassword = strings.TrimSpace(r.CurrentPassword)
+	r.NewPassword = strings.TrimSpace(r.NewPassword)
+	if r.CurrentPassword == "" || r.NewPassword == "" {
+		return errcode.ErrRequiredFieldMissing
+	}
+	if len(r.NewPassword) < 8 || len(r.NewPassword) > 72 {
+		return errcode.ErrInvalidPasswordFormat
+	}
+	return nil
+}
+
+func (r *ChangeEmailRequest) Validate() error {
+	r.Email = strings.TrimSpace(r.Email)
+	if r.Email == "" {
+		return errcode.ErrRequiredFieldMissing
+	}
+	if !utils.IsValidEmail(r.Email) || len(r.Email) > 255 {
+		return errcode.ErrInvalidEmailFormat
+	}
+	return nil
+}
+
+func (r *ChangeUsernameRequest) Validate() error {
+	r.Username = strings.TrimSpace(r.Username)
+	if r.Username == "" {
+		return errcode.ErrRequiredFieldMissing
+	}
+	if len(r.Username) < 4 || len(r.Username) > 50 {
+		return errcode.ErrInvalidUsernameFormat
+	}
+	return nil
+}
 
 func (r *CreateTweetRequest) Validate() error {
     r.Content = strings.TrimSpace(r.Content)
     if r.Content == "" {
         return errcode.ErrRequiredFieldMissing
     }
-	if r.ImageURL != nil {
-        trimmed := strings.TrimSpace(*r.ImageURL)
-        if trimmed == "" {
-            r.ImageURL = nil
-        } else {
-            *r.ImageURL = trimmed
-        }
-    }
+	if err := validateMediaAttachments(r.Media); err != nil {
+		return err
+	}
+	if r.Poll != nil {
+		if r.PublishAt != nil {
+			return errcode.ErrInvalidPoll
+		}
+		if err := r.Poll.Validate(); err != nil {
+			return err
+		}
+	}
    	if utf8.RuneCountInString(r.Content) > 1000 {
 		return errcode.ErrInvalidContentFormat
 	}
     return nil
 }
 
+// 下書きもツイート投稿と同じ検証を通す
+func (r *DraftRequest) Validate() error {
+	req := CreateTweetRequest{Content: r.Content, Media: r.Media}
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	r.Content = req.Content
+	return nil
+}
+
 func (r *UpdateTweetRequest) Validate() error {
     r.Content = strings.TrimSpace(r.Content)
     if r.Content == "" {
@@ -99,4 +269,78 @@ func (r *UpdateTweetRequest) Validate() error {
         return errcode.ErrInvalidContentFormat
     }
     return nil
-}
\ No newline at end of file
+}
+
+// 名前・説明は前後の空白を除く。名前が空白のみの場合は不可
+func (r *CreateListRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Description = strings.TrimSpace(r.Description)
+	if r.Name == "" {
+		return errcode.ErrRequiredFieldMissing
+	}
+	return nil
+}
+
+func (r *SendDMRequest) Validate() error {
+	r.Content = strings.TrimSpace(r.Content)
+	if r.Content == "" {
+		return errcode.ErrRequiredFieldMissing
+	}
+	if utf8.RuneCountInString(r.Content) > 1000 {
+		return errcode.ErrInvalidContentFormat
+	}
+	return nil
+}
+
+// 添付は最大4件、同じメディアの重複指定は不可。代替テキストは前後の空白を除く
+func validateMediaAttachments(media []MediaAttachmentRequest) error {
+	if len(media) > 4 {
+		return errcode.ErrInvalidMediaAttachment
+	}
+
+	seen := make(map[int64]struct{}, len(media))
+	for i := range media {
+		if media[i].MediaID <= 0 {
+			return errcode.ErrInvalidMediaAttachment
+		}
+		if _, ok := seen[media[i].MediaID]; ok {
+			return errcode.ErrInvalidMediaAttachment
+		}
+		seen[media[i].MediaID] = struct{}{}
+
+		media[i].AltText = strings.TrimSpace(media[i].AltText)
+		if utf8.RuneCountInString(media[i].AltText) > 1000 {
+			return errcode.ErrInvalidMediaAttachment
+		}
+	}
+	return nil
+}
+
+// 選択肢は前後の空白を除き、空・重複は不可。期間は5分〜7日
+func (r *PollRequest) Validate() error {
+	if len(r.Options) < 2 || len(r.Options) > 4 {
+		return errcode.ErrInvalidPoll
+	}
+	if r.DurationMinutes < 5 || r.DurationMinutes > 7*24*60 {
+		return errcode.ErrInvalidPoll
+	}
+
+	seen := make(map[string]struct{}, len(r.Options))
+	for i := range r.Options {
+		r.Options[i] = strings.TrimSpace(r.Options[i])
+		if r.Options[i] == "" || utf8.RuneCountInString(r.Options[i]) > 25 {
+			return errcode.ErrInvalidPoll
+		}
+		if _, ok := seen[r.Options[i]]; ok {
+			return errcode.ErrInvalidPoll
+		}
+		seen[r.Options[i]] = struct{}{}
+	}
+	return nil
+}
+
+// WebSocket でクライアントから送るメッセージ。type は subscribe / unsubscribe / typing / pong
+type GatewayRequest struct {
+	Type    string `json:"type"`
+	Channel string `json:"channel"`
+}
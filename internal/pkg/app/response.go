@@ -10,11 +10,25 @@ type UserResponse struct {
 	ID        int64     `json:"id"`
 	Username  string    `json:"username"`
 	Email     string    `json:"email"`
+	EmailVerified bool  `json:"email_verified"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type TokenResponse struct {
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+// WebSocket 接続時に ticket クエリで渡す使い捨てのチケット
+type GatewayTicketResponse struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
 type LoginResponse struct {
-	SessionToken string        `json:"session_token"`
+	TokenResponse
 	User         *UserResponse `json:"user"`
 }
 
@@ -40,6 +54,164 @@ type TweetResponse struct{
 	CreatedAt     time.Time    `json:"created_at"` 
 	UpdatedAt     time.Time    `json:"updated_at"`
 	IsEdited      bool         `json:"is_edited"`
+	EditCount     int          `json:"edit_count"`
+	LastEditedAt *time.Time    `json:"last_edited_at"`
+	EditWindowRemaining int64  `json:"edit_window_remaining"`
+	RemainingEdits int         `json:"remaining_edits"`
+	Media         []TweetMediaResponse `json:"media"`
+	Poll         *PollResponse `json:"poll"`
+	IsPinned      bool         `json:"is_pinned"`
+	LinkPreview  *LinkPreviewResponse `json:"link_preview"`
+	ViewCount     int64        `json:"view_count"`
+}
+
+type LinkPreviewResponse struct {
+	URL           string       `json:"url"`
+	Title         string       `json:"title"`
+	Description   string       `json:"description"`
+	ImageURL      string       `json:"image_url"`
+}
+
+// 得票数は投票済みか締切後のみ返す
+type PollResponse struct {
+	ID            int64        `json:"id"`
+	Options       []PollOptionResponse `json:"options"`
+	ClosesAt      time.Time    `json:"closes_at"`
+	IsClosed      bool         `json:"is_closed"`
+	ResultsVisible bool        `json:"results_visible"`
+	TotalVotes   *int64        `json:"total_votes,omitempty"`
+	VotedOptionID *int64       `json:"voted_option_id"`
+}
+
+type PollOptionResponse struct {
+	ID            int64        `json:"id"`
+	Position      int          `json:"position"`
+	Label         string       `json:"label"`
+	Votes        *int64        `json:"votes,omitempty"`
+}
+
+type TweetRevisionResponse struct {
+	Revision      int          `json:"revision"`
+	Content       string       `json:"content"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+type DraftResponse struct {
+	ID            int64        `json:"id"`
+	Content       string       `json:"content"`
+	Media         []MediaAttachmentResponse `json:"media"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
+
+type ScheduledTweetResponse struct {
+	ID            int64        `json:"id"`
+	UserID        int64        `json:"user_id"`
+	Content       string       `json:"content"`
+	Media         []MediaAttachmentResponse `json:"media"`
+	PublishAt     time.Time    `json:"publish_at"`
+	Status        string       `json:"status"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+type BookmarkResponse struct {
+	TweetID       int64        `json:"tweet_id"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+// 次のページがない場合 NextCursor は空
+type CursorMeta struct {
+	NextCursor    string       `json:"next_cursor,omitempty"`
+}
+
+type NotificationResponse struct {
+	ID            int64        `json:"id"`
+	Type          string       `json:"type"`
+	TweetID      *int64        `json:"tweet_id"`
+	Actors        []NotificationActorResponse `json:"actors"`
+	ActorCount    int          `json:"actor_count"`
+	Message       string       `json:"message"`
+	CreatedAt     time.Time    `json:"created_at"`
+	IsRead        bool         `json:"is_read"`
+}
+
+type NotificationActorResponse struct {
+	ID            int64        `json:"id"`
+	Username      string       `json:"username"`
+}
+
+type UnreadCountResponse struct {
+	UnreadCount   int64        `json:"unread_count"`
+}
+
+type DMConversationResponse struct {
+	ID            int64        `json:"id"`
+	IsGroup       bool         `json:"is_group"`
+	Participants  []DMParticipantResponse `json:"participants"`
+	UnreadCount   int64        `json:"unread_count"`
+	CreatedAt     time.Time    `json:"created_at"`
+	LastMessageAt *time.Time   `json:"last_message_at"`
+}
+
+// LastReadMessageID 以下のメッセージはその参加者が既読
+type DMParticipantResponse struct {
+	ID                int64    `json:"id"`
+	Username          string   `json:"username"`
+	LastReadMessageID int64    `json:"last_read_message_id"`
+}
+
+type DMMessageResponse struct {
+	ID             int64       `json:"id"`
+	ConversationID int64       `json:"conversation_id"`
+	SenderID       int64       `json:"sender_id"`
+	Content        string      `json:"content"`
+	CreatedAt      time.Time   `json:"created_at"`
+}
+
+type DMReadResponse struct {
+	LastReadMessageID int64    `json:"last_read_message_id"`
+}
+
+type ListResponse struct {
+	ID            int64        `json:"id"`
+	OwnerID       int64        `json:"owner_id"`
+	Name          string       `json:"name"`
+	Description   string       `json:"description"`
+	IsPrivate     bool         `json:"is_private"`
+	MemberCount   int64        `json:"member_count"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
+
+type ListMemberResponse struct {
+	ID            int64        `json:"id"`
+	Username      string       `json:"username"`
+}
+
+// WebSocket でサーバーから送るメッセージ。エラー時は code と message を埋める
+type GatewayMessage struct {
+	Type             string    `json:"type"`
+	Channel          string    `json:"channel,omitempty"`
+	ID               string    `json:"id,omitempty"`
+	TweetID          int64     `json:"tweet_id,omitempty"`
+	ActorID          int64     `json:"actor_id,omitempty"`
+	NotificationType string    `json:"notification_type,omitempty"`
+	MessageID        int64     `json:"message_id,omitempty"`
+	Code             string    `json:"code,omitempty"`
+	Message          string    `json:"message,omitempty"`
+}
+
+// SSE の data 部分。イベント種別は event フィールドで送る
+type StreamEventResponse struct {
+	TweetID          int64     `json:"tweet_id,omitempty"`
+	ActorID          int64     `json:"actor_id,omitempty"`
+	NotificationType string    `json:"notification_type,omitempty"`
+}
+
+type RecommendedUserResponse struct {
+	ID          int64  `json:"id"`
+	Username    string `json:"username"`
+	MutualCount int64  `json:"mutual_count"`
 }
 
 type Response struct {
@@ -58,6 +230,15 @@ func Fail(err error) Response {
 	}
 }
 
+func GatewayFail(channel string, err error) *GatewayMessage {
+	return &GatewayMessage{
+		Type:    "error",
+		Channel: channel,
+		Code:    errcode.GetBusinessCode(err),
+		Message: err.Error(),
+	}
+}
+
 func Success(data any) Response {
 	return Response{
 		Data: data,
@@ -79,3 +260,69 @@ func SuccessWithMeta(data any, meta any) Response {
 		Code: "SUCCESS",
 	}
 }
+
+type MediaResponse struct {
+	ID            int64        `json:"id"`
+	URL           string       `json:"url"`
+	ThumbnailURL  string       `json:"thumbnail_url"`
+	ContentType   string       `json:"content_type"`
+	SizeBytes     int64        `json:"size_bytes"`
+	Width         int          `json:"width"`
+	Height        int          `json:"height"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+type TweetMediaResponse struct {
+	MediaID       int64        `json:"media_id"`
+	Position      int          `json:"position"`
+	AltText       string       `json:"alt_text"`
+	URL           string       `json:"url"`
+	ThumbnailURL  string       `json:"thumbnail_url"`
+	ContentType   string       `json:"content_type"`
+	Width         int          `json:"width"`
+	Height        int          `json:"height"`
+}
+
+type MediaAttachmentResponse struct {
+	MediaID       int64        `json:"media_id"`
+	AltText       string       `json:"alt_text"`
+}
+
+type TrendResponse struct {
+	Rank          int          `json:"rank"`
+	Hashtag       string       `json:"hashtag"`
+	TweetCount    int64        `json:"tweet_count"`
+}
+
+type DailyStatsResponse struct {
+	Day             string       `json:"day"`
+	FollowersGained int64        `json:"followers_gained"`
+	FollowersLost   int64        `json:"followers_lost"`
+	TweetsPosted    int64        `json:"tweets_posted"`
+	Impressions     int64        `json:"impressions"`
+}
+
+type AnalyticsTotalsResponse struct {
+	FollowersGained int64        `json:"followers_gained"`
+	FollowersLost   int64        `json:"followers_lost"`
+	NetFollowers    int64        `json:"net_followers"`
+	TweetsPosted    int64        `json:"tweets_posted"`
+	Impressions     int64        `json:"impressions"`
+}
+
+type AnalyticsResponse struct {
+	From          string                     `json:"from"`
+	To            string                     `json:"to"`
+	Days          []*DailyStatsResponse      `json:"days"`
+	Totals        *AnalyticsTotalsResponse   `json:"totals"`
+}
+
+type SessionResponse struct {
+	ID            string        `json:"id"`
+	IPAddress     string        `json:"ip_address"`
+	UserAgent     string        `json:"user_agent"`
+	CreatedAt     time.Time     `json:"created_at"`
+	LastSeenAt    time.Time     `json:"last_seen_at"`
+	ExpiresAt     time.Time     `json:"expires_at"`
+	Current       bool          `json:"current"`
+}
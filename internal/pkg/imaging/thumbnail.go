@@ -0,0 +1,91 @@
+package imaging
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+
+	_ "image/gif"
+)
+
+const thumbnailJPEGQuality = 80
+
+// 長辺が maxSide に収まるよう縮小したサムネイルを返す。元画像が小さい場合は拡大しない。
+// 透過を保つため PNG は PNG のまま、それ以外(JPEG/GIF の1フレーム目)は JPEG で出力する
+func Thumbnail(data []byte, maxSide int) ([]byte, string, error) {
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("画像のデコードに失敗しました: %w", err)
+	}
+
+	thumb := resize(src, maxSide)
+
+	var buf bytes.Buffer
+	if format == "png" {
+		if err := png.Encode(&buf, thumb); err != nil {
+			return nil, "", fmt.Errorf("サムネイルのエンコードに失敗しました: %w", err)
+		}
+		return buf.Bytes(), "image/png", nil
+	}
+
+	if err := jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: thumbnailJPEGQuality}); err != nil {
+		return nil, "", fmt.Errorf("サムネイルのエンコードに失敗しました: %w", err)
+	}
+	return buf.Bytes(), "image/jpeg", nil
+}
+
+// 出力画素ごとに対応する元画像の範囲を平均する(エリア平均法)
+func resize(src image.Image, maxSide int) *image.RGBA {
+	b := src.Bounds()
+	srcW, srcH := b.Dx(), b.Dy()
+	dstW, dstH := fitSize(srcW, srcH, maxSide)
+
+	rgba := image.NewRGBA(image.Rect(0, 0, srcW, srcH))
+	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+	if dstW == srcW && dstH == srcH {
+		return rgba
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0 := y * srcH / dstH
+		y1 := max((y+1)*srcH/dstH, y0+1)
+		for x := 0; x < dstW; x++ {
+			x0 := x * srcW / dstW
+			x1 := max((x+1)*srcW/dstW, x0+1)
+
+			var r, g, bl, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				off := sy*rgba.Stride + x0*4
+				for sx := x0; sx < x1; sx++ {
+					r += uint32(rgba.Pix[off])
+					g += uint32(rgba.Pix[off+1])
+					bl += uint32(rgba.Pix[off+2])
+					a += uint32(rgba.Pix[off+3])
+					off += 4
+					n++
+				}
+			}
+
+			i := y*dst.Stride + x*4
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(bl / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+func fitSize(w, h, maxSide int) (int, int) {
+	if w <= maxSide && h <= maxSide {
+		return w, h
+	}
+	if w >= h {
+		return maxSide, max(h*maxSide/w, 1)
+	}
+	return max(w*maxSide/h, 1), maxSide
+}
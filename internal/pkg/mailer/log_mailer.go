@@ -0,0 +1,63 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// 開発・テスト用。実際には送らずログに出し、dir を指定した場合は .eml ファイルとして書き出す
+type logMailer struct {
+	from string
+	dir  string
+	seq  atomic.Int64
+}
+
+func NewLogMailer(from, dir string) (*logMailer, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("メール保存先ディレクトリの作成に失敗しました: %w", err)
+		}
+	}
+
+	return &logMailer{from: from, dir: dir}, nil
+}
+
+func (m *logMailer) Send(ctx context.Context, to, subject, body string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	msg, err := buildMessage(m.from, to, subject, body, now)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Mailer: メールを送信しました(ログ出力のみ)", "to", to, "subject", subject, "body", body)
+	if m.dir == "" {
+		return nil
+	}
+
+	name := fmt.Sprintf("%d_%d_%s.eml", now.UnixNano(), m.seq.Add(1), sanitizeFileName(to))
+	if err := os.WriteFile(filepath.Join(m.dir, name), msg, 0o644); err != nil {
+		return fmt.Errorf("メールの書き出しに失敗しました(to:%s): %w", to, err)
+	}
+	return nil
+}
+
+func sanitizeFileName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '@', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
@@ -0,0 +1,55 @@
+package mailer
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMessage(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("正常系: 件名はエンコードされ本文は base64 になること", func(t *testing.T) {
+		msg, err := buildMessage("no-reply@example.com", "a@example.com", "パスワードの再設定", "こんにちは", now)
+		require.NoError(t, err)
+
+		header, body, ok := strings.Cut(string(msg), "\r\n\r\n")
+		require.True(t, ok)
+		assert.Contains(t, header, "To: a@example.com\r\n")
+		assert.Contains(t, header, "Subject: =?UTF-8?b?")
+		assert.NotContains(t, header, "パスワード")
+
+		decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(body, "\r\n", ""))
+		require.NoError(t, err)
+		assert.Equal(t, "こんにちは", string(decoded))
+	})
+
+	t.Run("異常系: ヘッダーに改行を含められないこと", func(t *testing.T) {
+		_, err := buildMessage("no-reply@example.com", "a@example.com\r\nBcc: b@example.com", "件名", "本文", now)
+		assert.ErrorIs(t, err, errInvalidHeader)
+	})
+}
+
+func TestLogMailerWritesFile(t *testing.T) {
+	dir := t.TempDir()
+	m, err := NewLogMailer("no-reply@example.com", dir)
+	require.NoError(t, err)
+
+	require.NoError(t, m.Send(context.Background(), "a@example.com", "件名", "本文"))
+	require.NoError(t, m.Send(context.Background(), "a@example.com", "件名", "本文"))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "To: a@example.com")
+}
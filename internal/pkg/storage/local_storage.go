@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ローカルファイルシステムに保存する実装。baseURL 配下で静的配信されることを前提とする
+type localStorage struct {
+	baseDir string
+	baseURL string
+}
+
+func NewLocalStorage(baseDir, baseURL string) (*localStorage, error) {
+	if err := os.MkdirAll(baseDir, 0o755); err != nil {
+		return nil, fmt.Errorf("メディア保存先ディレクトリの作成に失敗しました: %w", err)
+	}
+
+	return &localStorage{
+		baseDir: baseDir,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}, nil
+}
+
+// 一時ファイルに書き込んでから rename するので、書き込み途中のファイルが配信されることはない
+func (s *localStorage) Save(ctx context.Context, key string, r io.Reader) error {
+	dst, err := s.resolve(key)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return fmt.Errorf("ディレクトリの作成に失敗しました(key:%s): %w", key, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("一時ファイルの作成に失敗しました(key:%s): %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("ファイルの書き込みに失敗しました(key:%s): %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("ファイルのクローズに失敗しました(key:%s): %w", key, err)
+	}
+
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return fmt.Errorf("ファイルの配置に失敗しました(key:%s): %w", key, err)
+	}
+	return nil
+}
+
+// 既に存在しないファイルの削除は成功として扱う
+func (s *localStorage) Delete(ctx context.Context, key string) error {
+	dst, err := s.resolve(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(dst); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("ファイルの削除に失敗しました(key:%s): %w", key, err)
+	}
+	return nil
+}
+
+func (s *localStorage) URL(key string) string {
+	return s.baseURL + "/" + path.Clean(key)
+}
+
+// キーが保存先ディレクトリの外を指さないことを確認する
+func (s *localStorage) resolve(key string) (string, error) {
+	if key == "" || !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("不正なストレージキーです(key:%s)", key)
+	}
+	return filepath.Join(s.baseDir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,272 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	ErrBlockedAddress     = errors.New("プライベートアドレスへのアクセスは禁止されています")
+	ErrUnsupportedURL     = errors.New("http/https 以外の URL は取得できません")
+	ErrUnsupportedContent = errors.New("HTML 以外のコンテンツはプレビューできません")
+)
+
+const (
+	maxRedirects      = 3
+	maxTitleLength    = 300
+	maxDescLength     = 1000
+	maxImageURLLength = 2048
+)
+
+var urlPattern = regexp.MustCompile(`https?://[A-Za-z0-9\-._~:/?#\[\]@!$&'()*+,;=%]+`)
+
+// CGNAT など net.IP のメソッドで判定できない範囲
+var extraBlockedNets = []*net.IPNet{
+	mustParseCIDR("100.64.0.0/10"),
+	mustParseCIDR("192.0.0.0/24"),
+	mustParseCIDR("198.18.0.0/15"),
+}
+
+type Preview struct {
+	URL         string
+	Title       string
+	Description string
+	ImageURL    string
+}
+
+// 本文中の最初の http/https URL。末尾の句読点や閉じ括弧は含めない
+func FirstURL(content string) string {
+	raw := urlPattern.FindString(content)
+	return strings.TrimRight(raw, ".,!?;:)]}'")
+}
+
+// Open Graph メタデータを取得するクライアント。
+// 接続先は名前解決後のアドレスで検査するため、DNS リバインディングでも内部ネットワークには届かない
+type Fetcher struct {
+	client   *http.Client
+	maxBytes int64
+	// テストでのみ差し替える
+	isBlocked func(ip net.IP) bool
+}
+
+func NewFetcher(timeout time.Duration, maxBytes int64) *Fetcher {
+	f := &Fetcher{
+		maxBytes:  maxBytes,
+		isBlocked: isPrivateIP,
+	}
+
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || f.isBlocked(ip) {
+				return ErrBlockedAddress
+			}
+			return nil
+		},
+	}
+
+	f.client = &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// 環境変数のプロキシを経由すると接続先の検査が効かなくなるため使わない
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   timeout,
+			ResponseHeaderTimeout: timeout,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       30 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("リダイレクト回数が上限(%d)を超えました", maxRedirects)
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return ErrUnsupportedURL
+			}
+			return nil
+		},
+	}
+	return f
+}
+
+// 先頭 maxBytes までの HTML から og:title / og:description / og:image を読む。
+// OG タグがなければ <title> と description にフォールバックする
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (*Preview, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, ErrUnsupportedURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("リクエストの作成に失敗しました: %w", err)
+	}
+	req.Header.Set("User-Agent", "aita-link-preview/1.0")
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrBlockedAddress) {
+			return nil, ErrBlockedAddress
+		}
+		return nil, fmt.Errorf("ページの取得に失敗しました(url:%s): %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ページの取得に失敗しました(url:%s, status:%d)", rawURL, resp.StatusCode)
+	}
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || (mediaType != "text/html" && mediaType != "application/xhtml+xml") {
+		return nil, ErrUnsupportedContent
+	}
+
+	preview := parseHead(io.LimitReader(resp.Body, f.maxBytes), resp.Request.URL)
+	preview.URL = rawURL
+	return preview, nil
+}
+
+func parseHead(r io.Reader, base *url.URL) *Preview {
+	var (
+		ogTitle, ogDesc, ogImage string
+		title, desc              string
+		inTitle                  bool
+	)
+
+	z := html.NewTokenizer(r)
+loop:
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			break loop
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tag := z.Token()
+			switch tag.Data {
+			case "title":
+				inTitle = title == ""
+			case "meta":
+				key, content := metaAttrs(tag)
+				switch key {
+				case "og:title":
+					ogTitle = content
+				case "og:description":
+					ogDesc = content
+				case "og:image", "og:image:url":
+					if ogImage == "" {
+						ogImage = content
+					}
+				case "description":
+					desc = content
+				}
+			case "body":
+				break loop
+			}
+		case html.TextToken:
+			if inTitle {
+				title = string(z.Text())
+				inTitle = false
+			}
+		case html.EndTagToken:
+			if z.Token().Data == "head" {
+				break loop
+			}
+		}
+	}
+
+	if ogTitle == "" {
+		ogTitle = title
+	}
+	if ogDesc == "" {
+		ogDesc = desc
+	}
+
+	return &Preview{
+		Title:       truncate(strings.TrimSpace(ogTitle), maxTitleLength),
+		Description: truncate(strings.TrimSpace(ogDesc), maxDescLength),
+		ImageURL:    resolveImageURL(base, strings.TrimSpace(ogImage)),
+	}
+}
+
+func metaAttrs(tag html.Token) (string, string) {
+	var key, content string
+	for _, a := range tag.Attr {
+		switch strings.ToLower(a.Key) {
+		case "property", "name":
+			if key == "" {
+				key = strings.ToLower(a.Val)
+			}
+		case "content":
+			content = a.Val
+		}
+	}
+	return key, content
+}
+
+// 相対 URL はページの URL を基準に解決し、http/https 以外は捨てる
+func resolveImageURL(base *url.URL, raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ""
+	}
+	s := u.String()
+	if len(s) > maxImageURLLength {
+		return ""
+	}
+	return s
+}
+
+func truncate(s string, maxRunes int) string {
+	if utf8.RuneCountInString(s) <= maxRunes {
+		return s
+	}
+	return string([]rune(s)[:maxRunes])
+}
+
+func isPrivateIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return true
+	}
+	for _, n := range extraBlockedNets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func mustParseCIDR(s string) *net.IPNet {
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
@@ -0,0 +1,197 @@
+package unfurl
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// httptest のサーバーはループバックで待ち受けるため、アドレス検査を外したクライアントを使う
+func newTestFetcher(maxBytes int64) *Fetcher {
+	f := NewFetcher(2*time.Second, maxBytes)
+	f.isBlocked = func(net.IP) bool { return false }
+	return f
+}
+
+func TestFetch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/og", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<html><head>
+			<title>タイトル要素</title>
+			<meta property="og:title" content="OGタイトル">
+			<meta property="og:description" content="OG説明">
+			<meta property="og:image" content="/img/cover.png">
+			</head><body>本文</body></html>`))
+	})
+	mux.HandleFunc("/plain", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><head><title>タイトルのみ</title><meta name="description" content="説明"></head></html>`))
+	})
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><head><!--` + strings.Repeat("x", 4096) + `--><meta property="og:title" content="届かない"></head></html>`))
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(5 * time.Second):
+		case <-r.Context().Done():
+		}
+	})
+	mux.HandleFunc("/missing", http.NotFound)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	tests := []struct {
+		name      string
+		path      string
+		want      *Preview
+		wantedErr error
+		wantErr   bool
+	}{
+		{
+			name: "正常系: OGタグを読み、相対画像URLを解決する",
+			path: "/og",
+			want: &Preview{Title: "OGタイトル", Description: "OG説明", ImageURL: srv.URL + "/img/cover.png"},
+		},
+		{
+			name: "正常系: OGタグがなければtitleとdescriptionを使う",
+			path: "/plain",
+			want: &Preview{Title: "タイトルのみ", Description: "説明"},
+		},
+		{
+			name: "正常系: 上限を超えた部分は読まない",
+			path: "/large",
+			want: &Preview{},
+		},
+		{
+			name:      "異常系: HTML以外",
+			path:      "/json",
+			wantedErr: ErrUnsupportedContent,
+		},
+		{
+			name:    "異常系: 404",
+			path:    "/missing",
+			wantErr: true,
+		},
+		{
+			name:    "異常系: タイムアウト",
+			path:    "/slow",
+			wantErr: true,
+		},
+	}
+
+	f := newTestFetcher(1024)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := f.Fetch(context.Background(), srv.URL+tt.path)
+
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+				return
+			}
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			tt.want.URL = srv.URL + tt.path
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFetchBlocksPrivateAddress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<title>内部</title>`))
+	}))
+	defer srv.Close()
+
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://127.0.0.1:1/", http.StatusFound)
+	}))
+	defer redirect.Close()
+
+	f := NewFetcher(2*time.Second, 1024)
+
+	t.Run("異常系: ループバックへの接続を拒否する", func(t *testing.T) {
+		_, err := f.Fetch(context.Background(), srv.URL)
+		assert.ErrorIs(t, err, ErrBlockedAddress)
+	})
+
+	t.Run("異常系: リダイレクト先も検査する", func(t *testing.T) {
+		allowOnce := newTestFetcher(1024)
+		first := true
+		allowOnce.isBlocked = func(ip net.IP) bool {
+			if first {
+				first = false
+				return false
+			}
+			return isPrivateIP(ip)
+		}
+		_, err := allowOnce.Fetch(context.Background(), redirect.URL)
+		assert.ErrorIs(t, err, ErrBlockedAddress)
+	})
+
+	t.Run("異常系: http以外のスキーム", func(t *testing.T) {
+		_, err := f.Fetch(context.Background(), "file:///etc/passwd")
+		assert.ErrorIs(t, err, ErrUnsupportedURL)
+	})
+}
+
+func TestIsPrivateIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"127.0.0.1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"100.64.0.1", true},
+		{"0.0.0.0", true},
+		{"::1", true},
+		{"fc00::1", true},
+		{"fe80::1", true},
+		{"93.184.216.34", false},
+		{"2606:2800:220:1::1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			assert.Equal(t, tt.want, isPrivateIP(net.ParseIP(tt.ip)))
+		})
+	}
+}
+
+func TestFirstURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"URLなし", "こんにちは", ""},
+		{"末尾の句読点を除く", "見て https://example.com/a?b=1.", "https://example.com/a?b=1"},
+		{"日本語が続いても区切る", "https://example.com/pathを見て", "https://example.com/path"},
+		{"最初のURLのみ", "(http://a.example) と https://b.example", "http://a.example"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FirstURL(tt.content))
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"aita/internal/dto"
+	"aita/internal/models"
+	"context"
+	"time"
+)
+
+type AnalyticsStore interface {
+	RollupDailyStats(ctx context.Context, from, to time.Time) (int64, error)
+	GetDailyStats(ctx context.Context, userID int64, from, to time.Time) ([]*models.DailyStats, error)
+}
+
+type analyticsRepository struct {
+	analyticsStore AnalyticsStore
+}
+
+func NewAnalyticsRepository(as AnalyticsStore) *analyticsRepository {
+	return &analyticsRepository{analyticsStore: as}
+}
+
+func (r *analyticsRepository) Rollup(ctx context.Context, from, to time.Time) (int64, error) {
+	return r.analyticsStore.RollupDailyStats(ctx, from, to)
+}
+
+func (r *analyticsRepository) GetDailyStats(ctx context.Context, userID int64, from, to time.Time) ([]*dto.DailyStatsRecord, error) {
+	stats, err := r.analyticsStore.GetDailyStats(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*dto.DailyStatsRecord, 0, len(stats))
+	for _, s := range stats {
+		records = append(records, dto.NewDailyStatsRecord(s))
+	}
+	return records, nil
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"aita/internal/dto"
+	"aita/internal/models"
+	sf "aita/internal/pkg/singleflight"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/panjf2000/ants/v2"
+	"golang.org/x/sync/singleflight"
+)
+
+type BookmarkStore interface {
+	CreateBookmark(ctx context.Context, userID, tweetID int64) (*models.Bookmark, error)
+	DeleteBookmark(ctx context.Context, userID, tweetID int64) error
+	GetBookmarksByUser(ctx context.Context, userID int64) ([]*models.Bookmark, error)
+	DeleteBookmarksByTweet(ctx context.Context, tweetID int64) ([]int64, error)
+}
+
+type BookmarkCache interface {
+	Add(ctx context.Context, userID, tweetID int64, score float64) error
+	AddBookmarks(ctx context.Context, userID int64, sets []*models.CacheMember) error
+	Range(ctx context.Context, userID int64, before int64, limit int) ([]*models.CacheMember, error)
+	Remove(ctx context.Context, tweetID int64, userIDs ...int64) error
+	Invalidate(ctx context.Context, userID int64) error
+}
+
+type bookmarkRepository struct {
+	bookmarkStore BookmarkStore
+	bookmarkCache BookmarkCache
+	sfBookmark    *singleflight.Group
+	pool          *ants.Pool
+}
+
+func NewBookmarkRepository(bs BookmarkStore, bc BookmarkCache, p *ants.Pool) *bookmarkRepository {
+	return &bookmarkRepository{
+		bookmarkStore: bs,
+		bookmarkCache: bc,
+		sfBookmark:    &singleflight.Group{},
+		pool:          p,
+	}
+}
+
+func (r *bookmarkRepository) Create(ctx context.Context, userID, tweetID int64) (*dto.BookmarkRecord, error) {
+	bookmark, err := r.bookmarkStore.CreateBookmark(ctx, userID, tweetID)
+	if err != nil {
+		return nil, err
+	}
+
+	taskData := bookmark
+	err = r.pool.Submit(func() {
+		bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if innerErr := r.bookmarkCache.Add(bgCtx, userID, tweetID, float64(taskData.ID)); innerErr != nil {
+			_ = r.bookmarkCache.Invalidate(bgCtx, userID)
+		}
+	})
+	if err != nil {
+		slog.Warn("ants pool へのタスク投入に失敗しました。同期的なブックマークキャッシュ破棄を実行します。", "err", err)
+		_ = r.bookmarkCache.Invalidate(context.Background(), userID)
+	}
+
+	return dto.NewBookmarkRecord(bookmark), nil
+}
+
+func (r *bookmarkRepository) Delete(ctx context.Context, userID, tweetID int64) error {
+	if err := r.bookmarkStore.DeleteBookmark(ctx, userID, tweetID); err != nil {
+		return err
+	}
+
+	if err := r.bookmarkCache.Remove(ctx, tweetID, userID); err != nil {
+		_ = r.bookmarkCache.Invalidate(ctx, userID)
+	}
+	return nil
+}
+
+// cursor はブックマークID。cursor より古いものを新しい順に最大 limit 件返す
+func (r *bookmarkRepository) List(ctx context.Context, userID, cursor int64, limit int) ([]*dto.BookmarkRecord, error) {
+	members, err := r.bookmarkCache.Range(ctx, userID, cursor, limit)
+	if err == nil {
+		records := make([]*dto.BookmarkRecord, 0, len(members))
+		for _, m := range members {
+			records = append(records, &dto.BookmarkRecord{ID: int64(m.Score), UserID: userID, TweetID: m.Member})
+		}
+		return records, nil
+	}
+
+	sfKey := fmt.Sprintf("bookmarks:%d", userID)
+	bookmarks, dberr := sf.GetDataWithSF(ctx, r.sfBookmark, sfKey, func(innerCtx context.Context) ([]*models.Bookmark, error) {
+		return r.bookmarkStore.GetBookmarksByUser(innerCtx, userID)
+	})
+	if dberr != nil {
+		return nil, dberr
+	}
+
+	cacheMembers := make([]*models.CacheMember, len(bookmarks))
+	records := make([]*dto.BookmarkRecord, 0, limit)
+	for i, b := range bookmarks {
+		cacheMembers[i] = &models.CacheMember{Member: b.TweetID, Score: float64(b.ID)}
+		if (cursor <= 0 || b.ID < cursor) && len(records) < limit {
+			records = append(records, dto.NewBookmarkRecord(b))
+		}
+	}
+
+	tasks := cacheMembers
+	err = r.pool.Submit(func() {
+		bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_ = r.bookmarkCache.AddBookmarks(bgCtx, userID, tasks)
+	})
+	if err != nil {
+		slog.Warn("ブックマーク一覧のバックフィル投入に失敗しました", "user_id", userID, "err", err)
+	}
+
+	return records, nil
+}
+
+// ツイート削除時に呼ぶ。キャッシュからの除去に失敗した場合は該当ユーザーの一覧を破棄する
+func (r *bookmarkRepository) DeleteByTweet(ctx context.Context, tweetID int64) error {
+	userIDs, err := r.bookmarkStore.DeleteBookmarksByTweet(ctx, tweetID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.bookmarkCache.Remove(ctx, tweetID, userIDs...); err != nil {
+		for _, userID := range userIDs {
+			_ = r.bookmarkCache.Invalidate(ctx, userID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"aita/internal/dto"
+	"aita/internal/models"
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+type DMStore interface {
+	CreateConversation(ctx context.Context, conv *models.DMConversation, participantIDs []int64) (*models.DMConversation, error)
+	GetConversationsByUser(ctx context.Context, userID int64, limit int) ([]*models.DMConversation, error)
+	GetConversation(ctx context.Context, conversationID, userID int64) (*models.DMConversation, error)
+	GetParticipants(ctx context.Context, conversationIDs []int64) ([]*models.DMParticipant, error)
+	IsParticipant(ctx context.Context, conversationID, userID int64) (bool, error)
+	CreateMessage(ctx context.Context, msg *models.DMMessage) (*models.DMMessage, error)
+	GetMessages(ctx context.Context, conversationID, before int64, limit int) ([]*models.DMMessage, error)
+	UpdateReadCursor(ctx context.Context, conversationID, userID, cursor int64) (int64, error)
+	CountUnread(ctx context.Context, userID int64) ([]*models.DMUnreadCount, error)
+	GetDeniedRecipients(ctx context.Context, senderID int64, recipientIDs []int64) ([]int64, error)
+	UpdateDMPolicy(ctx context.Context, userID int64, policy string) error
+}
+
+type DMCache interface {
+	GetUnread(ctx context.Context, userID int64) (map[int64]int64, error)
+	SetUnread(ctx context.Context, userID int64, counts map[int64]int64) error
+	IncrUnread(ctx context.Context, conversationID int64, userIDs ...int64) error
+	InvalidateUnread(ctx context.Context, userIDs ...int64) error
+}
+
+type dmRepository struct {
+	dmStore DMStore
+	dmCache DMCache
+}
+
+func NewDMRepository(ds DMStore, dc DMCache) *dmRepository {
+	return &dmRepository{
+		dmStore: ds,
+		dmCache: dc,
+	}
+}
+
+// participantIDs は作成者を含む。参加者が2人なら1対1の会話として、同じ2人の会話があればそれを返す
+func (r *dmRepository) CreateConversation(ctx context.Context, creatorID int64, participantIDs []int64) (*dto.DMConversationRecord, error) {
+	conv := &models.DMConversation{
+		IsGroup:   len(participantIDs) > 2,
+		CreatedBy: creatorID,
+	}
+	if !conv.IsGroup {
+		key := directKey(participantIDs[0], participantIDs[1])
+		conv.DirectKey = &key
+	}
+
+	created, err := r.dmStore.CreateConversation(ctx, conv, participantIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := r.withParticipants(ctx, []*models.DMConversation{created})
+	if err != nil {
+		return nil, err
+	}
+	return records[0], nil
+}
+
+func directKey(a, b int64) string {
+	return fmt.Sprintf("%d:%d", min(a, b), max(a, b))
+}
+
+func (r *dmRepository) ListConversations(ctx context.Context, userID int64, limit int) ([]*dto.DMConversationRecord, error) {
+	conversations, err := r.dmStore.GetConversationsByUser(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.withParticipants(ctx, conversations)
+}
+
+func (r *dmRepository) GetConversation(ctx context.Context, conversationID, userID int64) (*dto.DMConversationRecord, error) {
+	conv, err := r.dmStore.GetConversation(ctx, conversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := r.withParticipants(ctx, []*models.DMConversation{conv})
+	if err != nil {
+		return nil, err
+	}
+	return records[0], nil
+}
+
+func (r *dmRepository) withParticipants(ctx context.Context, conversations []*models.DMConversation) ([]*dto.DMConversationRecord, error) {
+	records := make([]*dto.DMConversationRecord, len(conversations))
+	byID := make(map[int64]*dto.DMConversationRecord, len(conversations))
+	ids := make([]int64, len(conversations))
+	for i, c := range conversations {
+		records[i] = dto.NewDMConversationRecord(c)
+		byID[c.ID] = records[i]
+		ids[i] = c.ID
+	}
+
+	participants, err := r.dmStore.GetParticipants(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range participants {
+		if rec, ok := byID[p.ConversationID]; ok {
+			rec.Participants = append(rec.Participants, dto.NewDMParticipantRecord(p))
+		}
+	}
+	return records, nil
+}
+
+func (r *dmRepository) IsParticipant(ctx context.Context, conversationID, userID int64) (bool, error) {
+	return r.dmStore.IsParticipant(ctx, conversationID, userID)
+}
+
+// recipientIDs の未読数を加算する。加算に失敗した場合は破棄して数え直させる
+func (r *dmRepository) SendMessage(ctx context.Context, record *dto.DMMessageRecord, recipientIDs []int64) (*dto.DMMessageRecord, error) {
+	msg, err := r.dmStore.CreateMessage(ctx, record.ToModel())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.dmCache.IncrUnread(ctx, msg.ConversationID, recipientIDs...); err != nil {
+		_ = r.dmCache.InvalidateUnread(ctx, recipientIDs...)
+	}
+	return dto.NewDMMessageRecord(msg), nil
+}
+
+func (r *dmRepository) ListMessages(ctx context.Context, conversationID, cursor int64, limit int) ([]*dto.DMMessageRecord, error) {
+	messages, err := r.dmStore.GetMessages(ctx, conversationID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*dto.DMMessageRecord, len(messages))
+	for i, m := range messages {
+		records[i] = dto.NewDMMessageRecord(m)
+	}
+	return records, nil
+}
+
+func (r *dmRepository) MarkRead(ctx context.Context, conversationID, userID, cursor int64) (int64, error) {
+	readID, err := r.dmStore.UpdateReadCursor(ctx, conversationID, userID, cursor)
+	if err != nil {
+		return 0, err
+	}
+
+	_ = r.dmCache.InvalidateUnread(ctx, userID)
+	return readID, nil
+}
+
+// 会話IDごとの未読数。未読のない会話は含まない
+func (r *dmRepository) UnreadCounts(ctx context.Context, userID int64) (map[int64]int64, error) {
+	counts, err := r.dmCache.GetUnread(ctx, userID)
+	if err == nil {
+		return counts, nil
+	}
+
+	unread, err := r.dmStore.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts = make(map[int64]int64, len(unread))
+	for _, u := range unread {
+		counts[u.ConversationID] = u.Count
+	}
+	if err := r.dmCache.SetUnread(ctx, userID, counts); err != nil {
+		slog.Warn("DM の未読数のキャッシュ保存に失敗しました", "user_id", userID, "err", err)
+	}
+	return counts, nil
+}
+
+func (r *dmRepository) GetDeniedRecipients(ctx context.Context, senderID int64, recipientIDs []int64) ([]int64, error) {
+	return r.dmStore.GetDeniedRecipients(ctx, senderID, recipientIDs)
+}
+
+func (r *dmRepository) UpdateDMPolicy(ctx context.Context, userID int64, policy string) error {
+	return r.dmStore.UpdateDMPolicy(ctx, userID, policy)
+}
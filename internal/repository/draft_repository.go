@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"aita/internal/dto"
+	"aita/internal/models"
+	"context"
+)
+
+type DraftStore interface {
+	CreateDraft(ctx context.Context, draft *models.Draft) (*models.Draft, error)
+	GetDraft(ctx context.Context, draftID, userID int64) (*models.Draft, error)
+	GetDraftsByUser(ctx context.Context, userID int64) ([]*models.Draft, error)
+	UpdateDraft(ctx context.Context, draft *models.Draft) (*models.Draft, error)
+	DeleteDraft(ctx context.Context, draftID, userID int64) (*models.Draft, error)
+}
+
+type draftRepository struct {
+	draftStore DraftStore
+}
+
+func NewDraftRepository(ds DraftStore) *draftRepository {
+	return &draftRepository{draftStore: ds}
+}
+
+func (r *draftRepository) Create(ctx context.Context, record *dto.DraftRecord) (*dto.DraftRecord, error) {
+	draft, err := r.draftStore.CreateDraft(ctx, record.ToModel())
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewDraftRecord(draft), nil
+}
+
+func (r *draftRepository) Get(ctx context.Context, draftID, userID int64) (*dto.DraftRecord, error) {
+	draft, err := r.draftStore.GetDraft(ctx, draftID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewDraftRecord(draft), nil
+}
+
+func (r *draftRepository) List(ctx context.Context, userID int64) ([]*dto.DraftRecord, error) {
+	drafts, err := r.draftStore.GetDraftsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*dto.DraftRecord, len(drafts))
+	for i, d := range drafts {
+		records[i] = dto.NewDraftRecord(d)
+	}
+
+	return records, nil
+}
+
+func (r *draftRepository) Update(ctx context.Context, record *dto.DraftRecord) (*dto.DraftRecord, error) {
+	draft, err := r.draftStore.UpdateDraft(ctx, record.ToModel())
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewDraftRecord(draft), nil
+}
+
+func (r *draftRepository) Delete(ctx context.Context, draftID, userID int64) (*dto.DraftRecord, error) {
+	draft, err := r.draftStore.DeleteDraft(ctx, draftID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewDraftRecord(draft), nil
+}
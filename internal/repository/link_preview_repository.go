@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"aita/internal/dto"
+	"aita/internal/models"
+	"aita/internal/pkg/utils"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// 取得に失敗した URL は短い期間だけ覚えておき、再試行を抑える
+const failedPreviewTTL = 1 * time.Hour
+
+type LinkPreviewCache interface {
+	Set(ctx context.Context, preview *models.LinkPreview, ttl time.Duration) error
+	Exists(ctx context.Context, url string) (bool, error)
+	MultiGet(ctx context.Context, urls []string) (map[string]*models.LinkPreview, error)
+}
+
+type linkPreviewRepository struct {
+	previewCache LinkPreviewCache
+}
+
+func NewLinkPreviewRepository(lc LinkPreviewCache) *linkPreviewRepository {
+	return &linkPreviewRepository{previewCache: lc}
+}
+
+func (r *linkPreviewRepository) Exists(ctx context.Context, url string) (bool, error) {
+	return r.previewCache.Exists(ctx, url)
+}
+
+func (r *linkPreviewRepository) Save(ctx context.Context, record *dto.LinkPreviewRecord) error {
+	return r.previewCache.Set(ctx, record.ToModel(), utils.GetRandomExpiration(24*time.Hour, 1*time.Hour))
+}
+
+func (r *linkPreviewRepository) SaveFailed(ctx context.Context, url string) error {
+	return r.previewCache.Set(ctx, &models.LinkPreview{URL: url, FetchedAt: time.Now().UTC()}, failedPreviewTTL)
+}
+
+// 本文中の最初の URL のプレビューをキャッシュから載せる。取得できなくてもツイートの表示は妨げない
+func attachLinkPreviews(ctx context.Context, cache LinkPreviewCache, tweets ...*dto.TweetRecord) {
+	urls := make([]string, 0, len(tweets))
+	for _, t := range tweets {
+		if u := t.FirstURL(); u != "" {
+			urls = append(urls, u)
+		}
+	}
+	if len(urls) == 0 {
+		return
+	}
+
+	previews, err := cache.MultiGet(ctx, urls)
+	if err != nil {
+		slog.Warn("リンクプレビューの取得をスキップしました", "count", len(urls), "err", err)
+		return
+	}
+
+	for _, t := range tweets {
+		if p, ok := previews[t.FirstURL()]; ok && !p.IsEmpty() {
+			t.LinkPreview = dto.NewLinkPreviewRecord(p)
+		}
+	}
+}
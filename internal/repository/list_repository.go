@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"aita/internal/dto"
+	"aita/internal/models"
+	sf "aita/internal/pkg/singleflight"
+	"context"
+	"fmt"
+	"log/slog"
+
+	"golang.org/x/sync/singleflight"
+)
+
+type ListStore interface {
+	CreateList(ctx context.Context, list *models.List) (*models.List, error)
+	GetList(ctx context.Context, listID int64) (*models.List, error)
+	GetListsByOwner(ctx context.Context, ownerID int64) ([]*models.List, error)
+	DeleteList(ctx context.Context, listID, ownerID int64) error
+	AddMember(ctx context.Context, listID, userID int64, maxMembers int) error
+	RemoveMember(ctx context.Context, listID, userID int64) error
+	GetMemberIDs(ctx context.Context, listID int64) ([]int64, error)
+}
+
+type ListCache interface {
+	GetMembers(ctx context.Context, listID int64) ([]int64, error)
+	SetMembers(ctx context.Context, listID int64, userIDs []int64) error
+	AddMember(ctx context.Context, listID, userID int64) error
+	RemoveMember(ctx context.Context, listID, userID int64) error
+	Invalidate(ctx context.Context, listID int64) error
+}
+
+type listRepository struct {
+	listStore ListStore
+	listCache ListCache
+	sfMembers *singleflight.Group
+}
+
+func NewListRepository(ls ListStore, lc ListCache) *listRepository {
+	return &listRepository{
+		listStore: ls,
+		listCache: lc,
+		sfMembers: &singleflight.Group{},
+	}
+}
+
+func (r *listRepository) Create(ctx context.Context, record *dto.ListRecord) (*dto.ListRecord, error) {
+	list, err := r.listStore.CreateList(ctx, record.ToModel())
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewListRecord(list), nil
+}
+
+func (r *listRepository) Get(ctx context.Context, listID int64) (*dto.ListRecord, error) {
+	list, err := r.listStore.GetList(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewListRecord(list), nil
+}
+
+func (r *listRepository) ListByOwner(ctx context.Context, ownerID int64) ([]*dto.ListRecord, error) {
+	lists, err := r.listStore.GetListsByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*dto.ListRecord, len(lists))
+	for i, l := range lists {
+		records[i] = dto.NewListRecord(l)
+	}
+	return records, nil
+}
+
+func (r *listRepository) Delete(ctx context.Context, listID, ownerID int64) error {
+	if err := r.listStore.DeleteList(ctx, listID, ownerID); err != nil {
+		return err
+	}
+
+	_ = r.listCache.Invalidate(ctx, listID)
+	return nil
+}
+
+// キャッシュの更新に失敗した場合は破棄して作り直させる
+func (r *listRepository) AddMember(ctx context.Context, listID, userID int64, maxMembers int) error {
+	if err := r.listStore.AddMember(ctx, listID, userID, maxMembers); err != nil {
+		return err
+	}
+
+	if err := r.listCache.AddMember(ctx, listID, userID); err != nil {
+		_ = r.listCache.Invalidate(ctx, listID)
+	}
+	return nil
+}
+
+func (r *listRepository) RemoveMember(ctx context.Context, listID, userID int64) error {
+	if err := r.listStore.RemoveMember(ctx, listID, userID); err != nil {
+		return err
+	}
+
+	if err := r.listCache.RemoveMember(ctx, listID, userID); err != nil {
+		_ = r.listCache.Invalidate(ctx, listID)
+	}
+	return nil
+}
+
+// キャッシュから返す場合は順序を保証しない
+func (r *listRepository) MemberIDs(ctx context.Context, listID int64) ([]int64, error) {
+	ids, err := r.listCache.GetMembers(ctx, listID)
+	if err == nil {
+		return ids, nil
+	}
+
+	sfKey := fmt.Sprintf("list_members:%d", listID)
+	ids, err = sf.GetDataWithSF(ctx, r.sfMembers, sfKey, func(innerCtx context.Context) ([]int64, error) {
+		return r.listStore.GetMemberIDs(innerCtx, listID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.listCache.SetMembers(ctx, listID, ids); err != nil {
+		slog.Warn("リストメンバーのキャッシュ保存に失敗しました", "list_id", listID, "err", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"aita/internal/dto"
+	"aita/internal/models"
+	"context"
+	"time"
+)
+
+type MediaStore interface {
+	CreateMedia(ctx context.Context, media *models.Media) (*models.Media, error)
+	PurgeOrphanedMedia(ctx context.Context, olderThan time.Duration, limit int) ([]*models.Media, error)
+}
+
+type mediaRepository struct {
+	mediaStore MediaStore
+}
+
+func NewMediaRepository(ms MediaStore) *mediaRepository {
+	return &mediaRepository{mediaStore: ms}
+}
+
+func (r *mediaRepository) Create(ctx context.Context, record *dto.MediaRecord) (*dto.MediaRecord, error) {
+	media, err := r.mediaStore.CreateMedia(ctx, record.ToModel())
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewMediaRecord(media), nil
+}
+
+func (r *mediaRepository) PurgeOrphans(ctx context.Context, olderThan time.Duration, limit int) ([]*dto.MediaRecord, error) {
+	purged, err := r.mediaStore.PurgeOrphanedMedia(ctx, olderThan, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*dto.MediaRecord, 0, len(purged))
+	for _, m := range purged {
+		records = append(records, dto.NewMediaRecord(m))
+	}
+	return records, nil
+}
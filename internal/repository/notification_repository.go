@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"aita/internal/dto"
+	"aita/internal/models"
+	"context"
+	"log/slog"
+)
+
+type NotificationStore interface {
+	CreateNotifications(ctx context.Context, notifications []*models.Notification) ([]int64, error)
+	GetNotifications(ctx context.Context, userID, before int64, limit int) ([]*models.Notification, error)
+	GetReadCursor(ctx context.Context, userID int64) (int64, error)
+	UpdateReadCursor(ctx context.Context, userID, cursor int64) error
+	CountUnread(ctx context.Context, userID int64) (int64, error)
+}
+
+type NotificationCache interface {
+	GetUnread(ctx context.Context, userID int64) (int64, error)
+	SetUnread(ctx context.Context, userID, count int64) error
+	IncrUnread(ctx context.Context, userIDs ...int64) error
+	InvalidateUnread(ctx context.Context, userIDs ...int64) error
+}
+
+type notificationRepository struct {
+	notificationStore NotificationStore
+	notificationCache NotificationCache
+}
+
+func NewNotificationRepository(ns NotificationStore, nc NotificationCache) *notificationRepository {
+	return &notificationRepository{
+		notificationStore: ns,
+		notificationCache: nc,
+	}
+}
+
+// 実際に作成された通知の受信者のみ未読数を加算し、その受信者IDを返す。加算に失敗した場合は破棄して数え直させる
+func (r *notificationRepository) Create(ctx context.Context, records []*dto.NotificationRecord) ([]int64, error) {
+	notifications := make([]*models.Notification, len(records))
+	for i, rec := range records {
+		notifications[i] = rec.ToModel()
+	}
+
+	recipientIDs, err := r.notificationStore.CreateNotifications(ctx, notifications)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.notificationCache.IncrUnread(ctx, recipientIDs...); err != nil {
+		_ = r.notificationCache.InvalidateUnread(ctx, recipientIDs...)
+	}
+	return recipientIDs, nil
+}
+
+func (r *notificationRepository) List(ctx context.Context, userID, cursor int64, limit int) ([]*dto.NotificationRecord, error) {
+	notifications, err := r.notificationStore.GetNotifications(ctx, userID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*dto.NotificationRecord, len(notifications))
+	for i, n := range notifications {
+		records[i] = dto.NewNotificationRecord(n)
+	}
+	return records, nil
+}
+
+func (r *notificationRepository) GetReadCursor(ctx context.Context, userID int64) (int64, error) {
+	return r.notificationStore.GetReadCursor(ctx, userID)
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, userID, cursor int64) error {
+	if err := r.notificationStore.UpdateReadCursor(ctx, userID, cursor); err != nil {
+		return err
+	}
+
+	_ = r.notificationCache.InvalidateUnread(ctx, userID)
+	return nil
+}
+
+func (r *notificationRepository) UnreadCount(ctx context.Context, userID int64) (int64, error) {
+	count, err := r.notificationCache.GetUnread(ctx, userID)
+	if err == nil {
+		return count, nil
+	}
+
+	count, err = r.notificationStore.CountUnread(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := r.notificationCache.SetUnread(ctx, userID, count); err != nil {
+		slog.Warn("未読数のキャッシュ保存に失敗しました", "user_id", userID, "err", err)
+	}
+	return count, nil
+}
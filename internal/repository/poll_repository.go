@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"aita/internal/dto"
+	"aita/internal/models"
+	"context"
+	"log/slog"
+)
+
+type PollStore interface {
+	GetPollByTweetID(ctx context.Context, tweetID int64) (*models.Poll, error)
+	CreateVote(ctx context.Context, pollID, optionID, userID int64) error
+	GetVotedOptionID(ctx context.Context, pollID, userID int64) (int64, bool, error)
+	CountVotes(ctx context.Context, pollID int64) (map[int64]int64, error)
+	UpdateVoteCounts(ctx context.Context, pollID int64, counts map[int64]int64) error
+	GetExpiredPollIDs(ctx context.Context, limit int) ([]int64, error)
+	ClosePoll(ctx context.Context, pollID int64) error
+}
+
+type PollCache interface {
+	GetTally(ctx context.Context, pollID int64) (map[int64]int64, error)
+	SetTally(ctx context.Context, pollID int64, counts map[int64]int64) error
+	IncrTally(ctx context.Context, pollID, optionID int64) error
+	DeleteTally(ctx context.Context, pollID int64) error
+	PopDirty(ctx context.Context, limit int) ([]int64, error)
+	MarkDirty(ctx context.Context, pollIDs ...int64) error
+}
+
+type pollRepository struct {
+	pollStore PollStore
+	pollCache PollCache
+}
+
+func NewPollRepository(ps PollStore, pc PollCache) *pollRepository {
+	return &pollRepository{
+		pollStore: ps,
+		pollCache: pc,
+	}
+}
+
+func (r *pollRepository) GetByTweetID(ctx context.Context, tweetID int64) (*dto.PollRecord, error) {
+	poll, err := r.pollStore.GetPollByTweetID(ctx, tweetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewPollRecord(poll), nil
+}
+
+// 集計ハッシュへの加算に失敗した場合は破棄し、次の読み込みで DB から作り直す
+func (r *pollRepository) Vote(ctx context.Context, pollID, optionID, userID int64) error {
+	if err := r.pollStore.CreateVote(ctx, pollID, optionID, userID); err != nil {
+		return err
+	}
+
+	if err := r.pollCache.IncrTally(ctx, pollID, optionID); err != nil {
+		_ = r.pollCache.DeleteTally(ctx, pollID)
+	}
+	return nil
+}
+
+func (r *pollRepository) GetVotedOptionID(ctx context.Context, pollID, userID int64) (int64, bool, error) {
+	return r.pollStore.GetVotedOptionID(ctx, pollID, userID)
+}
+
+// 受付中の投票の集計。キャッシュになければ poll_votes から数え直して載せる
+func (r *pollRepository) GetTally(ctx context.Context, pollID int64) (map[int64]int64, error) {
+	tally, err := r.pollCache.GetTally(ctx, pollID)
+	if err == nil {
+		return tally, nil
+	}
+
+	tally, err = r.pollStore.CountVotes(ctx, pollID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.pollCache.SetTally(ctx, pollID, tally); err != nil {
+		slog.Warn("投票集計のキャッシュ保存に失敗しました", "poll_id", pollID, "err", err)
+	}
+	return tally, nil
+}
+
+// 永続化待ちの集計を最大 limit 件 DB に書き戻し、処理した件数を返す。
+// 失敗した投票は次回に回す
+func (r *pollRepository) PersistTallies(ctx context.Context, limit int) (int, error) {
+	pollIDs, err := r.pollCache.PopDirty(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	failed := make([]int64, 0)
+	for _, pollID := range pollIDs {
+		tally, err := r.pollCache.GetTally(ctx, pollID)
+		if err != nil {
+			// 集計が期限切れなら締切時に数え直すため何もしない
+			continue
+		}
+
+		if err := r.pollStore.UpdateVoteCounts(ctx, pollID, tally); err != nil {
+			slog.Error("投票集計の永続化に失敗しました", "poll_id", pollID, "err", err)
+			failed = append(failed, pollID)
+		}
+	}
+
+	if len(failed) > 0 {
+		_ = r.pollCache.MarkDirty(ctx, failed...)
+	}
+	return len(pollIDs), nil
+}
+
+func (r *pollRepository) GetExpiredIDs(ctx context.Context, limit int) ([]int64, error) {
+	return r.pollStore.GetExpiredPollIDs(ctx, limit)
+}
+
+// 確定後の得票数は DB を正とするため集計ハッシュは破棄する
+func (r *pollRepository) Close(ctx context.Context, pollID int64) error {
+	if err := r.pollStore.ClosePoll(ctx, pollID); err != nil {
+		return err
+	}
+
+	_ = r.pollCache.DeleteTally(ctx, pollID)
+	return nil
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type RateLimitCache interface {
+	Allow(ctx context.Context, key string, limit int64, window time.Duration) (bool, error)
+}
+
+type rateLimitRepository struct {
+	rateLimitCache RateLimitCache
+}
+
+func NewRateLimitRepository(rc RateLimitCache) *rateLimitRepository {
+	return &rateLimitRepository{rateLimitCache: rc}
+}
+
+func (r *rateLimitRepository) Allow(ctx context.Context, key string, limit int64, window time.Duration) (bool, error) {
+	return r.rateLimitCache.Allow(ctx, key, limit, window)
+}
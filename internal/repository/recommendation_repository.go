@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"aita/internal/dto"
+	"aita/internal/models"
+	sf "aita/internal/pkg/singleflight"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/panjf2000/ants/v2"
+	"golang.org/x/sync/singleflight"
+)
+
+type RecommendationStore interface {
+	GetFriendsOfFriends(ctx context.Context, userID int64, limit int) ([]*models.Recommendation, error)
+	GetPopularUsers(ctx context.Context, limit int) ([]*models.Recommendation, error)
+	GetActiveUserIDs(ctx context.Context, afterID int64, limit int) ([]int64, error)
+}
+
+type RecommendationCache interface {
+	SetForUser(ctx context.Context, userID int64, members []*models.CacheMember) error
+	GetForUser(ctx context.Context, userID int64, limit int) ([]*models.CacheMember, error)
+	SetPopular(ctx context.Context, members []*models.CacheMember) error
+	GetPopular(ctx context.Context, limit int) ([]*models.CacheMember, error)
+}
+
+type recommendationRepository struct {
+	recommendationStore RecommendationStore
+	recommendationCache RecommendationCache
+	sfRecommend         *singleflight.Group
+	pool                *ants.Pool
+}
+
+func NewRecommendationRepository(rs RecommendationStore, rc RecommendationCache, p *ants.Pool) *recommendationRepository {
+	return &recommendationRepository{
+		recommendationStore: rs,
+		recommendationCache: rc,
+		sfRecommend:         &singleflight.Group{},
+		pool:                p,
+	}
+}
+
+func toCacheMembers(recommendations []*models.Recommendation) []*models.CacheMember {
+	members := make([]*models.CacheMember, len(recommendations))
+	for i, r := range recommendations {
+		members[i] = &models.CacheMember{
+			Member: r.UserID,
+			Score:  float64(r.Score),
+		}
+	}
+	return members
+}
+
+func toRecommendationRecords(members []*models.CacheMember) []*dto.RecommendationRecord {
+	records := make([]*dto.RecommendationRecord, len(members))
+	for i, m := range members {
+		records[i] = dto.NewRecommendationRecord(m)
+	}
+	return records
+}
+
+// ワーカーが事前計算した結果のみを返す。未計算の場合は空リスト
+func (r *recommendationRepository) GetForUser(ctx context.Context, userID int64, limit int) ([]*dto.RecommendationRecord, error) {
+	members, err := r.recommendationCache.GetForUser(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRecommendationRecords(members), nil
+}
+
+func (r *recommendationRepository) GetPopular(ctx context.Context, limit int) ([]*dto.RecommendationRecord, error) {
+	members, err := r.recommendationCache.GetPopular(ctx, limit)
+	if err == nil && len(members) > 0 {
+		return toRecommendationRecords(members), nil
+	}
+
+	sfKey := fmt.Sprintf("popular:%d", limit)
+	popular, dberr := sf.GetDataWithSF(ctx, r.sfRecommend, sfKey, func(innerCtx context.Context) ([]*models.Recommendation, error) {
+		return r.recommendationStore.GetPopularUsers(innerCtx, limit)
+	})
+	if dberr != nil {
+		return nil, dberr
+	}
+
+	members = toCacheMembers(popular)
+	tasks := members
+	err = r.pool.Submit(func() {
+		bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_ = r.recommendationCache.SetPopular(bgCtx, tasks)
+	})
+	if err != nil {
+		slog.Warn("ants pool へのタスク投入に失敗しました。人気ユーザーのバックフィルをスキップします。", "err", err)
+	}
+
+	return toRecommendationRecords(members), nil
+}
+
+func (r *recommendationRepository) RebuildForUser(ctx context.Context, userID int64, size int) error {
+	recommendations, err := r.recommendationStore.GetFriendsOfFriends(ctx, userID, size)
+	if err != nil {
+		return err
+	}
+
+	return r.recommendationCache.SetForUser(ctx, userID, toCacheMembers(recommendations))
+}
+
+func (r *recommendationRepository) RebuildPopular(ctx context.Context, size int) error {
+	popular, err := r.recommendationStore.GetPopularUsers(ctx, size)
+	if err != nil {
+		return err
+	}
+
+	return r.recommendationCache.SetPopular(ctx, toCacheMembers(popular))
+}
+
+func (r *recommendationRepository) ListActiveUserIDs(ctx context.Context, afterID int64, limit int) ([]int64, error) {
+	return r.recommendationStore.GetActiveUserIDs(ctx, afterID, limit)
+}
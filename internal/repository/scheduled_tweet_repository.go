@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+type ScheduledTweetStore interface {
+	CreateScheduledTweet(ctx context.Context, st *models.ScheduledTweet) (*models.ScheduledTweet, error)
+	GetPendingByUser(ctx context.Context, userID int64) ([]*models.ScheduledTweet, error)
+	GetPendingSchedules(ctx context.Context, afterID int64, limit int) ([]*models.ScheduledTweet, error)
+	CancelScheduledTweet(ctx context.Context, scheduledID, userID int64) error
+	PublishScheduledTweet(ctx context.Context, scheduledID int64) (*models.Tweet, error)
+}
+
+type ScheduleCache interface {
+	Add(ctx context.Context, scheduledID int64, publishAt time.Time) error
+	AddBatch(ctx context.Context, members []*models.CacheMember) error
+	Remove(ctx context.Context, scheduledID int64) error
+	GetDue(ctx context.Context, now time.Time, limit int) ([]int64, error)
+}
+
+type scheduledTweetRepository struct {
+	scheduledTweetStore ScheduledTweetStore
+	scheduleCache       ScheduleCache
+}
+
+func NewScheduledTweetRepository(ss ScheduledTweetStore, sc ScheduleCache) *scheduledTweetRepository {
+	return &scheduledTweetRepository{
+		scheduledTweetStore: ss,
+		scheduleCache:       sc,
+	}
+}
+
+// ZSET への登録に失敗しても Postgres が正であり、定期的な再同期で回復する
+func (r *scheduledTweetRepository) Create(ctx context.Context, record *dto.ScheduledTweetRecord) (*dto.ScheduledTweetRecord, error) {
+	created, err := r.scheduledTweetStore.CreateScheduledTweet(ctx, record.ToModel())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.scheduleCache.Add(ctx, created.ID, created.PublishAt); err != nil {
+		slog.Warn("予約投稿のスケジュール登録に失敗しました。再同期で補完します", "scheduled_id", created.ID, "err", err)
+	}
+
+	return dto.NewScheduledTweetRecord(created), nil
+}
+
+func (r *scheduledTweetRepository) ListPending(ctx context.Context, userID int64) ([]*dto.ScheduledTweetRecord, error) {
+	scheduled, err := r.scheduledTweetStore.GetPendingByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*dto.ScheduledTweetRecord, len(scheduled))
+	for i, st := range scheduled {
+		records[i] = dto.NewScheduledTweetRecord(st)
+	}
+
+	return records, nil
+}
+
+func (r *scheduledTweetRepository) Cancel(ctx context.Context, scheduledID, userID int64) error {
+	if err := r.scheduledTweetStore.CancelScheduledTweet(ctx, scheduledID, userID); err != nil {
+		return err
+	}
+
+	_ = r.scheduleCache.Remove(ctx, scheduledID)
+
+	return nil
+}
+
+func (r *scheduledTweetRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]int64, error) {
+	return r.scheduleCache.GetDue(ctx, now, limit)
+}
+
+// 公開済み・キャンセル済みの予約や、公開できず failed にした予約はスケジュールから外す
+func (r *scheduledTweetRepository) Publish(ctx context.Context, scheduledID int64) (*dto.TweetRecord, error) {
+	tweet, err := r.scheduledTweetStore.PublishScheduledTweet(ctx, scheduledID)
+	if err != nil {
+		if errors.Is(err, errcode.ErrScheduledTweetNotFound) || errors.Is(err, errcode.ErrScheduledTweetFailed) {
+			_ = r.scheduleCache.Remove(ctx, scheduledID)
+		}
+		return nil, err
+	}
+
+	_ = r.scheduleCache.Remove(ctx, scheduledID)
+
+	return dto.NewTweetRecord(tweet), nil
+}
+
+func (r *scheduledTweetRepository) Resync(ctx context.Context, batchSize int) (int, error) {
+	var cursor int64
+	total := 0
+	for {
+		scheduled, err := r.scheduledTweetStore.GetPendingSchedules(ctx, cursor, batchSize)
+		if err != nil {
+			return total, err
+		}
+		if len(scheduled) == 0 {
+			return total, nil
+		}
+
+		members := make([]*models.CacheMember, len(scheduled))
+		for i, st := range scheduled {
+			members[i] = &models.CacheMember{
+				Member: st.ID,
+				Score:  float64(st.PublishAt.Unix()),
+			}
+		}
+		if err := r.scheduleCache.AddBatch(ctx, members); err != nil {
+			return total, err
+		}
+
+		total += len(scheduled)
+		cursor = scheduled[len(scheduled)-1].ID
+	}
+}
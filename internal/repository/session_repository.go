@@ -5,6 +5,7 @@ import (
 	"aita/internal/errcode"
 	"aita/internal/models"
 	"context"
+	"time"
 )
 
 type SessionStore interface {
@@ -13,6 +14,14 @@ type SessionStore interface {
 	Update(ctx context.Context, session *models.Session) error
 	Delete(ctx context.Context, session *models.Session) error
 	DeleteByUserID(ctx context.Context, userID int64) error
+	DeleteOthersByUserID(ctx context.Context, userID int64, keepHash string) error
+	ListByUserID(ctx context.Context, userID int64) ([]*models.Session, error)
+	GetRefreshRef(ctx context.Context, refreshHash string) (*models.RefreshTokenRef, error)
+	GetByFamily(ctx context.Context, sessionID string) (*models.Session, error)
+	Rotate(ctx context.Context, current, next *models.Session) error
+	DeleteFamily(ctx context.Context, userID int64, sessionID string) error
+	CreateTicket(ctx context.Context, ticketHash, tokenHash string, ttl time.Duration) error
+	ConsumeTicket(ctx context.Context, ticketHash string) (string, error)
 }
 
 type sessionRepository struct {
@@ -74,4 +83,59 @@ func(r *sessionRepository) Delete(ctx context.Context, sr *dto.SessionRecord) er
 		return err
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+func (r *sessionRepository) DeleteByUserID(ctx context.Context, userID int64) error {
+	return r.sessionStore.DeleteByUserID(ctx, userID)
+}
+
+func (r *sessionRepository) DeleteOthersByUserID(ctx context.Context, userID int64, keepHash string) error {
+	return r.sessionStore.DeleteOthersByUserID(ctx, userID, keepHash)
+}
+
+func (r *sessionRepository) ListByUserID(ctx context.Context, userID int64) ([]*dto.SessionRecord, error) {
+	sessions, err := r.sessionStore.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*dto.SessionRecord, len(sessions))
+	for i, session := range sessions {
+		records[i] = dto.ToSessionRecord(session)
+	}
+	return records, nil
+}
+
+func (r *sessionRepository) GetRefreshRef(ctx context.Context, refreshHash string) (*dto.RefreshTokenRefRecord, error) {
+	ref, err := r.sessionStore.GetRefreshRef(ctx, refreshHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RefreshTokenRefRecord{UserID: ref.UserID, SessionID: ref.SessionID}, nil
+}
+
+func (r *sessionRepository) GetByFamily(ctx context.Context, sessionID string) (*dto.SessionRecord, error) {
+	session, err := r.sessionStore.GetByFamily(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToSessionRecord(session), nil
+}
+
+func (r *sessionRepository) Rotate(ctx context.Context, current, next *dto.SessionRecord) error {
+	return r.sessionStore.Rotate(ctx, current.ToModel(), next.ToModel())
+}
+
+func (r *sessionRepository) DeleteFamily(ctx context.Context, userID int64, sessionID string) error {
+	return r.sessionStore.DeleteFamily(ctx, userID, sessionID)
+}
+
+func (r *sessionRepository) CreateTicket(ctx context.Context, ticketHash, tokenHash string, ttl time.Duration) error {
+	return r.sessionStore.CreateTicket(ctx, ticketHash, tokenHash, ttl)
+}
+
+func (r *sessionRepository) ConsumeTicket(ctx context.Context, ticketHash string) (string, error) {
+	return r.sessionStore.ConsumeTicket(ctx, ticketHash)
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"aita/internal/dto"
+	"aita/internal/models"
+	"context"
+)
+
+type EventStreamCache interface {
+	Publish(ctx context.Context, event *models.StreamEvent) (string, error)
+	Range(ctx context.Context, afterID string, count int64) ([]*models.StreamEvent, error)
+	Subscribe(ctx context.Context) (<-chan *models.StreamEvent, func() error, error)
+}
+
+type streamRepository struct {
+	eventStreamCache EventStreamCache
+}
+
+func NewStreamRepository(c EventStreamCache) *streamRepository {
+	return &streamRepository{eventStreamCache: c}
+}
+
+func (r *streamRepository) Publish(ctx context.Context, record *dto.StreamEventRecord) error {
+	_, err := r.eventStreamCache.Publish(ctx, record.ToModel())
+	return err
+}
+
+func (r *streamRepository) Since(ctx context.Context, afterID string, count int64) ([]*dto.StreamEventRecord, error) {
+	events, err := r.eventStreamCache.Range(ctx, afterID, count)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*dto.StreamEventRecord, len(events))
+	for i, e := range events {
+		records[i] = dto.NewStreamEventRecord(e)
+	}
+	return records, nil
+}
+
+func (r *streamRepository) Subscribe(ctx context.Context) (<-chan *dto.StreamEventRecord, func() error, error) {
+	events, closeFn, err := r.eventStreamCache.Subscribe(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	records := make(chan *dto.StreamEventRecord, cap(events))
+	go func() {
+		defer close(records)
+		for e := range events {
+			records <- dto.NewStreamEventRecord(e)
+		}
+	}()
+	return records, closeFn, nil
+}
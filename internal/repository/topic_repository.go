@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"aita/internal/dto"
+	"aita/internal/models"
+	"context"
+)
+
+type TopicCache interface {
+	Publish(ctx context.Context, message *models.TopicMessage) error
+	Listen(ctx context.Context) (<-chan *models.TopicMessage, error)
+	Join(ctx context.Context, topics ...string) error
+	Leave(ctx context.Context, topics ...string) error
+	Close() error
+}
+
+type topicRepository struct {
+	topicCache TopicCache
+}
+
+func NewTopicRepository(c TopicCache) *topicRepository {
+	return &topicRepository{topicCache: c}
+}
+
+func (r *topicRepository) Publish(ctx context.Context, record *dto.TopicMessageRecord) error {
+	return r.topicCache.Publish(ctx, record.ToModel())
+}
+
+func (r *topicRepository) Listen(ctx context.Context) (<-chan *dto.TopicMessageRecord, error) {
+	messages, err := r.topicCache.Listen(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make(chan *dto.TopicMessageRecord, cap(messages))
+	go func() {
+		defer close(records)
+		for m := range messages {
+			records <- dto.NewTopicMessageRecord(m)
+		}
+	}()
+	return records, nil
+}
+
+func (r *topicRepository) Join(ctx context.Context, topics ...string) error {
+	return r.topicCache.Join(ctx, topics...)
+}
+
+func (r *topicRepository) Leave(ctx context.Context, topics ...string) error {
+	return r.topicCache.Leave(ctx, topics...)
+}
+
+func (r *topicRepository) Close() error {
+	return r.topicCache.Close()
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"aita/internal/dto"
+	"aita/internal/models"
+	"context"
+	"time"
+)
+
+type TrendCache interface {
+	IncrHashtags(ctx context.Context, bucket int64, hashtags []string, ttl time.Duration) error
+	SumBuckets(ctx context.Context, buckets []int64) (map[string]int64, error)
+	SetTop(ctx context.Context, trends []*models.Trend, ttl time.Duration) error
+	GetTop(ctx context.Context) ([]*models.Trend, error)
+}
+
+type trendRepository struct {
+	trendCache TrendCache
+}
+
+func NewTrendRepository(tc TrendCache) *trendRepository {
+	return &trendRepository{trendCache: tc}
+}
+
+func (r *trendRepository) IncrHashtags(ctx context.Context, bucket int64, hashtags []string, ttl time.Duration) error {
+	return r.trendCache.IncrHashtags(ctx, bucket, hashtags, ttl)
+}
+
+func (r *trendRepository) SumBuckets(ctx context.Context, buckets []int64) (map[string]int64, error) {
+	return r.trendCache.SumBuckets(ctx, buckets)
+}
+
+func (r *trendRepository) SaveTop(ctx context.Context, records []*dto.TrendRecord, ttl time.Duration) error {
+	trends := make([]*models.Trend, len(records))
+	for i, rec := range records {
+		trends[i] = rec.ToModel()
+	}
+	return r.trendCache.SetTop(ctx, trends, ttl)
+}
+
+// まだ集計されていなければ空のリストを返す
+func (r *trendRepository) GetTop(ctx context.Context) ([]*dto.TrendRecord, error) {
+	trends, err := r.trendCache.GetTop(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*dto.TrendRecord, 0, len(trends))
+	for _, t := range trends {
+		if t != nil {
+			records = append(records, dto.NewTrendRecord(t))
+		}
+	}
+	return records, nil
+}
@@ -17,10 +17,15 @@ import (
 type TweetStore interface {
 	CreateTweet(ctx context.Context, tweet *models.Tweet) (*models.Tweet, error)
 	GetTweetByTweetID(ctx context.Context, tweetID int64) (*models.Tweet, error)
-	UpdateContent(ctx context.Context, newContent string, tweetID int64) (*models.Tweet, error)
+	UpdateContent(ctx context.Context, newContent string, tweetID int64, editWindow time.Duration, maxEdits int) (*models.Tweet, error)
 	DeleteTweet(ctx context.Context, tweetID int64) error
 	GetTweetsByTweetIDs(ctx context.Context, tweetIDS []int64) ([]*models.Tweet, error)
 	GetTweetIDsByAuthor(ctx context.Context, authorID int64, page, size int) ([]int64, error) 
+	GetTweetIDsByAuthors(ctx context.Context, authorIDs []int64, before int64, limit int) ([]int64, error)
+	GetRevisions(ctx context.Context, tweetID int64) ([]*models.TweetRevision, error)
+	GetDeletedTweet(ctx context.Context, tweetID int64) (*models.Tweet, error)
+	RestoreTweet(ctx context.Context, tweetID int64, gracePeriod time.Duration) (*models.Tweet, error)
+	PurgeDeletedTweets(ctx context.Context, retention time.Duration, limit int) (int64, error)
 }
 
 type TweetCache interface {
@@ -34,25 +39,40 @@ type TweetCache interface {
 
 
 type tweetRepository struct {
-	tweetStore TweetStore
-	tweetCache TweetCache
-	sfTweet    *singleflight.Group
-	pool       *ants.Pool
+	tweetStore   TweetStore
+	tweetCache   TweetCache
+	previewCache LinkPreviewCache
+	viewStore    ViewStore
+	viewCache    ViewCache
+	sfTweet      *singleflight.Group
+	pool         *ants.Pool
 }
 
-func NewTweetRepository(ts TweetStore, tc TweetCache, p *ants.Pool) *tweetRepository {
+func NewTweetRepository(ts TweetStore, tc TweetCache, lc LinkPreviewCache, vs ViewStore, vc ViewCache, p *ants.Pool) *tweetRepository {
 	return &tweetRepository{
-		tweetStore: ts,
-		tweetCache: tc,
-		sfTweet: &singleflight.Group{},
-		pool:       p,
+		tweetStore:   ts,
+		tweetCache:   tc,
+		previewCache: lc,
+		viewStore:    vs,
+		viewCache:    vc,
+		sfTweet:      &singleflight.Group{},
+		pool:         p,
 	}
 }
 
 func (r *tweetRepository) Create(ctx context.Context, record *dto.TweetRecord) (*dto.TweetRecord, error) {
-	tweet := record.ToModel()
+	saved, err := r.Insert(ctx, record)
+	if err != nil {
+		return nil, err
+	}
 
-	dbTweet, err := r.tweetStore.CreateTweet(ctx, tweet)
+	r.Cache(saved)
+	return saved, nil
+}
+
+// 呼び出し側のトランザクション内で使うため、キャッシュには触れない。コミット後に Cache を呼ぶこと
+func (r *tweetRepository) Insert(ctx context.Context, record *dto.TweetRecord) (*dto.TweetRecord, error) {
+	dbTweet, err := r.tweetStore.CreateTweet(ctx, record.ToModel())
 	if err != nil {
 		return nil, err
 	}
@@ -61,9 +81,13 @@ func (r *tweetRepository) Create(ctx context.Context, record *dto.TweetRecord) (
 		return nil, errcode.ErrInternal
 	}
 
-	taskData := dbTweet
+	return dto.NewTweetRecord(dbTweet), nil
+}
+
+func (r *tweetRepository) Cache(record *dto.TweetRecord) {
+	taskData := record.ToModel()
 
-	err = r.pool.Submit(func() {
+	err := r.pool.Submit(func() {
 		bgCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
@@ -77,12 +101,10 @@ func (r *tweetRepository) Create(ctx context.Context, record *dto.TweetRecord) (
 		_ = r.tweetCache.Invalidate(context.Background(), taskData.ID)
 		slog.Warn("ants pool へのタスク投入に失敗しました。同期的なtweetキャッシュ破棄を実行します。", "err", err)
 	}
-
-	return dto.NewTweetRecord(dbTweet), nil
 }
 
-func (r *tweetRepository) Update(ctx context.Context, newContent string, tweetID int64) (*dto.TweetRecord, error) {
-	tweet, err := r.tweetStore.UpdateContent(ctx, newContent, tweetID)
+func (r *tweetRepository) Update(ctx context.Context, newContent string, tweetID int64, policy dto.EditPolicy) (*dto.TweetRecord, error) {
+	tweet, err := r.tweetStore.UpdateContent(ctx, newContent, tweetID, policy.Window, policy.MaxEdits)
 
 	if err != nil {
 		return nil, err
@@ -98,6 +120,20 @@ func (r *tweetRepository) Update(ctx context.Context, newContent string, tweetID
 	return dto.NewTweetRecord(tweet), nil
 }
 
+func (r *tweetRepository) GetRevisions(ctx context.Context, tweetID int64) ([]*dto.TweetRevisionRecord, error) {
+	revisions, err := r.tweetStore.GetRevisions(ctx, tweetID)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*dto.TweetRevisionRecord, len(revisions))
+	for i, rev := range revisions {
+		records[i] = dto.NewTweetRevisionRecord(rev)
+	}
+
+	return records, nil
+}
+
 func (r *tweetRepository) Delete(ctx context.Context, tweetID int64) error {
 	err := r.tweetStore.DeleteTweet(ctx, tweetID)
 
@@ -107,14 +143,50 @@ func (r *tweetRepository) Delete(ctx context.Context, tweetID int64) error {
 
 	_ = r.tweetCache.Invalidate(ctx, tweetID)
 
+	_ = r.pool.Submit(func() {
+		time.Sleep(800 * time.Millisecond)
+
+		_ = r.tweetCache.Invalidate(context.Background(), tweetID)
+	})
+
 	return nil
 }
 
+func (r *tweetRepository) GetDeleted(ctx context.Context, tweetID int64) (*dto.TweetRecord, error) {
+	tweet, err := r.tweetStore.GetDeletedTweet(ctx, tweetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewTweetRecord(tweet), nil
+}
+
+func (r *tweetRepository) Restore(ctx context.Context, tweetID int64, gracePeriod time.Duration) (*dto.TweetRecord, error) {
+	tweet, err := r.tweetStore.RestoreTweet(ctx, tweetID, gracePeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = r.tweetCache.Invalidate(ctx, tweetID)
+
+	return dto.NewTweetRecord(tweet), nil
+}
+
+func (r *tweetRepository) Purge(ctx context.Context, retention time.Duration, limit int) (int64, error) {
+	return r.tweetStore.PurgeDeletedTweets(ctx, retention, limit)
+}
+
 func (r *tweetRepository) Get(ctx context.Context, tweetID int64) (*dto.TweetRecord, error) {
 	tweet, err := r.tweetCache.GetTweet(ctx, tweetID)
 
 	if err == nil && tweet != nil {
-		return dto.NewTweetRecord(tweet), nil
+		if tweet.DeletedAt != nil {
+			return nil, errcode.ErrTweetNotFound
+		}
+		record := dto.NewTweetRecord(tweet)
+		attachLinkPreviews(ctx, r.previewCache, record)
+		attachViewCounts(ctx, r.viewStore, r.viewCache, record)
+		return record, nil
 	}
 
 	sfKey := fmt.Sprintf("tweet:%d", tweetID)
@@ -146,7 +218,10 @@ func (r *tweetRepository) Get(ctx context.Context, tweetID int64) (*dto.TweetRec
 		)
 	}
 
-	return dto.NewTweetRecord(tweet), nil
+	record := dto.NewTweetRecord(tweet)
+	attachLinkPreviews(ctx, r.previewCache, record)
+	attachViewCounts(ctx, r.viewStore, r.viewCache, record)
+	return record, nil
 }
 
 func (r *tweetRepository) MultiGet(ctx context.Context, tweetIDs []int64) ([]*dto.TweetRecord, error) {
@@ -194,11 +269,13 @@ func (r *tweetRepository) MultiGet(ctx context.Context, tweetIDs []int64) ([]*dt
 
 	finalResults := make([]*dto.TweetRecord, 0, len(tweetIDs))
 	for _, id := range tweetIDs {
-		if tweet, ok := tweetsMap[id]; ok {
+		if tweet, ok := tweetsMap[id]; ok && tweet.DeletedAt == nil {
 			finalResults = append(finalResults, dto.NewTweetRecord(tweet))
 		}
 	}
 
+	attachLinkPreviews(ctx, r.previewCache, finalResults...)
+	attachViewCounts(ctx, r.viewStore, r.viewCache, finalResults...)
 	return finalResults, nil
 }
 
@@ -220,3 +297,10 @@ func (r *tweetRepository) GetTweetsByAuthor(ctx context.Context, userID int64, p
 	return ids, nil
 }
 
+// 複数の投稿者のツイートIDを新しい順に返す。cursor より古いものを最大 limit 件
+func (r *tweetRepository) GetTweetIDsByAuthors(ctx context.Context, authorIDs []int64, cursor int64, limit int) ([]int64, error) {
+	if len(authorIDs) == 0 || limit <= 0 {
+		return []int64{}, nil
+	}
+	return r.tweetStore.GetTweetIDsByAuthors(ctx, authorIDs, cursor, limit)
+}
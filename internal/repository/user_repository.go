@@ -22,6 +22,14 @@ type UserStore interface {
 	IncreaseFollowerCount(ctx context.Context, userID, delta int64) error
 	IncreaseFollowingCount(ctx context.Context, userID, delta int64) error
 	GetNamesByIDs(ctx context.Context, userIDs []int64) ([]*models.UserInfo, error)
+	SetPinnedTweet(ctx context.Context, userID, tweetID int64) error
+	ClearPinnedTweet(ctx context.Context, userID, tweetID int64) error
+	GetPinnedTweetID(ctx context.Context, userID int64) (int64, error)
+	GetIDsByUsernames(ctx context.Context, usernames []string) ([]*models.UserInfo, error)
+	UpdatePassword(ctx context.Context, userID int64, passwordHash string) error
+	MarkEmailVerified(ctx context.Context, userID int64) error
+	UpdateEmail(ctx context.Context, userID int64, email string) error
+	UpdateUsername(ctx context.Context, userID int64, username string, changedBefore time.Time) error
 }
 
 type UserCache interface {
@@ -233,3 +241,68 @@ func (r *userRepository) GetBaseInfos(ctx context.Context, userIDs []int64) ([]*
 
 	return finalResults, nil
 }
+
+func (r *userRepository) SetPinnedTweet(ctx context.Context, userID, tweetID int64) error {
+	return r.userStore.SetPinnedTweet(ctx, userID, tweetID)
+}
+
+func (r *userRepository) ClearPinnedTweet(ctx context.Context, userID, tweetID int64) error {
+	return r.userStore.ClearPinnedTweet(ctx, userID, tweetID)
+}
+
+// 固定していない場合は 0 を返す
+func (r *userRepository) GetPinnedTweetID(ctx context.Context, userID int64) (int64, error) {
+	return r.userStore.GetPinnedTweetID(ctx, userID)
+}
+
+// 存在しないユーザー名は結果に含めない
+func (r *userRepository) GetIDsByUsernames(ctx context.Context, usernames []string) ([]*dto.UserSlimRecord, error) {
+	infos, err := r.userStore.GetIDsByUsernames(ctx, usernames)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*dto.UserSlimRecord, len(infos))
+	for i, info := range infos {
+		records[i] = &dto.UserSlimRecord{ID: info.ID, Username: info.Username}
+	}
+	return records, nil
+}
+
+func (r *userRepository) UpdatePassword(ctx context.Context, userID int64, passwordHash string) error {
+	if err := r.userStore.UpdatePassword(ctx, userID, passwordHash); err != nil {
+		return err
+	}
+
+	r.userCache.Invalidate(ctx, userID)
+	return nil
+}
+
+func (r *userRepository) MarkEmailVerified(ctx context.Context, userID int64) error {
+	return r.userStore.MarkEmailVerified(ctx, userID)
+}
+
+// トランザクション内で呼ばれるため、コミット前に古い値が再キャッシュされてもよいよう遅れてもう一度破棄する
+func (r *userRepository) UpdateEmail(ctx context.Context, userID int64, email string) error {
+	if err := r.userStore.UpdateEmail(ctx, userID, email); err != nil {
+		return err
+	}
+
+	r.userCache.Invalidate(ctx, userID)
+
+	_ = r.pool.Submit(func() {
+		time.Sleep(800 * time.Millisecond)
+
+		r.userCache.Invalidate(context.Background(), userID)
+	})
+	return nil
+}
+
+func (r *userRepository) UpdateUsername(ctx context.Context, userID int64, username string, changedBefore time.Time) error {
+	if err := r.userStore.UpdateUsername(ctx, userID, username, changedBefore); err != nil {
+		return err
+	}
+
+	r.userCache.Invalidate(ctx, userID)
+	return nil
+}
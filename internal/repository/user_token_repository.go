@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"aita/internal/dto"
+	"aita/internal/models"
+	"context"
+)
+
+type UserTokenStore interface {
+	CreateToken(ctx context.Context, token *models.UserToken) (*models.UserToken, error)
+	ConsumeToken(ctx context.Context, purpose, tokenHash string) (*models.UserToken, error)
+	DeleteTokens(ctx context.Context, userID int64, purpose string) error
+}
+
+type userTokenRepository struct {
+	userTokenStore UserTokenStore
+}
+
+func NewUserTokenRepository(uts UserTokenStore) *userTokenRepository {
+	return &userTokenRepository{userTokenStore: uts}
+}
+
+func (r *userTokenRepository) Issue(ctx context.Context, record *dto.UserTokenRecord) (*dto.UserTokenRecord, error) {
+	token, err := r.userTokenStore.CreateToken(ctx, record.ToModel())
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewUserTokenRecord(token), nil
+}
+
+func (r *userTokenRepository) Consume(ctx context.Context, purpose, tokenHash string) (*dto.UserTokenRecord, error) {
+	token, err := r.userTokenStore.ConsumeToken(ctx, purpose, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewUserTokenRecord(token), nil
+}
+
+func (r *userTokenRepository) Revoke(ctx context.Context, userID int64, purpose string) error {
+	return r.userTokenStore.DeleteTokens(ctx, userID, purpose)
+}
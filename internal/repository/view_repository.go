@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"aita/internal/dto"
+	"aita/internal/models"
+	"context"
+	"log/slog"
+	"time"
+)
+
+type ViewStore interface {
+	GetViewCounts(ctx context.Context, tweetIDs []int64) ([]*models.TweetViewCount, error)
+	IncrementViewCounts(ctx context.Context, deltas map[int64]int64) error
+}
+
+type ViewCache interface {
+	RecordViews(ctx context.Context, viewer string, window int64, tweetIDs []int64, ttl time.Duration) (int64, error)
+	GetCounts(ctx context.Context, tweetIDs []int64) (map[int64]int64, error)
+	SetCounts(ctx context.Context, counts map[int64]int64) error
+	GetPending(ctx context.Context, tweetIDs []int64) (map[int64]int64, error)
+	PopPending(ctx context.Context) (map[int64]int64, error)
+	RestorePending(ctx context.Context, deltas map[int64]int64) error
+}
+
+type viewRepository struct {
+	viewStore ViewStore
+	viewCache ViewCache
+}
+
+func NewViewRepository(vs ViewStore, vc ViewCache) *viewRepository {
+	return &viewRepository{
+		viewStore: vs,
+		viewCache: vc,
+	}
+}
+
+func (r *viewRepository) Record(ctx context.Context, viewer string, window int64, tweetIDs []int64, ttl time.Duration) (int64, error) {
+	return r.viewCache.RecordViews(ctx, viewer, window, tweetIDs, ttl)
+}
+
+// 永続化待ちの増分を DB に加算する。失敗した場合は次回に回す
+func (r *viewRepository) Flush(ctx context.Context) (int, error) {
+	deltas, err := r.viewCache.PopPending(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(deltas) == 0 {
+		return 0, nil
+	}
+
+	if err := r.viewStore.IncrementViewCounts(ctx, deltas); err != nil {
+		_ = r.viewCache.RestorePending(ctx, deltas)
+		return 0, err
+	}
+	return len(deltas), nil
+}
+
+// 閲覧数は頻繁に変わるためツイートのキャッシュには載せず、合計を別に持つ。
+// 合計がなければ DB の値に永続化待ちの増分を足して作り直す
+func attachViewCounts(ctx context.Context, store ViewStore, cache ViewCache, tweets ...*dto.TweetRecord) {
+	if len(tweets) == 0 {
+		return
+	}
+
+	ids := make([]int64, len(tweets))
+	for i, t := range tweets {
+		ids[i] = t.ID
+	}
+
+	counts, err := cache.GetCounts(ctx, ids)
+	cacheOK := err == nil
+	if !cacheOK {
+		counts = make(map[int64]int64, len(ids))
+	}
+
+	missed := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := counts[id]; !ok {
+			missed = append(missed, id)
+		}
+	}
+
+	if len(missed) > 0 {
+		stored, err := store.GetViewCounts(ctx, missed)
+		if err != nil {
+			slog.Warn("閲覧数の取得をスキップしました", "count", len(missed), "err", err)
+			return
+		}
+
+		rebuilt := make(map[int64]int64, len(stored))
+		for _, s := range stored {
+			rebuilt[s.TweetID] = s.ViewCount
+		}
+		if cacheOK {
+			pending, err := cache.GetPending(ctx, missed)
+			if err == nil {
+				for id, delta := range pending {
+					if _, ok := rebuilt[id]; ok {
+						rebuilt[id] += delta
+					}
+				}
+				_ = cache.SetCounts(ctx, rebuilt)
+			}
+		}
+		for id, count := range rebuilt {
+			counts[id] = count
+		}
+	}
+
+	for _, t := range tweets {
+		t.ViewCount = counts[t.ID]
+	}
+}
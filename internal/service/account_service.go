@@ -0,0 +1,160 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+type AccountUserRepository interface {
+	GetFullByID(ctx context.Context, userID int64) (*dto.UserRecord, error)
+	UpdatePassword(ctx context.Context, userID int64, passwordHash string) error
+	UpdateEmail(ctx context.Context, userID int64, email string) error
+	UpdateUsername(ctx context.Context, userID int64, username string, changedBefore time.Time) error
+}
+
+type OtherSessionRevoker interface {
+	RevokeOthers(ctx context.Context, userID int64, currentToken string) error
+}
+
+type VerificationMailSender interface {
+	SendVerification(ctx context.Context, userID int64) error
+}
+
+type accountService struct {
+	userRepository      AccountUserRepository
+	userTokenRepository UserTokenRepository
+	transactionManager  TransactionManager
+	sessionRevoker      OtherSessionRevoker
+	verificationSender  VerificationMailSender
+	hasher              PasswordHasher
+	usernameCooldown    time.Duration
+	now                 func() time.Time
+}
+
+func NewAccountService(
+	ur AccountUserRepository,
+	utr UserTokenRepository,
+	tm TransactionManager,
+	sr OtherSessionRevoker,
+	vs VerificationMailSender,
+	h PasswordHasher,
+	usernameCooldown time.Duration,
+) *accountService {
+	return &accountService{
+		userRepository:      ur,
+		userTokenRepository: utr,
+		transactionManager:  tm,
+		sessionRevoker:      sr,
+		verificationSender:  vs,
+		hasher:              h,
+		usernameCooldown:    usernameCooldown,
+		now:                 time.Now,
+	}
+}
+
+// 現在のパスワードを確認してから変更し、今使っているセッション以外をログアウトさせる
+func (s *accountService) ChangePassword(ctx context.Context, userID int64, currentToken, currentPassword, newPassword string) error {
+	if userID <= 0 {
+		return errcode.ErrInvalidUserID
+	}
+	if currentPassword == "" || newPassword == "" {
+		return errcode.ErrRequiredFieldMissing
+	}
+
+	user, err := s.userRepository.GetFullByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("ユーザー情報の取得に失敗しました: %w", err)
+	}
+	if err := s.hasher.Compare(user.PasswordHash, currentPassword); err != nil {
+		return errcode.ErrIncorrectPassword
+	}
+	if currentPassword == newPassword {
+		return errcode.ErrUnchangedValue
+	}
+
+	hash, err := s.hasher.Generate(newPassword)
+	if err != nil {
+		return fmt.Errorf("パスワードをハッシュ化に失敗しました: %w", err)
+	}
+	if err := s.userRepository.UpdatePassword(ctx, userID, hash); err != nil {
+		return fmt.Errorf("パスワードの更新に失敗しました: %w", err)
+	}
+
+	if err := s.sessionRevoker.RevokeOthers(ctx, userID, currentToken); err != nil {
+		return fmt.Errorf("ChangePassword: パスワードは更新されましたが、他のセッションの無効化に失敗しました (user_id: %d): %w", userID, err)
+	}
+
+	return nil
+}
+
+// 変更後のアドレスは未確認に戻し、古いアドレス宛ての確認リンクは使えなくする
+func (s *accountService) ChangeEmail(ctx context.Context, userID int64, email string) (*dto.UserRecord, error) {
+	if userID <= 0 {
+		return nil, errcode.ErrInvalidUserID
+	}
+	if email == "" {
+		return nil, errcode.ErrRequiredFieldMissing
+	}
+
+	user, err := s.userRepository.GetFullByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザー情報の取得に失敗しました: %w", err)
+	}
+	if strings.EqualFold(user.Email, email) {
+		return nil, errcode.ErrUnchangedValue
+	}
+
+	err = s.transactionManager.Exec(ctx, func(txCtx context.Context) error {
+		if err := s.userRepository.UpdateEmail(txCtx, userID, email); err != nil {
+			return fmt.Errorf("メールアドレスの更新に失敗しました: %w", err)
+		}
+		if err := s.userTokenRepository.Revoke(txCtx, userID, models.TokenPurposeEmailVerification); err != nil {
+			return fmt.Errorf("確認トークンの無効化に失敗しました: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 確認メールが送れなくても変更は完了させ、再送で対応してもらう
+	if err := s.verificationSender.SendVerification(ctx, userID); err != nil {
+		slog.Warn("確認メールの送信に失敗しました", "user_id", userID, "err", err)
+	}
+
+	user.Email = email
+	user.EmailVerifiedAt = nil
+	return user, nil
+}
+
+// 前回の変更から usernameCooldown が経つまでは変更できない
+func (s *accountService) ChangeUsername(ctx context.Context, userID int64, username string) (*dto.UserRecord, error) {
+	if userID <= 0 {
+		return nil, errcode.ErrInvalidUserID
+	}
+	if username == "" {
+		return nil, errcode.ErrRequiredFieldMissing
+	}
+
+	user, err := s.userRepository.GetFullByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザー情報の取得に失敗しました: %w", err)
+	}
+	if user.Username == username {
+		return nil, errcode.ErrUnchangedValue
+	}
+
+	changedBefore := s.now().Add(-s.usernameCooldown).UTC()
+	if err := s.userRepository.UpdateUsername(ctx, userID, username, changedBefore); err != nil {
+		return nil, fmt.Errorf("ユーザーネームの更新に失敗しました: %w", err)
+	}
+
+	user.Username = username
+	return user, nil
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type accountMocks struct {
+	users    *mockUserRepository
+	tokens   *mockUserTokenRepository
+	sessions *mockOtherSessionRevoker
+	sender   *mockVerificationMailSender
+	hasher   *mockBcryptHasher
+}
+
+func newAccountServiceForTest() (*accountService, *accountMocks) {
+	m := &accountMocks{
+		users:    new(mockUserRepository),
+		tokens:   new(mockUserTokenRepository),
+		sessions: new(mockOtherSessionRevoker),
+		sender:   new(mockVerificationMailSender),
+		hasher:   new(mockBcryptHasher),
+	}
+	tx := new(mockTransactionManager)
+	tx.On("Exec", mock.Anything).Maybe()
+	return NewAccountService(m.users, m.tokens, tx, m.sessions, m.sender, m.hasher, 30*24*time.Hour), m
+}
+
+func TestChangePassword(t *testing.T) {
+	user := &dto.UserRecord{ID: 3, PasswordHash: "oldhash"}
+
+	tests := []struct {
+		name      string
+		current   string
+		next      string
+		setupMock func(m *accountMocks)
+		wantErr   error
+	}{
+		{
+			name:    "正常系: パスワードを更新して他のセッションを無効にする",
+			current: "oldpassword",
+			next:    "newpassword",
+			setupMock: func(m *accountMocks) {
+				m.users.On("GetFullByID", mock.Anything, int64(3)).Return(user, nil)
+				m.hasher.On("Compare", "oldhash", "oldpassword").Return(nil)
+				m.hasher.On("Generate", "newpassword").Return("newhash", nil)
+				m.users.On("UpdatePassword", mock.Anything, int64(3), "newhash").Return(nil)
+				m.sessions.On("RevokeOthers", mock.Anything, int64(3), "current_token").Return(nil)
+			},
+		},
+		{
+			name:    "現在のパスワードが違う",
+			current: "wrongpassword",
+			next:    "newpassword",
+			setupMock: func(m *accountMocks) {
+				m.users.On("GetFullByID", mock.Anything, int64(3)).Return(user, nil)
+				m.hasher.On("Compare", "oldhash", "wrongpassword").Return(errors.New("mismatch"))
+			},
+			wantErr: errcode.ErrIncorrectPassword,
+		},
+		{
+			name:    "同じパスワードには変更できない",
+			current: "oldpassword",
+			next:    "oldpassword",
+			setupMock: func(m *accountMocks) {
+				m.users.On("GetFullByID", mock.Anything, int64(3)).Return(user, nil)
+				m.hasher.On("Compare", "oldhash", "oldpassword").Return(nil)
+			},
+			wantErr: errcode.ErrUnchangedValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newAccountServiceForTest()
+			tt.setupMock(m)
+
+			err := svc.ChangePassword(context.Background(), 3, "current_token", tt.current, tt.next)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				m.users.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			m.sessions.AssertExpectations(t)
+		})
+	}
+}
+
+func TestChangeEmail(t *testing.T) {
+	verifiedAt := time.Now()
+
+	t.Run("正常系: 未確認に戻して確認メールを送る", func(t *testing.T) {
+		svc, m := newAccountServiceForTest()
+		m.users.On("GetFullByID", mock.Anything, int64(3)).Return(&dto.UserRecord{ID: 3, Email: "old@example.com", EmailVerifiedAt: &verifiedAt}, nil)
+		m.users.On("UpdateEmail", mock.Anything, int64(3), "new@example.com").Return(nil)
+		m.tokens.On("Revoke", mock.Anything, int64(3), models.TokenPurposeEmailVerification).Return(nil)
+		m.sender.On("SendVerification", mock.Anything, int64(3)).Return(nil)
+
+		user, err := svc.ChangeEmail(context.Background(), 3, "new@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "new@example.com", user.Email)
+		assert.False(t, user.IsEmailVerified())
+		m.tokens.AssertExpectations(t)
+		m.sender.AssertExpectations(t)
+	})
+
+	t.Run("既に使われているアドレス", func(t *testing.T) {
+		svc, m := newAccountServiceForTest()
+		m.users.On("GetFullByID", mock.Anything, int64(3)).Return(&dto.UserRecord{ID: 3, Email: "old@example.com"}, nil)
+		m.users.On("UpdateEmail", mock.Anything, int64(3), "taken@example.com").Return(errcode.ErrEmailConflict)
+
+		_, err := svc.ChangeEmail(context.Background(), 3, "taken@example.com")
+		assert.ErrorIs(t, err, errcode.ErrEmailConflict)
+		m.sender.AssertNotCalled(t, "SendVerification", mock.Anything, mock.Anything)
+	})
+
+	t.Run("大文字小文字だけの違いは変更とみなさない", func(t *testing.T) {
+		svc, m := newAccountServiceForTest()
+		m.users.On("GetFullByID", mock.Anything, int64(3)).Return(&dto.UserRecord{ID: 3, Email: "old@example.com"}, nil)
+
+		_, err := svc.ChangeEmail(context.Background(), 3, "OLD@example.com")
+		assert.ErrorIs(t, err, errcode.ErrUnchangedValue)
+	})
+}
+
+func TestChangeUsername(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		username  string
+		setupMock func(m *accountMocks)
+		wantErr   error
+	}{
+		{
+			name:     "正常系: 待機期間の境界を渡して更新する",
+			username: "new_name",
+			setupMock: func(m *accountMocks) {
+				m.users.On("UpdateUsername", mock.Anything, int64(3), "new_name", now.Add(-30*24*time.Hour)).Return(nil)
+			},
+		},
+		{
+			name:     "既に使われているユーザーネーム",
+			username: "taken_name",
+			setupMock: func(m *accountMocks) {
+				m.users.On("UpdateUsername", mock.Anything, int64(3), "taken_name", mock.Anything).Return(errcode.ErrUsernameConflict)
+			},
+			wantErr: errcode.ErrUsernameConflict,
+		},
+		{
+			name:     "待機期間中",
+			username: "new_name",
+			setupMock: func(m *accountMocks) {
+				m.users.On("UpdateUsername", mock.Anything, int64(3), "new_name", mock.Anything).Return(errcode.ErrUsernameChangeTooSoon)
+			},
+			wantErr: errcode.ErrUsernameChangeTooSoon,
+		},
+		{
+			name:      "同じユーザーネーム",
+			username:  "old_name",
+			setupMock: func(m *accountMocks) {},
+			wantErr:   errcode.ErrUnchangedValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newAccountServiceForTest()
+			svc.now = func() time.Time { return now }
+			m.users.On("GetFullByID", mock.Anything, int64(3)).Return(&dto.UserRecord{ID: 3, Username: "old_name"}, nil)
+			tt.setupMock(m)
+
+			user, err := svc.ChangeUsername(context.Background(), 3, tt.username)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.username, user.Username)
+			m.users.AssertExpectations(t)
+		})
+	}
+}
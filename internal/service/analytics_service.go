@@ -0,0 +1,101 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"context"
+	"fmt"
+	"time"
+)
+
+type AnalyticsRepository interface {
+	Rollup(ctx context.Context, from, to time.Time) (int64, error)
+	GetDailyStats(ctx context.Context, userID int64, from, to time.Time) ([]*dto.DailyStatsRecord, error)
+}
+
+type analyticsService struct {
+	analyticsRepository AnalyticsRepository
+	now                 func() time.Time
+}
+
+func NewAnalyticsService(ar AnalyticsRepository) *analyticsService {
+	return &analyticsService{
+		analyticsRepository: ar,
+		now:                 time.Now,
+	}
+}
+
+// from, to は YYYY-MM-DD(UTC)で両端を含む。省略時は今日までの28日間
+func (s *analyticsService) GetAnalytics(ctx context.Context, userID int64, fromStr, toStr string) (*dto.AnalyticsRecord, error) {
+	from, to, err := s.parseDateRange(fromStr, toStr)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.analyticsRepository.GetDailyStats(ctx, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("GetAnalytics: 日別集計の取得に失敗しました (user_id: %d): %w", userID, err)
+	}
+
+	byDay := make(map[string]*dto.DailyStatsRecord, len(records))
+	for _, r := range records {
+		byDay[r.Day.UTC().Format(dto.DayLayout)] = r
+	}
+
+	// 動きのなかった日も0で埋めてグラフにそのまま使えるようにする
+	res := &dto.AnalyticsRecord{
+		From: from,
+		To:   to,
+		Days: []*dto.DailyStatsRecord{},
+	}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		r, ok := byDay[day.Format(dto.DayLayout)]
+		if !ok {
+			r = &dto.DailyStatsRecord{Day: day}
+		}
+		res.Days = append(res.Days, r)
+
+		res.Totals.FollowersGained += r.FollowersGained
+		res.Totals.FollowersLost += r.FollowersLost
+		res.Totals.TweetsPosted += r.TweetsPosted
+		res.Totals.Impressions += r.Impressions
+	}
+
+	return res, nil
+}
+
+// 直近の日別集計を作り直す
+func (s *analyticsService) RollupRecent(ctx context.Context) (int64, error) {
+	tomorrow := truncateDay(s.now()).AddDate(0, 0, 1)
+	return s.analyticsRepository.Rollup(ctx, tomorrow.AddDate(0, 0, -analyticsRollupDays), tomorrow)
+}
+
+func (s *analyticsService) parseDateRange(fromStr, toStr string) (time.Time, time.Time, error) {
+	to := truncateDay(s.now())
+	if toStr != "" {
+		t, err := time.Parse(dto.DayLayout, toStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, errcode.ErrInvalidDateRange
+		}
+		to = t
+	}
+
+	from := to.AddDate(0, 0, -(defaultAnalyticsDays - 1))
+	if fromStr != "" {
+		f, err := time.Parse(dto.DayLayout, fromStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, errcode.ErrInvalidDateRange
+		}
+		from = f
+	}
+
+	if from.After(to) || to.Sub(from) >= maxAnalyticsDays*24*time.Hour {
+		return time.Time{}, time.Time{}, errcode.ErrInvalidDateRange
+	}
+	return from, to, nil
+}
+
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
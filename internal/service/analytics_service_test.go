@@ -0,0 +1,114 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAnalytics(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name      string
+		from      string
+		to        string
+		setupMock func(m *mockAnalyticsRepository)
+		wantErr   error
+		wantDays  int
+		check     func(t *testing.T, days map[string]int64, gained, lost, net, impressions int64)
+	}{
+		{
+			name: "正常系: 指定期間を0で埋めて合計を出す",
+			from: "2026-03-01",
+			to:   "2026-03-03",
+			setupMock: func(m *mockAnalyticsRepository) {
+				m.On("GetDailyStats", mock.Anything, int64(1), day(1), day(3)).Return([]*dto.DailyStatsRecord{
+					{Day: day(1), FollowersGained: 3, FollowersLost: 1, Impressions: 100},
+					{Day: day(3), FollowersGained: 1, TweetsPosted: 2, Impressions: 50},
+				}, nil)
+			},
+			wantDays: 3,
+			check: func(t *testing.T, days map[string]int64, gained, lost, net, impressions int64) {
+				assert.Equal(t, map[string]int64{"2026-03-01": 100, "2026-03-02": 0, "2026-03-03": 50}, days)
+				assert.EqualValues(t, 4, gained)
+				assert.EqualValues(t, 1, lost)
+				assert.EqualValues(t, 3, net)
+				assert.EqualValues(t, 150, impressions)
+			},
+		},
+		{
+			name: "正常系: 省略時は今日までの28日間",
+			setupMock: func(m *mockAnalyticsRepository) {
+				m.On("GetDailyStats", mock.Anything, int64(1), day(10).AddDate(0, 0, -27), day(10)).Return([]*dto.DailyStatsRecord{}, nil)
+			},
+			wantDays: 28,
+		},
+		{name: "日付の形式が不正", from: "2026/03/01", setupMock: func(m *mockAnalyticsRepository) {}, wantErr: errcode.ErrInvalidDateRange},
+		{name: "開始日が終了日より後", from: "2026-03-05", to: "2026-03-01", setupMock: func(m *mockAnalyticsRepository) {}, wantErr: errcode.ErrInvalidDateRange},
+		{name: "期間が90日を超える", from: "2025-12-01", to: "2026-03-01", setupMock: func(m *mockAnalyticsRepository) {}, wantErr: errcode.ErrInvalidDateRange},
+		{
+			name: "異常系: 取得に失敗",
+			from: "2026-03-01",
+			to:   "2026-03-01",
+			setupMock: func(m *mockAnalyticsRepository) {
+				m.On("GetDailyStats", mock.Anything, int64(1), day(1), day(1)).Return(nil, errors.New("db error"))
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(mockAnalyticsRepository)
+			tt.setupMock(m)
+			svc := NewAnalyticsService(m)
+			svc.now = func() time.Time { return now }
+
+			res, err := svc.GetAnalytics(context.Background(), 1, tt.from, tt.to)
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				if errors.Is(tt.wantErr, errcode.ErrInvalidDateRange) {
+					assert.ErrorIs(t, err, errcode.ErrInvalidDateRange)
+				}
+				m.AssertExpectations(t)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, res.Days, tt.wantDays)
+			if tt.check != nil {
+				days := make(map[string]int64, len(res.Days))
+				for _, d := range res.Days {
+					days[d.Day.Format(dto.DayLayout)] = d.Impressions
+				}
+				tt.check(t, days, res.Totals.FollowersGained, res.Totals.FollowersLost, res.Totals.FollowersGained-res.Totals.FollowersLost, res.Totals.Impressions)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRollupRecent(t *testing.T) {
+	m := new(mockAnalyticsRepository)
+	m.On("Rollup", mock.Anything,
+		time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC),
+	).Return(int64(4), nil)
+
+	svc := NewAnalyticsService(m)
+	svc.now = func() time.Time { return time.Date(2026, 3, 10, 23, 59, 0, 0, time.UTC) }
+
+	rows, err := svc.RollupRecent(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, rows)
+	m.AssertExpectations(t)
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"context"
+	"fmt"
+)
+
+type BookmarkRepository interface {
+	Create(ctx context.Context, userID, tweetID int64) (*dto.BookmarkRecord, error)
+	Delete(ctx context.Context, userID, tweetID int64) error
+	List(ctx context.Context, userID, cursor int64, limit int) ([]*dto.BookmarkRecord, error)
+	DeleteByTweet(ctx context.Context, tweetID int64) error
+}
+
+type bookmarkService struct {
+	bookmarkRepository BookmarkRepository
+	tweetProvider      TweetProvider
+}
+
+func NewBookmarkService(br BookmarkRepository, tp TweetProvider) *bookmarkService {
+	return &bookmarkService{
+		bookmarkRepository: br,
+		tweetProvider:      tp,
+	}
+}
+
+func (s *bookmarkService) AddBookmark(ctx context.Context, userID, tweetID int64) (*dto.BookmarkRecord, error) {
+	if userID <= 0 {
+		return nil, errcode.ErrInvalidUserID
+	}
+	if tweetID <= 0 {
+		return nil, errcode.ErrInvalidTweetID
+	}
+
+	return s.bookmarkRepository.Create(ctx, userID, tweetID)
+}
+
+func (s *bookmarkService) RemoveBookmark(ctx context.Context, userID, tweetID int64) error {
+	if userID <= 0 {
+		return errcode.ErrInvalidUserID
+	}
+	if tweetID <= 0 {
+		return errcode.ErrInvalidTweetID
+	}
+
+	return s.bookmarkRepository.Delete(ctx, userID, tweetID)
+}
+
+// 新しい順に返す。次のページがなければ nextCursor は 0
+func (s *bookmarkService) ListBookmarks(ctx context.Context, userID, cursor int64, limit int) ([]*dto.TweetRecord, int64, error) {
+	if userID <= 0 {
+		return nil, 0, errcode.ErrInvalidUserID
+	}
+	if cursor < 0 {
+		return nil, 0, errcode.ErrInvalidCursor
+	}
+	if limit <= 0 {
+		limit = defaultBookmarkLimit
+	}
+	limit = min(limit, maxBookmarkLimit)
+
+	bookmarks, err := s.bookmarkRepository.List(ctx, userID, cursor, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ListBookmarks: ブックマーク一覧の取得に失敗しました (user_id: %d): %w", userID, err)
+	}
+
+	var nextCursor int64
+	if len(bookmarks) == limit {
+		nextCursor = bookmarks[len(bookmarks)-1].ID
+	}
+	if len(bookmarks) == 0 {
+		return []*dto.TweetRecord{}, nextCursor, nil
+	}
+
+	tweetIDs := make([]int64, len(bookmarks))
+	for i, b := range bookmarks {
+		tweetIDs[i] = b.TweetID
+	}
+
+	tweets, err := s.tweetProvider.GetTweets(ctx, tweetIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	return tweets, nextCursor, nil
+}
+
+// ツイート削除の拡散処理から呼ばれる
+func (s *bookmarkService) RemoveBookmarksForTweet(ctx context.Context, tweetID int64) error {
+	if tweetID <= 0 {
+		return errcode.ErrTweetNotFound
+	}
+
+	if err := s.bookmarkRepository.DeleteByTweet(ctx, tweetID); err != nil {
+		return fmt.Errorf("RemoveBookmarksForTweet: ブックマークの削除に失敗しました (tweet_id: %d): %w", tweetID, err)
+	}
+	return nil
+}
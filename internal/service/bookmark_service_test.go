@@ -0,0 +1,112 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListBookmarks(t *testing.T) {
+	tests := []struct {
+		name           string
+		cursor         int64
+		limit          int
+		setupMock      func(mr *mockBookmarkRepository, mp *mockTweetProvider)
+		wantedErr      error
+		wantTweetIDs   []int64
+		wantNextCursor int64
+	}{
+		{
+			name:  "正常系: ページが埋まれば最後のブックマークIDを次のカーソルにする",
+			limit: 2,
+			setupMock: func(mr *mockBookmarkRepository, mp *mockTweetProvider) {
+				mr.On("List", mock.Anything, int64(1), int64(0), 2).Return([]*dto.BookmarkRecord{
+					{ID: 9, TweetID: 100},
+					{ID: 7, TweetID: 50},
+				}, nil)
+				mp.On("GetTweets", mock.Anything, []int64{100, 50}).Return([]*dto.TweetRecord{{ID: 100}, {ID: 50}}, nil)
+			},
+			wantTweetIDs:   []int64{100, 50},
+			wantNextCursor: 7,
+		},
+		{
+			name:   "正常系: 最後のページでは次のカーソルを返さない",
+			cursor: 7,
+			limit:  2,
+			setupMock: func(mr *mockBookmarkRepository, mp *mockTweetProvider) {
+				mr.On("List", mock.Anything, int64(1), int64(7), 2).Return([]*dto.BookmarkRecord{{ID: 3, TweetID: 10}}, nil)
+				mp.On("GetTweets", mock.Anything, []int64{10}).Return([]*dto.TweetRecord{{ID: 10}}, nil)
+			},
+			wantTweetIDs:   []int64{10},
+			wantNextCursor: 0,
+		},
+		{
+			name:  "正常系: 件数の上限を超える指定は丸める",
+			limit: 1000,
+			setupMock: func(mr *mockBookmarkRepository, mp *mockTweetProvider) {
+				mr.On("List", mock.Anything, int64(1), int64(0), maxBookmarkLimit).Return([]*dto.BookmarkRecord{}, nil)
+			},
+			wantTweetIDs: []int64{},
+		},
+		{
+			name:      "異常系: 負のカーソル",
+			cursor:    -1,
+			setupMock: func(mr *mockBookmarkRepository, mp *mockTweetProvider) {},
+			wantedErr: errcode.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := new(mockBookmarkRepository)
+			mp := new(mockTweetProvider)
+			tt.setupMock(mr, mp)
+			svc := NewBookmarkService(mr, mp)
+
+			tweets, next, err := svc.ListBookmarks(context.Background(), 1, tt.cursor, tt.limit)
+
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+				return
+			}
+			require.NoError(t, err)
+			ids := make([]int64, 0, len(tweets))
+			for _, tw := range tweets {
+				ids = append(ids, tw.ID)
+			}
+			assert.Equal(t, tt.wantTweetIDs, ids)
+			assert.Equal(t, tt.wantNextCursor, next)
+			mr.AssertExpectations(t)
+			mp.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAddBookmark(t *testing.T) {
+	mr := new(mockBookmarkRepository)
+	mr.On("Create", mock.Anything, int64(1), int64(5)).Return(nil, errcode.ErrAlreadyBookmarked)
+	svc := NewBookmarkService(mr, new(mockTweetProvider))
+
+	_, err := svc.AddBookmark(context.Background(), 1, 5)
+	assert.ErrorIs(t, err, errcode.ErrAlreadyBookmarked)
+
+	_, err = svc.AddBookmark(context.Background(), 1, 0)
+	assert.ErrorIs(t, err, errcode.ErrInvalidTweetID)
+	mr.AssertExpectations(t)
+}
+
+func TestRemoveBookmarksForTweet(t *testing.T) {
+	mr := new(mockBookmarkRepository)
+	mr.On("DeleteByTweet", mock.Anything, int64(5)).Return(errMockInternal)
+	svc := NewBookmarkService(mr, new(mockTweetProvider))
+
+	err := svc.RemoveBookmarksForTweet(context.Background(), 5)
+
+	assert.ErrorIs(t, err, errMockInternal)
+	mr.AssertExpectations(t)
+}
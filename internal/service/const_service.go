@@ -3,7 +3,129 @@ package service
 import "time"
 
 const (
-	MaxSessionLife		= 7 * 24 * time.Hour
-	SessionDuration     = 24 * time.Hour
-	editWindow          = 10 * time.Minute
-)
\ No newline at end of file
+	// 一覧で表示するセッションIDのバイト数
+	sessionIDBytes      = 12
+	maxUserAgentLength  = 512
+	sessionTouchInterval = 5 * time.Minute
+
+	defaultRecommendationLimit = 20
+	maxRecommendationLimit     = 50
+	popularPoolSize            = 200
+
+	maxScheduleAhead           = 365 * 24 * time.Hour
+	scheduleResyncBatchSize    = 500
+
+	maxMediaPerTweet           = 4
+
+	minPollOptions             = 2
+	maxPollOptions             = 4
+	maxPollOptionLength        = 25
+	minPollDuration            = 5 * time.Minute
+	maxPollDuration            = 7 * 24 * time.Hour
+
+	defaultBookmarkLimit       = 20
+	maxBookmarkLimit           = 100
+
+	defaultNotificationLimit   = 20
+	maxNotificationLimit       = 100
+	maxMentionsPerTweet        = 10
+	maxGroupActors             = 3
+
+	// 1件の Pub/Sub メッセージに載せる配信先の上限
+	streamPublishBatchSize     = 1000
+	// 再接続時に Stream から一度に読むイベント数(全ユーザー分)
+	streamReplayPageSize       = 500
+	// 再接続時に再送するイベント数の上限(接続したユーザー宛て)
+	maxStreamReplay            = 1000
+	// 再接続時に遡って読むイベント数の上限(全ユーザー分)
+	maxStreamReplayScan        = 10000
+	// 読み取りが追いつかない接続を切断するまでに溜められるイベント数
+	streamBufferSize           = 64
+
+	gatewayChannelHome         = "home"
+	gatewayChannelTweetPrefix  = "tweet:"
+	gatewayChannelDMPrefix     = "dm:"
+	maxGatewayChannels         = 20
+	gatewayBufferSize          = 64
+	// 同じチャンネルへの入力中通知はこの間隔より頻繁には配らない
+	gatewayTypingInterval      = 3 * time.Second
+	// 接続用チケットは発行後すぐに使う前提で短くする
+	gatewayTicketTTL           = 30 * time.Second
+
+	// 自分を含む会話の参加者数の上限
+	maxDMParticipants          = 10
+	defaultDMConversationLimit = 20
+	maxDMConversationLimit     = 50
+	defaultDMMessageLimit      = 50
+	maxDMMessageLimit          = 100
+
+	maxListMembers             = 500
+	defaultListTimelineLimit   = 20
+	maxListTimelineLimit       = 100
+
+	// ハッシュタグを数える時間枠の幅
+	trendBucketSize            = 5 * time.Minute
+	// 直近とみなす枠の数(1時間)
+	trendRecentBuckets         = 12
+	// 伸びを比べる過去の枠の数(24時間)
+	trendBaselineBuckets       = 288
+	trendBucketTTL             = (trendRecentBuckets + trendBaselineBuckets + 1) * trendBucketSize
+	// 集計が止まった場合に古いトレンドを出し続けないための期限
+	trendTopTTL                = 1 * time.Hour
+	maxTrendTopN               = 30
+	defaultTrendLimit          = 10
+	// 直近の出現数がこれ未満のハッシュタグはトレンドにしない
+	minTrendTweetCount         = 5
+	maxHashtagsPerTweet        = 10
+
+	// 同じ閲覧者の閲覧をこの時間枠内では1回と数える
+	viewDedupWindow            = 24 * time.Hour
+
+	// メールで送るトークンの乱数のバイト数
+	userTokenBytes             = 32
+	maxVerificationResendsPerHour = 5
+
+	defaultAnalyticsDays       = 28
+	maxAnalyticsDays           = 90
+	// 遅れて届いた閲覧数の永続化などを拾えるよう、前日分も作り直す
+	analyticsRollupDays        = 2
+)
+
+const (
+	passwordResetMailSubject = "パスワードの再設定"
+	passwordResetMailBody    = `%s さん
+
+パスワードの再設定が申請されました。
+以下のリンクから新しいパスワードを設定してください。リンクの有効期限は%d分です。
+
+%s
+
+このメールに心当たりがない場合は、何もする必要はありません。
+`
+)
+
+const (
+	emailVerificationMailSubject = "メールアドレスの確認"
+	emailVerificationMailBody    = `%s さん
+
+ご登録ありがとうございます。
+以下のリンクからメールアドレスを確認してください。リンクの有効期限は%d時間です。
+
+%s
+
+このメールに心当たりがない場合は、何もする必要はありません。
+`
+)
+
+// 受け付ける画像形式。キーは http.DetectContentType の判定結果、値は image.DecodeConfig が返す形式名
+var allowedMediaTypes = map[string]string{
+	"image/jpeg": "jpeg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+var mediaExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
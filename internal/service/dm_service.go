@@ -0,0 +1,290 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+)
+
+type DMRepository interface {
+	CreateConversation(ctx context.Context, creatorID int64, participantIDs []int64) (*dto.DMConversationRecord, error)
+	ListConversations(ctx context.Context, userID int64, limit int) ([]*dto.DMConversationRecord, error)
+	GetConversation(ctx context.Context, conversationID, userID int64) (*dto.DMConversationRecord, error)
+	IsParticipant(ctx context.Context, conversationID, userID int64) (bool, error)
+	SendMessage(ctx context.Context, record *dto.DMMessageRecord, recipientIDs []int64) (*dto.DMMessageRecord, error)
+	ListMessages(ctx context.Context, conversationID, cursor int64, limit int) ([]*dto.DMMessageRecord, error)
+	MarkRead(ctx context.Context, conversationID, userID, cursor int64) (int64, error)
+	UnreadCounts(ctx context.Context, userID int64) (map[int64]int64, error)
+	GetDeniedRecipients(ctx context.Context, senderID int64, recipientIDs []int64) ([]int64, error)
+	UpdateDMPolicy(ctx context.Context, userID int64, policy string) error
+}
+
+type DMUserProvider interface {
+	GetInfoLists(ctx context.Context, userIDs []int64) ([]*dto.UserSlimRecord, error)
+}
+
+type dmService struct {
+	dmRepository    DMRepository
+	userProvider    DMUserProvider
+	topicRepository TopicRepository
+}
+
+func NewDMService(dr DMRepository, up DMUserProvider, tr TopicRepository) *dmService {
+	return &dmService{
+		dmRepository:    dr,
+		userProvider:    up,
+		topicRepository: tr,
+	}
+}
+
+// participantIDs は自分以外の参加者。1人なら1対1の会話になり、既にあればそれを返す
+func (s *dmService) CreateConversation(ctx context.Context, userID int64, participantIDs []int64) (*dto.DMConversationRecord, error) {
+	if userID <= 0 {
+		return nil, errcode.ErrInvalidUserID
+	}
+	if len(participantIDs) == 0 || len(participantIDs) > maxDMParticipants-1 {
+		return nil, errcode.ErrInvalidDMParticipants
+	}
+
+	seen := make(map[int64]struct{}, len(participantIDs))
+	for _, id := range participantIDs {
+		if id <= 0 || id == userID {
+			return nil, errcode.ErrInvalidDMParticipants
+		}
+		if _, ok := seen[id]; ok {
+			return nil, errcode.ErrInvalidDMParticipants
+		}
+		seen[id] = struct{}{}
+	}
+
+	users, err := s.userProvider.GetInfoLists(ctx, participantIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) != len(participantIDs) {
+		return nil, errcode.ErrUserNotFound
+	}
+
+	if err := s.checkAllowed(ctx, userID, participantIDs); err != nil {
+		return nil, err
+	}
+
+	members := append([]int64{userID}, participantIDs...)
+	conv, err := s.dmRepository.CreateConversation(ctx, userID, members)
+	if err != nil {
+		return nil, fmt.Errorf("CreateConversation: 会話の作成に失敗しました (user_id: %d): %w", userID, err)
+	}
+
+	if err := s.fillUsernames(ctx, []*dto.DMConversationRecord{conv}); err != nil {
+		return nil, err
+	}
+	return conv, nil
+}
+
+// 受信者の DM 設定で1人でも拒否されていれば送れない
+func (s *dmService) checkAllowed(ctx context.Context, senderID int64, recipientIDs []int64) error {
+	denied, err := s.dmRepository.GetDeniedRecipients(ctx, senderID, recipientIDs)
+	if err != nil {
+		return err
+	}
+	if len(denied) > 0 {
+		return errcode.ErrDMNotAllowed
+	}
+	return nil
+}
+
+// 最後のメッセージが新しい順に、会話ごとの未読数を付けて返す
+func (s *dmService) ListConversations(ctx context.Context, userID int64, limit int) ([]*dto.DMConversationRecord, error) {
+	if userID <= 0 {
+		return nil, errcode.ErrInvalidUserID
+	}
+	if limit <= 0 {
+		limit = defaultDMConversationLimit
+	}
+	limit = min(limit, maxDMConversationLimit)
+
+	conversations, err := s.dmRepository.ListConversations(ctx, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ListConversations: 会話一覧の取得に失敗しました (user_id: %d): %w", userID, err)
+	}
+	if len(conversations) == 0 {
+		return []*dto.DMConversationRecord{}, nil
+	}
+
+	unread, err := s.dmRepository.UnreadCounts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range conversations {
+		c.UnreadCount = unread[c.ID]
+	}
+
+	if err := s.fillUsernames(ctx, conversations); err != nil {
+		return nil, err
+	}
+	return conversations, nil
+}
+
+func (s *dmService) fillUsernames(ctx context.Context, conversations []*dto.DMConversationRecord) error {
+	ids := make([]int64, 0, len(conversations)*2)
+	seen := make(map[int64]bool, len(conversations)*2)
+	for _, c := range conversations {
+		for _, p := range c.Participants {
+			if !seen[p.UserID] {
+				seen[p.UserID] = true
+				ids = append(ids, p.UserID)
+			}
+		}
+	}
+
+	users, err := s.userProvider.GetInfoLists(ctx, ids)
+	if err != nil {
+		return err
+	}
+	usernames := make(map[int64]string, len(users))
+	for _, u := range users {
+		usernames[u.ID] = u.Username
+	}
+	for _, c := range conversations {
+		for _, p := range c.Participants {
+			p.Username = usernames[p.UserID]
+		}
+	}
+	return nil
+}
+
+// 1対1の会話は相手が DM 設定を変えた場合に備えて送信のたびに確認する
+func (s *dmService) SendMessage(ctx context.Context, userID, conversationID int64, content string) (*dto.DMMessageRecord, error) {
+	if userID <= 0 {
+		return nil, errcode.ErrInvalidUserID
+	}
+	if conversationID <= 0 {
+		return nil, errcode.ErrInvalidConversationID
+	}
+
+	conv, err := s.dmRepository.GetConversation(ctx, conversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	recipientIDs := conv.OtherParticipantIDs(userID)
+	if !conv.IsGroup {
+		if err := s.checkAllowed(ctx, userID, recipientIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	msg, err := s.dmRepository.SendMessage(ctx, &dto.DMMessageRecord{
+		ConversationID: conversationID,
+		SenderID:       userID,
+		Content:        content,
+	}, recipientIDs)
+	if err != nil {
+		return nil, fmt.Errorf("SendMessage: メッセージの送信に失敗しました (conversation_id: %d): %w", conversationID, err)
+	}
+
+	s.publish(ctx, conversationID, models.TopicMessageDM, userID, msg.ID)
+	return msg, nil
+}
+
+// 新しい順に返す。次のページがなければ nextCursor は 0
+func (s *dmService) ListMessages(ctx context.Context, userID, conversationID, cursor int64, limit int) ([]*dto.DMMessageRecord, int64, error) {
+	if userID <= 0 {
+		return nil, 0, errcode.ErrInvalidUserID
+	}
+	if conversationID <= 0 {
+		return nil, 0, errcode.ErrInvalidConversationID
+	}
+	if cursor < 0 {
+		return nil, 0, errcode.ErrInvalidCursor
+	}
+	if limit <= 0 {
+		limit = defaultDMMessageLimit
+	}
+	limit = min(limit, maxDMMessageLimit)
+
+	if err := s.requireParticipant(ctx, conversationID, userID); err != nil {
+		return nil, 0, err
+	}
+
+	messages, err := s.dmRepository.ListMessages(ctx, conversationID, cursor, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ListMessages: メッセージの取得に失敗しました (conversation_id: %d): %w", conversationID, err)
+	}
+
+	var nextCursor int64
+	if len(messages) == limit {
+		nextCursor = messages[len(messages)-1].ID
+	}
+	return messages, nextCursor, nil
+}
+
+// cursor 以下のメッセージを既読にし、既読位置を他の参加者に知らせる。0 なら最新まで
+func (s *dmService) MarkRead(ctx context.Context, userID, conversationID, cursor int64) (int64, error) {
+	if userID <= 0 {
+		return 0, errcode.ErrInvalidUserID
+	}
+	if conversationID <= 0 {
+		return 0, errcode.ErrInvalidConversationID
+	}
+	if cursor < 0 {
+		return 0, errcode.ErrInvalidCursor
+	}
+
+	readID, err := s.dmRepository.MarkRead(ctx, conversationID, userID, cursor)
+	if err != nil {
+		return 0, err
+	}
+
+	s.publish(ctx, conversationID, models.TopicMessageDMRead, userID, readID)
+	return readID, nil
+}
+
+func (s *dmService) UpdateDMPolicy(ctx context.Context, userID int64, policy string) error {
+	if userID <= 0 {
+		return errcode.ErrInvalidUserID
+	}
+	switch policy {
+	case models.DMPolicyEveryone, models.DMPolicyFollowing, models.DMPolicyNobody:
+	default:
+		return errcode.ErrInvalidRequestFormat
+	}
+
+	return s.dmRepository.UpdateDMPolicy(ctx, userID, policy)
+}
+
+// WebSocket の DM チャンネルを購読できるかの確認に使う
+func (s *dmService) IsParticipant(ctx context.Context, conversationID, userID int64) (bool, error) {
+	if conversationID <= 0 || userID <= 0 {
+		return false, nil
+	}
+	return s.dmRepository.IsParticipant(ctx, conversationID, userID)
+}
+
+func (s *dmService) requireParticipant(ctx context.Context, conversationID, userID int64) error {
+	ok, err := s.dmRepository.IsParticipant(ctx, conversationID, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errcode.ErrConversationNotFound
+	}
+	return nil
+}
+
+// 接続中のクライアントへの配信は補助的なものなので、失敗しても操作は成功とする
+func (s *dmService) publish(ctx context.Context, conversationID int64, messageType string, actorID, messageID int64) {
+	err := s.topicRepository.Publish(ctx, &dto.TopicMessageRecord{
+		Topic:     gatewayChannelDMPrefix + strconv.FormatInt(conversationID, 10),
+		Type:      messageType,
+		ActorID:   actorID,
+		MessageID: messageID,
+	})
+	if err != nil {
+		slog.Warn("DM のリアルタイム配信に失敗しました", "conversation_id", conversationID, "type", messageType, "err", err)
+	}
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateConversation(t *testing.T) {
+	direct := &dto.DMConversationRecord{
+		ID: 7,
+		Participants: []*dto.DMParticipantRecord{{UserID: 1}, {UserID: 2}},
+	}
+
+	tests := []struct {
+		name           string
+		participantIDs []int64
+		setupMock      func(mr *mockDMRepository, mu *mockNotificationUserProvider)
+		wantedErr      error
+	}{
+		{
+			name:           "正常系: 1対1の会話を作成し参加者のユーザー名を埋める",
+			participantIDs: []int64{2},
+			setupMock: func(mr *mockDMRepository, mu *mockNotificationUserProvider) {
+				mu.On("GetInfoLists", mock.Anything, []int64{2}).Return([]*dto.UserSlimRecord{{ID: 2, Username: "bob"}}, nil).Once()
+				mr.On("GetDeniedRecipients", mock.Anything, int64(1), []int64{2}).Return([]int64{}, nil)
+				mr.On("CreateConversation", mock.Anything, int64(1), []int64{1, 2}).Return(direct, nil)
+				mu.On("GetInfoLists", mock.Anything, []int64{1, 2}).Return([]*dto.UserSlimRecord{{ID: 1, Username: "alice"}, {ID: 2, Username: "bob"}}, nil).Once()
+			},
+		},
+		{
+			name:           "異常系: 自分を指定",
+			participantIDs: []int64{1},
+			setupMock:      func(mr *mockDMRepository, mu *mockNotificationUserProvider) {},
+			wantedErr:      errcode.ErrInvalidDMParticipants,
+		},
+		{
+			name:           "異常系: 重複した参加者",
+			participantIDs: []int64{2, 2},
+			setupMock:      func(mr *mockDMRepository, mu *mockNotificationUserProvider) {},
+			wantedErr:      errcode.ErrInvalidDMParticipants,
+		},
+		{
+			name:           "異常系: 参加者が多すぎる",
+			participantIDs: []int64{2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+			setupMock:      func(mr *mockDMRepository, mu *mockNotificationUserProvider) {},
+			wantedErr:      errcode.ErrInvalidDMParticipants,
+		},
+		{
+			name:           "異常系: 存在しないユーザー",
+			participantIDs: []int64{2, 3},
+			setupMock: func(mr *mockDMRepository, mu *mockNotificationUserProvider) {
+				mu.On("GetInfoLists", mock.Anything, []int64{2, 3}).Return([]*dto.UserSlimRecord{{ID: 2, Username: "bob"}}, nil)
+			},
+			wantedErr: errcode.ErrUserNotFound,
+		},
+		{
+			name:           "異常系: DM 設定で拒否されている",
+			participantIDs: []int64{2, 3},
+			setupMock: func(mr *mockDMRepository, mu *mockNotificationUserProvider) {
+				mu.On("GetInfoLists", mock.Anything, []int64{2, 3}).Return([]*dto.UserSlimRecord{{ID: 2}, {ID: 3}}, nil)
+				mr.On("GetDeniedRecipients", mock.Anything, int64(1), []int64{2, 3}).Return([]int64{3}, nil)
+			},
+			wantedErr: errcode.ErrDMNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := new(mockDMRepository)
+			mu := new(mockNotificationUserProvider)
+			tt.setupMock(mr, mu)
+			svc := NewDMService(mr, mu, new(mockTopicRepository))
+
+			conv, err := svc.CreateConversation(context.Background(), 1, tt.participantIDs)
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+				mr.AssertNotCalled(t, "CreateConversation", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "alice", conv.Participants[0].Username)
+			assert.Equal(t, "bob", conv.Participants[1].Username)
+			mr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSendDM(t *testing.T) {
+	direct := &dto.DMConversationRecord{ID: 7, Participants: []*dto.DMParticipantRecord{{UserID: 1}, {UserID: 2}}}
+	group := &dto.DMConversationRecord{ID: 8, IsGroup: true, Participants: []*dto.DMParticipantRecord{{UserID: 1}, {UserID: 2}, {UserID: 3}}}
+
+	tests := []struct {
+		name           string
+		conversationID int64
+		setupMock      func(mr *mockDMRepository, mt *mockTopicRepository)
+		wantedErr      error
+	}{
+		{
+			name:           "正常系: 他の参加者の未読を増やしチャンネルに配信する",
+			conversationID: 7,
+			setupMock: func(mr *mockDMRepository, mt *mockTopicRepository) {
+				mr.On("GetConversation", mock.Anything, int64(7), int64(1)).Return(direct, nil)
+				mr.On("GetDeniedRecipients", mock.Anything, int64(1), []int64{2}).Return([]int64{}, nil)
+				mr.On("SendMessage", mock.Anything, &dto.DMMessageRecord{ConversationID: 7, SenderID: 1, Content: "hi"}, []int64{2}).
+					Return(&dto.DMMessageRecord{ID: 30, ConversationID: 7, SenderID: 1, Content: "hi"}, nil)
+				mt.On("Publish", mock.Anything, &dto.TopicMessageRecord{Topic: "dm:7", Type: models.TopicMessageDM, ActorID: 1, MessageID: 30}).Return(nil)
+			},
+		},
+		{
+			name:           "正常系: グループは送信時に DM 設定を確認しない。配信の失敗は無視する",
+			conversationID: 8,
+			setupMock: func(mr *mockDMRepository, mt *mockTopicRepository) {
+				mr.On("GetConversation", mock.Anything, int64(8), int64(1)).Return(group, nil)
+				mr.On("SendMessage", mock.Anything, mock.Anything, []int64{2, 3}).Return(&dto.DMMessageRecord{ID: 31, ConversationID: 8}, nil)
+				mt.On("Publish", mock.Anything, mock.Anything).Return(errors.New("redis down"))
+			},
+		},
+		{
+			name:           "異常系: 相手が DM 設定を変更した",
+			conversationID: 7,
+			setupMock: func(mr *mockDMRepository, mt *mockTopicRepository) {
+				mr.On("GetConversation", mock.Anything, int64(7), int64(1)).Return(direct, nil)
+				mr.On("GetDeniedRecipients", mock.Anything, int64(1), []int64{2}).Return([]int64{2}, nil)
+			},
+			wantedErr: errcode.ErrDMNotAllowed,
+		},
+		{
+			name:           "異常系: 参加していない会話",
+			conversationID: 9,
+			setupMock: func(mr *mockDMRepository, mt *mockTopicRepository) {
+				mr.On("GetConversation", mock.Anything, int64(9), int64(1)).Return(nil, errcode.ErrConversationNotFound)
+			},
+			wantedErr: errcode.ErrConversationNotFound,
+		},
+		{
+			name:           "異常系: 会話IDが不正",
+			conversationID: 0,
+			setupMock:      func(mr *mockDMRepository, mt *mockTopicRepository) {},
+			wantedErr:      errcode.ErrInvalidConversationID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := new(mockDMRepository)
+			mt := new(mockTopicRepository)
+			tt.setupMock(mr, mt)
+			svc := NewDMService(mr, new(mockNotificationUserProvider), mt)
+
+			msg, err := svc.SendMessage(context.Background(), 1, tt.conversationID, "hi")
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+				mr.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.conversationID, msg.ConversationID)
+			mr.AssertExpectations(t)
+			mt.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListDMConversations(t *testing.T) {
+	mr := new(mockDMRepository)
+	mu := new(mockNotificationUserProvider)
+	mr.On("ListConversations", mock.Anything, int64(1), defaultDMConversationLimit).Return([]*dto.DMConversationRecord{
+		{ID: 8, Participants: []*dto.DMParticipantRecord{{UserID: 1}, {UserID: 3}}},
+		{ID: 7, Participants: []*dto.DMParticipantRecord{{UserID: 1}, {UserID: 2}}},
+	}, nil)
+	mr.On("UnreadCounts", mock.Anything, int64(1)).Return(map[int64]int64{7: 4}, nil)
+	mu.On("GetInfoLists", mock.Anything, []int64{1, 3, 2}).Return([]*dto.UserSlimRecord{
+		{ID: 1, Username: "alice"}, {ID: 2, Username: "bob"}, {ID: 3, Username: "carol"},
+	}, nil)
+	svc := NewDMService(mr, mu, new(mockTopicRepository))
+
+	conversations, err := svc.ListConversations(context.Background(), 1, 0)
+	require.NoError(t, err)
+	require.Len(t, conversations, 2)
+	assert.Equal(t, int64(0), conversations[0].UnreadCount)
+	assert.Equal(t, int64(4), conversations[1].UnreadCount)
+	assert.Equal(t, "carol", conversations[0].Participants[1].Username)
+}
+
+func TestListDMMessages(t *testing.T) {
+	t.Run("正常系: ページが埋まれば最後のメッセージIDを次のカーソルにする", func(t *testing.T) {
+		mr := new(mockDMRepository)
+		mr.On("IsParticipant", mock.Anything, int64(7), int64(1)).Return(true, nil)
+		mr.On("ListMessages", mock.Anything, int64(7), int64(50), 2).Return([]*dto.DMMessageRecord{{ID: 49}, {ID: 48}}, nil)
+		svc := NewDMService(mr, new(mockNotificationUserProvider), new(mockTopicRepository))
+
+		messages, next, err := svc.ListMessages(context.Background(), 1, 7, 50, 2)
+		require.NoError(t, err)
+		assert.Len(t, messages, 2)
+		assert.Equal(t, int64(48), next)
+	})
+
+	t.Run("異常系: 参加していない会話", func(t *testing.T) {
+		mr := new(mockDMRepository)
+		mr.On("IsParticipant", mock.Anything, int64(7), int64(1)).Return(false, nil)
+		svc := NewDMService(mr, new(mockNotificationUserProvider), new(mockTopicRepository))
+
+		_, _, err := svc.ListMessages(context.Background(), 1, 7, 0, 0)
+		assert.ErrorIs(t, err, errcode.ErrConversationNotFound)
+		mr.AssertNotCalled(t, "ListMessages", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestMarkDMRead(t *testing.T) {
+	mr := new(mockDMRepository)
+	mt := new(mockTopicRepository)
+	mr.On("MarkRead", mock.Anything, int64(7), int64(1), int64(0)).Return(int64(30), nil)
+	mt.On("Publish", mock.Anything, &dto.TopicMessageRecord{Topic: "dm:7", Type: models.TopicMessageDMRead, ActorID: 1, MessageID: 30}).Return(nil)
+	svc := NewDMService(mr, new(mockNotificationUserProvider), mt)
+
+	readID, err := svc.MarkRead(context.Background(), 1, 7, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(30), readID)
+	mt.AssertExpectations(t)
+
+	_, err = svc.MarkRead(context.Background(), 1, 7, -1)
+	assert.ErrorIs(t, err, errcode.ErrInvalidCursor)
+}
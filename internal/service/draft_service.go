@@ -0,0 +1,165 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"context"
+	"fmt"
+)
+
+type DraftRepository interface {
+	Create(ctx context.Context, record *dto.DraftRecord) (*dto.DraftRecord, error)
+	Get(ctx context.Context, draftID, userID int64) (*dto.DraftRecord, error)
+	List(ctx context.Context, userID int64) ([]*dto.DraftRecord, error)
+	Update(ctx context.Context, record *dto.DraftRecord) (*dto.DraftRecord, error)
+	Delete(ctx context.Context, draftID, userID int64) (*dto.DraftRecord, error)
+}
+
+type TweetPoster interface {
+	InsertTweet(ctx context.Context, userID int64, content string, media []dto.MediaAttachment) (*dto.TweetRecord, error)
+	AnnounceTweet(ctx context.Context, tweet *dto.TweetRecord)
+}
+
+type draftService struct {
+	draftRepository    DraftRepository
+	tweetPoster        TweetPoster
+	transactionManager TransactionManager
+}
+
+func NewDraftService(dr DraftRepository, tp TweetPoster, tm TransactionManager) *draftService {
+	return &draftService{
+		draftRepository:    dr,
+		tweetPoster:        tp,
+		transactionManager: tm,
+	}
+}
+
+func (s *draftService) SaveDraft(ctx context.Context, userID int64, content string, media []dto.MediaAttachment) (*dto.DraftRecord, error) {
+	if userID <= 0 {
+		return nil, errcode.ErrInvalidUserID
+	}
+
+	if content == "" {
+		return nil, errcode.ErrRequiredFieldMissing
+	}
+
+	if err := validateMediaAttachments(media); err != nil {
+		return nil, err
+	}
+
+	draft, err := s.draftRepository.Create(ctx, &dto.DraftRecord{
+		UserID:   userID,
+		Content:  content,
+		Media:    media,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("下書きの保存に失敗しました: %w", err)
+	}
+
+	return draft, nil
+}
+
+func (s *draftService) FetchDraft(ctx context.Context, draftID, userID int64) (*dto.DraftRecord, error) {
+	if err := validateDraftIDs(draftID, userID); err != nil {
+		return nil, err
+	}
+
+	draft, err := s.draftRepository.Get(ctx, draftID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("下書きの取得に失敗しました: %w", err)
+	}
+
+	return draft, nil
+}
+
+func (s *draftService) ListDrafts(ctx context.Context, userID int64) ([]*dto.DraftRecord, error) {
+	if userID <= 0 {
+		return nil, errcode.ErrInvalidUserID
+	}
+
+	drafts, err := s.draftRepository.List(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("下書き一覧の取得に失敗しました: %w", err)
+	}
+
+	return drafts, nil
+}
+
+func (s *draftService) EditDraft(ctx context.Context, draftID, userID int64, content string, media []dto.MediaAttachment) (*dto.DraftRecord, error) {
+	if err := validateDraftIDs(draftID, userID); err != nil {
+		return nil, err
+	}
+
+	if content == "" {
+		return nil, errcode.ErrRequiredFieldMissing
+	}
+
+	if err := validateMediaAttachments(media); err != nil {
+		return nil, err
+	}
+
+	draft, err := s.draftRepository.Update(ctx, &dto.DraftRecord{
+		ID:       draftID,
+		UserID:   userID,
+		Content:  content,
+		Media:    media,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("下書きの更新に失敗しました: %w", err)
+	}
+
+	return draft, nil
+}
+
+func (s *draftService) RemoveDraft(ctx context.Context, draftID, userID int64) error {
+	if err := validateDraftIDs(draftID, userID); err != nil {
+		return err
+	}
+
+	if _, err := s.draftRepository.Delete(ctx, draftID, userID); err != nil {
+		return fmt.Errorf("下書きの削除に失敗しました: %w", err)
+	}
+
+	return nil
+}
+
+// 下書きの削除とツイートの作成を同一トランザクションで行い、どちらか一方だけが残らないようにする。
+// キャッシュ登録とファンアウトはロールバックされたツイートを配信しないようコミット後に行う
+func (s *draftService) PublishDraft(ctx context.Context, draftID, userID int64) (*dto.TweetRecord, error) {
+	if err := validateDraftIDs(draftID, userID); err != nil {
+		return nil, err
+	}
+
+	var tweet *dto.TweetRecord
+	err := s.transactionManager.Exec(ctx, func(txCtx context.Context) error {
+		draft, err := s.draftRepository.Delete(txCtx, draftID, userID)
+		if err != nil {
+			return fmt.Errorf("下書きの取得に失敗しました: %w", err)
+		}
+
+		tweet, err = s.tweetPoster.InsertTweet(txCtx, userID, draft.Content, draft.Media)
+		if err != nil {
+			return fmt.Errorf("下書きの公開に失敗しました: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.tweetPoster.AnnounceTweet(ctx, tweet)
+	return tweet, nil
+}
+
+func validateDraftIDs(draftID, userID int64) error {
+	if userID <= 0 {
+		return errcode.ErrInvalidUserID
+	}
+
+	if draftID <= 0 {
+		return errcode.ErrInvalidDraftID
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveDraft(t *testing.T) {
+	tests := []struct {
+		name      string
+		userID    int64
+		content   string
+		setupMock func(md *mockDraftRepository)
+		wantedErr error
+		errMsg    string
+	}{
+		{
+			name:    "正常系: 下書きが保存される",
+			userID:  1,
+			content: "書きかけ",
+			setupMock: func(md *mockDraftRepository) {
+				md.On("Create", mock.Anything, mock.MatchedBy(func(r *dto.DraftRecord) bool {
+					return r.UserID == 1 && r.Content == "書きかけ"
+				})).Return(&dto.DraftRecord{ID: 3, UserID: 1, Content: "書きかけ"}, nil)
+			},
+		},
+		{
+			name:      "異常系: 本文が空",
+			userID:    1,
+			content:   "",
+			setupMock: func(md *mockDraftRepository) {},
+			wantedErr: errcode.ErrRequiredFieldMissing,
+		},
+		{
+			name:      "異常系: 無効なユーザーID",
+			userID:    0,
+			content:   "書きかけ",
+			setupMock: func(md *mockDraftRepository) {},
+			wantedErr: errcode.ErrInvalidUserID,
+		},
+		{
+			name:    "異常系: DBエラー",
+			userID:  1,
+			content: "書きかけ",
+			setupMock: func(md *mockDraftRepository) {
+				md.On("Create", mock.Anything, mock.Anything).Return(nil, errMockInternal)
+			},
+			wantedErr: errMockInternal,
+			errMsg:    "下書きの保存に失敗しました",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := new(mockDraftRepository)
+			tt.setupMock(md)
+			svc := NewDraftService(md, new(mockTweetPoster), new(mockTransactionManager))
+
+			res, err := svc.SaveDraft(context.Background(), tt.userID, tt.content, nil)
+
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+				assert.Nil(t, res)
+				if tt.errMsg != "" {
+					assert.Contains(t, err.Error(), tt.errMsg)
+				}
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, int64(3), res.ID)
+			}
+
+			md.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPublishDraft(t *testing.T) {
+	media := []dto.MediaAttachment{{MediaID: 9, AltText: "写真"}}
+	tests := []struct {
+		name      string
+		draftID   int64
+		userID    int64
+		setupMock func(md *mockDraftRepository, mp *mockTweetPoster, mtx *mockTransactionManager)
+		wantedErr error
+		errMsg    string
+	}{
+		{
+			name:    "正常系: 下書きがツイートとして公開される",
+			draftID: 3,
+			userID:  1,
+			setupMock: func(md *mockDraftRepository, mp *mockTweetPoster, mtx *mockTransactionManager) {
+				mtx.On("Exec", mock.Anything).Once()
+				md.On("Delete", mock.Anything, int64(3), int64(1)).Return(&dto.DraftRecord{ID: 3, UserID: 1, Content: "公開します", Media: media}, nil)
+				mp.On("InsertTweet", mock.Anything, int64(1), "公開します", media).Return(&dto.TweetRecord{ID: 100, UserID: 1, Content: "公開します"}, nil)
+				mp.On("AnnounceTweet", mock.Anything, mock.MatchedBy(func(tr *dto.TweetRecord) bool { return tr.ID == 100 })).Once()
+			},
+		},
+		{
+			name:    "異常系: 下書きが存在しない",
+			draftID: 3,
+			userID:  1,
+			setupMock: func(md *mockDraftRepository, mp *mockTweetPoster, mtx *mockTransactionManager) {
+				mtx.On("Exec", mock.Anything).Once()
+				md.On("Delete", mock.Anything, int64(3), int64(1)).Return(nil, errcode.ErrDraftNotFound)
+			},
+			wantedErr: errcode.ErrDraftNotFound,
+		},
+		{
+			name:    "異常系: ツイート作成に失敗した場合はエラーを返しトランザクションを中断する",
+			draftID: 3,
+			userID:  1,
+			setupMock: func(md *mockDraftRepository, mp *mockTweetPoster, mtx *mockTransactionManager) {
+				mtx.On("Exec", mock.Anything).Once()
+				md.On("Delete", mock.Anything, int64(3), int64(1)).Return(&dto.DraftRecord{ID: 3, UserID: 1, Content: "公開します"}, nil)
+				mp.On("InsertTweet", mock.Anything, int64(1), "公開します", []dto.MediaAttachment(nil)).Return(nil, errMockInternal)
+			},
+			wantedErr: errMockInternal,
+			errMsg:    "下書きの公開に失敗しました",
+		},
+		{
+			name:    "異常系: コミットに失敗した場合はキャッシュ登録も配信もしない",
+			draftID: 3,
+			userID:  1,
+			setupMock: func(md *mockDraftRepository, mp *mockTweetPoster, mtx *mockTransactionManager) {
+				mtx.On("Exec", mock.Anything).Return(errMockInternal).Once()
+				md.On("Delete", mock.Anything, int64(3), int64(1)).Return(&dto.DraftRecord{ID: 3, UserID: 1, Content: "公開します"}, nil)
+				mp.On("InsertTweet", mock.Anything, int64(1), "公開します", []dto.MediaAttachment(nil)).Return(&dto.TweetRecord{ID: 100, UserID: 1, Content: "公開します"}, nil)
+			},
+			wantedErr: errMockInternal,
+		},
+		{
+			name:      "異常系: 無効な下書きID",
+			draftID:   0,
+			userID:    1,
+			setupMock: func(md *mockDraftRepository, mp *mockTweetPoster, mtx *mockTransactionManager) {},
+			wantedErr: errcode.ErrInvalidDraftID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := new(mockDraftRepository)
+			mp := new(mockTweetPoster)
+			mtx := new(mockTransactionManager)
+			tt.setupMock(md, mp, mtx)
+			svc := NewDraftService(md, mp, mtx)
+
+			res, err := svc.PublishDraft(context.Background(), tt.draftID, tt.userID)
+
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+				assert.Nil(t, res)
+				if tt.errMsg != "" {
+					assert.Contains(t, err.Error(), tt.errMsg)
+				}
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, int64(100), res.ID)
+			}
+
+			if tt.wantedErr != nil {
+				mp.AssertNotCalled(t, "AnnounceTweet", mock.Anything, mock.Anything)
+			}
+			md.AssertExpectations(t)
+			mp.AssertExpectations(t)
+			mtx.AssertExpectations(t)
+		})
+	}
+}
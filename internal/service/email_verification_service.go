@@ -0,0 +1,197 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/panjf2000/ants/v2"
+)
+
+type VerificationUserRepository interface {
+	GetFullByID(ctx context.Context, userID int64) (*dto.UserRecord, error)
+	MarkEmailVerified(ctx context.Context, userID int64) error
+}
+
+type RateLimitRepository interface {
+	Allow(ctx context.Context, key string, limit int64, window time.Duration) (bool, error)
+}
+
+type emailVerificationService struct {
+	userRepository      VerificationUserRepository
+	userTokenRepository UserTokenRepository
+	rateLimitRepository RateLimitRepository
+	transactionManager  TransactionManager
+	tokenManager        TokenManager
+	mailer              Mailer
+	pool                *ants.Pool
+	policy              dto.VerificationPolicy
+	tokenTTL            time.Duration
+	verifyURL           string
+	now                 func() time.Time
+}
+
+func NewEmailVerificationService(
+	ur VerificationUserRepository,
+	utr UserTokenRepository,
+	rl RateLimitRepository,
+	tm TransactionManager,
+	tkm TokenManager,
+	m Mailer,
+	p *ants.Pool,
+	policy dto.VerificationPolicy,
+	tokenTTL time.Duration,
+	verifyURL string,
+) *emailVerificationService {
+	return &emailVerificationService{
+		userRepository:      ur,
+		userTokenRepository: utr,
+		rateLimitRepository: rl,
+		transactionManager:  tm,
+		tokenManager:        tkm,
+		mailer:              m,
+		pool:                p,
+		policy:              policy,
+		tokenTTL:            tokenTTL,
+		verifyURL:           verifyURL,
+		now:                 time.Now,
+	}
+}
+
+// 登録直後に呼ぶ。送信に失敗しても登録は成功させ、再送で対応してもらう
+func (s *emailVerificationService) SendVerification(ctx context.Context, userID int64) error {
+	if userID <= 0 {
+		return errcode.ErrInvalidUserID
+	}
+
+	return s.submitVerificationMail(userID)
+}
+
+// 再送は1分に1回、1時間に5回まで
+func (s *emailVerificationService) ResendVerification(ctx context.Context, userID int64) error {
+	if userID <= 0 {
+		return errcode.ErrInvalidUserID
+	}
+
+	user, err := s.userRepository.GetFullByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("ResendVerification: ユーザー情報の取得に失敗しました: %w", err)
+	}
+	if user.IsEmailVerified() {
+		return errcode.ErrEmailAlreadyVerified
+	}
+
+	limits := []struct {
+		key    string
+		limit  int64
+		window time.Duration
+	}{
+		{key: fmt.Sprintf("verify_resend:min:%d", userID), limit: 1, window: time.Minute},
+		{key: fmt.Sprintf("verify_resend:hour:%d", userID), limit: maxVerificationResendsPerHour, window: time.Hour},
+	}
+	for _, l := range limits {
+		allowed, err := s.rateLimitRepository.Allow(ctx, l.key, l.limit, l.window)
+		if err != nil {
+			return fmt.Errorf("ResendVerification: 回数制限の確認に失敗しました: %w", err)
+		}
+		if !allowed {
+			return errcode.ErrTooManyRequests
+		}
+	}
+
+	return s.submitVerificationMail(userID)
+}
+
+func (s *emailVerificationService) submitVerificationMail(userID int64) error {
+	err := s.pool.Submit(func() {
+		bgCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		s.sendVerificationMail(bgCtx, userID)
+	})
+	if err != nil {
+		return fmt.Errorf("確認メールのタスク投入に失敗しました (user_id: %d): %w", userID, err)
+	}
+
+	return nil
+}
+
+func (s *emailVerificationService) sendVerificationMail(ctx context.Context, userID int64) {
+	user, err := s.userRepository.GetFullByID(ctx, userID)
+	if err != nil {
+		slog.Error("メールアドレス確認: ユーザーの取得に失敗しました", "user_id", userID, "err", err)
+		return
+	}
+	if user.IsEmailVerified() {
+		return
+	}
+
+	token, err := s.tokenManager.Generate(userTokenBytes)
+	if err != nil {
+		slog.Error("メールアドレス確認: トークンの生成に失敗しました", "user_id", userID, "err", err)
+		return
+	}
+
+	_, err = s.userTokenRepository.Issue(ctx, &dto.UserTokenRecord{
+		UserID:    user.ID,
+		Purpose:   models.TokenPurposeEmailVerification,
+		TokenHash: s.tokenManager.Hash(token),
+		ExpiresAt: s.now().Add(s.tokenTTL).UTC(),
+	})
+	if err != nil {
+		slog.Error("メールアドレス確認: トークンの保存に失敗しました", "user_id", userID, "err", err)
+		return
+	}
+
+	body := fmt.Sprintf(emailVerificationMailBody, user.Username, int(s.tokenTTL.Hours()), s.verifyURL+"?token="+url.QueryEscape(token))
+	if err := s.mailer.Send(ctx, user.Email, emailVerificationMailSubject, body); err != nil {
+		slog.Error("メールアドレス確認: メールの送信に失敗しました", "user_id", userID, "err", err)
+	}
+}
+
+func (s *emailVerificationService) VerifyEmail(ctx context.Context, token string) error {
+	if token == "" {
+		return errcode.ErrRequiredFieldMissing
+	}
+	if len(token) < 32 || len(token) > 255 {
+		return errcode.ErrInvalidVerificationToken
+	}
+
+	return s.transactionManager.Exec(ctx, func(txCtx context.Context) error {
+		record, err := s.userTokenRepository.Consume(txCtx, models.TokenPurposeEmailVerification, s.tokenManager.Hash(token))
+		if err != nil {
+			if errors.Is(err, errcode.ErrUserTokenNotFound) {
+				return errcode.ErrInvalidVerificationToken
+			}
+			return fmt.Errorf("トークンの確認に失敗しました: %w", err)
+		}
+
+		if err := s.userRepository.MarkEmailVerified(txCtx, record.UserID); err != nil {
+			return fmt.Errorf("メールアドレスの確認状態の更新に失敗しました: %w", err)
+		}
+		return nil
+	})
+}
+
+// ポリシーで確認が必要とされた操作を、未確認のユーザーに対して ErrEmailNotVerified で拒否する
+func (s *emailVerificationService) CheckAllowed(ctx context.Context, userID int64, action string) error {
+	if !s.policy.Requires(action) {
+		return nil
+	}
+
+	user, err := s.userRepository.GetFullByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("CheckAllowed: ユーザー情報の取得に失敗しました: %w", err)
+	}
+	if !user.IsEmailVerified() {
+		return errcode.ErrEmailNotVerified
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type verificationMocks struct {
+	users   *mockUserRepository
+	tokens  *mockUserTokenRepository
+	limiter *mockRateLimitRepository
+	tm      *mockTokenManager
+	mailer  *mockMailer
+}
+
+func newEmailVerificationServiceForTest(policy string) (*emailVerificationService, *verificationMocks) {
+	m := &verificationMocks{
+		users:   new(mockUserRepository),
+		tokens:  new(mockUserTokenRepository),
+		limiter: new(mockRateLimitRepository),
+		tm:      new(mockTokenManager),
+		mailer:  new(mockMailer),
+	}
+	tx := new(mockTransactionManager)
+	tx.On("Exec", mock.Anything).Maybe()
+	svc := NewEmailVerificationService(m.users, m.tokens, m.limiter, tx, m.tm, m.mailer, nil,
+		dto.NewVerificationPolicy(policy), 24*time.Hour, "https://aita.example/email/verify")
+	return svc, m
+}
+
+func TestSendVerificationMail(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	verifiedAt := now
+
+	tests := []struct {
+		name      string
+		setupMock func(m *verificationMocks)
+	}{
+		{
+			name: "正常系: トークンを保存してリンクを送る",
+			setupMock: func(m *verificationMocks) {
+				m.users.On("GetFullByID", mock.Anything, int64(3)).Return(&dto.UserRecord{ID: 3, Username: "alice", Email: "a@example.com"}, nil)
+				m.tm.On("Generate", userTokenBytes).Return("plain", nil)
+				m.tm.On("Hash", "plain").Return("hashed")
+				m.tokens.On("Issue", mock.Anything, &dto.UserTokenRecord{
+					UserID:    3,
+					Purpose:   models.TokenPurposeEmailVerification,
+					TokenHash: "hashed",
+					ExpiresAt: now.Add(24 * time.Hour),
+				}).Return(&dto.UserTokenRecord{ID: 1}, nil)
+				m.mailer.On("Send", mock.Anything, "a@example.com", emailVerificationMailSubject, mock.MatchedBy(func(body string) bool {
+					return strings.Contains(body, "https://aita.example/email/verify?token=plain")
+				})).Return(nil)
+			},
+		},
+		{
+			name: "確認済みなら送らない",
+			setupMock: func(m *verificationMocks) {
+				m.users.On("GetFullByID", mock.Anything, int64(3)).Return(&dto.UserRecord{ID: 3, EmailVerifiedAt: &verifiedAt}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newEmailVerificationServiceForTest("")
+			svc.now = func() time.Time { return now }
+			tt.setupMock(m)
+
+			svc.sendVerificationMail(context.Background(), 3)
+
+			m.tokens.AssertExpectations(t)
+			m.mailer.AssertExpectations(t)
+		})
+	}
+}
+
+func TestResendVerification(t *testing.T) {
+	verifiedAt := time.Now()
+
+	tests := []struct {
+		name      string
+		setupMock func(m *verificationMocks)
+		wantErr   error
+	}{
+		{
+			name: "確認済みなら再送しない",
+			setupMock: func(m *verificationMocks) {
+				m.users.On("GetFullByID", mock.Anything, int64(3)).Return(&dto.UserRecord{ID: 3, EmailVerifiedAt: &verifiedAt}, nil)
+			},
+			wantErr: errcode.ErrEmailAlreadyVerified,
+		},
+		{
+			name: "1分以内の再送は拒否する",
+			setupMock: func(m *verificationMocks) {
+				m.users.On("GetFullByID", mock.Anything, int64(3)).Return(&dto.UserRecord{ID: 3}, nil)
+				m.limiter.On("Allow", mock.Anything, "verify_resend:min:3", int64(1), time.Minute).Return(false, nil)
+			},
+			wantErr: errcode.ErrTooManyRequests,
+		},
+		{
+			name: "1時間の上限を超えた再送は拒否する",
+			setupMock: func(m *verificationMocks) {
+				m.users.On("GetFullByID", mock.Anything, int64(3)).Return(&dto.UserRecord{ID: 3}, nil)
+				m.limiter.On("Allow", mock.Anything, "verify_resend:min:3", int64(1), time.Minute).Return(true, nil)
+				m.limiter.On("Allow", mock.Anything, "verify_resend:hour:3", int64(maxVerificationResendsPerHour), time.Hour).Return(false, nil)
+			},
+			wantErr: errcode.ErrTooManyRequests,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newEmailVerificationServiceForTest("")
+			tt.setupMock(m)
+
+			err := svc.ResendVerification(context.Background(), 3)
+			assert.ErrorIs(t, err, tt.wantErr)
+			m.limiter.AssertExpectations(t)
+		})
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	token := strings.Repeat("t", 43)
+
+	t.Run("正常系: トークンを使って確認済みにする", func(t *testing.T) {
+		svc, m := newEmailVerificationServiceForTest("")
+		m.tm.On("Hash", token).Return("hashed")
+		m.tokens.On("Consume", mock.Anything, models.TokenPurposeEmailVerification, "hashed").Return(&dto.UserTokenRecord{UserID: 3}, nil)
+		m.users.On("MarkEmailVerified", mock.Anything, int64(3)).Return(nil)
+
+		require.NoError(t, svc.VerifyEmail(context.Background(), token))
+		m.users.AssertExpectations(t)
+	})
+
+	t.Run("使用済み・期限切れのトークン", func(t *testing.T) {
+		svc, m := newEmailVerificationServiceForTest("")
+		m.tm.On("Hash", token).Return("hashed")
+		m.tokens.On("Consume", mock.Anything, models.TokenPurposeEmailVerification, "hashed").Return(nil, errcode.ErrUserTokenNotFound)
+
+		assert.ErrorIs(t, svc.VerifyEmail(context.Background(), token), errcode.ErrInvalidVerificationToken)
+		m.users.AssertNotCalled(t, "MarkEmailVerified", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCheckAllowed(t *testing.T) {
+	verifiedAt := time.Now()
+
+	tests := []struct {
+		name      string
+		action    string
+		user      *dto.UserRecord
+		wantErr   error
+		wantQuery bool
+	}{
+		{name: "ポリシー外の操作は確認しない", action: dto.VerifiedActionFollow},
+		{name: "未確認のユーザーは投稿できない", action: dto.VerifiedActionTweet, user: &dto.UserRecord{ID: 3}, wantErr: errcode.ErrEmailNotVerified, wantQuery: true},
+		{name: "確認済みのユーザーは投稿できる", action: dto.VerifiedActionTweet, user: &dto.UserRecord{ID: 3, EmailVerifiedAt: &verifiedAt}, wantQuery: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newEmailVerificationServiceForTest("tweet, dm")
+			if tt.wantQuery {
+				m.users.On("GetFullByID", mock.Anything, int64(3)).Return(tt.user, nil)
+			}
+
+			err := svc.CheckAllowed(context.Background(), 3, tt.action)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			m.users.AssertExpectations(t)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"aita/internal/errcode"
 	"context"
 	"fmt"
+	"log/slog"
 )
 
 type FollowRepository interface {
@@ -26,16 +27,23 @@ type TransactionManager interface {
 	Exec(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
+type FollowNotifier interface {
+	NotifyFollow(ctx context.Context, actorID, targetID int64) error
+}
+
 type followService struct {
 	followRepository 	FollowRepository
 	countManager     	CountManager
 	transactionManager  TransactionManager
+	notifier            FollowNotifier
 }
 
-func NewFollowService(fr FollowRepository, cm CountManager) *followService {
+func NewFollowService(fr FollowRepository, cm CountManager, tm TransactionManager, n FollowNotifier) *followService {
 	return &followService{
 		followRepository: fr,
 		countManager: cm,
+		transactionManager: tm,
+		notifier: n,
 	}
 }
 
@@ -76,6 +84,11 @@ func (s *followService) Follow(ctx context.Context, userID, targetID int64) (*dt
         return nil, err 
     }
 
+	// 通知はフォローの成否に影響させない
+	if err := s.notifier.NotifyFollow(ctx, userID, targetID); err != nil {
+		slog.Error("フォロー通知の作成に失敗しました", "user_id", userID, "target_id", targetID, "err", err)
+	}
+
     return record, nil
 }
 
@@ -0,0 +1,446 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"aita/internal/pkg/utils"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+type TopicRepository interface {
+	Publish(ctx context.Context, record *dto.TopicMessageRecord) error
+	Listen(ctx context.Context) (<-chan *dto.TopicMessageRecord, error)
+	Join(ctx context.Context, topics ...string) error
+	Leave(ctx context.Context, topics ...string) error
+	Close() error
+}
+
+type UserEventSubscriber interface {
+	Subscribe(ctx context.Context, userID int64, lastEventID string) (<-chan *dto.StreamEventRecord, func(), error)
+}
+
+type DMParticipantChecker interface {
+	IsParticipant(ctx context.Context, conversationID, userID int64) (bool, error)
+}
+
+type gatewaySession struct {
+	id     uint64
+	userID int64
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// events への送信と close は mu で直列化する
+	mu       sync.Mutex
+	events   chan *dto.GatewayEventRecord
+	closed   bool
+	channels map[string]func()
+	typedAt  map[string]time.Time
+}
+
+// 溢れた場合、入力中通知のような一時的なイベントは捨て、それ以外は接続を切って再接続させる
+func (sess *gatewaySession) deliver(event *dto.GatewayEventRecord, ephemeral bool) {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	if sess.closed {
+		return
+	}
+	select {
+	case sess.events <- event:
+	default:
+		if ephemeral {
+			return
+		}
+		slog.Warn("GatewayService: バッファが溢れたため接続を切断します", "user_id", sess.userID, "session_id", sess.id)
+		sess.closeLocked()
+	}
+}
+
+func (sess *gatewaySession) close() {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	sess.closeLocked()
+}
+
+func (sess *gatewaySession) closeLocked() {
+	if sess.closed {
+		return
+	}
+	sess.closed = true
+	close(sess.events)
+}
+
+// WebSocket のチャンネル購読を管理する。home はユーザー宛てのイベント、
+// それ以外のチャンネルは Redis の Pub/Sub で全 API ノードに配られる
+type gatewayService struct {
+	topicRepository TopicRepository
+	userEvents      UserEventSubscriber
+	tweetProvider   TweetProvider
+	dmChecker       DMParticipantChecker
+
+	mu       sync.Mutex
+	nextID   uint64
+	sessions map[uint64]*gatewaySession
+	topics   map[string]map[*gatewaySession]struct{}
+	draining bool
+	active   sync.WaitGroup
+}
+
+func NewGatewayService(tr TopicRepository, ue UserEventSubscriber, tp TweetProvider, dc DMParticipantChecker) *gatewayService {
+	return &gatewayService{
+		topicRepository: tr,
+		userEvents:      ue,
+		tweetProvider:   tp,
+		dmChecker:       dc,
+		sessions:        make(map[uint64]*gatewaySession),
+		topics:          make(map[string]map[*gatewaySession]struct{}),
+	}
+}
+
+func (s *gatewayService) Connect(ctx context.Context, userID int64) (*dto.GatewaySession, error) {
+	if userID <= 0 {
+		return nil, errcode.ErrInvalidUserID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.draining {
+		return nil, errcode.ErrServerDraining
+	}
+
+	s.nextID++
+	// 接続の寿命はリクエストではなく Disconnect までとする
+	sessCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	sess := &gatewaySession{
+		id:       s.nextID,
+		userID:   userID,
+		ctx:      sessCtx,
+		cancel:   cancel,
+		events:   make(chan *dto.GatewayEventRecord, gatewayBufferSize),
+		channels: make(map[string]func()),
+		typedAt:  make(map[string]time.Time),
+	}
+	s.sessions[sess.id] = sess
+	s.active.Add(1)
+
+	return &dto.GatewaySession{
+		ID:     sess.id,
+		UserID: userID,
+		Events: sess.events,
+	}, nil
+}
+
+func (s *gatewayService) Subscribe(ctx context.Context, session *dto.GatewaySession, channel string) error {
+	sess, err := s.session(session)
+	if err != nil {
+		return err
+	}
+
+	sess.mu.Lock()
+	_, subscribed := sess.channels[channel]
+	count := len(sess.channels)
+	sess.mu.Unlock()
+	if subscribed {
+		return nil
+	}
+	if count >= maxGatewayChannels {
+		return errcode.ErrTooManyChannels
+	}
+
+	if channel == gatewayChannelHome {
+		return s.subscribeHome(sess)
+	}
+
+	if err := s.authorizeTopic(ctx, sess.userID, channel); err != nil {
+		return err
+	}
+	return s.joinTopic(ctx, sess, channel)
+}
+
+// ユーザー宛てのイベント(タイムライン・通知)を SSE と同じ経路で受け取り、home チャンネルとして流す
+func (s *gatewayService) subscribeHome(sess *gatewaySession) error {
+	events, cancel, err := s.userEvents.Subscribe(sess.ctx, sess.userID, "")
+	if err != nil {
+		return err
+	}
+
+	sess.mu.Lock()
+	sess.channels[gatewayChannelHome] = cancel
+	sess.mu.Unlock()
+
+	go func() {
+		for event := range events {
+			sess.deliver(dto.NewGatewayEventFromStream(gatewayChannelHome, event), false)
+		}
+	}()
+	return nil
+}
+
+// 購読できるチャンネルかを確認する。ツイートのチャンネルは存在するツイートなら誰でも、
+// DM のチャンネルは会話の参加者のみ購読できる
+func (s *gatewayService) authorizeTopic(ctx context.Context, userID int64, channel string) error {
+	switch {
+	case strings.HasPrefix(channel, gatewayChannelTweetPrefix):
+		tweetID, err := utils.ParseInt64WithErr(strings.TrimPrefix(channel, gatewayChannelTweetPrefix))
+		if err != nil || tweetID <= 0 {
+			return errcode.ErrInvalidChannel
+		}
+		tweets, err := s.tweetProvider.GetTweets(ctx, []int64{tweetID})
+		if err != nil {
+			return err
+		}
+		if len(tweets) == 0 {
+			return errcode.ErrTweetNotFound
+		}
+		return nil
+	case strings.HasPrefix(channel, gatewayChannelDMPrefix):
+		conversationID, err := utils.ParseInt64WithErr(strings.TrimPrefix(channel, gatewayChannelDMPrefix))
+		if err != nil || conversationID <= 0 {
+			return errcode.ErrInvalidChannel
+		}
+		ok, err := s.dmChecker.IsParticipant(ctx, conversationID, userID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errcode.ErrConversationNotFound
+		}
+		return nil
+	default:
+		return errcode.ErrInvalidChannel
+	}
+}
+
+func (s *gatewayService) joinTopic(ctx context.Context, sess *gatewaySession, topic string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subs := s.topics[topic]
+	if subs == nil {
+		// このノードで最初の購読者のときだけ Redis 側を購読する
+		if err := s.topicRepository.Join(ctx, topic); err != nil {
+			return fmt.Errorf("Subscribe: チャンネルの購読に失敗しました (channel: %s): %w", topic, err)
+		}
+		subs = make(map[*gatewaySession]struct{})
+		s.topics[topic] = subs
+	}
+	subs[sess] = struct{}{}
+
+	sess.mu.Lock()
+	sess.channels[topic] = nil
+	sess.mu.Unlock()
+	return nil
+}
+
+func (s *gatewayService) Unsubscribe(ctx context.Context, session *dto.GatewaySession, channel string) error {
+	sess, err := s.session(session)
+	if err != nil {
+		return err
+	}
+
+	sess.mu.Lock()
+	cancel, subscribed := sess.channels[channel]
+	delete(sess.channels, channel)
+	delete(sess.typedAt, channel)
+	sess.mu.Unlock()
+	if !subscribed {
+		return errcode.ErrNotSubscribed
+	}
+
+	if cancel != nil {
+		cancel()
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.leaveTopicLocked(ctx, sess, channel)
+	return nil
+}
+
+func (s *gatewayService) leaveTopicLocked(ctx context.Context, sess *gatewaySession, topic string) {
+	subs := s.topics[topic]
+	delete(subs, sess)
+	if len(subs) > 0 {
+		return
+	}
+	delete(s.topics, topic)
+	if err := s.topicRepository.Leave(ctx, topic); err != nil {
+		slog.Warn("GatewayService: チャンネルの購読解除に失敗しました", "channel", topic, "err", err)
+	}
+}
+
+// 購読中のチャンネルに入力中であることを知らせる。短い間隔の連続送信は間引く
+func (s *gatewayService) Typing(ctx context.Context, session *dto.GatewaySession, channel string) error {
+	sess, err := s.session(session)
+	if err != nil {
+		return err
+	}
+	if channel == gatewayChannelHome {
+		return errcode.ErrInvalidChannel
+	}
+
+	sess.mu.Lock()
+	_, subscribed := sess.channels[channel]
+	last := sess.typedAt[channel]
+	now := time.Now()
+	throttled := now.Sub(last) < gatewayTypingInterval
+	if subscribed && !throttled {
+		sess.typedAt[channel] = now
+	}
+	sess.mu.Unlock()
+
+	if !subscribed {
+		return errcode.ErrNotSubscribed
+	}
+	if throttled {
+		return nil
+	}
+
+	return s.topicRepository.Publish(ctx, &dto.TopicMessageRecord{
+		Topic:   channel,
+		Type:    models.TopicMessageTyping,
+		ActorID: sess.userID,
+	})
+}
+
+func (s *gatewayService) Disconnect(session *dto.GatewaySession) {
+	s.mu.Lock()
+	sess, ok := s.sessions[session.ID]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	delete(s.sessions, session.ID)
+
+	sess.mu.Lock()
+	channels := sess.channels
+	sess.channels = make(map[string]func())
+	sess.mu.Unlock()
+
+	for channel, cancel := range channels {
+		if cancel == nil {
+			s.leaveTopicLocked(sess.ctx, sess, channel)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, cancel := range channels {
+		if cancel != nil {
+			cancel()
+		}
+	}
+	sess.cancel()
+	sess.close()
+	s.active.Done()
+}
+
+func (s *gatewayService) session(session *dto.GatewaySession) (*gatewaySession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess, ok := s.sessions[session.ID]
+	if !ok || sess.userID != session.UserID {
+		return nil, errcode.ErrSessionNotFound
+	}
+	return sess, nil
+}
+
+// ctx が終わるまで Redis から届いたチャンネルのメッセージを購読者へ振り分ける
+func (s *gatewayService) Run(ctx context.Context) {
+	defer s.topicRepository.Close()
+
+	for {
+		messages, err := s.topicRepository.Listen(ctx)
+		if err != nil {
+			slog.Error("GatewayService: チャンネルの購読に失敗しました", "err", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(2 * time.Second):
+				continue
+			}
+		}
+
+		s.consume(ctx, messages)
+		if ctx.Err() != nil {
+			return
+		}
+		slog.Warn("GatewayService: 購読が切断されました。再購読します")
+		s.rejoinTopics(ctx)
+	}
+}
+
+func (s *gatewayService) consume(ctx context.Context, messages <-chan *dto.TopicMessageRecord) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message, ok := <-messages:
+			if !ok {
+				return
+			}
+			s.dispatch(message)
+		}
+	}
+}
+
+func (s *gatewayService) dispatch(message *dto.TopicMessageRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ephemeral := message.Type == models.TopicMessageTyping
+	event := dto.NewGatewayEventFromTopic(message)
+	for sess := range s.topics[message.Topic] {
+		// 入力中通知は本人には返さない
+		if ephemeral && sess.userID == message.ActorID {
+			continue
+		}
+		sess.deliver(event, ephemeral)
+	}
+}
+
+func (s *gatewayService) rejoinTopics(ctx context.Context) {
+	s.mu.Lock()
+	topics := make([]string, 0, len(s.topics))
+	for topic := range s.topics {
+		topics = append(topics, topic)
+	}
+	s.mu.Unlock()
+
+	if err := s.topicRepository.Join(ctx, topics...); err != nil {
+		slog.Error("GatewayService: チャンネルの再購読に失敗しました", "count", len(topics), "err", err)
+	}
+}
+
+// 新しい接続を断り、接続中のクライアントに再接続を促してから切断する。
+// すべての接続が閉じるか ctx が終わるまで待つ
+func (s *gatewayService) Drain(ctx context.Context) error {
+	s.mu.Lock()
+	s.draining = true
+	for _, sess := range s.sessions {
+		sess.deliver(&dto.GatewayEventRecord{Type: dto.GatewayEventReconnect}, true)
+		sess.close()
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.active.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGateway(t *testing.T) (*gatewayService, *mockTopicRepository, *mockUserEventSubscriber) {
+	t.Helper()
+	mr := new(mockTopicRepository)
+	mu := new(mockUserEventSubscriber)
+	mt := new(mockTweetProvider)
+	mt.On("GetTweets", mock.Anything, []int64{5}).Return([]*dto.TweetRecord{{ID: 5}}, nil).Maybe()
+	mt.On("GetTweets", mock.Anything, []int64{404}).Return([]*dto.TweetRecord{}, nil).Maybe()
+	md := new(mockDMParticipantChecker)
+	md.On("IsParticipant", mock.Anything, int64(3), int64(1)).Return(true, nil).Maybe()
+	md.On("IsParticipant", mock.Anything, int64(4), int64(1)).Return(false, nil).Maybe()
+	return NewGatewayService(mr, mu, mt, md), mr, mu
+}
+
+func TestGatewaySubscribe(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: ノード内で最初の購読者だけが Redis を購読し、最後の購読者が抜けたら解除する", func(t *testing.T) {
+		svc, mr, _ := newTestGateway(t)
+		mr.On("Join", mock.Anything, []string{"tweet:5"}).Return(nil).Once()
+		mr.On("Leave", mock.Anything, []string{"tweet:5"}).Return(nil).Once()
+
+		a, err := svc.Connect(ctx, 1)
+		require.NoError(t, err)
+		b, err := svc.Connect(ctx, 2)
+		require.NoError(t, err)
+
+		require.NoError(t, svc.Subscribe(ctx, a, "tweet:5"))
+		require.NoError(t, svc.Subscribe(ctx, b, "tweet:5"))
+		require.NoError(t, svc.Unsubscribe(ctx, a, "tweet:5"))
+		svc.Disconnect(b)
+
+		mr.AssertExpectations(t)
+	})
+
+	t.Run("正常系: home はユーザー宛てのイベントを流す", func(t *testing.T) {
+		svc, _, mu := newTestGateway(t)
+		events := make(chan *dto.StreamEventRecord, 1)
+		mu.On("Subscribe", mock.Anything, int64(1), "").Return((<-chan *dto.StreamEventRecord)(events), func() { close(events) }, nil)
+
+		sess, err := svc.Connect(ctx, 1)
+		require.NoError(t, err)
+		require.NoError(t, svc.Subscribe(ctx, sess, "home"))
+		events <- &dto.StreamEventRecord{ID: "1-0", Type: models.StreamEventTimeline, TweetID: 9}
+
+		select {
+		case e := <-sess.Events:
+			assert.Equal(t, "home", e.Channel)
+			assert.Equal(t, int64(9), e.TweetID)
+		case <-time.After(time.Second):
+			t.Fatal("イベントが届きません")
+		}
+		svc.Disconnect(sess)
+	})
+
+	tests := []struct {
+		name      string
+		channel   string
+		wantedErr error
+	}{
+		{"異常系: 未知のチャンネル", "user:1", errcode.ErrInvalidChannel},
+		{"異常系: 会話IDが不正", "dm:abc", errcode.ErrInvalidChannel},
+		{"異常系: 参加していない会話", "dm:4", errcode.ErrConversationNotFound},
+		{"異常系: ツイートIDが不正", "tweet:abc", errcode.ErrInvalidChannel},
+		{"異常系: ツイートが存在しない", "tweet:404", errcode.ErrTweetNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, _ := newTestGateway(t)
+			sess, err := svc.Connect(ctx, 1)
+			require.NoError(t, err)
+
+			assert.ErrorIs(t, svc.Subscribe(ctx, sess, tt.channel), tt.wantedErr)
+		})
+	}
+
+	t.Run("正常系: 参加している会話を購読できる", func(t *testing.T) {
+		svc, mr, _ := newTestGateway(t)
+		mr.On("Join", mock.Anything, []string{"dm:3"}).Return(nil).Once()
+		sess, err := svc.Connect(ctx, 1)
+		require.NoError(t, err)
+
+		assert.NoError(t, svc.Subscribe(ctx, sess, "dm:3"))
+		mr.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 購読数の上限", func(t *testing.T) {
+		svc, mr, _ := newTestGateway(t)
+		mr.On("Join", mock.Anything, mock.Anything).Return(nil)
+		sess, err := svc.Connect(ctx, 1)
+		require.NoError(t, err)
+
+		for i := 0; i < maxGatewayChannels; i++ {
+			svc.sessions[sess.ID].channels[fmt.Sprintf("tweet:%d", 1000+i)] = nil
+		}
+		assert.ErrorIs(t, svc.Subscribe(ctx, sess, "tweet:5"), errcode.ErrTooManyChannels)
+	})
+}
+
+func TestGatewayTyping(t *testing.T) {
+	ctx := context.Background()
+	svc, mr, _ := newTestGateway(t)
+	mr.On("Join", mock.Anything, []string{"tweet:5"}).Return(nil)
+	mr.On("Publish", mock.Anything, &dto.TopicMessageRecord{Topic: "tweet:5", Type: models.TopicMessageTyping, ActorID: 1}).Return(nil).Once()
+
+	sess, err := svc.Connect(ctx, 1)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, svc.Typing(ctx, sess, "tweet:5"), errcode.ErrNotSubscribed)
+
+	require.NoError(t, svc.Subscribe(ctx, sess, "tweet:5"))
+	require.NoError(t, svc.Typing(ctx, sess, "tweet:5"))
+	// 間隔内の連続送信は配らない
+	require.NoError(t, svc.Typing(ctx, sess, "tweet:5"))
+	mr.AssertExpectations(t)
+}
+
+func TestGatewayDispatch(t *testing.T) {
+	ctx := context.Background()
+	svc, mr, _ := newTestGateway(t)
+	mr.On("Join", mock.Anything, []string{"tweet:5"}).Return(nil)
+
+	actor, err := svc.Connect(ctx, 1)
+	require.NoError(t, err)
+	other, err := svc.Connect(ctx, 2)
+	require.NoError(t, err)
+	require.NoError(t, svc.Subscribe(ctx, actor, "tweet:5"))
+	require.NoError(t, svc.Subscribe(ctx, other, "tweet:5"))
+
+	t.Run("正常系: 入力中通知は本人以外に届く", func(t *testing.T) {
+		svc.dispatch(&dto.TopicMessageRecord{Topic: "tweet:5", Type: models.TopicMessageTyping, ActorID: 1})
+
+		assert.Len(t, actor.Events, 0)
+		require.Len(t, other.Events, 1)
+		e := <-other.Events
+		assert.Equal(t, models.TopicMessageTyping, e.Type)
+		assert.Equal(t, int64(1), e.ActorID)
+	})
+
+	t.Run("正常系: バッファが溢れても入力中通知では切断しない", func(t *testing.T) {
+		for i := 0; i < gatewayBufferSize+5; i++ {
+			svc.dispatch(&dto.TopicMessageRecord{Topic: "tweet:5", Type: models.TopicMessageTyping, ActorID: 1})
+		}
+		assert.Len(t, other.Events, gatewayBufferSize)
+		assert.False(t, svc.sessions[other.ID].closed)
+	})
+
+	t.Run("正常系: バッファが溢れたらそれ以外のイベントでは切断する", func(t *testing.T) {
+		svc.dispatch(&dto.TopicMessageRecord{Topic: "tweet:5", Type: "reply", ActorID: 1})
+		assert.True(t, svc.sessions[other.ID].closed)
+	})
+}
+
+func TestGatewayDrain(t *testing.T) {
+	ctx := context.Background()
+	svc, _, _ := newTestGateway(t)
+
+	sess, err := svc.Connect(ctx, 1)
+	require.NoError(t, err)
+
+	go func() {
+		// 接続側は Events が閉じたら切断する
+		for range sess.Events {
+		}
+		svc.Disconnect(sess)
+	}()
+
+	drainCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	require.NoError(t, svc.Drain(drainCtx))
+
+	_, err = svc.Connect(ctx, 2)
+	assert.ErrorIs(t, err, errcode.ErrServerDraining)
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/pkg/unfurl"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type LinkPreviewRepository interface {
+	Exists(ctx context.Context, url string) (bool, error)
+	Save(ctx context.Context, record *dto.LinkPreviewRecord) error
+	SaveFailed(ctx context.Context, url string) error
+}
+
+type PreviewFetcher interface {
+	Fetch(ctx context.Context, url string) (*unfurl.Preview, error)
+}
+
+type linkPreviewService struct {
+	linkPreviewRepository LinkPreviewRepository
+	fetcher               PreviewFetcher
+	tweetProvider         TweetProvider
+}
+
+func NewLinkPreviewService(lr LinkPreviewRepository, f PreviewFetcher, tp TweetProvider) *linkPreviewService {
+	return &linkPreviewService{
+		linkPreviewRepository: lr,
+		fetcher:               f,
+		tweetProvider:         tp,
+	}
+}
+
+// 本文中の最初の URL のプレビューを取得して保存する。
+// 取得済みの URL は取り直さず、取得に失敗した URL は空のプレビューとして記録する
+func (s *linkPreviewService) UnfurlTweet(ctx context.Context, tweetID int64) error {
+	tweets, err := s.tweetProvider.GetTweets(ctx, []int64{tweetID})
+	if err != nil {
+		return err
+	}
+	if len(tweets) == 0 {
+		return nil
+	}
+
+	url := tweets[0].FirstURL()
+	if url == "" {
+		return nil
+	}
+
+	exists, err := s.linkPreviewRepository.Exists(ctx, url)
+	if err != nil {
+		return fmt.Errorf("UnfurlTweet: プレビューの確認に失敗しました (tweet_id: %d): %w", tweetID, err)
+	}
+	if exists {
+		return nil
+	}
+
+	preview, err := s.fetcher.Fetch(ctx, url)
+	if err != nil {
+		slog.Info("リンクプレビューを取得できませんでした", "tweet_id", tweetID, "url", url, "err", err)
+		return s.linkPreviewRepository.SaveFailed(ctx, url)
+	}
+
+	return s.linkPreviewRepository.Save(ctx, dto.NewLinkPreviewRecordFromUnfurl(preview, time.Now().UTC()))
+}
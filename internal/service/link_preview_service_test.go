@@ -0,0 +1,90 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/pkg/unfurl"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUnfurlTweet(t *testing.T) {
+	const url = "https://example.com/article"
+
+	tests := []struct {
+		name      string
+		setupMock func(mr *mockLinkPreviewRepository, mf *mockPreviewFetcher, mp *mockTweetProvider)
+		wantedErr error
+	}{
+		{
+			name: "正常系: 取得したプレビューを保存する",
+			setupMock: func(mr *mockLinkPreviewRepository, mf *mockPreviewFetcher, mp *mockTweetProvider) {
+				mp.On("GetTweets", mock.Anything, []int64{1}).Return([]*dto.TweetRecord{{ID: 1, Content: "読んで " + url}}, nil)
+				mr.On("Exists", mock.Anything, url).Return(false, nil)
+				mf.On("Fetch", mock.Anything, url).Return(&unfurl.Preview{URL: url, Title: "記事"}, nil)
+				mr.On("Save", mock.Anything, mock.MatchedBy(func(r *dto.LinkPreviewRecord) bool {
+					return r.URL == url && r.Title == "記事" && !r.FetchedAt.IsZero()
+				})).Return(nil)
+			},
+		},
+		{
+			name: "正常系: 取得済みのURLは取り直さない",
+			setupMock: func(mr *mockLinkPreviewRepository, mf *mockPreviewFetcher, mp *mockTweetProvider) {
+				mp.On("GetTweets", mock.Anything, []int64{1}).Return([]*dto.TweetRecord{{ID: 1, Content: url}}, nil)
+				mr.On("Exists", mock.Anything, url).Return(true, nil)
+			},
+		},
+		{
+			name: "正常系: 取得に失敗したURLは空のプレビューとして記録する",
+			setupMock: func(mr *mockLinkPreviewRepository, mf *mockPreviewFetcher, mp *mockTweetProvider) {
+				mp.On("GetTweets", mock.Anything, []int64{1}).Return([]*dto.TweetRecord{{ID: 1, Content: url}}, nil)
+				mr.On("Exists", mock.Anything, url).Return(false, nil)
+				mf.On("Fetch", mock.Anything, url).Return(nil, unfurl.ErrBlockedAddress)
+				mr.On("SaveFailed", mock.Anything, url).Return(nil)
+			},
+		},
+		{
+			name: "正常系: URLを含まないツイートは何もしない",
+			setupMock: func(mr *mockLinkPreviewRepository, mf *mockPreviewFetcher, mp *mockTweetProvider) {
+				mp.On("GetTweets", mock.Anything, []int64{1}).Return([]*dto.TweetRecord{{ID: 1, Content: "こんにちは"}}, nil)
+			},
+		},
+		{
+			name: "正常系: 削除済みのツイートは何もしない",
+			setupMock: func(mr *mockLinkPreviewRepository, mf *mockPreviewFetcher, mp *mockTweetProvider) {
+				mp.On("GetTweets", mock.Anything, []int64{1}).Return([]*dto.TweetRecord{}, nil)
+			},
+		},
+		{
+			name: "異常系: キャッシュの確認に失敗",
+			setupMock: func(mr *mockLinkPreviewRepository, mf *mockPreviewFetcher, mp *mockTweetProvider) {
+				mp.On("GetTweets", mock.Anything, []int64{1}).Return([]*dto.TweetRecord{{ID: 1, Content: url}}, nil)
+				mr.On("Exists", mock.Anything, url).Return(false, errMockInternal)
+			},
+			wantedErr: errMockInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := new(mockLinkPreviewRepository)
+			mf := new(mockPreviewFetcher)
+			mp := new(mockTweetProvider)
+			tt.setupMock(mr, mf, mp)
+			svc := NewLinkPreviewService(mr, mf, mp)
+
+			err := svc.UnfurlTweet(context.Background(), 1)
+
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mr.AssertExpectations(t)
+			mf.AssertExpectations(t)
+			mp.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"context"
+	"fmt"
+)
+
+type ListRepository interface {
+	Create(ctx context.Context, record *dto.ListRecord) (*dto.ListRecord, error)
+	Get(ctx context.Context, listID int64) (*dto.ListRecord, error)
+	ListByOwner(ctx context.Context, ownerID int64) ([]*dto.ListRecord, error)
+	Delete(ctx context.Context, listID, ownerID int64) error
+	AddMember(ctx context.Context, listID, userID int64, maxMembers int) error
+	RemoveMember(ctx context.Context, listID, userID int64) error
+	MemberIDs(ctx context.Context, listID int64) ([]int64, error)
+}
+
+type ListTweetProvider interface {
+	GetTweetsByAuthors(ctx context.Context, authorIDs []int64, cursor int64, limit int) ([]*dto.TweetRecord, int64, error)
+}
+
+type ListUserProvider interface {
+	GetInfoLists(ctx context.Context, userIDs []int64) ([]*dto.UserSlimRecord, error)
+}
+
+type listService struct {
+	listRepository ListRepository
+	tweetProvider  ListTweetProvider
+	userProvider   ListUserProvider
+}
+
+func NewListService(lr ListRepository, tp ListTweetProvider, up ListUserProvider) *listService {
+	return &listService{
+		listRepository: lr,
+		tweetProvider:  tp,
+		userProvider:   up,
+	}
+}
+
+func (s *listService) CreateList(ctx context.Context, ownerID int64, name, description string, isPrivate bool) (*dto.ListRecord, error) {
+	if ownerID <= 0 {
+		return nil, errcode.ErrInvalidUserID
+	}
+
+	list, err := s.listRepository.Create(ctx, &dto.ListRecord{
+		OwnerID:     ownerID,
+		Name:        name,
+		Description: description,
+		IsPrivate:   isPrivate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CreateList: リストの作成に失敗しました (owner_id: %d): %w", ownerID, err)
+	}
+	return list, nil
+}
+
+// 閲覧できないリストは存在しないものとして扱う
+func (s *listService) GetList(ctx context.Context, viewerID, listID int64) (*dto.ListRecord, error) {
+	if listID <= 0 {
+		return nil, errcode.ErrInvalidListID
+	}
+
+	list, err := s.listRepository.Get(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+	if !list.VisibleTo(viewerID) {
+		return nil, errcode.ErrListNotFound
+	}
+	return list, nil
+}
+
+func (s *listService) ListOwnedLists(ctx context.Context, ownerID int64) ([]*dto.ListRecord, error) {
+	if ownerID <= 0 {
+		return nil, errcode.ErrInvalidUserID
+	}
+
+	return s.listRepository.ListByOwner(ctx, ownerID)
+}
+
+func (s *listService) DeleteList(ctx context.Context, ownerID, listID int64) error {
+	if listID <= 0 {
+		return errcode.ErrInvalidListID
+	}
+
+	return s.listRepository.Delete(ctx, listID, ownerID)
+}
+
+// メンバーの追加・削除は作成者のみ。他人のリストは公開でも操作できない
+func (s *listService) AddMember(ctx context.Context, ownerID, listID, userID int64) error {
+	if userID <= 0 {
+		return errcode.ErrInvalidUserID
+	}
+	if err := s.requireOwner(ctx, ownerID, listID); err != nil {
+		return err
+	}
+
+	return s.listRepository.AddMember(ctx, listID, userID, maxListMembers)
+}
+
+func (s *listService) RemoveMember(ctx context.Context, ownerID, listID, userID int64) error {
+	if userID <= 0 {
+		return errcode.ErrInvalidUserID
+	}
+	if err := s.requireOwner(ctx, ownerID, listID); err != nil {
+		return err
+	}
+
+	return s.listRepository.RemoveMember(ctx, listID, userID)
+}
+
+func (s *listService) requireOwner(ctx context.Context, ownerID, listID int64) error {
+	list, err := s.GetList(ctx, ownerID, listID)
+	if err != nil {
+		return err
+	}
+	if list.OwnerID != ownerID {
+		return errcode.ErrForbidden
+	}
+	return nil
+}
+
+func (s *listService) ListMembers(ctx context.Context, viewerID, listID int64) ([]*dto.UserSlimRecord, error) {
+	if _, err := s.GetList(ctx, viewerID, listID); err != nil {
+		return nil, err
+	}
+
+	ids, err := s.listRepository.MemberIDs(ctx, listID)
+	if err != nil {
+		return nil, fmt.Errorf("ListMembers: メンバーの取得に失敗しました (list_id: %d): %w", listID, err)
+	}
+	return s.userProvider.GetInfoLists(ctx, ids)
+}
+
+// メンバーの最新ツイートを読み込み時に集めて新しい順に返す。次のページがなければ nextCursor は 0
+func (s *listService) GetTimeline(ctx context.Context, viewerID, listID, cursor int64, limit int) ([]*dto.TweetRecord, int64, error) {
+	if cursor < 0 {
+		return nil, 0, errcode.ErrInvalidCursor
+	}
+	if limit <= 0 {
+		limit = defaultListTimelineLimit
+	}
+	limit = min(limit, maxListTimelineLimit)
+
+	if _, err := s.GetList(ctx, viewerID, listID); err != nil {
+		return nil, 0, err
+	}
+
+	memberIDs, err := s.listRepository.MemberIDs(ctx, listID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("GetTimeline: メンバーの取得に失敗しました (list_id: %d): %w", listID, err)
+	}
+	if len(memberIDs) == 0 {
+		return []*dto.TweetRecord{}, 0, nil
+	}
+
+	return s.tweetProvider.GetTweetsByAuthors(ctx, memberIDs, cursor, limit)
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAddMember(t *testing.T) {
+	public := &dto.ListRecord{ID: 5, OwnerID: 1}
+	private := &dto.ListRecord{ID: 6, OwnerID: 1, IsPrivate: true}
+
+	tests := []struct {
+		name      string
+		ownerID   int64
+		listID    int64
+		setupMock func(mr *mockListRepository)
+		wantedErr error
+	}{
+		{
+			name:    "正常系: 作成者はメンバーを追加できる",
+			ownerID: 1,
+			listID:  5,
+			setupMock: func(mr *mockListRepository) {
+				mr.On("Get", mock.Anything, int64(5)).Return(public, nil)
+				mr.On("AddMember", mock.Anything, int64(5), int64(9), maxListMembers).Return(nil)
+			},
+		},
+		{
+			name:    "異常系: 公開リストでも作成者以外は追加できない",
+			ownerID: 2,
+			listID:  5,
+			setupMock: func(mr *mockListRepository) {
+				mr.On("Get", mock.Anything, int64(5)).Return(public, nil)
+			},
+			wantedErr: errcode.ErrForbidden,
+		},
+		{
+			name:    "異常系: 他人の非公開リストは存在しないものとして扱う",
+			ownerID: 2,
+			listID:  6,
+			setupMock: func(mr *mockListRepository) {
+				mr.On("Get", mock.Anything, int64(6)).Return(private, nil)
+			},
+			wantedErr: errcode.ErrListNotFound,
+		},
+		{
+			name:      "異常系: リストIDが不正",
+			ownerID:   1,
+			listID:    0,
+			setupMock: func(mr *mockListRepository) {},
+			wantedErr: errcode.ErrInvalidListID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := new(mockListRepository)
+			tt.setupMock(mr)
+			svc := NewListService(mr, new(mockListTweetProvider), new(mockNotificationUserProvider))
+
+			err := svc.AddMember(context.Background(), tt.ownerID, tt.listID, 9)
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+				mr.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			mr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListTimeline(t *testing.T) {
+	t.Run("正常系: メンバーのツイートを集めて返す", func(t *testing.T) {
+		mr := new(mockListRepository)
+		mt := new(mockListTweetProvider)
+		mr.On("Get", mock.Anything, int64(5)).Return(&dto.ListRecord{ID: 5, OwnerID: 1}, nil)
+		mr.On("MemberIDs", mock.Anything, int64(5)).Return([]int64{3, 4}, nil)
+		mt.On("GetTweetsByAuthors", mock.Anything, []int64{3, 4}, int64(100), defaultListTimelineLimit).
+			Return([]*dto.TweetRecord{{ID: 99}, {ID: 98}}, int64(98), nil)
+		svc := NewListService(mr, mt, new(mockNotificationUserProvider))
+
+		tweets, next, err := svc.GetTimeline(context.Background(), 2, 5, 100, 0)
+		require.NoError(t, err)
+		assert.Len(t, tweets, 2)
+		assert.Equal(t, int64(98), next)
+	})
+
+	t.Run("正常系: メンバーがいなければツイートを取得しない", func(t *testing.T) {
+		mr := new(mockListRepository)
+		mt := new(mockListTweetProvider)
+		mr.On("Get", mock.Anything, int64(5)).Return(&dto.ListRecord{ID: 5, OwnerID: 1}, nil)
+		mr.On("MemberIDs", mock.Anything, int64(5)).Return([]int64{}, nil)
+		svc := NewListService(mr, mt, new(mockNotificationUserProvider))
+
+		tweets, _, err := svc.GetTimeline(context.Background(), 1, 5, 0, 0)
+		require.NoError(t, err)
+		assert.Empty(t, tweets)
+		mt.AssertNotCalled(t, "GetTweetsByAuthors", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 他人の非公開リスト", func(t *testing.T) {
+		mr := new(mockListRepository)
+		mr.On("Get", mock.Anything, int64(6)).Return(&dto.ListRecord{ID: 6, OwnerID: 1, IsPrivate: true}, nil)
+		svc := NewListService(mr, new(mockListTweetProvider), new(mockNotificationUserProvider))
+
+		_, _, err := svc.GetTimeline(context.Background(), 2, 6, 0, 0)
+		assert.ErrorIs(t, err, errcode.ErrListNotFound)
+	})
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/pkg/imaging"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"image"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type MediaRepository interface {
+	Create(ctx context.Context, record *dto.MediaRecord) (*dto.MediaRecord, error)
+	PurgeOrphans(ctx context.Context, olderThan time.Duration, limit int) ([]*dto.MediaRecord, error)
+}
+
+// メディアの実体の保存先。現在はローカルファイルシステム実装のみ
+type MediaStorage interface {
+	Save(ctx context.Context, key string, r io.Reader) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+type mediaService struct {
+	mediaRepository MediaRepository
+	mediaStorage    MediaStorage
+	mediaPolicy     dto.MediaPolicy
+}
+
+func NewMediaService(mr MediaRepository, ms MediaStorage, mp dto.MediaPolicy) *mediaService {
+	return &mediaService{
+		mediaRepository: mr,
+		mediaStorage:    ms,
+		mediaPolicy:     mp,
+	}
+}
+
+// Content-Type はクライアントの申告ではなく先頭バイトから判定する
+func (s *mediaService) Upload(ctx context.Context, userID int64, r io.Reader) (*dto.MediaRecord, error) {
+	if userID <= 0 {
+		return nil, errcode.ErrInvalidUserID
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, s.mediaPolicy.MaxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("アップロードデータの読み込みに失敗しました: %w", err)
+	}
+	if int64(len(data)) > s.mediaPolicy.MaxBytes {
+		return nil, errcode.ErrMediaTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	format, ok := allowedMediaTypes[contentType]
+	if !ok {
+		return nil, errcode.ErrUnsupportedMediaType
+	}
+
+	// デコード前に画素数を確認し、展開後に巨大になる画像を弾く
+	cfg, decodedFormat, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || decodedFormat != format {
+		return nil, errcode.ErrUnsupportedMediaType
+	}
+	if cfg.Width*cfg.Height > s.mediaPolicy.MaxPixels {
+		return nil, errcode.ErrMediaTooLarge
+	}
+
+	thumbnail, thumbnailType, err := imaging.Thumbnail(data, s.mediaPolicy.ThumbnailSize)
+	if err != nil {
+		slog.Warn("サムネイルの生成に失敗しました", "user_id", userID, "err", err)
+		return nil, errcode.ErrUnsupportedMediaType
+	}
+
+	baseKey := fmt.Sprintf("%d/%s", userID, strings.ToLower(rand.Text()))
+	storageKey := baseKey + mediaExtensions[contentType]
+	thumbnailKey := baseKey + "_thumb" + mediaExtensions[thumbnailType]
+
+	if err := s.mediaStorage.Save(ctx, storageKey, bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("メディアの保存に失敗しました: %w", err)
+	}
+	if err := s.mediaStorage.Save(ctx, thumbnailKey, bytes.NewReader(thumbnail)); err != nil {
+		s.deleteFiles(storageKey)
+		return nil, fmt.Errorf("サムネイルの保存に失敗しました: %w", err)
+	}
+
+	record := &dto.MediaRecord{
+		UserID:       userID,
+		StorageKey:   storageKey,
+		ThumbnailKey: thumbnailKey,
+		URL:          s.mediaStorage.URL(storageKey),
+		ThumbnailURL: s.mediaStorage.URL(thumbnailKey),
+		ContentType:  contentType,
+		SizeBytes:    int64(len(data)),
+		Width:        cfg.Width,
+		Height:       cfg.Height,
+	}
+
+	created, err := s.mediaRepository.Create(ctx, record)
+	if err != nil {
+		s.deleteFiles(storageKey, thumbnailKey)
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// ツイートに紐付かないまま猶予期間を過ぎたメディアを、DB の行とファイルの両方から削除する
+func (s *mediaService) CollectOrphans(ctx context.Context, limit int) (int, error) {
+	purged, err := s.mediaRepository.PurgeOrphans(ctx, s.mediaPolicy.OrphanTTL, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, m := range purged {
+		s.deleteFiles(m.StorageKey, m.ThumbnailKey)
+	}
+	return len(purged), nil
+}
+
+func validateMediaAttachments(media []dto.MediaAttachment) error {
+	if len(media) > maxMediaPerTweet {
+		return errcode.ErrInvalidMediaAttachment
+	}
+
+	seen := make(map[int64]struct{}, len(media))
+	for _, m := range media {
+		if m.MediaID <= 0 {
+			return errcode.ErrInvalidMediaAttachment
+		}
+		if _, ok := seen[m.MediaID]; ok {
+			return errcode.ErrInvalidMediaAttachment
+		}
+		seen[m.MediaID] = struct{}{}
+	}
+	return nil
+}
+
+// ファイル削除の失敗はログに残すのみとする。DB 側の整合性を優先する
+func (s *mediaService) deleteFiles(keys ...string) {
+	for _, key := range keys {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := s.mediaStorage.Delete(ctx, key); err != nil {
+			slog.Warn("メディアファイルの削除に失敗しました", "key", key, "err", err)
+		}
+		cancel()
+	}
+}
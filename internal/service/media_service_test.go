@@ -0,0 +1,141 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestUploadMedia(t *testing.T) {
+	validPNG := newTestPNG(t, 200, 100)
+	tests := []struct {
+		name      string
+		userID    int64
+		data      []byte
+		setupMock func(mr *mockMediaRepository, ms *mockMediaStorage)
+		wantedErr error
+	}{
+		{
+			name:   "正常系: PNGを保存しサムネイルと寸法を記録する",
+			userID: 1,
+			data:   validPNG,
+			setupMock: func(mr *mockMediaRepository, ms *mockMediaStorage) {
+				ms.On("Save", mock.Anything, mock.MatchedBy(func(k string) bool {
+					return strings.HasPrefix(k, "1/") && strings.HasSuffix(k, ".png") && !strings.Contains(k, "_thumb")
+				}), mock.Anything).Return(nil).Once()
+				ms.On("Save", mock.Anything, mock.MatchedBy(func(k string) bool {
+					return strings.HasSuffix(k, "_thumb.png")
+				}), mock.Anything).Return(nil).Once()
+				ms.On("URL", mock.Anything).Return("/media/x.png")
+				mr.On("Create", mock.Anything, mock.MatchedBy(func(r *dto.MediaRecord) bool {
+					return r.UserID == 1 && r.ContentType == "image/png" && r.Width == 200 && r.Height == 100 && r.SizeBytes == int64(len(validPNG))
+				})).Return(&dto.MediaRecord{ID: 5, UserID: 1, ContentType: "image/png", Width: 200, Height: 100}, nil)
+			},
+		},
+		{
+			name:      "異常系: 上限サイズを超えるファイル",
+			userID:    1,
+			data:      make([]byte, testMediaPolicy.MaxBytes+1),
+			setupMock: func(mr *mockMediaRepository, ms *mockMediaStorage) {},
+			wantedErr: errcode.ErrMediaTooLarge,
+		},
+		{
+			name:      "異常系: 画像以外のファイル",
+			userID:    1,
+			data:      []byte("<html><body>not an image</body></html>"),
+			setupMock: func(mr *mockMediaRepository, ms *mockMediaStorage) {},
+			wantedErr: errcode.ErrUnsupportedMediaType,
+		},
+		{
+			name:      "異常系: 画素数が上限を超える画像",
+			userID:    1,
+			data:      newTestPNG(t, 1001, 1000),
+			setupMock: func(mr *mockMediaRepository, ms *mockMediaStorage) {},
+			wantedErr: errcode.ErrMediaTooLarge,
+		},
+		{
+			name:      "異常系: 無効なユーザーID",
+			userID:    0,
+			data:      validPNG,
+			setupMock: func(mr *mockMediaRepository, ms *mockMediaStorage) {},
+			wantedErr: errcode.ErrInvalidUserID,
+		},
+		{
+			name:   "異常系: DB登録に失敗した場合は保存したファイルを削除する",
+			userID: 1,
+			data:   validPNG,
+			setupMock: func(mr *mockMediaRepository, ms *mockMediaStorage) {
+				ms.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+				ms.On("URL", mock.Anything).Return("/media/x.png")
+				mr.On("Create", mock.Anything, mock.Anything).Return(nil, errMockInternal)
+				ms.On("Delete", mock.Anything, mock.Anything).Return(nil).Twice()
+			},
+			wantedErr: errMockInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := new(mockMediaRepository)
+			ms := new(mockMediaStorage)
+			tt.setupMock(mr, ms)
+			svc := NewMediaService(mr, ms, testMediaPolicy)
+
+			res, err := svc.Upload(context.Background(), tt.userID, bytes.NewReader(tt.data))
+
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+				assert.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, int64(5), res.ID)
+			}
+
+			mr.AssertExpectations(t)
+			ms.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCollectOrphans(t *testing.T) {
+	mr := new(mockMediaRepository)
+	ms := new(mockMediaStorage)
+	mr.On("PurgeOrphans", mock.Anything, testMediaPolicy.OrphanTTL, 10).Return([]*dto.MediaRecord{
+		{ID: 1, StorageKey: "1/a.png", ThumbnailKey: "1/a_thumb.png"},
+		{ID: 2, StorageKey: "1/b.jpg", ThumbnailKey: "1/b_thumb.jpg"},
+	}, nil)
+	for _, key := range []string{"1/a.png", "1/a_thumb.png", "1/b.jpg", "1/b_thumb.jpg"} {
+		ms.On("Delete", mock.Anything, key).Return(nil).Once()
+	}
+	svc := NewMediaService(mr, ms, testMediaPolicy)
+
+	collected, err := svc.CollectOrphans(context.Background(), 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, collected)
+	mr.AssertExpectations(t)
+	ms.AssertExpectations(t)
+}
@@ -3,8 +3,10 @@ package service
 import (
 	"aita/internal/dto"
 	"aita/internal/pkg/testutils"
+	"aita/internal/pkg/unfurl"
 	"context"
 	"errors"
+	"io"
 	"time"
 
 	"github.com/stretchr/testify/mock"
@@ -14,6 +16,10 @@ var (
     errMockInternal = errors.New("接続拒否")
     errMockHashFailed = errors.New("暗号化内部エラー")
 	errMockTokenFailed = errors.New("トークン内部エラー")
+
+	testEditPolicy = dto.NewEditPolicy(10*time.Minute, 3)
+	testDeletionPolicy = dto.DeletionPolicy{GracePeriod: 30 * time.Minute, Retention: 24 * time.Hour}
+	testMediaPolicy = dto.MediaPolicy{MaxBytes: 1 << 20, MaxPixels: 1_000_000, ThumbnailSize: 32, OrphanTTL: 24 * time.Hour}
 )
 
 type mockUserRepository struct {
@@ -52,6 +58,31 @@ func(m *mockUserRepository) GetBaseInfos(ctx context.Context, userIDs []int64) (
 	return testutils.SafeGetSlice[*dto.UserSlimRecord](args, 0), args.Error(1)
 }
 
+func (m *mockUserRepository) GetIDsByUsernames(ctx context.Context, usernames []string) ([]*dto.UserSlimRecord, error) {
+	args := m.Called(ctx, usernames)
+	return testutils.SafeGetSlice[*dto.UserSlimRecord](args, 0), args.Error(1)
+}
+
+func (m *mockUserRepository) UpdatePassword(ctx context.Context, userID int64, passwordHash string) error {
+	args := m.Called(ctx, userID, passwordHash)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) MarkEmailVerified(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) UpdateEmail(ctx context.Context, userID int64, email string) error {
+	args := m.Called(ctx, userID, email)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) UpdateUsername(ctx context.Context, userID int64, username string, changedBefore time.Time) error {
+	args := m.Called(ctx, userID, username, changedBefore)
+	return args.Error(0)
+}
+
 
 type mockSessionRepository struct {
 	mock.Mock
@@ -77,6 +108,51 @@ func(m *mockSessionRepository) Delete(ctx context.Context, sr *dto.SessionRecord
 	return args.Error(0)
 }
 
+func (m *mockSessionRepository) DeleteByUserID(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *mockSessionRepository) DeleteOthersByUserID(ctx context.Context, userID int64, keepHash string) error {
+	args := m.Called(ctx, userID, keepHash)
+	return args.Error(0)
+}
+
+func (m *mockSessionRepository) ListByUserID(ctx context.Context, userID int64) ([]*dto.SessionRecord, error) {
+	args := m.Called(ctx, userID)
+	return testutils.SafeGetSlice[*dto.SessionRecord](args, 0), args.Error(1)
+}
+
+func (m *mockSessionRepository) GetRefreshRef(ctx context.Context, refreshHash string) (*dto.RefreshTokenRefRecord, error) {
+	args := m.Called(ctx, refreshHash)
+	return testutils.SafeGet[dto.RefreshTokenRefRecord](args, 0), args.Error(1)
+}
+
+func (m *mockSessionRepository) GetByFamily(ctx context.Context, sessionID string) (*dto.SessionRecord, error) {
+	args := m.Called(ctx, sessionID)
+	return testutils.SafeGet[dto.SessionRecord](args, 0), args.Error(1)
+}
+
+func (m *mockSessionRepository) Rotate(ctx context.Context, current, next *dto.SessionRecord) error {
+	args := m.Called(ctx, current, next)
+	return args.Error(0)
+}
+
+func (m *mockSessionRepository) DeleteFamily(ctx context.Context, userID int64, sessionID string) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *mockSessionRepository) CreateTicket(ctx context.Context, ticketHash, tokenHash string, ttl time.Duration) error {
+	args := m.Called(ctx, ticketHash, tokenHash, ttl)
+	return args.Error(0)
+}
+
+func (m *mockSessionRepository) ConsumeTicket(ctx context.Context, ticketHash string) (string, error) {
+	args := m.Called(ctx, ticketHash)
+	return args.String(0), args.Error(1)
+}
+
 type mockTweetRepository struct {
 	mock.Mock
 }
@@ -86,13 +162,22 @@ func (m *mockTweetRepository) Create(ctx context.Context, record *dto.TweetRecor
 	return testutils.SafeGet[dto.TweetRecord](args, 0), args.Error(1)
 }
 
+func (m *mockTweetRepository) Insert(ctx context.Context, record *dto.TweetRecord) (*dto.TweetRecord, error) {
+	args := m.Called(ctx, record)
+	return testutils.SafeGet[dto.TweetRecord](args, 0), args.Error(1)
+}
+
+func (m *mockTweetRepository) Cache(record *dto.TweetRecord) {
+	m.Called(record)
+}
+
 func(m *mockTweetRepository) Get(ctx context.Context, tweetID int64) (*dto.TweetRecord, error)  {
 	args := m.Called(ctx, tweetID)
 	return testutils.SafeGet[dto.TweetRecord](args, 0), args.Error(1)
 }
 
-func(m *mockTweetRepository) Update(ctx context.Context, newContent string, tweetID int64) (*dto.TweetRecord, error)  {
-	args := m.Called(ctx, newContent, tweetID)
+func(m *mockTweetRepository) Update(ctx context.Context, newContent string, tweetID int64, policy dto.EditPolicy) (*dto.TweetRecord, error)  {
+	args := m.Called(ctx, newContent, tweetID, policy)
 	return testutils.SafeGet[dto.TweetRecord](args, 0), args.Error(1)
 }
 
@@ -101,11 +186,36 @@ func (m *mockTweetRepository) MultiGet(ctx context.Context, tweetIDs []int64) ([
 	return testutils.SafeGetSlice[*dto.TweetRecord](args, 0), args.Error(1)
 }
 
+func (m *mockTweetRepository) GetTweetIDsByAuthors(ctx context.Context, authorIDs []int64, cursor int64, limit int) ([]int64, error) {
+	args := m.Called(ctx, authorIDs, cursor, limit)
+	return testutils.SafeGetSlice[int64](args, 0), args.Error(1)
+}
+
 func (m *mockTweetRepository) GetTweetsByAuthor(ctx context.Context, userID int64, page, size int) ([]int64, error) {
 	args := m.Called(ctx, userID, page, size)
 	return testutils.SafeGetSlice[int64](args, 0), args.Error(1)
 }
 
+func (m *mockTweetRepository) GetDeleted(ctx context.Context, tweetID int64) (*dto.TweetRecord, error) {
+	args := m.Called(ctx, tweetID)
+	return testutils.SafeGet[dto.TweetRecord](args, 0), args.Error(1)
+}
+
+func (m *mockTweetRepository) Restore(ctx context.Context, tweetID int64, gracePeriod time.Duration) (*dto.TweetRecord, error) {
+	args := m.Called(ctx, tweetID, gracePeriod)
+	return testutils.SafeGet[dto.TweetRecord](args, 0), args.Error(1)
+}
+
+func (m *mockTweetRepository) Purge(ctx context.Context, retention time.Duration, limit int) (int64, error) {
+	args := m.Called(ctx, retention, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockTweetRepository) GetRevisions(ctx context.Context, tweetID int64) ([]*dto.TweetRevisionRecord, error) {
+	args := m.Called(ctx, tweetID)
+	return testutils.SafeGetSlice[*dto.TweetRevisionRecord](args, 0), args.Error(1)
+}
+
 
 func(m *mockTweetRepository) Delete(ctx context.Context, tweetID int64) error {
 	args := m.Called(ctx, tweetID)
@@ -157,3 +267,656 @@ func (m *mockUserService)Exists(ctx context.Context, userID int64) (bool, error)
 	args := m.Called(ctx, userID)
 	return args.Bool(0), args.Error(1)
 }
+
+type mockRecommendationRepository struct {
+	mock.Mock
+}
+
+func (m *mockRecommendationRepository) GetForUser(ctx context.Context, userID int64, limit int) ([]*dto.RecommendationRecord, error) {
+	args := m.Called(ctx, userID, limit)
+	return testutils.SafeGetSlice[*dto.RecommendationRecord](args, 0), args.Error(1)
+}
+
+func (m *mockRecommendationRepository) GetPopular(ctx context.Context, limit int) ([]*dto.RecommendationRecord, error) {
+	args := m.Called(ctx, limit)
+	return testutils.SafeGetSlice[*dto.RecommendationRecord](args, 0), args.Error(1)
+}
+
+func (m *mockRecommendationRepository) RebuildForUser(ctx context.Context, userID int64, size int) error {
+	args := m.Called(ctx, userID, size)
+	return args.Error(0)
+}
+
+func (m *mockRecommendationRepository) RebuildPopular(ctx context.Context, size int) error {
+	args := m.Called(ctx, size)
+	return args.Error(0)
+}
+
+func (m *mockRecommendationRepository) ListActiveUserIDs(ctx context.Context, afterID int64, limit int) ([]int64, error) {
+	args := m.Called(ctx, afterID, limit)
+	return testutils.SafeGetSlice[int64](args, 0), args.Error(1)
+}
+
+type mockFollowingIDProvider struct {
+	mock.Mock
+}
+
+func (m *mockFollowingIDProvider) GetFollowingIDs(ctx context.Context, userID int64) ([]int64, error) {
+	args := m.Called(ctx, userID)
+	return testutils.SafeGetSlice[int64](args, 0), args.Error(1)
+}
+
+type mockUserListProvider struct {
+	mock.Mock
+}
+
+func (m *mockUserListProvider) GetInfoLists(ctx context.Context, userIDs []int64) ([]*dto.UserSlimRecord, error) {
+	args := m.Called(ctx, userIDs)
+	return testutils.SafeGetSlice[*dto.UserSlimRecord](args, 0), args.Error(1)
+}
+
+type mockScheduledTweetRepository struct {
+	mock.Mock
+}
+
+func (m *mockScheduledTweetRepository) Create(ctx context.Context, record *dto.ScheduledTweetRecord) (*dto.ScheduledTweetRecord, error) {
+	args := m.Called(ctx, record)
+	return testutils.SafeGet[dto.ScheduledTweetRecord](args, 0), args.Error(1)
+}
+
+func (m *mockScheduledTweetRepository) ListPending(ctx context.Context, userID int64) ([]*dto.ScheduledTweetRecord, error) {
+	args := m.Called(ctx, userID)
+	return testutils.SafeGetSlice[*dto.ScheduledTweetRecord](args, 0), args.Error(1)
+}
+
+func (m *mockScheduledTweetRepository) Cancel(ctx context.Context, scheduledID, userID int64) error {
+	args := m.Called(ctx, scheduledID, userID)
+	return args.Error(0)
+}
+
+func (m *mockScheduledTweetRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]int64, error) {
+	args := m.Called(ctx, now, limit)
+	return testutils.SafeGetSlice[int64](args, 0), args.Error(1)
+}
+
+func (m *mockScheduledTweetRepository) Publish(ctx context.Context, scheduledID int64) (*dto.TweetRecord, error) {
+	args := m.Called(ctx, scheduledID)
+	return testutils.SafeGet[dto.TweetRecord](args, 0), args.Error(1)
+}
+
+func (m *mockScheduledTweetRepository) Resync(ctx context.Context, batchSize int) (int, error) {
+	args := m.Called(ctx, batchSize)
+	return args.Int(0), args.Error(1)
+}
+
+type mockDraftRepository struct {
+	mock.Mock
+}
+
+func (m *mockDraftRepository) Create(ctx context.Context, record *dto.DraftRecord) (*dto.DraftRecord, error) {
+	args := m.Called(ctx, record)
+	return testutils.SafeGet[dto.DraftRecord](args, 0), args.Error(1)
+}
+
+func (m *mockDraftRepository) Get(ctx context.Context, draftID, userID int64) (*dto.DraftRecord, error) {
+	args := m.Called(ctx, draftID, userID)
+	return testutils.SafeGet[dto.DraftRecord](args, 0), args.Error(1)
+}
+
+func (m *mockDraftRepository) List(ctx context.Context, userID int64) ([]*dto.DraftRecord, error) {
+	args := m.Called(ctx, userID)
+	return testutils.SafeGetSlice[*dto.DraftRecord](args, 0), args.Error(1)
+}
+
+func (m *mockDraftRepository) Update(ctx context.Context, record *dto.DraftRecord) (*dto.DraftRecord, error) {
+	args := m.Called(ctx, record)
+	return testutils.SafeGet[dto.DraftRecord](args, 0), args.Error(1)
+}
+
+func (m *mockDraftRepository) Delete(ctx context.Context, draftID, userID int64) (*dto.DraftRecord, error) {
+	args := m.Called(ctx, draftID, userID)
+	return testutils.SafeGet[dto.DraftRecord](args, 0), args.Error(1)
+}
+
+type mockTweetPoster struct {
+	mock.Mock
+}
+
+func (m *mockTweetPoster) InsertTweet(ctx context.Context, userID int64, content string, media []dto.MediaAttachment) (*dto.TweetRecord, error) {
+	args := m.Called(ctx, userID, content, media)
+	return testutils.SafeGet[dto.TweetRecord](args, 0), args.Error(1)
+}
+
+func (m *mockTweetPoster) AnnounceTweet(ctx context.Context, tweet *dto.TweetRecord) {
+	m.Called(ctx, tweet)
+}
+
+// fn がエラーを返せばそれを返す。Return でエラーを指定した場合は fn 成功後のコミット失敗として扱う
+type mockTransactionManager struct {
+	mock.Mock
+}
+
+func (m *mockTransactionManager) Exec(ctx context.Context, fn func(ctx context.Context) error) error {
+	args := m.Called(ctx)
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		return args.Error(0)
+	}
+	return nil
+}
+
+type mockMediaRepository struct {
+	mock.Mock
+}
+
+func (m *mockMediaRepository) Create(ctx context.Context, record *dto.MediaRecord) (*dto.MediaRecord, error) {
+	args := m.Called(ctx, record)
+	return testutils.SafeGet[dto.MediaRecord](args, 0), args.Error(1)
+}
+
+func (m *mockMediaRepository) PurgeOrphans(ctx context.Context, olderThan time.Duration, limit int) ([]*dto.MediaRecord, error) {
+	args := m.Called(ctx, olderThan, limit)
+	return testutils.SafeGetSlice[*dto.MediaRecord](args, 0), args.Error(1)
+}
+
+type mockMediaStorage struct {
+	mock.Mock
+}
+
+func (m *mockMediaStorage) Save(ctx context.Context, key string, r io.Reader) error {
+	args := m.Called(ctx, key, r)
+	return args.Error(0)
+}
+
+func (m *mockMediaStorage) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *mockMediaStorage) URL(key string) string {
+	args := m.Called(key)
+	return args.String(0)
+}
+
+type mockPollRepository struct {
+	mock.Mock
+}
+
+func (m *mockPollRepository) GetByTweetID(ctx context.Context, tweetID int64) (*dto.PollRecord, error) {
+	args := m.Called(ctx, tweetID)
+	return testutils.SafeGet[dto.PollRecord](args, 0), args.Error(1)
+}
+
+func (m *mockPollRepository) Vote(ctx context.Context, pollID, optionID, userID int64) error {
+	args := m.Called(ctx, pollID, optionID, userID)
+	return args.Error(0)
+}
+
+func (m *mockPollRepository) GetVotedOptionID(ctx context.Context, pollID, userID int64) (int64, bool, error) {
+	args := m.Called(ctx, pollID, userID)
+	return args.Get(0).(int64), args.Bool(1), args.Error(2)
+}
+
+func (m *mockPollRepository) GetTally(ctx context.Context, pollID int64) (map[int64]int64, error) {
+	args := m.Called(ctx, pollID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]int64), args.Error(1)
+}
+
+func (m *mockPollRepository) PersistTallies(ctx context.Context, limit int) (int, error) {
+	args := m.Called(ctx, limit)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockPollRepository) GetExpiredIDs(ctx context.Context, limit int) ([]int64, error) {
+	args := m.Called(ctx, limit)
+	return testutils.SafeGetSlice[int64](args, 0), args.Error(1)
+}
+
+func (m *mockPollRepository) Close(ctx context.Context, pollID int64) error {
+	args := m.Called(ctx, pollID)
+	return args.Error(0)
+}
+
+type mockBookmarkRepository struct {
+	mock.Mock
+}
+
+func (m *mockBookmarkRepository) Create(ctx context.Context, userID, tweetID int64) (*dto.BookmarkRecord, error) {
+	args := m.Called(ctx, userID, tweetID)
+	return testutils.SafeGet[dto.BookmarkRecord](args, 0), args.Error(1)
+}
+
+func (m *mockBookmarkRepository) Delete(ctx context.Context, userID, tweetID int64) error {
+	args := m.Called(ctx, userID, tweetID)
+	return args.Error(0)
+}
+
+func (m *mockBookmarkRepository) List(ctx context.Context, userID, cursor int64, limit int) ([]*dto.BookmarkRecord, error) {
+	args := m.Called(ctx, userID, cursor, limit)
+	return testutils.SafeGetSlice[*dto.BookmarkRecord](args, 0), args.Error(1)
+}
+
+func (m *mockBookmarkRepository) DeleteByTweet(ctx context.Context, tweetID int64) error {
+	args := m.Called(ctx, tweetID)
+	return args.Error(0)
+}
+
+type mockTweetProvider struct {
+	mock.Mock
+}
+
+func (m *mockTweetProvider) GetTweets(ctx context.Context, tweetIDs []int64) ([]*dto.TweetRecord, error) {
+	args := m.Called(ctx, tweetIDs)
+	return testutils.SafeGetSlice[*dto.TweetRecord](args, 0), args.Error(1)
+}
+
+func (m *mockTweetProvider) GetMyTweets(ctx context.Context, userID int64, page, size int) ([]*dto.TweetRecord, error) {
+	args := m.Called(ctx, userID, page, size)
+	return testutils.SafeGetSlice[*dto.TweetRecord](args, 0), args.Error(1)
+}
+
+type mockPinRepository struct {
+	mock.Mock
+}
+
+func (m *mockPinRepository) SetPinnedTweet(ctx context.Context, userID, tweetID int64) error {
+	args := m.Called(ctx, userID, tweetID)
+	return args.Error(0)
+}
+
+func (m *mockPinRepository) ClearPinnedTweet(ctx context.Context, userID, tweetID int64) error {
+	args := m.Called(ctx, userID, tweetID)
+	return args.Error(0)
+}
+
+func (m *mockPinRepository) GetPinnedTweetID(ctx context.Context, userID int64) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type mockLinkPreviewRepository struct {
+	mock.Mock
+}
+
+func (m *mockLinkPreviewRepository) Exists(ctx context.Context, url string) (bool, error) {
+	args := m.Called(ctx, url)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockLinkPreviewRepository) Save(ctx context.Context, record *dto.LinkPreviewRecord) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
+
+func (m *mockLinkPreviewRepository) SaveFailed(ctx context.Context, url string) error {
+	args := m.Called(ctx, url)
+	return args.Error(0)
+}
+
+type mockPreviewFetcher struct {
+	mock.Mock
+}
+
+func (m *mockPreviewFetcher) Fetch(ctx context.Context, url string) (*unfurl.Preview, error) {
+	args := m.Called(ctx, url)
+	return testutils.SafeGet[unfurl.Preview](args, 0), args.Error(1)
+}
+
+type mockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *mockNotificationRepository) Create(ctx context.Context, records []*dto.NotificationRecord) ([]int64, error) {
+	args := m.Called(ctx, records)
+	return testutils.SafeGetSlice[int64](args, 0), args.Error(1)
+}
+
+func (m *mockNotificationRepository) List(ctx context.Context, userID, cursor int64, limit int) ([]*dto.NotificationRecord, error) {
+	args := m.Called(ctx, userID, cursor, limit)
+	return testutils.SafeGetSlice[*dto.NotificationRecord](args, 0), args.Error(1)
+}
+
+func (m *mockNotificationRepository) GetReadCursor(ctx context.Context, userID int64) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockNotificationRepository) MarkRead(ctx context.Context, userID, cursor int64) error {
+	args := m.Called(ctx, userID, cursor)
+	return args.Error(0)
+}
+
+func (m *mockNotificationRepository) UnreadCount(ctx context.Context, userID int64) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type mockNotificationUserProvider struct {
+	mock.Mock
+}
+
+func (m *mockNotificationUserProvider) GetInfoLists(ctx context.Context, userIDs []int64) ([]*dto.UserSlimRecord, error) {
+	args := m.Called(ctx, userIDs)
+	return testutils.SafeGetSlice[*dto.UserSlimRecord](args, 0), args.Error(1)
+}
+
+func (m *mockNotificationUserProvider) GetIDsByUsernames(ctx context.Context, usernames []string) ([]*dto.UserSlimRecord, error) {
+	args := m.Called(ctx, usernames)
+	return testutils.SafeGetSlice[*dto.UserSlimRecord](args, 0), args.Error(1)
+}
+
+type mockNotificationPublisher struct {
+	mock.Mock
+}
+
+func (m *mockNotificationPublisher) PublishNotification(ctx context.Context, notificationType string, actorID, tweetID int64, userIDs []int64) error {
+	args := m.Called(ctx, notificationType, actorID, tweetID, userIDs)
+	return args.Error(0)
+}
+
+type mockStreamRepository struct {
+	mock.Mock
+}
+
+func (m *mockStreamRepository) Publish(ctx context.Context, record *dto.StreamEventRecord) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
+
+func (m *mockStreamRepository) Since(ctx context.Context, afterID string, count int64) ([]*dto.StreamEventRecord, error) {
+	args := m.Called(ctx, afterID, count)
+	return testutils.SafeGetSlice[*dto.StreamEventRecord](args, 0), args.Error(1)
+}
+
+func (m *mockStreamRepository) Subscribe(ctx context.Context) (<-chan *dto.StreamEventRecord, func() error, error) {
+	args := m.Called(ctx)
+	events, _ := args.Get(0).(<-chan *dto.StreamEventRecord)
+	closeFn, _ := args.Get(1).(func() error)
+	return events, closeFn, args.Error(2)
+}
+
+type mockTopicRepository struct {
+	mock.Mock
+}
+
+func (m *mockTopicRepository) Publish(ctx context.Context, record *dto.TopicMessageRecord) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
+
+func (m *mockTopicRepository) Listen(ctx context.Context) (<-chan *dto.TopicMessageRecord, error) {
+	args := m.Called(ctx)
+	messages, _ := args.Get(0).(<-chan *dto.TopicMessageRecord)
+	return messages, args.Error(1)
+}
+
+func (m *mockTopicRepository) Join(ctx context.Context, topics ...string) error {
+	args := m.Called(ctx, topics)
+	return args.Error(0)
+}
+
+func (m *mockTopicRepository) Leave(ctx context.Context, topics ...string) error {
+	args := m.Called(ctx, topics)
+	return args.Error(0)
+}
+
+func (m *mockTopicRepository) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+type mockUserEventSubscriber struct {
+	mock.Mock
+}
+
+func (m *mockUserEventSubscriber) Subscribe(ctx context.Context, userID int64, lastEventID string) (<-chan *dto.StreamEventRecord, func(), error) {
+	args := m.Called(ctx, userID, lastEventID)
+	events, _ := args.Get(0).(<-chan *dto.StreamEventRecord)
+	cancel, _ := args.Get(1).(func())
+	return events, cancel, args.Error(2)
+}
+
+type mockDMParticipantChecker struct {
+	mock.Mock
+}
+
+func (m *mockDMParticipantChecker) IsParticipant(ctx context.Context, conversationID, userID int64) (bool, error) {
+	args := m.Called(ctx, conversationID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+type mockDMRepository struct {
+	mock.Mock
+}
+
+func (m *mockDMRepository) CreateConversation(ctx context.Context, creatorID int64, participantIDs []int64) (*dto.DMConversationRecord, error) {
+	args := m.Called(ctx, creatorID, participantIDs)
+	return testutils.SafeGet[dto.DMConversationRecord](args, 0), args.Error(1)
+}
+
+func (m *mockDMRepository) ListConversations(ctx context.Context, userID int64, limit int) ([]*dto.DMConversationRecord, error) {
+	args := m.Called(ctx, userID, limit)
+	return testutils.SafeGetSlice[*dto.DMConversationRecord](args, 0), args.Error(1)
+}
+
+func (m *mockDMRepository) GetConversation(ctx context.Context, conversationID, userID int64) (*dto.DMConversationRecord, error) {
+	args := m.Called(ctx, conversationID, userID)
+	return testutils.SafeGet[dto.DMConversationRecord](args, 0), args.Error(1)
+}
+
+func (m *mockDMRepository) IsParticipant(ctx context.Context, conversationID, userID int64) (bool, error) {
+	args := m.Called(ctx, conversationID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockDMRepository) SendMessage(ctx context.Context, record *dto.DMMessageRecord, recipientIDs []int64) (*dto.DMMessageRecord, error) {
+	args := m.Called(ctx, record, recipientIDs)
+	return testutils.SafeGet[dto.DMMessageRecord](args, 0), args.Error(1)
+}
+
+func (m *mockDMRepository) ListMessages(ctx context.Context, conversationID, cursor int64, limit int) ([]*dto.DMMessageRecord, error) {
+	args := m.Called(ctx, conversationID, cursor, limit)
+	return testutils.SafeGetSlice[*dto.DMMessageRecord](args, 0), args.Error(1)
+}
+
+func (m *mockDMRepository) MarkRead(ctx context.Context, conversationID, userID, cursor int64) (int64, error) {
+	args := m.Called(ctx, conversationID, userID, cursor)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockDMRepository) UnreadCounts(ctx context.Context, userID int64) (map[int64]int64, error) {
+	args := m.Called(ctx, userID)
+	counts, _ := args.Get(0).(map[int64]int64)
+	return counts, args.Error(1)
+}
+
+func (m *mockDMRepository) GetDeniedRecipients(ctx context.Context, senderID int64, recipientIDs []int64) ([]int64, error) {
+	args := m.Called(ctx, senderID, recipientIDs)
+	return testutils.SafeGetSlice[int64](args, 0), args.Error(1)
+}
+
+func (m *mockDMRepository) UpdateDMPolicy(ctx context.Context, userID int64, policy string) error {
+	args := m.Called(ctx, userID, policy)
+	return args.Error(0)
+}
+
+type mockListRepository struct {
+	mock.Mock
+}
+
+func (m *mockListRepository) Create(ctx context.Context, record *dto.ListRecord) (*dto.ListRecord, error) {
+	args := m.Called(ctx, record)
+	return testutils.SafeGet[dto.ListRecord](args, 0), args.Error(1)
+}
+
+func (m *mockListRepository) Get(ctx context.Context, listID int64) (*dto.ListRecord, error) {
+	args := m.Called(ctx, listID)
+	return testutils.SafeGet[dto.ListRecord](args, 0), args.Error(1)
+}
+
+func (m *mockListRepository) ListByOwner(ctx context.Context, ownerID int64) ([]*dto.ListRecord, error) {
+	args := m.Called(ctx, ownerID)
+	return testutils.SafeGetSlice[*dto.ListRecord](args, 0), args.Error(1)
+}
+
+func (m *mockListRepository) Delete(ctx context.Context, listID, ownerID int64) error {
+	args := m.Called(ctx, listID, ownerID)
+	return args.Error(0)
+}
+
+func (m *mockListRepository) AddMember(ctx context.Context, listID, userID int64, maxMembers int) error {
+	args := m.Called(ctx, listID, userID, maxMembers)
+	return args.Error(0)
+}
+
+func (m *mockListRepository) RemoveMember(ctx context.Context, listID, userID int64) error {
+	args := m.Called(ctx, listID, userID)
+	return args.Error(0)
+}
+
+func (m *mockListRepository) MemberIDs(ctx context.Context, listID int64) ([]int64, error) {
+	args := m.Called(ctx, listID)
+	return testutils.SafeGetSlice[int64](args, 0), args.Error(1)
+}
+
+type mockListTweetProvider struct {
+	mock.Mock
+}
+
+func (m *mockListTweetProvider) GetTweetsByAuthors(ctx context.Context, authorIDs []int64, cursor int64, limit int) ([]*dto.TweetRecord, int64, error) {
+	args := m.Called(ctx, authorIDs, cursor, limit)
+	return testutils.SafeGetSlice[*dto.TweetRecord](args, 0), args.Get(1).(int64), args.Error(2)
+}
+
+type mockTrendRepository struct {
+	mock.Mock
+}
+
+func (m *mockTrendRepository) IncrHashtags(ctx context.Context, bucket int64, hashtags []string, ttl time.Duration) error {
+	args := m.Called(ctx, bucket, hashtags, ttl)
+	return args.Error(0)
+}
+
+func (m *mockTrendRepository) SumBuckets(ctx context.Context, buckets []int64) (map[string]int64, error) {
+	args := m.Called(ctx, buckets)
+	if v := args.Get(0); v != nil {
+		return v.(map[string]int64), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockTrendRepository) SaveTop(ctx context.Context, records []*dto.TrendRecord, ttl time.Duration) error {
+	args := m.Called(ctx, records, ttl)
+	return args.Error(0)
+}
+
+func (m *mockTrendRepository) GetTop(ctx context.Context) ([]*dto.TrendRecord, error) {
+	args := m.Called(ctx)
+	return testutils.SafeGetSlice[*dto.TrendRecord](args, 0), args.Error(1)
+}
+
+type mockTrendUserProvider struct {
+	mock.Mock
+}
+
+func (m *mockTrendUserProvider) ToMyAccount(ctx context.Context, userID int64) (*dto.UserRecord, error) {
+	args := m.Called(ctx, userID)
+	return testutils.SafeGet[dto.UserRecord](args, 0), args.Error(1)
+}
+
+type mockViewRepository struct {
+	mock.Mock
+}
+
+func (m *mockViewRepository) Record(ctx context.Context, viewer string, window int64, tweetIDs []int64, ttl time.Duration) (int64, error) {
+	args := m.Called(ctx, viewer, window, tweetIDs, ttl)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockViewRepository) Flush(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+type mockAnalyticsRepository struct {
+	mock.Mock
+}
+
+func (m *mockAnalyticsRepository) Rollup(ctx context.Context, from, to time.Time) (int64, error) {
+	args := m.Called(ctx, from, to)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockAnalyticsRepository) GetDailyStats(ctx context.Context, userID int64, from, to time.Time) ([]*dto.DailyStatsRecord, error) {
+	args := m.Called(ctx, userID, from, to)
+	return testutils.SafeGetSlice[*dto.DailyStatsRecord](args, 0), args.Error(1)
+}
+
+type mockUserTokenRepository struct {
+	mock.Mock
+}
+
+func (m *mockUserTokenRepository) Issue(ctx context.Context, record *dto.UserTokenRecord) (*dto.UserTokenRecord, error) {
+	args := m.Called(ctx, record)
+	return testutils.SafeGet[dto.UserTokenRecord](args, 0), args.Error(1)
+}
+
+func (m *mockUserTokenRepository) Consume(ctx context.Context, purpose, tokenHash string) (*dto.UserTokenRecord, error) {
+	args := m.Called(ctx, purpose, tokenHash)
+	return testutils.SafeGet[dto.UserTokenRecord](args, 0), args.Error(1)
+}
+
+func (m *mockUserTokenRepository) Revoke(ctx context.Context, userID int64, purpose string) error {
+	args := m.Called(ctx, userID, purpose)
+	return args.Error(0)
+}
+
+type mockSessionRevoker struct {
+	mock.Mock
+}
+
+func (m *mockSessionRevoker) RevokeAll(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+type mockMailer struct {
+	mock.Mock
+}
+
+func (m *mockMailer) Send(ctx context.Context, to, subject, body string) error {
+	args := m.Called(ctx, to, subject, body)
+	return args.Error(0)
+}
+
+type mockRateLimitRepository struct {
+	mock.Mock
+}
+
+func (m *mockRateLimitRepository) Allow(ctx context.Context, key string, limit int64, window time.Duration) (bool, error) {
+	args := m.Called(ctx, key, limit, window)
+	return args.Bool(0), args.Error(1)
+}
+
+type mockOtherSessionRevoker struct {
+	mock.Mock
+}
+
+func (m *mockOtherSessionRevoker) RevokeOthers(ctx context.Context, userID int64, currentToken string) error {
+	args := m.Called(ctx, userID, currentToken)
+	return args.Error(0)
+}
+
+type mockVerificationMailSender struct {
+	mock.Mock
+}
+
+func (m *mockVerificationMailSender) SendVerification(ctx context.Context, userID int64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+)
+
+// メールアドレスの @ を拾わないよう、直前が英数字でない場合のみメンションとみなす
+var mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_@])@([A-Za-z0-9_]{4,50})\b`)
+
+type NotificationRepository interface {
+	Create(ctx context.Context, records []*dto.NotificationRecord) ([]int64, error)
+	List(ctx context.Context, userID, cursor int64, limit int) ([]*dto.NotificationRecord, error)
+	GetReadCursor(ctx context.Context, userID int64) (int64, error)
+	MarkRead(ctx context.Context, userID, cursor int64) error
+	UnreadCount(ctx context.Context, userID int64) (int64, error)
+}
+
+type NotificationUserProvider interface {
+	GetInfoLists(ctx context.Context, userIDs []int64) ([]*dto.UserSlimRecord, error)
+	GetIDsByUsernames(ctx context.Context, usernames []string) ([]*dto.UserSlimRecord, error)
+}
+
+type NotificationPublisher interface {
+	PublishNotification(ctx context.Context, notificationType string, actorID, tweetID int64, userIDs []int64) error
+}
+
+type notificationService struct {
+	notificationRepository NotificationRepository
+	userProvider           NotificationUserProvider
+	tweetProvider          TweetProvider
+	publisher              NotificationPublisher
+}
+
+func NewNotificationService(nr NotificationRepository, up NotificationUserProvider, tp TweetProvider, p NotificationPublisher) *notificationService {
+	return &notificationService{
+		notificationRepository: nr,
+		userProvider:           up,
+		tweetProvider:          tp,
+		publisher:              p,
+	}
+}
+
+func (s *notificationService) NotifyFollow(ctx context.Context, actorID, targetID int64) error {
+	return s.Notify(ctx, targetID, actorID, models.NotificationTypeFollow, 0)
+}
+
+// いいね・返信・リツイートなど個別の操作から呼ぶ。フォロー以外は tweetID を指定する
+func (s *notificationService) Notify(ctx context.Context, recipientID, actorID int64, notificationType string, tweetID int64) error {
+	if recipientID <= 0 || actorID <= 0 {
+		return errcode.ErrInvalidUserID
+	}
+	if recipientID == actorID {
+		return nil
+	}
+
+	record := &dto.NotificationRecord{
+		UserID:  recipientID,
+		ActorID: actorID,
+		Type:    notificationType,
+	}
+	if tweetID > 0 {
+		record.TweetID = &tweetID
+	}
+
+	recipientIDs, err := s.notificationRepository.Create(ctx, []*dto.NotificationRecord{record})
+	if err != nil {
+		return fmt.Errorf("Notify: 通知の作成に失敗しました (type: %s, user_id: %d): %w", notificationType, recipientID, err)
+	}
+	s.publish(ctx, notificationType, actorID, tweetID, recipientIDs)
+	return nil
+}
+
+// 本文中の @ユーザー名 に通知する。同じツイートで何度メンションしても通知は1件
+func (s *notificationService) NotifyMentions(ctx context.Context, tweetID int64) error {
+	tweets, err := s.tweetProvider.GetTweets(ctx, []int64{tweetID})
+	if err != nil {
+		return err
+	}
+	if len(tweets) == 0 {
+		return nil
+	}
+	tweet := tweets[0]
+
+	usernames := extractMentions(tweet.Content)
+	if len(usernames) == 0 {
+		return nil
+	}
+
+	users, err := s.userProvider.GetIDsByUsernames(ctx, usernames)
+	if err != nil {
+		return fmt.Errorf("NotifyMentions: メンション先の取得に失敗しました (tweet_id: %d): %w", tweetID, err)
+	}
+
+	records := make([]*dto.NotificationRecord, 0, len(users))
+	for _, u := range users {
+		if u.ID == tweet.UserID {
+			continue
+		}
+		records = append(records, &dto.NotificationRecord{
+			UserID:  u.ID,
+			ActorID: tweet.UserID,
+			Type:    models.NotificationTypeMention,
+			TweetID: &tweet.ID,
+		})
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	recipientIDs, err := s.notificationRepository.Create(ctx, records)
+	if err != nil {
+		return fmt.Errorf("NotifyMentions: 通知の作成に失敗しました (tweet_id: %d): %w", tweetID, err)
+	}
+	s.publish(ctx, models.NotificationTypeMention, tweet.UserID, tweet.ID, recipientIDs)
+	return nil
+}
+
+// 接続中のクライアントへの配信は補助的なものなので、失敗しても通知の作成は成功とする
+func (s *notificationService) publish(ctx context.Context, notificationType string, actorID, tweetID int64, recipientIDs []int64) {
+	if len(recipientIDs) == 0 {
+		return
+	}
+	if err := s.publisher.PublishNotification(ctx, notificationType, actorID, tweetID, recipientIDs); err != nil {
+		slog.Warn("通知のリアルタイム配信に失敗しました", "type", notificationType, "count", len(recipientIDs), "err", err)
+	}
+}
+
+// 新しい順に、同じ種類・同じツイートへの通知をまとめて返す。
+// まとめるのは同じページ内の通知のみ。次のページがなければ nextCursor は 0
+func (s *notificationService) ListNotifications(ctx context.Context, userID, cursor int64, limit int) ([]*dto.NotificationGroupRecord, int64, error) {
+	if userID <= 0 {
+		return nil, 0, errcode.ErrInvalidUserID
+	}
+	if cursor < 0 {
+		return nil, 0, errcode.ErrInvalidCursor
+	}
+	if limit <= 0 {
+		limit = defaultNotificationLimit
+	}
+	limit = min(limit, maxNotificationLimit)
+
+	readCursor, err := s.notificationRepository.GetReadCursor(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	notifications, err := s.notificationRepository.List(ctx, userID, cursor, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ListNotifications: 通知一覧の取得に失敗しました (user_id: %d): %w", userID, err)
+	}
+
+	var nextCursor int64
+	if len(notifications) == limit {
+		nextCursor = notifications[len(notifications)-1].ID
+	}
+	if len(notifications) == 0 {
+		return []*dto.NotificationGroupRecord{}, nextCursor, nil
+	}
+
+	groups, actorIDs := groupNotifications(notifications, readCursor)
+
+	actors, err := s.userProvider.GetInfoLists(ctx, actorIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	actorByID := make(map[int64]*dto.UserSlimRecord, len(actors))
+	for _, a := range actors {
+		actorByID[a.ID] = a
+	}
+
+	result := make([]*dto.NotificationGroupRecord, 0, len(groups))
+	for _, g := range groups {
+		resolved := make([]*dto.UserSlimRecord, 0, len(g.Actors))
+		for _, a := range g.Actors {
+			if info, ok := actorByID[a.ID]; ok {
+				resolved = append(resolved, info)
+			}
+		}
+		if len(resolved) == 0 {
+			continue
+		}
+		g.Actors = resolved
+		result = append(result, g)
+	}
+	return result, nextCursor, nil
+}
+
+// cursor 以下の通知を既読にする。0 ならすべて
+func (s *notificationService) MarkRead(ctx context.Context, userID, cursor int64) error {
+	if userID <= 0 {
+		return errcode.ErrInvalidUserID
+	}
+	if cursor < 0 {
+		return errcode.ErrInvalidCursor
+	}
+
+	return s.notificationRepository.MarkRead(ctx, userID, cursor)
+}
+
+func (s *notificationService) UnreadCount(ctx context.Context, userID int64) (int64, error) {
+	if userID <= 0 {
+		return 0, errcode.ErrInvalidUserID
+	}
+
+	return s.notificationRepository.UnreadCount(ctx, userID)
+}
+
+// 並び順を保ったまままとめる。表示する行為者は新しい順に maxGroupActors 人まで
+func groupNotifications(notifications []*dto.NotificationRecord, readCursor int64) ([]*dto.NotificationGroupRecord, []int64) {
+	groups := make([]*dto.NotificationGroupRecord, 0, len(notifications))
+	groupByKey := make(map[string]*dto.NotificationGroupRecord, len(notifications))
+	actorIDs := make([]int64, 0, len(notifications))
+	seenActor := make(map[int64]bool, len(notifications))
+
+	for _, n := range notifications {
+		key := n.GroupKey()
+		g, ok := groupByKey[key]
+		if !ok {
+			g = &dto.NotificationGroupRecord{
+				ID:        n.ID,
+				Type:      n.Type,
+				TweetID:   n.TweetID,
+				CreatedAt: n.CreatedAt,
+				IsRead:    n.ID <= readCursor,
+			}
+			groupByKey[key] = g
+			groups = append(groups, g)
+		}
+
+		g.ActorCount++
+		if len(g.Actors) < maxGroupActors {
+			g.Actors = append(g.Actors, &dto.UserSlimRecord{ID: n.ActorID})
+			if !seenActor[n.ActorID] {
+				seenActor[n.ActorID] = true
+				actorIDs = append(actorIDs, n.ActorID)
+			}
+		}
+	}
+	return groups, actorIDs
+}
+
+func extractMentions(content string) []string {
+	matches := mentionPattern.FindAllStringSubmatch(content, -1)
+	usernames := make([]string, 0, len(matches))
+	seen := make(map[string]bool, len(matches))
+	for _, m := range matches {
+		if seen[m[1]] {
+			continue
+		}
+		seen[m[1]] = true
+		usernames = append(usernames, m[1])
+		if len(usernames) == maxMentionsPerTweet {
+			break
+		}
+	}
+	return usernames
+}
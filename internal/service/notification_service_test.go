@@ -0,0 +1,183 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestListNotifications(t *testing.T) {
+	like := func(id, actorID, tweetID int64) *dto.NotificationRecord {
+		return &dto.NotificationRecord{ID: id, UserID: 1, ActorID: actorID, Type: models.NotificationTypeLike, TweetID: int64Ptr(tweetID)}
+	}
+
+	tests := []struct {
+		name           string
+		setupMock      func(mr *mockNotificationRepository, mu *mockNotificationUserProvider)
+		wantedErr      error
+		wantMessages   []string
+		wantRead       []bool
+		wantNextCursor int64
+	}{
+		{
+			name: "正常系: 同じツイートへのいいねをまとめ、既読位置で既読を判定する",
+			setupMock: func(mr *mockNotificationRepository, mu *mockNotificationUserProvider) {
+				mr.On("GetReadCursor", mock.Anything, int64(1)).Return(int64(6), nil)
+				mr.On("List", mock.Anything, int64(1), int64(0), defaultNotificationLimit).Return([]*dto.NotificationRecord{
+					like(10, 2, 100),
+					{ID: 9, UserID: 1, ActorID: 3, Type: models.NotificationTypeMention, TweetID: int64Ptr(200)},
+					like(8, 3, 100),
+					like(7, 4, 100),
+					like(6, 5, 100),
+					{ID: 5, UserID: 1, ActorID: 2, Type: models.NotificationTypeFollow},
+				}, nil)
+				mu.On("GetInfoLists", mock.Anything, []int64{2, 3, 4}).Return([]*dto.UserSlimRecord{
+					{ID: 2, Username: "alice"}, {ID: 3, Username: "bob"}, {ID: 4, Username: "carol"},
+				}, nil)
+			},
+			wantMessages: []string{
+				"aliceさん他3人があなたのツイートにいいねしました",
+				"bobさんがあなたをメンションしました",
+				"aliceさんがあなたをフォローしました",
+			},
+			wantRead: []bool{false, false, true},
+		},
+		{
+			name: "正常系: ページが埋まれば最後の通知IDを次のカーソルにする",
+			setupMock: func(mr *mockNotificationRepository, mu *mockNotificationUserProvider) {
+				mr.On("GetReadCursor", mock.Anything, int64(1)).Return(int64(0), nil)
+				mr.On("List", mock.Anything, int64(1), int64(0), defaultNotificationLimit).Return(
+					func() []*dto.NotificationRecord {
+						list := make([]*dto.NotificationRecord, 0, defaultNotificationLimit)
+						for i := defaultNotificationLimit; i > 0; i-- {
+							list = append(list, like(int64(i+10), 2, 100))
+						}
+						return list
+					}(), nil)
+				mu.On("GetInfoLists", mock.Anything, []int64{2}).Return([]*dto.UserSlimRecord{{ID: 2, Username: "alice"}}, nil)
+			},
+			wantMessages:   []string{"aliceさん他19人があなたのツイートにいいねしました"},
+			wantRead:       []bool{false},
+			wantNextCursor: 11,
+		},
+		{
+			name: "正常系: 退会したユーザーだけの通知は表示しない",
+			setupMock: func(mr *mockNotificationRepository, mu *mockNotificationUserProvider) {
+				mr.On("GetReadCursor", mock.Anything, int64(1)).Return(int64(0), nil)
+				mr.On("List", mock.Anything, int64(1), int64(0), defaultNotificationLimit).Return([]*dto.NotificationRecord{like(3, 9, 100)}, nil)
+				mu.On("GetInfoLists", mock.Anything, []int64{9}).Return([]*dto.UserSlimRecord{}, nil)
+			},
+			wantMessages: []string{},
+			wantRead:     []bool{},
+		},
+		{
+			name: "異常系: ユーザーが存在しない",
+			setupMock: func(mr *mockNotificationRepository, mu *mockNotificationUserProvider) {
+				mr.On("GetReadCursor", mock.Anything, int64(1)).Return(int64(0), errcode.ErrUserNotFound)
+			},
+			wantedErr: errcode.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := new(mockNotificationRepository)
+			mu := new(mockNotificationUserProvider)
+			tt.setupMock(mr, mu)
+			svc := NewNotificationService(mr, mu, new(mockTweetProvider), new(mockNotificationPublisher))
+
+			groups, next, err := svc.ListNotifications(context.Background(), 1, 0, 0)
+
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+				return
+			}
+			require.NoError(t, err)
+			messages := make([]string, 0, len(groups))
+			read := make([]bool, 0, len(groups))
+			for _, g := range groups {
+				messages = append(messages, g.Message())
+				read = append(read, g.IsRead)
+			}
+			assert.Equal(t, tt.wantMessages, messages)
+			assert.Equal(t, tt.wantRead, read)
+			assert.Equal(t, tt.wantNextCursor, next)
+			mr.AssertExpectations(t)
+			mu.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNotifyMentions(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		setupMock func(mr *mockNotificationRepository, mu *mockNotificationUserProvider, mp *mockNotificationPublisher)
+	}{
+		{
+			name:    "正常系: 重複と自分宛てを除いて通知し、作成できた分だけ配信する",
+			content: "@alice_01 と @bob_02 さん、@alice_01 もう一度。@author_1",
+			setupMock: func(mr *mockNotificationRepository, mu *mockNotificationUserProvider, mp *mockNotificationPublisher) {
+				mu.On("GetIDsByUsernames", mock.Anything, []string{"alice_01", "bob_02", "author_1"}).Return([]*dto.UserSlimRecord{
+					{ID: 2, Username: "alice_01"}, {ID: 3, Username: "bob_02"}, {ID: 1, Username: "author_1"},
+				}, nil)
+				mr.On("Create", mock.Anything, mock.MatchedBy(func(records []*dto.NotificationRecord) bool {
+					return len(records) == 2 &&
+						records[0].UserID == 2 && records[1].UserID == 3 &&
+						records[0].ActorID == 1 && records[0].Type == models.NotificationTypeMention &&
+						*records[0].TweetID == 50
+				})).Return([]int64{3}, nil)
+				mp.On("PublishNotification", mock.Anything, models.NotificationTypeMention, int64(1), int64(50), []int64{3}).Return(nil)
+			},
+		},
+		{
+			name:      "正常系: メールアドレスはメンションとみなさない",
+			content:   "連絡は mail@example.com まで",
+			setupMock: func(mr *mockNotificationRepository, mu *mockNotificationUserProvider, mp *mockNotificationPublisher) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := new(mockNotificationRepository)
+			mu := new(mockNotificationUserProvider)
+			mt := new(mockTweetProvider)
+			mt.On("GetTweets", mock.Anything, []int64{50}).Return([]*dto.TweetRecord{{ID: 50, UserID: 1, Content: tt.content}}, nil)
+			mp := new(mockNotificationPublisher)
+			tt.setupMock(mr, mu, mp)
+			svc := NewNotificationService(mr, mu, mt, mp)
+
+			err := svc.NotifyMentions(context.Background(), 50)
+
+			require.NoError(t, err)
+			mr.AssertExpectations(t)
+			mu.AssertExpectations(t)
+			mp.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNotifyFollow(t *testing.T) {
+	mr := new(mockNotificationRepository)
+	mr.On("Create", mock.Anything, []*dto.NotificationRecord{{UserID: 2, ActorID: 1, Type: models.NotificationTypeFollow}}).Return([]int64{2}, nil)
+	mp := new(mockNotificationPublisher)
+	mp.On("PublishNotification", mock.Anything, models.NotificationTypeFollow, int64(1), int64(0), []int64{2}).Return(errors.New("redis down"))
+	svc := NewNotificationService(mr, new(mockNotificationUserProvider), new(mockTweetProvider), mp)
+
+	// 配信に失敗しても通知の作成は成功とする
+	require.NoError(t, svc.NotifyFollow(context.Background(), 1, 2))
+	require.NoError(t, svc.NotifyFollow(context.Background(), 2, 2))
+	mr.AssertExpectations(t)
+	mp.AssertExpectations(t)
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/panjf2000/ants/v2"
+)
+
+type PasswordUserRepository interface {
+	GetByEmail(ctx context.Context, email string) (*dto.UserRecord, error)
+	UpdatePassword(ctx context.Context, userID int64, passwordHash string) error
+}
+
+type UserTokenRepository interface {
+	Issue(ctx context.Context, record *dto.UserTokenRecord) (*dto.UserTokenRecord, error)
+	Consume(ctx context.Context, purpose, tokenHash string) (*dto.UserTokenRecord, error)
+	Revoke(ctx context.Context, userID int64, purpose string) error
+}
+
+type SessionRevoker interface {
+	RevokeAll(ctx context.Context, userID int64) error
+}
+
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+type passwordService struct {
+	userRepository      PasswordUserRepository
+	userTokenRepository UserTokenRepository
+	transactionManager  TransactionManager
+	sessionRevoker      SessionRevoker
+	tokenManager        TokenManager
+	hasher              PasswordHasher
+	mailer              Mailer
+	pool                *ants.Pool
+	resetTTL            time.Duration
+	resetURL            string
+	now                 func() time.Time
+}
+
+func NewPasswordService(
+	ur PasswordUserRepository,
+	utr UserTokenRepository,
+	tm TransactionManager,
+	sr SessionRevoker,
+	tkm TokenManager,
+	h PasswordHasher,
+	m Mailer,
+	p *ants.Pool,
+	resetTTL time.Duration,
+	resetURL string,
+) *passwordService {
+	return &passwordService{
+		userRepository:      ur,
+		userTokenRepository: utr,
+		transactionManager:  tm,
+		sessionRevoker:      sr,
+		tokenManager:        tkm,
+		hasher:              h,
+		mailer:              m,
+		pool:                p,
+		resetTTL:            resetTTL,
+		resetURL:            resetURL,
+		now:                 time.Now,
+	}
+}
+
+// 登録の有無が応答や応答時間から分からないよう、検索とメール送信はバックグラウンドで行う
+func (s *passwordService) RequestPasswordReset(ctx context.Context, email string) error {
+	if email == "" {
+		return errcode.ErrRequiredFieldMissing
+	}
+
+	err := s.pool.Submit(func() {
+		bgCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		s.sendResetMail(bgCtx, email)
+	})
+	if err != nil {
+		return fmt.Errorf("RequestPasswordReset: タスクの投入に失敗しました: %w", err)
+	}
+
+	return nil
+}
+
+func (s *passwordService) sendResetMail(ctx context.Context, email string) {
+	user, err := s.userRepository.GetByEmail(ctx, email)
+	if err != nil {
+		if !errors.Is(err, errcode.ErrUserNotFound) {
+			slog.Error("パスワード再設定: ユーザーの取得に失敗しました", "err", err)
+		}
+		return
+	}
+
+	token, err := s.tokenManager.Generate(userTokenBytes)
+	if err != nil {
+		slog.Error("パスワード再設定: トークンの生成に失敗しました", "user_id", user.ID, "err", err)
+		return
+	}
+
+	_, err = s.userTokenRepository.Issue(ctx, &dto.UserTokenRecord{
+		UserID:    user.ID,
+		Purpose:   models.TokenPurposePasswordReset,
+		TokenHash: s.tokenManager.Hash(token),
+		ExpiresAt: s.now().Add(s.resetTTL).UTC(),
+	})
+	if err != nil {
+		slog.Error("パスワード再設定: トークンの保存に失敗しました", "user_id", user.ID, "err", err)
+		return
+	}
+
+	body := fmt.Sprintf(passwordResetMailBody, user.Username, int(s.resetTTL.Minutes()), s.resetURL+"?token="+url.QueryEscape(token))
+	if err := s.mailer.Send(ctx, user.Email, passwordResetMailSubject, body); err != nil {
+		slog.Error("パスワード再設定: メールの送信に失敗しました", "user_id", user.ID, "err", err)
+	}
+}
+
+// トークンは1回だけ使える。再設定後は盗まれたセッションを残さないよう全端末からログアウトさせる
+func (s *passwordService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if token == "" || newPassword == "" {
+		return errcode.ErrRequiredFieldMissing
+	}
+	if len(token) < 32 || len(token) > 255 {
+		return errcode.ErrInvalidResetToken
+	}
+
+	hash, err := s.hasher.Generate(newPassword)
+	if err != nil {
+		return fmt.Errorf("パスワードをハッシュ化に失敗しました: %w", err)
+	}
+
+	var userID int64
+	err = s.transactionManager.Exec(ctx, func(txCtx context.Context) error {
+		record, err := s.userTokenRepository.Consume(txCtx, models.TokenPurposePasswordReset, s.tokenManager.Hash(token))
+		if err != nil {
+			if errors.Is(err, errcode.ErrUserTokenNotFound) {
+				return errcode.ErrInvalidResetToken
+			}
+			return fmt.Errorf("トークンの確認に失敗しました: %w", err)
+		}
+		userID = record.UserID
+
+		if err := s.userRepository.UpdatePassword(txCtx, userID, hash); err != nil {
+			return fmt.Errorf("パスワードの更新に失敗しました: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.sessionRevoker.RevokeAll(ctx, userID); err != nil {
+		return fmt.Errorf("ResetPassword: パスワードは更新されましたが、セッションの無効化に失敗しました (user_id: %d): %w", userID, err)
+	}
+
+	return nil
+}
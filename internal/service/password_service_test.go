@@ -0,0 +1,154 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type passwordMocks struct {
+	users    *mockUserRepository
+	tokens   *mockUserTokenRepository
+	sessions *mockSessionRevoker
+	tm       *mockTokenManager
+	hasher   *mockBcryptHasher
+	mailer   *mockMailer
+}
+
+func newPasswordServiceForTest() (*passwordService, *passwordMocks) {
+	m := &passwordMocks{
+		users:    new(mockUserRepository),
+		tokens:   new(mockUserTokenRepository),
+		sessions: new(mockSessionRevoker),
+		tm:       new(mockTokenManager),
+		hasher:   new(mockBcryptHasher),
+		mailer:   new(mockMailer),
+	}
+	tx := new(mockTransactionManager)
+	tx.On("Exec", mock.Anything).Maybe()
+	svc := NewPasswordService(m.users, m.tokens, tx, m.sessions, m.tm, m.hasher, m.mailer, nil, 30*time.Minute, "https://aita.example/password/reset")
+	return svc, m
+}
+
+func TestSendResetMail(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		setupMock func(m *passwordMocks)
+	}{
+		{
+			name: "正常系: ハッシュを保存して平文のトークンをメールで送る",
+			setupMock: func(m *passwordMocks) {
+				m.users.On("GetByEmail", mock.Anything, "a@example.com").Return(&dto.UserRecord{ID: 3, Username: "alice", Email: "a@example.com"}, nil)
+				m.tm.On("Generate", userTokenBytes).Return("plain+token", nil)
+				m.tm.On("Hash", "plain+token").Return("hashed")
+				m.tokens.On("Issue", mock.Anything, &dto.UserTokenRecord{
+					UserID:    3,
+					Purpose:   models.TokenPurposePasswordReset,
+					TokenHash: "hashed",
+					ExpiresAt: now.Add(30 * time.Minute),
+				}).Return(&dto.UserTokenRecord{ID: 1}, nil)
+				m.mailer.On("Send", mock.Anything, "a@example.com", passwordResetMailSubject, mock.MatchedBy(func(body string) bool {
+					return strings.Contains(body, "https://aita.example/password/reset?token=plain%2Btoken") && !strings.Contains(body, "hashed")
+				})).Return(nil)
+			},
+		},
+		{
+			name: "未登録のメールアドレスには何もしない",
+			setupMock: func(m *passwordMocks) {
+				m.users.On("GetByEmail", mock.Anything, "a@example.com").Return(nil, errcode.ErrUserNotFound)
+			},
+		},
+		{
+			name: "トークンを保存できなければ送らない",
+			setupMock: func(m *passwordMocks) {
+				m.users.On("GetByEmail", mock.Anything, "a@example.com").Return(&dto.UserRecord{ID: 3, Email: "a@example.com"}, nil)
+				m.tm.On("Generate", userTokenBytes).Return("plain", nil)
+				m.tm.On("Hash", "plain").Return("hashed")
+				m.tokens.On("Issue", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newPasswordServiceForTest()
+			svc.now = func() time.Time { return now }
+			tt.setupMock(m)
+
+			svc.sendResetMail(context.Background(), "a@example.com")
+
+			m.users.AssertExpectations(t)
+			m.tokens.AssertExpectations(t)
+			m.mailer.AssertExpectations(t)
+		})
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	token := strings.Repeat("t", 43)
+
+	tests := []struct {
+		name      string
+		token     string
+		setupMock func(m *passwordMocks)
+		wantErr   error
+	}{
+		{
+			name:  "正常系: パスワードを更新して全セッションを無効にする",
+			token: token,
+			setupMock: func(m *passwordMocks) {
+				m.hasher.On("Generate", "newpassword").Return("newhash", nil)
+				m.tm.On("Hash", token).Return("hashed")
+				m.tokens.On("Consume", mock.Anything, models.TokenPurposePasswordReset, "hashed").Return(&dto.UserTokenRecord{UserID: 3}, nil)
+				m.users.On("UpdatePassword", mock.Anything, int64(3), "newhash").Return(nil)
+				m.sessions.On("RevokeAll", mock.Anything, int64(3)).Return(nil)
+			},
+		},
+		{
+			name:      "形式が不正なトークン",
+			token:     "short",
+			setupMock: func(m *passwordMocks) {},
+			wantErr:   errcode.ErrInvalidResetToken,
+		},
+		{
+			name:  "使用済み・期限切れのトークン",
+			token: token,
+			setupMock: func(m *passwordMocks) {
+				m.hasher.On("Generate", "newpassword").Return("newhash", nil)
+				m.tm.On("Hash", token).Return("hashed")
+				m.tokens.On("Consume", mock.Anything, models.TokenPurposePasswordReset, "hashed").Return(nil, errcode.ErrUserTokenNotFound)
+			},
+			wantErr: errcode.ErrInvalidResetToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newPasswordServiceForTest()
+			tt.setupMock(m)
+
+			err := svc.ResetPassword(context.Background(), tt.token, "newpassword")
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				m.users.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+				m.sessions.AssertNotCalled(t, "RevokeAll", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+			m.tokens.AssertExpectations(t)
+			m.users.AssertExpectations(t)
+			m.sessions.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+type PollRepository interface {
+	GetByTweetID(ctx context.Context, tweetID int64) (*dto.PollRecord, error)
+	Vote(ctx context.Context, pollID, optionID, userID int64) error
+	GetVotedOptionID(ctx context.Context, pollID, userID int64) (int64, bool, error)
+	GetTally(ctx context.Context, pollID int64) (map[int64]int64, error)
+	PersistTallies(ctx context.Context, limit int) (int, error)
+	GetExpiredIDs(ctx context.Context, limit int) ([]int64, error)
+	Close(ctx context.Context, pollID int64) error
+}
+
+type pollService struct {
+	pollRepository PollRepository
+}
+
+func NewPollService(pr PollRepository) *pollService {
+	return &pollService{pollRepository: pr}
+}
+
+// 投票後は結果を返す
+func (s *pollService) Vote(ctx context.Context, tweetID, userID, optionID int64) (*dto.PollRecord, error) {
+	if userID <= 0 {
+		return nil, errcode.ErrInvalidUserID
+	}
+	if tweetID <= 0 {
+		return nil, errcode.ErrInvalidTweetID
+	}
+	if optionID <= 0 {
+		return nil, errcode.ErrPollOptionNotFound
+	}
+
+	poll, err := s.pollRepository.GetByTweetID(ctx, tweetID)
+	if err != nil {
+		return nil, err
+	}
+	if poll.IsClosed(time.Now()) {
+		return nil, errcode.ErrPollClosed
+	}
+
+	if err := s.pollRepository.Vote(ctx, poll.ID, optionID, userID); err != nil {
+		return nil, err
+	}
+
+	poll.VotedOptionID = &optionID
+	if err := s.applyResults(ctx, poll); err != nil {
+		return nil, err
+	}
+	return poll, nil
+}
+
+// 得票数は閲覧者が投票済みか、投票が締め切られている場合のみ見せる
+func (s *pollService) GetPoll(ctx context.Context, tweetID, viewerID int64) (*dto.PollRecord, error) {
+	if tweetID <= 0 {
+		return nil, errcode.ErrInvalidTweetID
+	}
+
+	poll, err := s.pollRepository.GetByTweetID(ctx, tweetID)
+	if err != nil {
+		return nil, err
+	}
+
+	optionID, voted, err := s.pollRepository.GetVotedOptionID(ctx, poll.ID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if voted {
+		poll.VotedOptionID = &optionID
+	}
+
+	if !voted && !poll.IsClosed(time.Now()) {
+		poll.ApplyTally(nil)
+		return poll, nil
+	}
+
+	if err := s.applyResults(ctx, poll); err != nil {
+		return nil, err
+	}
+	return poll, nil
+}
+
+// 確定済みの投票は DB の得票数、それ以外は Redis の集計を使う
+func (s *pollService) applyResults(ctx context.Context, poll *dto.PollRecord) error {
+	poll.ResultsVisible = true
+	if poll.ClosedAt != nil {
+		return nil
+	}
+
+	tally, err := s.pollRepository.GetTally(ctx, poll.ID)
+	if err != nil {
+		return err
+	}
+	poll.ApplyTally(tally)
+	return nil
+}
+
+func (s *pollService) PersistTallies(ctx context.Context, limit int) (int, error) {
+	return s.pollRepository.PersistTallies(ctx, limit)
+}
+
+// 締切時刻を過ぎた投票を最大 limit 件確定させ、確定した件数を返す
+func (s *pollService) CloseExpiredPolls(ctx context.Context, limit int) (int, error) {
+	pollIDs, err := s.pollRepository.GetExpiredIDs(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	closed := 0
+	for _, pollID := range pollIDs {
+		if err := s.pollRepository.Close(ctx, pollID); err != nil {
+			if errors.Is(err, errcode.ErrPollNotFound) {
+				continue
+			}
+			slog.Error("投票の締切に失敗しました", "poll_id", pollID, "err", err)
+			continue
+		}
+		closed++
+	}
+	return closed, nil
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPoll(closesAt time.Time, closedAt *time.Time) *dto.PollRecord {
+	return &dto.PollRecord{
+		ID:       1,
+		TweetID:  10,
+		ClosesAt: closesAt,
+		ClosedAt: closedAt,
+		Options: []dto.PollOptionRecord{
+			{ID: 11, Position: 0, Label: "犬", Votes: 5},
+			{ID: 12, Position: 1, Label: "猫", Votes: 3},
+		},
+	}
+}
+
+func TestVotePoll(t *testing.T) {
+	open := time.Now().Add(time.Hour)
+	tests := []struct {
+		name      string
+		optionID  int64
+		setupMock func(mr *mockPollRepository)
+		wantedErr error
+		wantVotes []int64
+	}{
+		{
+			name:     "正常系: 投票すると最新の集計が見える",
+			optionID: 12,
+			setupMock: func(mr *mockPollRepository) {
+				mr.On("GetByTweetID", mock.Anything, int64(10)).Return(newTestPoll(open, nil), nil)
+				mr.On("Vote", mock.Anything, int64(1), int64(12), int64(2)).Return(nil)
+				mr.On("GetTally", mock.Anything, int64(1)).Return(map[int64]int64{11: 7, 12: 4}, nil)
+			},
+			wantVotes: []int64{7, 4},
+		},
+		{
+			name:     "異常系: 締切時刻を過ぎた投票",
+			optionID: 12,
+			setupMock: func(mr *mockPollRepository) {
+				mr.On("GetByTweetID", mock.Anything, int64(10)).Return(newTestPoll(time.Now().Add(-time.Minute), nil), nil)
+			},
+			wantedErr: errcode.ErrPollClosed,
+		},
+		{
+			name:     "異常系: 二重投票",
+			optionID: 11,
+			setupMock: func(mr *mockPollRepository) {
+				mr.On("GetByTweetID", mock.Anything, int64(10)).Return(newTestPoll(open, nil), nil)
+				mr.On("Vote", mock.Anything, int64(1), int64(11), int64(2)).Return(errcode.ErrAlreadyVoted)
+			},
+			wantedErr: errcode.ErrAlreadyVoted,
+		},
+		{
+			name:      "異常系: 無効な選択肢ID",
+			optionID:  0,
+			setupMock: func(mr *mockPollRepository) {},
+			wantedErr: errcode.ErrPollOptionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := new(mockPollRepository)
+			tt.setupMock(mr)
+			svc := NewPollService(mr)
+
+			res, err := svc.Vote(context.Background(), 10, 2, tt.optionID)
+
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+				assert.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				assert.True(t, res.ResultsVisible)
+				require.NotNil(t, res.VotedOptionID)
+				assert.Equal(t, tt.optionID, *res.VotedOptionID)
+				for i, want := range tt.wantVotes {
+					assert.Equal(t, want, res.Options[i].Votes)
+				}
+			}
+			mr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetPoll(t *testing.T) {
+	closedAt := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name        string
+		setupMock   func(mr *mockPollRepository)
+		wantVisible bool
+		wantVotes   []int64
+	}{
+		{
+			name: "未投票かつ受付中は得票数を隠す",
+			setupMock: func(mr *mockPollRepository) {
+				mr.On("GetByTweetID", mock.Anything, int64(10)).Return(newTestPoll(time.Now().Add(time.Hour), nil), nil)
+				mr.On("GetVotedOptionID", mock.Anything, int64(1), int64(2)).Return(int64(0), false, nil)
+			},
+			wantVisible: false,
+			wantVotes:   []int64{0, 0},
+		},
+		{
+			name: "投票済みなら Redis の集計を見せる",
+			setupMock: func(mr *mockPollRepository) {
+				mr.On("GetByTweetID", mock.Anything, int64(10)).Return(newTestPoll(time.Now().Add(time.Hour), nil), nil)
+				mr.On("GetVotedOptionID", mock.Anything, int64(1), int64(2)).Return(int64(11), true, nil)
+				mr.On("GetTally", mock.Anything, int64(1)).Return(map[int64]int64{11: 6}, nil)
+			},
+			wantVisible: true,
+			wantVotes:   []int64{6, 0},
+		},
+		{
+			name: "締切済みなら未投票でも確定した得票数を見せる",
+			setupMock: func(mr *mockPollRepository) {
+				mr.On("GetByTweetID", mock.Anything, int64(10)).Return(newTestPoll(closedAt, &closedAt), nil)
+				mr.On("GetVotedOptionID", mock.Anything, int64(1), int64(2)).Return(int64(0), false, nil)
+			},
+			wantVisible: true,
+			wantVotes:   []int64{5, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := new(mockPollRepository)
+			tt.setupMock(mr)
+			svc := NewPollService(mr)
+
+			res, err := svc.GetPoll(context.Background(), 10, 2)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantVisible, res.ResultsVisible)
+			for i, want := range tt.wantVotes {
+				assert.Equal(t, want, res.Options[i].Votes)
+			}
+			resp := res.ToPollResponse()
+			if tt.wantVisible {
+				assert.NotNil(t, resp.TotalVotes)
+			} else {
+				assert.Nil(t, resp.TotalVotes)
+				assert.Nil(t, resp.Options[0].Votes)
+			}
+			mr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCloseExpiredPolls(t *testing.T) {
+	mr := new(mockPollRepository)
+	mr.On("GetExpiredIDs", mock.Anything, 10).Return([]int64{1, 2, 3}, nil)
+	mr.On("Close", mock.Anything, int64(1)).Return(nil)
+	mr.On("Close", mock.Anything, int64(2)).Return(errcode.ErrPollNotFound)
+	mr.On("Close", mock.Anything, int64(3)).Return(nil)
+	svc := NewPollService(mr)
+
+	closed, err := svc.CloseExpiredPolls(context.Background(), 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, closed)
+	mr.AssertExpectations(t)
+}
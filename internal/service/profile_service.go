@@ -0,0 +1,89 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"context"
+	"fmt"
+)
+
+type PinRepository interface {
+	SetPinnedTweet(ctx context.Context, userID, tweetID int64) error
+	ClearPinnedTweet(ctx context.Context, userID, tweetID int64) error
+	GetPinnedTweetID(ctx context.Context, userID int64) (int64, error)
+}
+
+type profileService struct {
+	pinRepository PinRepository
+	tweetProvider TweetProvider
+}
+
+func NewProfileService(pr PinRepository, tp TweetProvider) *profileService {
+	return &profileService{
+		pinRepository: pr,
+		tweetProvider: tp,
+	}
+}
+
+// 既に別のツイートを固定していれば置き換える
+func (s *profileService) PinTweet(ctx context.Context, userID, tweetID int64) error {
+	if userID <= 0 {
+		return errcode.ErrInvalidUserID
+	}
+	if tweetID <= 0 {
+		return errcode.ErrInvalidTweetID
+	}
+
+	return s.pinRepository.SetPinnedTweet(ctx, userID, tweetID)
+}
+
+func (s *profileService) UnpinTweet(ctx context.Context, userID, tweetID int64) error {
+	if userID <= 0 {
+		return errcode.ErrInvalidUserID
+	}
+	if tweetID <= 0 {
+		return errcode.ErrInvalidTweetID
+	}
+
+	return s.pinRepository.ClearPinnedTweet(ctx, userID, tweetID)
+}
+
+// 新しい順の投稿一覧。固定ツイートは最初のページの先頭に置き、以降の一覧からは除く
+func (s *profileService) GetProfileTweets(ctx context.Context, userID int64, page, size int) ([]*dto.TweetRecord, error) {
+	if userID <= 0 {
+		return nil, errcode.ErrInvalidUserID
+	}
+
+	pinnedID, err := s.pinRepository.GetPinnedTweetID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("GetProfileTweets: 固定ツイートの取得に失敗しました (user_id: %d): %w", userID, err)
+	}
+
+	tweets, err := s.tweetProvider.GetMyTweets(ctx, userID, page, size)
+	if err != nil {
+		return nil, err
+	}
+	if pinnedID == 0 {
+		return tweets, nil
+	}
+
+	result := make([]*dto.TweetRecord, 0, len(tweets)+1)
+	if page <= 0 {
+		pinned, err := s.tweetProvider.GetTweets(ctx, []int64{pinnedID})
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range pinned {
+			t.IsPinned = true
+			result = append(result, t)
+		}
+	}
+
+	for _, t := range tweets {
+		if t.ID == pinnedID {
+			continue
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
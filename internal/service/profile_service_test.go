@@ -0,0 +1,96 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProfileTweets(t *testing.T) {
+	tests := []struct {
+		name         string
+		page         int
+		setupMock    func(mr *mockPinRepository, mp *mockTweetProvider)
+		wantedErr    error
+		wantTweetIDs []int64
+		wantPinnedID int64
+	}{
+		{
+			name: "正常系: 固定ツイートを先頭に置き、一覧からは除く",
+			setupMock: func(mr *mockPinRepository, mp *mockTweetProvider) {
+				mr.On("GetPinnedTweetID", mock.Anything, int64(1)).Return(int64(5), nil)
+				mp.On("GetMyTweets", mock.Anything, int64(1), 0, 20).Return([]*dto.TweetRecord{{ID: 9}, {ID: 5}, {ID: 3}}, nil)
+				mp.On("GetTweets", mock.Anything, []int64{5}).Return([]*dto.TweetRecord{{ID: 5}}, nil)
+			},
+			wantTweetIDs: []int64{5, 9, 3},
+			wantPinnedID: 5,
+		},
+		{
+			name: "正常系: 2ページ目以降には固定ツイートを置かない",
+			page: 1,
+			setupMock: func(mr *mockPinRepository, mp *mockTweetProvider) {
+				mr.On("GetPinnedTweetID", mock.Anything, int64(1)).Return(int64(5), nil)
+				mp.On("GetMyTweets", mock.Anything, int64(1), 1, 20).Return([]*dto.TweetRecord{{ID: 5}, {ID: 2}}, nil)
+			},
+			wantTweetIDs: []int64{2},
+		},
+		{
+			name: "正常系: 固定していなければ一覧をそのまま返す",
+			setupMock: func(mr *mockPinRepository, mp *mockTweetProvider) {
+				mr.On("GetPinnedTweetID", mock.Anything, int64(1)).Return(int64(0), nil)
+				mp.On("GetMyTweets", mock.Anything, int64(1), 0, 20).Return([]*dto.TweetRecord{{ID: 9}}, nil)
+			},
+			wantTweetIDs: []int64{9},
+		},
+		{
+			name: "異常系: ユーザーが存在しない",
+			setupMock: func(mr *mockPinRepository, mp *mockTweetProvider) {
+				mr.On("GetPinnedTweetID", mock.Anything, int64(1)).Return(int64(0), errcode.ErrUserNotFound)
+			},
+			wantedErr: errcode.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := new(mockPinRepository)
+			mp := new(mockTweetProvider)
+			tt.setupMock(mr, mp)
+			svc := NewProfileService(mr, mp)
+
+			tweets, err := svc.GetProfileTweets(context.Background(), 1, tt.page, 20)
+
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+				return
+			}
+			require.NoError(t, err)
+			ids := make([]int64, 0, len(tweets))
+			for _, tw := range tweets {
+				ids = append(ids, tw.ID)
+				assert.Equal(t, tw.ID == tt.wantPinnedID, tw.IsPinned)
+			}
+			assert.Equal(t, tt.wantTweetIDs, ids)
+			mr.AssertExpectations(t)
+			mp.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPinTweet(t *testing.T) {
+	mr := new(mockPinRepository)
+	mr.On("SetPinnedTweet", mock.Anything, int64(1), int64(5)).Return(errcode.ErrForbidden)
+	svc := NewProfileService(mr, new(mockTweetProvider))
+
+	err := svc.PinTweet(context.Background(), 1, 5)
+	assert.ErrorIs(t, err, errcode.ErrForbidden)
+
+	err = svc.PinTweet(context.Background(), 1, 0)
+	assert.ErrorIs(t, err, errcode.ErrInvalidTweetID)
+	mr.AssertExpectations(t)
+}
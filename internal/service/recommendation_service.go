@@ -0,0 +1,149 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+type RecommendationRepository interface {
+	GetForUser(ctx context.Context, userID int64, limit int) ([]*dto.RecommendationRecord, error)
+	GetPopular(ctx context.Context, limit int) ([]*dto.RecommendationRecord, error)
+	RebuildForUser(ctx context.Context, userID int64, size int) error
+	RebuildPopular(ctx context.Context, size int) error
+	ListActiveUserIDs(ctx context.Context, afterID int64, limit int) ([]int64, error)
+}
+
+type FollowingIDProvider interface {
+	GetFollowingIDs(ctx context.Context, userID int64) ([]int64, error)
+}
+
+type UserListProvider interface {
+	GetInfoLists(ctx context.Context, userIDs []int64) ([]*dto.UserSlimRecord, error)
+}
+
+type recommendationService struct {
+	recommendationRepository RecommendationRepository
+	followingProvider        FollowingIDProvider
+	userListProvider         UserListProvider
+}
+
+func NewRecommendationService(rr RecommendationRepository, fp FollowingIDProvider, up UserListProvider) *recommendationService {
+	return &recommendationService{
+		recommendationRepository: rr,
+		followingProvider:        fp,
+		userListProvider:         up,
+	}
+}
+
+func (s *recommendationService) RecommendUsers(ctx context.Context, userID int64, limit int) ([]*dto.RecommendedUserRecord, error) {
+	if userID <= 0 {
+		return nil, errcode.ErrInvalidUserID
+	}
+
+	if limit <= 0 || limit > maxRecommendationLimit {
+		limit = defaultRecommendationLimit
+	}
+
+	followingIDs, err := s.followingProvider.GetFollowingIDs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("RecommendUsers: フォロー中リストの取得に失敗しました(user_id:%d): %w", userID, err)
+	}
+
+	excluded := make(map[int64]struct{}, len(followingIDs)+1)
+	excluded[userID] = struct{}{}
+	for _, id := range followingIDs {
+		excluded[id] = struct{}{}
+	}
+
+	candidates := make([]*dto.RecommendationRecord, 0, limit)
+	pick := func(records []*dto.RecommendationRecord, isPopular bool) {
+		for _, rec := range records {
+			if len(candidates) >= limit {
+				return
+			}
+			if _, skip := excluded[rec.UserID]; skip {
+				continue
+			}
+			excluded[rec.UserID] = struct{}{}
+			if isPopular {
+				rec = &dto.RecommendationRecord{UserID: rec.UserID}
+			}
+			candidates = append(candidates, rec)
+		}
+	}
+
+	precomputed, err := s.recommendationRepository.GetForUser(ctx, userID, maxRecommendationLimit)
+	if err != nil {
+		slog.Warn("RecommendUsers: 事前計算済みの候補の取得に失敗しました。人気ユーザーで代替します", "user_id", userID, "err", err)
+	}
+	pick(precomputed, false)
+
+	if len(candidates) < limit {
+		popular, err := s.recommendationRepository.GetPopular(ctx, popularPoolSize)
+		if err != nil {
+			return nil, fmt.Errorf("RecommendUsers: 人気ユーザーの取得に失敗しました: %w", err)
+		}
+		pick(popular, true)
+	}
+
+	if len(candidates) == 0 {
+		return []*dto.RecommendedUserRecord{}, nil
+	}
+
+	ids := make([]int64, len(candidates))
+	for i, c := range candidates {
+		ids[i] = c.UserID
+	}
+
+	infos, err := s.userListProvider.GetInfoLists(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("RecommendUsers: ユーザー情報の取得に失敗しました: %w", err)
+	}
+
+	names := make(map[int64]string, len(infos))
+	for _, info := range infos {
+		names[info.ID] = info.Username
+	}
+
+	results := make([]*dto.RecommendedUserRecord, 0, len(candidates))
+	for _, c := range candidates {
+		name, ok := names[c.UserID]
+		if !ok {
+			continue
+		}
+		results = append(results, &dto.RecommendedUserRecord{
+			ID:          c.UserID,
+			Username:    name,
+			MutualCount: c.Score,
+		})
+	}
+
+	return results, nil
+}
+
+func (s *recommendationService) RefreshForUser(ctx context.Context, userID int64) error {
+	if userID <= 0 {
+		return errcode.ErrInvalidUserID
+	}
+
+	if err := s.recommendationRepository.RebuildForUser(ctx, userID, maxRecommendationLimit); err != nil {
+		return fmt.Errorf("RefreshForUser: おすすめユーザーの再計算に失敗しました(user_id:%d): %w", userID, err)
+	}
+
+	return nil
+}
+
+func (s *recommendationService) RefreshPopular(ctx context.Context) error {
+	if err := s.recommendationRepository.RebuildPopular(ctx, popularPoolSize); err != nil {
+		return fmt.Errorf("RefreshPopular: 人気ユーザーの再計算に失敗しました: %w", err)
+	}
+
+	return nil
+}
+
+func (s *recommendationService) ListActiveUserIDs(ctx context.Context, afterID int64, limit int) ([]int64, error) {
+	return s.recommendationRepository.ListActiveUserIDs(ctx, afterID, limit)
+}
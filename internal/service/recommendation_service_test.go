@@ -0,0 +1,133 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecommendUsers(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int64
+		limit      int
+		setupMock  func(mr *mockRecommendationRepository, mf *mockFollowingIDProvider, mu *mockUserListProvider)
+		wantedErr  error
+		wantedIDs  []int64
+		wantedMuts []int64
+	}{
+		{
+			name:   "正常系：事前計算済みの候補からフォロー済みを除外して返す",
+			userID: 1,
+			limit:  2,
+			setupMock: func(mr *mockRecommendationRepository, mf *mockFollowingIDProvider, mu *mockUserListProvider) {
+				mf.On("GetFollowingIDs", mock.Anything, int64(1)).Return([]int64{2}, nil)
+				mr.On("GetForUser", mock.Anything, int64(1), maxRecommendationLimit).Return([]*dto.RecommendationRecord{
+					{UserID: 2, Score: 9},
+					{UserID: 5, Score: 4},
+					{UserID: 6, Score: 3},
+				}, nil)
+				mu.On("GetInfoLists", mock.Anything, []int64{5, 6}).Return([]*dto.UserSlimRecord{
+					{ID: 6, Username: "six"},
+					{ID: 5, Username: "five"},
+				}, nil)
+			},
+			wantedIDs:  []int64{5, 6},
+			wantedMuts: []int64{4, 3},
+		},
+		{
+			name:   "正常系：候補が足りない場合は人気ユーザーで補完する",
+			userID: 1,
+			limit:  3,
+			setupMock: func(mr *mockRecommendationRepository, mf *mockFollowingIDProvider, mu *mockUserListProvider) {
+				mf.On("GetFollowingIDs", mock.Anything, int64(1)).Return([]int64{3}, nil)
+				mr.On("GetForUser", mock.Anything, int64(1), maxRecommendationLimit).Return([]*dto.RecommendationRecord{
+					{UserID: 5, Score: 2},
+				}, nil)
+				mr.On("GetPopular", mock.Anything, popularPoolSize).Return([]*dto.RecommendationRecord{
+					{UserID: 1, Score: 1000},
+					{UserID: 3, Score: 800},
+					{UserID: 5, Score: 500},
+					{UserID: 7, Score: 300},
+					{UserID: 8, Score: 100},
+				}, nil)
+				mu.On("GetInfoLists", mock.Anything, []int64{5, 7, 8}).Return([]*dto.UserSlimRecord{
+					{ID: 5, Username: "five"},
+					{ID: 7, Username: "seven"},
+					{ID: 8, Username: "eight"},
+				}, nil)
+			},
+			wantedIDs:  []int64{5, 7, 8},
+			wantedMuts: []int64{2, 0, 0},
+		},
+		{
+			name:   "正常系：キャッシュ取得に失敗しても人気ユーザーで応答する",
+			userID: 1,
+			limit:  1,
+			setupMock: func(mr *mockRecommendationRepository, mf *mockFollowingIDProvider, mu *mockUserListProvider) {
+				mf.On("GetFollowingIDs", mock.Anything, int64(1)).Return([]int64{}, nil)
+				mr.On("GetForUser", mock.Anything, int64(1), maxRecommendationLimit).Return(nil, errMockInternal)
+				mr.On("GetPopular", mock.Anything, popularPoolSize).Return([]*dto.RecommendationRecord{
+					{UserID: 9, Score: 10},
+				}, nil)
+				mu.On("GetInfoLists", mock.Anything, []int64{9}).Return([]*dto.UserSlimRecord{
+					{ID: 9, Username: "nine"},
+				}, nil)
+			},
+			wantedIDs:  []int64{9},
+			wantedMuts: []int64{0},
+		},
+		{
+			name:      "異常系：無効なユーザーID",
+			userID:    0,
+			limit:     10,
+			setupMock: func(mr *mockRecommendationRepository, mf *mockFollowingIDProvider, mu *mockUserListProvider) {},
+			wantedErr: errcode.ErrInvalidUserID,
+		},
+		{
+			name:   "異常系：人気ユーザーの取得に失敗",
+			userID: 1,
+			limit:  5,
+			setupMock: func(mr *mockRecommendationRepository, mf *mockFollowingIDProvider, mu *mockUserListProvider) {
+				mf.On("GetFollowingIDs", mock.Anything, int64(1)).Return([]int64{}, nil)
+				mr.On("GetForUser", mock.Anything, int64(1), maxRecommendationLimit).Return([]*dto.RecommendationRecord{}, nil)
+				mr.On("GetPopular", mock.Anything, popularPoolSize).Return(nil, errMockInternal)
+			},
+			wantedErr: errMockInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := new(mockRecommendationRepository)
+			mf := new(mockFollowingIDProvider)
+			mu := new(mockUserListProvider)
+			tt.setupMock(mr, mf, mu)
+			svc := NewRecommendationService(mr, mf, mu)
+
+			res, err := svc.RecommendUsers(context.Background(), tt.userID, tt.limit)
+
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+				assert.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res, len(tt.wantedIDs))
+				for i, r := range res {
+					assert.Equal(t, tt.wantedIDs[i], r.ID)
+					assert.Equal(t, tt.wantedMuts[i], r.MutualCount)
+					assert.NotEmpty(t, r.Username)
+				}
+			}
+
+			mr.AssertExpectations(t)
+			mf.AssertExpectations(t)
+			mu.AssertExpectations(t)
+		})
+	}
+}
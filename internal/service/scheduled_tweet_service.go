@@ -0,0 +1,133 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type ScheduledTweetRepository interface {
+	Create(ctx context.Context, record *dto.ScheduledTweetRecord) (*dto.ScheduledTweetRecord, error)
+	ListPending(ctx context.Context, userID int64) ([]*dto.ScheduledTweetRecord, error)
+	Cancel(ctx context.Context, scheduledID, userID int64) error
+	GetDue(ctx context.Context, now time.Time, limit int) ([]int64, error)
+	Publish(ctx context.Context, scheduledID int64) (*dto.TweetRecord, error)
+	Resync(ctx context.Context, batchSize int) (int, error)
+}
+
+type scheduledTweetService struct {
+	scheduledTweetRepository ScheduledTweetRepository
+	messageSender            MessageSender
+}
+
+func NewScheduledTweetService(sr ScheduledTweetRepository, m MessageSender) *scheduledTweetService {
+	return &scheduledTweetService{
+		scheduledTweetRepository: sr,
+		messageSender:            m,
+	}
+}
+
+func (s *scheduledTweetService) ScheduleTweet(ctx context.Context, userID int64, content string, media []dto.MediaAttachment, publishAt time.Time) (*dto.ScheduledTweetRecord, error) {
+	if userID <= 0 {
+		return nil, errcode.ErrInvalidUserID
+	}
+
+	if content == "" {
+		return nil, errcode.ErrRequiredFieldMissing
+	}
+
+	if err := validateMediaAttachments(media); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if !publishAt.After(now) || publishAt.Sub(now) > maxScheduleAhead {
+		return nil, errcode.ErrInvalidPublishAt
+	}
+
+	record := &dto.ScheduledTweetRecord{
+		UserID:    userID,
+		Content:   content,
+		Media:     media,
+		PublishAt: publishAt.UTC(),
+	}
+
+	scheduled, err := s.scheduledTweetRepository.Create(ctx, record)
+	if err != nil {
+		return nil, fmt.Errorf("予約投稿の登録に失敗しました: %w", err)
+	}
+
+	return scheduled, nil
+}
+
+func (s *scheduledTweetService) ListScheduled(ctx context.Context, userID int64) ([]*dto.ScheduledTweetRecord, error) {
+	if userID <= 0 {
+		return nil, errcode.ErrInvalidUserID
+	}
+
+	scheduled, err := s.scheduledTweetRepository.ListPending(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("予約投稿一覧の取得に失敗しました: %w", err)
+	}
+
+	return scheduled, nil
+}
+
+func (s *scheduledTweetService) CancelScheduled(ctx context.Context, scheduledID, userID int64) error {
+	if userID <= 0 {
+		return errcode.ErrInvalidUserID
+	}
+
+	if scheduledID <= 0 {
+		return errcode.ErrInvalidTweetID
+	}
+
+	if err := s.scheduledTweetRepository.Cancel(ctx, scheduledID, userID); err != nil {
+		return fmt.Errorf("予約投稿のキャンセルに失敗しました: %w", err)
+	}
+
+	return nil
+}
+
+// 公開に成功したワーカーだけが作成イベントを送るため、レプリカが複数あっても配信は一度になる
+func (s *scheduledTweetService) PublishDue(ctx context.Context, limit int) (int, error) {
+	ids, err := s.scheduledTweetRepository.GetDue(ctx, time.Now().UTC(), limit)
+	if err != nil {
+		return 0, fmt.Errorf("PublishDue: 公開予定の予約投稿の取得に失敗しました: %w", err)
+	}
+
+	published := 0
+	for _, id := range ids {
+		tweet, err := s.scheduledTweetRepository.Publish(ctx, id)
+		if err != nil {
+			if !errors.Is(err, errcode.ErrScheduledTweetNotFound) {
+				slog.Warn("PublishDue: 予約投稿の公開に失敗しました", "scheduled_id", id, "err", err)
+			}
+			continue
+		}
+
+		_ = s.messageSender.AsyncToMQ(
+			ctx,
+			tweet.ID,
+			tweet.UserID,
+			tweet.CreatedAt,
+			dto.ActionCreate,
+		)
+		published++
+	}
+
+	return published, nil
+}
+
+func (s *scheduledTweetService) ResyncSchedules(ctx context.Context) error {
+	count, err := s.scheduledTweetRepository.Resync(ctx, scheduleResyncBatchSize)
+	if err != nil {
+		return fmt.Errorf("ResyncSchedules: 予約投稿の再同期に失敗しました(synced:%d): %w", count, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleTweet(t *testing.T) {
+	future := time.Now().UTC().Add(1 * time.Hour)
+	tests := []struct {
+		name      string
+		userID    int64
+		content   string
+		publishAt time.Time
+		setupMock func(mr *mockScheduledTweetRepository)
+		wantedErr error
+		errMsg    string
+	}{
+		{
+			name:      "正常系: 未来の日時で予約投稿が登録される",
+			userID:    1,
+			content:   "予約",
+			publishAt: future,
+			setupMock: func(mr *mockScheduledTweetRepository) {
+				mr.On("Create", mock.Anything, mock.MatchedBy(func(r *dto.ScheduledTweetRecord) bool {
+					return r.UserID == 1 && r.Content == "予約" && r.PublishAt.Equal(future)
+				})).Return(&dto.ScheduledTweetRecord{ID: 10, UserID: 1, Content: "予約", PublishAt: future, Status: "pending"}, nil)
+			},
+		},
+		{
+			name:      "異常系: 過去の日時は指定できない",
+			userID:    1,
+			content:   "予約",
+			publishAt: time.Now().UTC().Add(-1 * time.Minute),
+			setupMock: func(mr *mockScheduledTweetRepository) {},
+			wantedErr: errcode.ErrInvalidPublishAt,
+		},
+		{
+			name:      "異常系: 1年より先の日時は指定できない",
+			userID:    1,
+			content:   "予約",
+			publishAt: time.Now().UTC().Add(maxScheduleAhead + time.Hour),
+			setupMock: func(mr *mockScheduledTweetRepository) {},
+			wantedErr: errcode.ErrInvalidPublishAt,
+		},
+		{
+			name:      "異常系: 無効なユーザーID",
+			userID:    0,
+			content:   "予約",
+			publishAt: future,
+			setupMock: func(mr *mockScheduledTweetRepository) {},
+			wantedErr: errcode.ErrInvalidUserID,
+		},
+		{
+			name:      "異常系: DBエラーによる登録失敗",
+			userID:    1,
+			content:   "予約",
+			publishAt: future,
+			setupMock: func(mr *mockScheduledTweetRepository) {
+				mr.On("Create", mock.Anything, mock.Anything).Return(nil, errMockInternal)
+			},
+			wantedErr: errMockInternal,
+			errMsg:    "予約投稿の登録に失敗しました",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := new(mockScheduledTweetRepository)
+			mm := new(mockMessageSender)
+			tt.setupMock(mr)
+			svc := NewScheduledTweetService(mr, mm)
+
+			res, err := svc.ScheduleTweet(context.Background(), tt.userID, tt.content, nil, tt.publishAt)
+
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+				assert.Nil(t, res)
+				if tt.errMsg != "" {
+					assert.Contains(t, err.Error(), tt.errMsg)
+				}
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				assert.Equal(t, int64(10), res.ID)
+			}
+
+			mr.AssertExpectations(t)
+			mm.AssertNotCalled(t, "AsyncToMQ")
+		})
+	}
+}
+
+func TestPublishDue(t *testing.T) {
+	createdAt := time.Now().UTC()
+	tests := []struct {
+		name            string
+		setupMock       func(mr *mockScheduledTweetRepository, mm *mockMessageSender)
+		wantedErr       error
+		wantedPublished int
+	}{
+		{
+			name: "正常系: 公開に成功した予約のみ作成イベントを送る",
+			setupMock: func(mr *mockScheduledTweetRepository, mm *mockMessageSender) {
+				mr.On("GetDue", mock.Anything, mock.Anything, 100).Return([]int64{1, 2, 3}, nil)
+				mr.On("Publish", mock.Anything, int64(1)).Return(&dto.TweetRecord{ID: 101, UserID: 7, CreatedAt: createdAt}, nil)
+				mr.On("Publish", mock.Anything, int64(2)).Return(nil, errcode.ErrScheduledTweetNotFound)
+				mr.On("Publish", mock.Anything, int64(3)).Return(nil, errMockInternal)
+				mm.On("AsyncToMQ", mock.Anything, int64(101), int64(7), createdAt, dto.ActionCreate).Return(nil).Once()
+			},
+			wantedPublished: 1,
+		},
+		{
+			name: "正常系: 公開できず失敗にした予約はイベントを送らない",
+			setupMock: func(mr *mockScheduledTweetRepository, mm *mockMessageSender) {
+				mr.On("GetDue", mock.Anything, mock.Anything, 100).Return([]int64{4}, nil)
+				mr.On("Publish", mock.Anything, int64(4)).Return(nil, errcode.ErrScheduledTweetFailed)
+			},
+			wantedPublished: 0,
+		},
+		{
+			name: "正常系: 公開予定の予約がない",
+			setupMock: func(mr *mockScheduledTweetRepository, mm *mockMessageSender) {
+				mr.On("GetDue", mock.Anything, mock.Anything, 100).Return([]int64{}, nil)
+			},
+			wantedPublished: 0,
+		},
+		{
+			name: "異常系: スケジュールの取得に失敗",
+			setupMock: func(mr *mockScheduledTweetRepository, mm *mockMessageSender) {
+				mr.On("GetDue", mock.Anything, mock.Anything, 100).Return(nil, errMockInternal)
+			},
+			wantedErr: errMockInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := new(mockScheduledTweetRepository)
+			mm := new(mockMessageSender)
+			tt.setupMock(mr, mm)
+			svc := NewScheduledTweetService(mr, mm)
+
+			published, err := svc.PublishDue(context.Background(), 100)
+
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantedPublished, published)
+
+			mr.AssertExpectations(t)
+			mm.AssertExpectations(t)
+		})
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"aita/internal/dto"
 	"aita/internal/errcode"
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"log/slog"
+	"sort"
 	"time"
 )
 
@@ -14,6 +17,15 @@ type SessionRepository interface {
 	Get(ctx context.Context, tokenHash string) (*dto.SessionRecord, error)
 	Update(ctx context.Context, sr *dto.SessionRecord) error
 	Delete(ctx context.Context, sr *dto.SessionRecord) error
+	DeleteByUserID(ctx context.Context, userID int64) error
+	DeleteOthersByUserID(ctx context.Context, userID int64, keepHash string) error
+	ListByUserID(ctx context.Context, userID int64) ([]*dto.SessionRecord, error)
+	GetRefreshRef(ctx context.Context, refreshHash string) (*dto.RefreshTokenRefRecord, error)
+	GetByFamily(ctx context.Context, sessionID string) (*dto.SessionRecord, error)
+	Rotate(ctx context.Context, current, next *dto.SessionRecord) error
+	DeleteFamily(ctx context.Context, userID int64, sessionID string) error
+	CreateTicket(ctx context.Context, ticketHash, tokenHash string, ttl time.Duration) error
+	ConsumeTicket(ctx context.Context, ticketHash string) (string, error)
 }
 
 type UserInfoProvider interface {
@@ -29,13 +41,15 @@ type sessionService struct {
 	sessionRepository SessionRepository
 	userService       UserInfoProvider
 	tokenManager      TokenManager
+	policy            dto.SessionPolicy
 }
 
-func NewSessionService(sr SessionRepository, usvc UserInfoProvider, tm TokenManager) *sessionService {
+func NewSessionService(sr SessionRepository, usvc UserInfoProvider, tm TokenManager, policy dto.SessionPolicy) *sessionService {
 	return &sessionService{
 		sessionRepository: sr,
 		userService:       usvc,
 		tokenManager:      tm,
+		policy:            policy,
 	}
 }
 func (s *sessionService) validateAndHash(token string) (string, error) {
@@ -58,7 +72,7 @@ func (s *sessionService) expirationCheck(expiresAt time.Time, createdAt time.Tim
 		return errcode.ErrSessionExpired
 	}
 
-	if now.After(createdAt.UTC().Add(MaxSessionLife)) {
+	if now.After(createdAt.UTC().Add(s.policy.MaxLife)) {
 		return errcode.ErrSessionExpired
 	}
 
@@ -83,56 +97,49 @@ func (s *sessionService) authenticate(ctx context.Context, token string) (*dto.S
 	return record, nil
 }
 
-func (s *sessionService) executeRefresh(ctx context.Context, token string) error {
-	tokenHash, err := s.validateAndHash(token)
-	record, err := s.sessionRepository.Get(ctx, tokenHash)
-	if err != nil || record == nil {
-		return fmt.Errorf("セッションの取得に失敗しました: %w", err)
-	}
-
-	newExpiry := time.Now().Add(SessionDuration).UTC()
-	maxExpiry := record.CreatedAt.Add(MaxSessionLife).UTC()
-	if newExpiry.After(maxExpiry) {
-		newExpiry = maxExpiry
+func (s *sessionService) generateTokenPair() (string, string, error) {
+	token, err := s.tokenManager.Generate(32)
+	if err != nil {
+		return "", "", fmt.Errorf("トークンの生成に失敗しました: %w", err)
 	}
-	record.ExpiresAt = newExpiry
-	err = s.sessionRepository.Update(ctx, record)
+	refreshToken, err := s.tokenManager.Generate(32)
 	if err != nil {
-		return fmt.Errorf("セッション期限の更新に失敗しました: %w", err)
+		return "", "", fmt.Errorf("リフレッシュトークンの生成に失敗しました: %w", err)
 	}
-
-	return nil
+	return token, refreshToken, nil
 }
 
-func (s *sessionService) RefreshAsync(token string) {
-	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-
-		defer func() {
-			if r := recover(); r != nil {
-				log.Printf("RefreshAsync panic: %v", r)
-			}
-		}()
-		_ = s.executeRefresh(ctx, token)
-	}()
-}
-
-func (s *sessionService) Issue(ctx context.Context, userID int64) (*dto.AuthRecord, error) {
+func (s *sessionService) Issue(ctx context.Context, userID int64, client dto.ClientInfo) (*dto.AuthRecord, error) {
 	if userID <= 0 {
 		return nil, errcode.ErrRequiredFieldMissing
 	}
 
-	token, err := s.tokenManager.Generate(32)
+	token, refreshToken, err := s.generateTokenPair()
 	if err != nil {
-		return nil, fmt.Errorf("トークンの生成に失敗しました: %w", err)
+		return nil, err
+	}
+	sessionID, err := s.tokenManager.Generate(sessionIDBytes)
+	if err != nil {
+		return nil, fmt.Errorf("セッションIDの生成に失敗しました: %w", err)
+	}
+
+	userAgent := client.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
 	}
 
+	now := time.Now().UTC()
 	data := &dto.SessionRecord{
-		UserID:    userID,
-		TokenHash: s.tokenManager.Hash(token),
-		ExpiresAt: time.Now().Add(SessionDuration).UTC(),
-		CreatedAt: time.Now().UTC(),
+		UserID:     userID,
+		TokenHash:  s.tokenManager.Hash(token),
+		ExpiresAt:  now.Add(s.policy.AccessTTL),
+		CreatedAt:  now,
+		SessionID:  sessionID,
+		IPAddress:  client.IPAddress,
+		UserAgent:  userAgent,
+		LastSeenAt: now,
+		RefreshHash:      s.tokenManager.Hash(refreshToken),
+		RefreshExpiresAt: s.policy.RefreshExpiry(now, now),
 	}
 
 	record, err := s.sessionRepository.Create(ctx, data)
@@ -140,7 +147,69 @@ func (s *sessionService) Issue(ctx context.Context, userID int64) (*dto.AuthReco
 		return nil, fmt.Errorf("発行に失敗しました: %w", err)
 	}
 
-	return dto.ToAuthRecord(record, token), nil
+	return dto.ToIssuedAuthRecord(record, token, refreshToken), nil
+}
+
+// リフレッシュトークンを使い捨てにして新しいトークンの組を返す。
+// ローテーション済みのトークンが使われた場合は盗用とみなし、ファミリーごと無効にする
+func (s *sessionService) Refresh(ctx context.Context, refreshToken string) (*dto.AuthRecord, error) {
+	if len(refreshToken) < 32 || len(refreshToken) > 255 {
+		return nil, errcode.ErrInvalidRefreshToken
+	}
+	refreshHash := s.tokenManager.Hash(refreshToken)
+
+	ref, err := s.sessionRepository.GetRefreshRef(ctx, refreshHash)
+	if err != nil {
+		if errors.Is(err, errcode.ErrSessionNotFound) {
+			return nil, errcode.ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("リフレッシュトークンの取得に失敗しました: %w", err)
+	}
+
+	current, err := s.sessionRepository.GetByFamily(ctx, ref.SessionID)
+	if err != nil {
+		if errors.Is(err, errcode.ErrSessionNotFound) || errors.Is(err, errcode.ErrSessionExpired) {
+			return nil, errcode.ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("セッションの取得に失敗しました: %w", err)
+	}
+
+	if current.RefreshHash != refreshHash {
+		if err := s.sessionRepository.DeleteFamily(ctx, ref.UserID, ref.SessionID); err != nil {
+			return nil, fmt.Errorf("再利用されたトークンのファミリーの無効化に失敗しました: %w", err)
+		}
+		slog.Warn("ローテーション済みのリフレッシュトークンが再利用されたため、セッションを無効にしました", "user_id", ref.UserID, "session_id", ref.SessionID)
+		return nil, errcode.ErrRefreshTokenReused
+	}
+
+	now := time.Now().UTC()
+	if !now.Before(current.CreatedAt.Add(s.policy.MaxLife)) {
+		return nil, errcode.ErrInvalidRefreshToken
+	}
+
+	token, nextRefreshToken, err := s.generateTokenPair()
+	if err != nil {
+		return nil, err
+	}
+
+	next := *current
+	next.TokenHash = s.tokenManager.Hash(token)
+	next.ExpiresAt = now.Add(s.policy.AccessTTL)
+	next.LastSeenAt = now
+	next.RefreshHash = s.tokenManager.Hash(nextRefreshToken)
+	next.RefreshExpiresAt = s.policy.RefreshExpiry(now, current.CreatedAt)
+	if next.ExpiresAt.After(next.RefreshExpiresAt) {
+		next.ExpiresAt = next.RefreshExpiresAt
+	}
+
+	if err := s.sessionRepository.Rotate(ctx, current, &next); err != nil {
+		if errors.Is(err, errcode.ErrTokenConflict) {
+			return nil, errcode.ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("トークンのローテーションに失敗しました: %w", err)
+	}
+
+	return dto.ToIssuedAuthRecord(&next, token, nextRefreshToken), nil
 }
 
 func (s *sessionService) Validate(ctx context.Context, token string) (*dto.AuthRecord, error) {
@@ -158,22 +227,32 @@ func (s *sessionService) Validate(ctx context.Context, token string) (*dto.AuthR
 		return nil, errcode.ErrUserNotFound
 	}
 
+	if time.Since(record.LastSeenAt) >= sessionTouchInterval {
+		s.touchAsync(record.TokenHash)
+	}
+
 	return dto.ToAuthRecord(record, token), nil
 }
 
-func (s *sessionService) ShouldRefresh(expiresAt time.Time, createdAt time.Time) (bool, error) {
-	err := s.expirationCheck(expiresAt, createdAt)
-	if err != nil {
-		return false, err
-	}
+// リクエストのたびに書き込まないよう、最終利用日時は一定間隔でだけ更新する
+func (s *sessionService) touchAsync(tokenHash string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
 
-	totalDuration := expiresAt.UTC().Sub(createdAt.UTC())
-	remaining := expiresAt.UTC().Sub(time.Now().UTC())
-	if totalDuration <= 0 {
-		return false, errcode.ErrSessionExpired
-	}
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("touchAsync panic: %v", r)
+			}
+		}()
 
-	return remaining < totalDuration/4, nil
+		record, err := s.sessionRepository.Get(ctx, tokenHash)
+		if err != nil || record == nil {
+			return
+		}
+		record.LastSeenAt = time.Now().UTC()
+		_ = s.sessionRepository.Update(ctx, record)
+	}()
 }
 
 func (s *sessionService) Revoke(ctx context.Context, userID int64, token string) error {
@@ -188,6 +267,7 @@ func (s *sessionService) Revoke(ctx context.Context, userID int64, token string)
 	data := &dto.SessionRecord{
 		UserID:    userID,
 		TokenHash: check.TokenHash,
+		SessionID: check.SessionID,
 	}
 
 	err = s.sessionRepository.Delete(ctx, data)
@@ -197,3 +277,141 @@ func (s *sessionService) Revoke(ctx context.Context, userID int64, token string)
 
 	return nil
 }
+
+// パスワードの再設定時など、ユーザーのすべてのセッションを無効にする
+func (s *sessionService) RevokeAll(ctx context.Context, userID int64) error {
+	if userID <= 0 {
+		return errcode.ErrInvalidUserID
+	}
+
+	if err := s.sessionRepository.DeleteByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("セッションの一括削除に失敗しました: %w", err)
+	}
+
+	return nil
+}
+
+// パスワードの変更時など、現在のセッション以外を無効にする
+func (s *sessionService) RevokeOthers(ctx context.Context, userID int64, currentToken string) error {
+	current, err := s.authenticate(ctx, currentToken)
+	if err != nil {
+		return err
+	}
+	if current.UserID != userID {
+		return errcode.ErrForbidden
+	}
+
+	if err := s.sessionRepository.DeleteOthersByUserID(ctx, userID, current.TokenHash); err != nil {
+		return fmt.Errorf("他のセッションの削除に失敗しました: %w", err)
+	}
+
+	return nil
+}
+
+// 最近使われた順に並べ、リクエスト中のセッションには印を付ける
+func (s *sessionService) ListSessions(ctx context.Context, userID int64, currentToken string) ([]*dto.SessionRecord, error) {
+	if userID <= 0 {
+		return nil, errcode.ErrInvalidUserID
+	}
+
+	records, err := s.sessionRepository.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("セッション一覧の取得に失敗しました: %w", err)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].LastSeenAt.After(records[j].LastSeenAt)
+	})
+
+	currentHash := s.tokenManager.Hash(currentToken)
+	for _, record := range records {
+		record.Current = record.TokenHash == currentHash
+	}
+	return records, nil
+}
+
+func (s *sessionService) RevokeSession(ctx context.Context, userID int64, sessionID string) error {
+	if userID <= 0 {
+		return errcode.ErrInvalidUserID
+	}
+	if sessionID == "" {
+		return errcode.ErrInvalidSessionID
+	}
+
+	records, err := s.sessionRepository.ListByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("セッション一覧の取得に失敗しました: %w", err)
+	}
+
+	for _, record := range records {
+		if record.SessionID != sessionID {
+			continue
+		}
+		if err := s.sessionRepository.Delete(ctx, record); err != nil {
+			if errors.Is(err, errcode.ErrSessionNotFound) {
+				return errcode.ErrUserSessionNotFound
+			}
+			return fmt.Errorf("セッションの削除に失敗しました: %w", err)
+		}
+		return nil
+	}
+
+	return errcode.ErrUserSessionNotFound
+}
+
+// ブラウザの WebSocket はヘッダーを付けられないため、アクセストークンの代わりに URL に載せる使い捨てのチケットを発行する
+func (s *sessionService) IssueGatewayTicket(ctx context.Context, token string) (*dto.GatewayTicketRecord, error) {
+	record, err := s.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.tokenManager.Generate(32)
+	if err != nil {
+		return nil, fmt.Errorf("チケットの生成に失敗しました: %w", err)
+	}
+	if err := s.sessionRepository.CreateTicket(ctx, s.tokenManager.Hash(ticket), record.TokenHash, gatewayTicketTTL); err != nil {
+		return nil, fmt.Errorf("チケットの保存に失敗しました: %w", err)
+	}
+
+	return &dto.GatewayTicketRecord{
+		Ticket:    ticket,
+		ExpiresAt: time.Now().UTC().Add(gatewayTicketTTL),
+	}, nil
+}
+
+// チケットは一度使うと消える。発行元のセッションが無効になっていれば接続させない
+func (s *sessionService) RedeemGatewayTicket(ctx context.Context, ticket string) (*dto.AuthRecord, error) {
+	if len(ticket) < 32 || len(ticket) > 255 {
+		return nil, errcode.ErrInvalidGatewayTicket
+	}
+
+	tokenHash, err := s.sessionRepository.ConsumeTicket(ctx, s.tokenManager.Hash(ticket))
+	if err != nil {
+		if errors.Is(err, errcode.ErrSessionNotFound) {
+			return nil, errcode.ErrInvalidGatewayTicket
+		}
+		return nil, fmt.Errorf("チケットの取得に失敗しました: %w", err)
+	}
+
+	record, err := s.sessionRepository.Get(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, errcode.ErrSessionNotFound) || errors.Is(err, errcode.ErrSessionExpired) {
+			return nil, errcode.ErrInvalidGatewayTicket
+		}
+		return nil, fmt.Errorf("セッションの取得に失敗しました: %w", err)
+	}
+	if err := s.expirationCheck(record.ExpiresAt, record.CreatedAt); err != nil {
+		return nil, errcode.ErrInvalidGatewayTicket
+	}
+
+	exists, err := s.userService.Exists(ctx, record.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errcode.ErrUserNotFound
+	}
+
+	return dto.ToAuthRecord(record, ""), nil
+}
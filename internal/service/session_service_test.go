@@ -4,7 +4,6 @@ import (
 	"aita/internal/dto"
 	"aita/internal/errcode"
 	"context"
-	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -15,6 +14,8 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var testSessionPolicy = dto.NewSessionPolicy(0, 0, 0)
+
 func TestIssue(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	tests := map[string]struct {
@@ -30,6 +31,7 @@ func TestIssue(t *testing.T) {
 				hashedToken := "hashed_token"
 
 				mt.On("Generate", 32).Return(rawToken, nil)
+				mt.On("Generate", sessionIDBytes).Return("session_id", nil)
 				mt.On("Hash", rawToken).Return(hashedToken)
 				expectedRecord := &dto.SessionRecord{
 					UserID:    1,
@@ -39,7 +41,8 @@ func TestIssue(t *testing.T) {
 				}
 
 				ms.On("Create", mock.Anything, mock.MatchedBy(func(sr *dto.SessionRecord) bool {
-					return sr.TokenHash == hashedToken
+					return sr.TokenHash == hashedToken && sr.SessionID == "session_id" &&
+						sr.IPAddress == "192.0.2.1" && sr.UserAgent == "Firefox" && !sr.LastSeenAt.IsZero()
 				})).Return(expectedRecord, nil)
 			},
 			wantedErr: nil,
@@ -61,6 +64,7 @@ func TestIssue(t *testing.T) {
 			userID: 1,
 			setupMock: func(ms *mockSessionRepository, mt *mockTokenManager) {
 				mt.On("Generate", 32).Return("token", nil)
+				mt.On("Generate", sessionIDBytes).Return("session_id", nil)
 				mt.On("Hash", "token").Return("hash")
 				ms.On("Create", mock.Anything, mock.Anything).Return(nil, errMockInternal)
 			},
@@ -77,8 +81,8 @@ func TestIssue(t *testing.T) {
 
 			tt.setupMock(ms, mt)
 
-			svc := NewSessionService(ms, mu, mt)
-			res, err := svc.Issue(context.Background(), tt.userID)
+			svc := NewSessionService(ms, mu, mt, testSessionPolicy)
+			res, err := svc.Issue(context.Background(), tt.userID, dto.ClientInfo{IPAddress: "192.0.2.1", UserAgent: "Firefox"})
 
 			if tt.wantedErr != nil {
 				assert.ErrorIs(t, err, tt.wantedErr)
@@ -184,7 +188,7 @@ func TestAuthenticate(t *testing.T) {
 
 			tt.setupMock(ms, mt)
 
-			svc := NewSessionService(ms, mu, mt)
+			svc := NewSessionService(ms, mu, mt, testSessionPolicy)
 			res, err := svc.authenticate(context.Background(), tt.token)
 
 			if tt.wantedErr != nil {
@@ -242,10 +246,11 @@ func TestValidate(t *testing.T) {
 			setupMock: func(ms *mockSessionRepository, mu *mockUserService, mt *mockTokenManager) {
 				mt.On("Hash", validToken).Return("hashed_ok")
 				record := &dto.SessionRecord{
-					UserID:    10,
-					TokenHash: "hashed_ok",
-					ExpiresAt: time.Now().Add(23 * time.Hour).UTC(),
-					CreatedAt: time.Now().Add(-1 * time.Hour).UTC(),
+					UserID:     10,
+					TokenHash:  "hashed_ok",
+					ExpiresAt:  time.Now().Add(23 * time.Hour).UTC(),
+					CreatedAt:  time.Now().Add(-1 * time.Hour).UTC(),
+					LastSeenAt: time.Now().UTC(),
 				}
 				ms.On("Get", mock.Anything, "hashed_ok").Return(record, nil)
 				mu.On("Exists", mock.Anything, int64(10)).Return(true, nil)
@@ -277,7 +282,7 @@ func TestValidate(t *testing.T) {
 			mu := new(mockUserService)
 
 			tt.setupMock(ms, mu, mt)
-			svc := NewSessionService(ms, mu, mt)
+			svc := NewSessionService(ms, mu, mt, testSessionPolicy)
 
 			res, err := svc.Validate(context.Background(), tt.token)
 
@@ -290,11 +295,6 @@ func TestValidate(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 				assert.NotNil(t, res)
-
-				if tt.name == "【成功】セッションの自動更新がトリガーされる" {
-					expectedNewExpiry := time.Now().Add(SessionDuration).UTC()
-					assert.WithinDuration(t, expectedNewExpiry, res.ExpiresAt, 10*time.Second)
-				}
 			}
 
 			ms.AssertExpectations(t)
@@ -339,7 +339,7 @@ func TestExpirationCheck(t *testing.T) {
 			ms := new(mockSessionRepository)
 			mt := new(mockTokenManager)
 			mu := new(mockUserService)
-			svc := NewSessionService(ms, mu, mt)
+			svc := NewSessionService(ms, mu, mt, testSessionPolicy)
 			err := svc.expirationCheck(tt.setupExpiresAt, tt.setupCreatedAt)
 			if tt.expectedErr == nil {
 				require.NoError(t, err)
@@ -350,255 +350,6 @@ func TestExpirationCheck(t *testing.T) {
 	}
 }
 
-func TestShouldRefresh(t *testing.T) {
-	tests := []struct {
-		name			string
-		setupExpiresAt  time.Time
-		setupCreatedAt  time.Time
-		expectedBool 	bool
-		expectedErr 	error
-	}{
-		{
-			name: "正常系：期限延長される必要なし",
-			setupExpiresAt: time.Now().Add(24*time.Hour).UTC(),
-        	setupCreatedAt: time.Now().Add(-48*time.Hour).UTC(),
-			expectedBool: false,
-			expectedErr: nil,
-		},
-		{
-			name: "正常系：期限延長される必要がある",
-        	setupExpiresAt: time.Now().Add(12*time.Hour).UTC(),
-        	setupCreatedAt: time.Now().Add(-24*6*time.Hour).UTC(),
-			expectedBool: true,
-			expectedErr: nil,
-		},
-		{
-			name: "異常系：パラメーター異常",
-        	setupExpiresAt: time.Now().Add(12*time.Hour).UTC(),
-        	setupCreatedAt: time.Now().Add(12*6*time.Hour).UTC(),
-			expectedBool: false,
-			expectedErr:  errcode.ErrSessionExpired,
-		},
-		{
-			name: "異常系：なし",
-			expectedBool: false,
-			expectedErr:  errcode.ErrRequiredFieldMissing,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ms := new(mockSessionRepository)
-			mt := new(mockTokenManager)
-			mu := new(mockUserService)
-
-			svc := NewSessionService(ms, mu, mt)
-
-			foundBool, err := svc.ShouldRefresh(tt.setupExpiresAt, tt.setupCreatedAt)
-
-			if tt.expectedErr != nil {
-				assert.ErrorIs(t, err, tt.expectedErr)
-			} else {
-				assert.Equal(t, tt.expectedBool, foundBool)
-			}
-
-		})
-	}
-}
-
-func TestExecuteRefresh(t *testing.T) {
-	now := time.Now().UTC()
-	initialExpiry := now.Add(1 * time.Hour).UTC()
-	userID := 10
-	token := "sbjbabfhbanjkaflansflasbfabfb223ebfhb"
-	tokenHash := "valid_token_hash"
-	detailErr := fmt.Errorf("セッション取得後に返却されたオブジェクトが nil です")
-	sr := &dto.SessionRecord{
-		UserID:    int64(userID),
-		TokenHash: tokenHash,
-		ExpiresAt: initialExpiry,
-		CreatedAt: now.Add(-72*time.Hour).UTC(),
-	}
-
-	srMax := &dto.SessionRecord{
-        UserID:    10,
-        TokenHash: tokenHash,
-        ExpiresAt: time.Now().Add(1 * time.Hour).UTC(),
-        CreatedAt: time.Now().Add(-MaxSessionLife).Add(1 * time.Hour).UTC(), 
-    }
-	tests := []struct {
-		name      	string
-		setupuserID int64
-		setupToken 	string
-		setupMock 	func(ms *mockSessionRepository,mt *mockTokenManager)
-		expectErr  	error
-		check       func(t *testing.T, sr *dto.SessionRecord)
-		errMsg    	string
-	} {
-		{
-			name: "正常系：セッション期限が正常に更新される(newExpiry)",
-			setupuserID: 10,
-			setupToken: token,
-			setupMock: func(ms *mockSessionRepository, mt *mockTokenManager) {
-				mt.On("Hash", token).Return(tokenHash, nil)
-				ms.On("Get", mock.Anything, tokenHash).Return(sr, nil)
-				ms.On("Update", mock.Anything,mock.Anything).Return(nil)
-			},
-			check: func(t *testing.T, sr *dto.SessionRecord) {
-           		expected := time.Now().Add(SessionDuration).UTC()
-            	assert.WithinDuration(t, expected, sr.ExpiresAt, 2*time.Second)
-       		 },
-		},
-		{
-			name: "正常系：セッション期限が正常に更新される(maxExpiry)",
-			setupuserID: 10,
-			setupToken: token,
-			setupMock: func(ms *mockSessionRepository, mt *mockTokenManager) {
-				mt.On("Hash", token).Return(tokenHash, nil)
-				ms.On("Get", mock.Anything, tokenHash).Return(srMax, nil)
-				ms.On("Update", mock.Anything,mock.Anything).Return(nil)
-			},
-			check: func(t *testing.T, sr *dto.SessionRecord) {
-            	expectedMax := sr.CreatedAt.Add(MaxSessionLife).UTC()
-            	assert.True(t, sr.ExpiresAt.Equal(expectedMax))
-        	},
-		},
-		{
-			name: "異常系：recordなし",
-			setupuserID: 100,
-			setupToken: token,
-			setupMock: func(ms *mockSessionRepository, mt *mockTokenManager) {
-				mt.On("Hash", token).Return(tokenHash, nil)
-				ms.On("Get", mock.Anything, tokenHash).Return(nil, detailErr)
-			},
-			expectErr: detailErr,
-			errMsg: "セッションの取得に失敗しました",
-		},
-		{
-			name: "異常系：データベース更新エラー",
-			setupuserID: 10,
-			setupToken: token,
-			setupMock: func(ms *mockSessionRepository,  mt *mockTokenManager) {
-				mt.On("Hash", token).Return(tokenHash, nil)
-				ms.On("Get", mock.Anything, tokenHash).Return(sr, nil)
-				ms.On("Update", mock.Anything, mock.Anything).Return(errMockInternal)
-			},
-			expectErr: errMockInternal,
-			errMsg:  "セッション期限の更新に失敗しました",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ms := new(mockSessionRepository)
-			mt := new(mockTokenManager)
-			mu := new(mockUserService)
-
-			tt.setupMock(ms, mt)
-			svc := NewSessionService(ms, mu, mt)
-
-			err := svc.executeRefresh(context.Background(), tt.setupToken)
-			
-
-			if tt.expectErr != nil {
-				if tt.errMsg != "" {
-					assert.Error(t, err)
-					assert.Contains(t, err.Error(), tt.errMsg)
-				} else {
-					assert.ErrorIs(t, err, tt.expectErr)
-				}
-			} else {
-				call := ms.Calls[len(ms.Calls)-1] 
-    			updatedSR := call.Arguments.Get(1).(*dto.SessionRecord)
-    			if tt.check != nil {
-       				 tt.check(t, updatedSR)
-    			}
-			}
-
-			ms.AssertExpectations(t)
-		})
-	}
-}
-
-func TestRefreshAsyncErrorHandling(t *testing.T) {
-    ms := new(mockSessionRepository)
-    mt := new(mockTokenManager)
-    mu := new(mockUserService)
-    
-    svc := NewSessionService(ms, mu, mt)
-
-    testToken := "valid-token-at-least-32-characters-long"
-    testHash := "mocked-hash"
-
-	mt.On("Hash", testToken).Return(testHash, nil)
-    ms.On("Get", mock.Anything, testHash).Return(nil, errMockInternal)
-
-    svc.RefreshAsync(testToken)
-
-    time.Sleep(50 * time.Millisecond)
-    ms.AssertExpectations(t)
-    mt.AssertExpectations(t)
-    
-    t.Log("Async task failed as expected, but service is still alive.")
-}
-
-func TestRefreshAsyncSuccess(t *testing.T) {
-    ms := new(mockSessionRepository)
-    mt := new(mockTokenManager)
-    mu := new(mockUserService)
-    svc := NewSessionService(ms, mu, mt)
-
-    testToken := "valid-token-at-least-32-characters-long"
-    testHash := "mocked-hash"
-    userID := int64(123)
-
-    mt.On("Hash", testToken).Return(testHash, nil)
-    ms.On("Get", mock.Anything, testHash).Return(&dto.SessionRecord{
-        UserID: userID,
-        TokenHash: testHash,
-        ExpiresAt: time.Now().Add(1 * time.Hour),
-        CreatedAt: time.Now().Add(-1 * time.Hour),
-    }, nil)
-
-    ms.On("Update", mock.Anything, mock.MatchedBy(func(r *dto.SessionRecord) bool {
-        return r.UserID == userID && r.ExpiresAt.After(time.Now())
-    })).Return(nil)
-
-    svc.RefreshAsync(testToken)
-
-    time.Sleep(50 * time.Millisecond)
-
-
-    ms.AssertExpectations(t)
-    mt.AssertExpectations(t)
-}
-
-func TestRefreshAsync_Isolation(t *testing.T) {
-    ms := new(mockSessionRepository)
-    mt := new(mockTokenManager)
-    mu := new(mockUserService)
-    svc := NewSessionService(ms, mu, mt)
-
-    mt.On("Hash", mock.Anything).Return("hash", nil)
-    ms.On("Get", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
-        time.Sleep(200 * time.Millisecond) 
-    }).Return(&dto.SessionRecord{UserID: 123}, nil)
-    ms.On("Update", mock.Anything, mock.MatchedBy(func(r *dto.SessionRecord) bool {
-        return r.UserID == 123 && r.ExpiresAt.After(time.Now())})).Return(nil)
-
-    startTime := time.Now()
-
-    svc.RefreshAsync("token-at-least-32-characters-long-xxx")
-
-    duration := time.Since(startTime)
-    assert.Less(t, duration, 10*time.Millisecond, "メインプロセスがブロックされています。非同期の隔離に失敗しました。")
-
-    t.Logf("メインプロセスの実行時間: %v - 即時レスポンスを確認しました。", duration)
-
-    time.Sleep(500 * time.Millisecond)
-    ms.AssertExpectations(t)
-}
-
 func TestRevoke(t *testing.T) {
 	type testCase struct {
 		name           string
@@ -671,7 +422,7 @@ func TestRevoke(t *testing.T) {
 			ms := new(mockSessionRepository)
 			mt := new(mockTokenManager)
 			mu := new(mockUserService)
-			svc := NewSessionService(ms, mu, mt)
+			svc := NewSessionService(ms, mu, mt, testSessionPolicy)
 
 			tt.setupMock(ms, mt)
 
@@ -690,3 +441,353 @@ func TestRevoke(t *testing.T) {
 		})
 	}
 }
+
+func TestRevokeOthers(t *testing.T) {
+	validToken := "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2"
+	current := &dto.SessionRecord{
+		UserID:    101,
+		TokenHash: "token_hash",
+		ExpiresAt: time.Now().Add(24 * time.Hour).UTC(),
+		CreatedAt: time.Now().Add(-1 * time.Hour).UTC(),
+	}
+
+	t.Run("【成功】現在のセッション以外を削除する", func(t *testing.T) {
+		ms, mt := new(mockSessionRepository), new(mockTokenManager)
+		mt.On("Hash", validToken).Return("token_hash")
+		ms.On("Get", mock.Anything, "token_hash").Return(current, nil)
+		ms.On("DeleteOthersByUserID", mock.Anything, int64(101), "token_hash").Return(nil)
+		svc := NewSessionService(ms, new(mockUserService), mt, testSessionPolicy)
+
+		require.NoError(t, svc.RevokeOthers(context.Background(), 101, validToken))
+		ms.AssertExpectations(t)
+	})
+
+	t.Run("【失敗】他人のセッション", func(t *testing.T) {
+		ms, mt := new(mockSessionRepository), new(mockTokenManager)
+		mt.On("Hash", validToken).Return("token_hash")
+		ms.On("Get", mock.Anything, "token_hash").Return(current, nil)
+		svc := NewSessionService(ms, new(mockUserService), mt, testSessionPolicy)
+
+		assert.ErrorIs(t, svc.RevokeOthers(context.Background(), 200, validToken), errcode.ErrForbidden)
+		ms.AssertNotCalled(t, "DeleteOthersByUserID", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestValidateTouchesLastSeen(t *testing.T) {
+	validToken := "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2"
+	record := &dto.SessionRecord{
+		UserID:     10,
+		TokenHash:  "hashed_ok",
+		ExpiresAt:  time.Now().Add(23 * time.Hour).UTC(),
+		CreatedAt:  time.Now().Add(-1 * time.Hour).UTC(),
+		LastSeenAt: time.Now().Add(-1 * time.Hour).UTC(),
+	}
+
+	ms, mt, mu := new(mockSessionRepository), new(mockTokenManager), new(mockUserService)
+	mt.On("Hash", validToken).Return("hashed_ok")
+	ms.On("Get", mock.Anything, "hashed_ok").Return(record, nil)
+	mu.On("Exists", mock.Anything, int64(10)).Return(true, nil)
+	updated := make(chan time.Time, 1)
+	ms.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		updated <- args.Get(1).(*dto.SessionRecord).LastSeenAt
+	}).Return(nil)
+
+	svc := NewSessionService(ms, mu, mt, testSessionPolicy)
+	_, err := svc.Validate(context.Background(), validToken)
+	require.NoError(t, err)
+
+	select {
+	case seen := <-updated:
+		assert.WithinDuration(t, time.Now(), seen, 5*time.Second)
+	case <-time.After(2 * time.Second):
+		t.Fatal("最終利用日時が更新されませんでした")
+	}
+}
+
+func TestListSessions(t *testing.T) {
+	now := time.Now().UTC()
+	ms, mt := new(mockSessionRepository), new(mockTokenManager)
+	mt.On("Hash", "current_token").Return("hash_current")
+	ms.On("ListByUserID", mock.Anything, int64(10)).Return([]*dto.SessionRecord{
+		{UserID: 10, TokenHash: "hash_old", SessionID: "sid-old", CreatedAt: now.Add(-48 * time.Hour), LastSeenAt: now.Add(-24 * time.Hour)},
+		{UserID: 10, TokenHash: "hash_current", SessionID: "sid-current", CreatedAt: now.Add(-1 * time.Hour), LastSeenAt: now},
+		{UserID: 10, TokenHash: "hash_legacy", SessionID: "sid-legacy", CreatedAt: now.Add(-2 * time.Hour)},
+	}, nil)
+	svc := NewSessionService(ms, new(mockUserService), mt, testSessionPolicy)
+
+	sessions, err := svc.ListSessions(context.Background(), 10, "current_token")
+	require.NoError(t, err)
+	require.Len(t, sessions, 3)
+	assert.Equal(t, "sid-current", sessions[0].SessionID, "最近使われた順に並ぶべきです")
+	assert.True(t, sessions[0].Current)
+	assert.False(t, sessions[1].Current)
+	assert.Equal(t, "sid-legacy", sessions[2].SessionID)
+	assert.False(t, sessions[2].Current)
+}
+
+func TestRevokeSession(t *testing.T) {
+	records := []*dto.SessionRecord{
+		{UserID: 10, TokenHash: "hash_a", SessionID: "sid-a"},
+		{UserID: 10, TokenHash: "hash_b", SessionID: "sid-b"},
+	}
+
+	tests := []struct {
+		name      string
+		sessionID string
+		setupMock func(ms *mockSessionRepository)
+		wantErr   error
+	}{
+		{
+			name:      "【成功】指定したセッションだけを削除する",
+			sessionID: "sid-b",
+			setupMock: func(ms *mockSessionRepository) {
+				ms.On("ListByUserID", mock.Anything, int64(10)).Return(records, nil)
+				ms.On("Delete", mock.Anything, records[1]).Return(nil)
+			},
+		},
+		{
+			name:      "【失敗】自分のものではないセッションID",
+			sessionID: "sid-other",
+			setupMock: func(ms *mockSessionRepository) {
+				ms.On("ListByUserID", mock.Anything, int64(10)).Return(records, nil)
+			},
+			wantErr: errcode.ErrUserSessionNotFound,
+		},
+		{
+			name:      "【失敗】一覧の取得後に期限切れになった",
+			sessionID: "sid-a",
+			setupMock: func(ms *mockSessionRepository) {
+				ms.On("ListByUserID", mock.Anything, int64(10)).Return(records, nil)
+				ms.On("Delete", mock.Anything, records[0]).Return(errcode.ErrSessionNotFound)
+			},
+			wantErr: errcode.ErrUserSessionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(mockSessionRepository)
+			tt.setupMock(ms)
+			svc := NewSessionService(ms, new(mockUserService), new(mockTokenManager), testSessionPolicy)
+
+			err := svc.RevokeSession(context.Background(), 10, tt.sessionID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			ms.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	refreshToken := strings.Repeat("r", 43)
+	now := time.Now().UTC()
+	ref := &dto.RefreshTokenRefRecord{UserID: 10, SessionID: "sid"}
+	current := &dto.SessionRecord{
+		UserID:           10,
+		TokenHash:        "access_hash",
+		CreatedAt:        now.Add(-2 * time.Hour),
+		ExpiresAt:        now.Add(-1 * time.Minute),
+		SessionID:        "sid",
+		RefreshHash:      "refresh_hash",
+		RefreshExpiresAt: now.Add(20 * time.Hour),
+	}
+
+	tests := []struct {
+		name      string
+		token     string
+		setupMock func(ms *mockSessionRepository, mt *mockTokenManager)
+		wantErr   error
+	}{
+		{
+			name:  "【成功】トークンの組をローテーションする",
+			token: refreshToken,
+			setupMock: func(ms *mockSessionRepository, mt *mockTokenManager) {
+				mt.On("Hash", refreshToken).Return("refresh_hash")
+				ms.On("GetRefreshRef", mock.Anything, "refresh_hash").Return(ref, nil)
+				ms.On("GetByFamily", mock.Anything, "sid").Return(current, nil)
+				mt.On("Generate", 32).Return("new_token_0123456789012345678901234567890", nil).Twice()
+				mt.On("Hash", "new_token_0123456789012345678901234567890").Return("new_hash")
+				ms.On("Rotate", mock.Anything, current, mock.MatchedBy(func(next *dto.SessionRecord) bool {
+					return next.SessionID == "sid" && next.TokenHash == "new_hash" && next.RefreshHash == "new_hash" &&
+						next.CreatedAt.Equal(current.CreatedAt) && next.ExpiresAt.After(now) &&
+						!next.RefreshExpiresAt.After(current.CreatedAt.Add(testSessionPolicy.MaxLife))
+				})).Return(nil)
+			},
+		},
+		{
+			name:  "【失敗】ローテーション済みのトークンの再利用はファミリーごと無効にする",
+			token: refreshToken,
+			setupMock: func(ms *mockSessionRepository, mt *mockTokenManager) {
+				mt.On("Hash", refreshToken).Return("old_refresh_hash")
+				ms.On("GetRefreshRef", mock.Anything, "old_refresh_hash").Return(ref, nil)
+				ms.On("GetByFamily", mock.Anything, "sid").Return(current, nil)
+				ms.On("DeleteFamily", mock.Anything, int64(10), "sid").Return(nil)
+			},
+			wantErr: errcode.ErrRefreshTokenReused,
+		},
+		{
+			name:  "【失敗】存在しないトークン",
+			token: refreshToken,
+			setupMock: func(ms *mockSessionRepository, mt *mockTokenManager) {
+				mt.On("Hash", refreshToken).Return("unknown_hash")
+				ms.On("GetRefreshRef", mock.Anything, "unknown_hash").Return(nil, errcode.ErrSessionNotFound)
+			},
+			wantErr: errcode.ErrInvalidRefreshToken,
+		},
+		{
+			name:  "【失敗】ファミリーが既に無効にされている",
+			token: refreshToken,
+			setupMock: func(ms *mockSessionRepository, mt *mockTokenManager) {
+				mt.On("Hash", refreshToken).Return("refresh_hash")
+				ms.On("GetRefreshRef", mock.Anything, "refresh_hash").Return(ref, nil)
+				ms.On("GetByFamily", mock.Anything, "sid").Return(nil, errcode.ErrSessionNotFound)
+			},
+			wantErr: errcode.ErrInvalidRefreshToken,
+		},
+		{
+			name:  "【失敗】同時に使われて先にローテーションされた",
+			token: refreshToken,
+			setupMock: func(ms *mockSessionRepository, mt *mockTokenManager) {
+				mt.On("Hash", refreshToken).Return("refresh_hash")
+				ms.On("GetRefreshRef", mock.Anything, "refresh_hash").Return(ref, nil)
+				ms.On("GetByFamily", mock.Anything, "sid").Return(current, nil)
+				mt.On("Generate", 32).Return("new_token_0123456789012345678901234567890", nil)
+				mt.On("Hash", "new_token_0123456789012345678901234567890").Return("new_hash")
+				ms.On("Rotate", mock.Anything, current, mock.Anything).Return(errcode.ErrTokenConflict)
+			},
+			wantErr: errcode.ErrInvalidRefreshToken,
+		},
+		{
+			name:      "【失敗】形式が不正なトークン",
+			token:     "short",
+			setupMock: func(ms *mockSessionRepository, mt *mockTokenManager) {},
+			wantErr:   errcode.ErrInvalidRefreshToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms, mt := new(mockSessionRepository), new(mockTokenManager)
+			tt.setupMock(ms, mt)
+			svc := NewSessionService(ms, new(mockUserService), mt, testSessionPolicy)
+
+			res, err := svc.Refresh(context.Background(), tt.token)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "new_token_0123456789012345678901234567890", res.Token)
+				assert.Equal(t, "new_token_0123456789012345678901234567890", res.RefreshToken)
+			}
+			ms.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSessionPolicyRefreshExpiry(t *testing.T) {
+	policy := dto.NewSessionPolicy(15*time.Minute, 24*time.Hour, 7*24*time.Hour)
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, createdAt.Add(24*time.Hour), policy.RefreshExpiry(createdAt, createdAt))
+	// ログインから7日を超えて延長されない
+	assert.Equal(t, createdAt.Add(7*24*time.Hour), policy.RefreshExpiry(createdAt.Add(6*24*time.Hour+12*time.Hour), createdAt))
+}
+
+func TestRedeemGatewayTicket(t *testing.T) {
+	ticket := strings.Repeat("t", 44)
+	record := &dto.SessionRecord{
+		UserID:    10,
+		TokenHash: "access_hash",
+		ExpiresAt: time.Now().Add(10 * time.Minute).UTC(),
+		CreatedAt: time.Now().Add(-1 * time.Hour).UTC(),
+	}
+
+	tests := []struct {
+		name      string
+		ticket    string
+		setupMock func(ms *mockSessionRepository, mu *mockUserService, mt *mockTokenManager)
+		wantErr   error
+	}{
+		{
+			name:   "【成功】チケットを消費して発行元のユーザーを返す",
+			ticket: ticket,
+			setupMock: func(ms *mockSessionRepository, mu *mockUserService, mt *mockTokenManager) {
+				mt.On("Hash", ticket).Return("ticket_hash")
+				ms.On("ConsumeTicket", mock.Anything, "ticket_hash").Return("access_hash", nil)
+				ms.On("Get", mock.Anything, "access_hash").Return(record, nil)
+				mu.On("Exists", mock.Anything, int64(10)).Return(true, nil)
+			},
+		},
+		{
+			name:   "【失敗】使用済みまたは期限切れのチケット",
+			ticket: ticket,
+			setupMock: func(ms *mockSessionRepository, mu *mockUserService, mt *mockTokenManager) {
+				mt.On("Hash", ticket).Return("ticket_hash")
+				ms.On("ConsumeTicket", mock.Anything, "ticket_hash").Return("", errcode.ErrSessionNotFound)
+			},
+			wantErr: errcode.ErrInvalidGatewayTicket,
+		},
+		{
+			name:   "【失敗】発行元のセッションがログアウト済み",
+			ticket: ticket,
+			setupMock: func(ms *mockSessionRepository, mu *mockUserService, mt *mockTokenManager) {
+				mt.On("Hash", ticket).Return("ticket_hash")
+				ms.On("ConsumeTicket", mock.Anything, "ticket_hash").Return("access_hash", nil)
+				ms.On("Get", mock.Anything, "access_hash").Return(nil, errcode.ErrSessionNotFound)
+			},
+			wantErr: errcode.ErrInvalidGatewayTicket,
+		},
+		{
+			name:      "【失敗】形式が不正なチケット",
+			ticket:    "short",
+			setupMock: func(ms *mockSessionRepository, mu *mockUserService, mt *mockTokenManager) {},
+			wantErr:   errcode.ErrInvalidGatewayTicket,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms, mu, mt := new(mockSessionRepository), new(mockUserService), new(mockTokenManager)
+			tt.setupMock(ms, mu, mt)
+			svc := NewSessionService(ms, mu, mt, testSessionPolicy)
+
+			res, err := svc.RedeemGatewayTicket(context.Background(), tt.ticket)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, int64(10), res.UserID)
+			}
+			ms.AssertExpectations(t)
+		})
+	}
+}
+
+func TestIssueGatewayTicket(t *testing.T) {
+	token := strings.Repeat("a", 44)
+	record := &dto.SessionRecord{
+		UserID:    10,
+		TokenHash: "access_hash",
+		ExpiresAt: time.Now().Add(10 * time.Minute).UTC(),
+		CreatedAt: time.Now().Add(-1 * time.Hour).UTC(),
+	}
+
+	ms, mt := new(mockSessionRepository), new(mockTokenManager)
+	mt.On("Hash", token).Return("access_hash")
+	ms.On("Get", mock.Anything, "access_hash").Return(record, nil)
+	mt.On("Generate", 32).Return("ws_ticket", nil)
+	mt.On("Hash", "ws_ticket").Return("ticket_hash")
+	ms.On("CreateTicket", mock.Anything, "ticket_hash", "access_hash", gatewayTicketTTL).Return(nil)
+	svc := NewSessionService(ms, new(mockUserService), mt, testSessionPolicy)
+
+	res, err := svc.IssueGatewayTicket(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, "ws_ticket", res.Ticket)
+	assert.WithinDuration(t, time.Now().Add(gatewayTicketTTL), res.ExpiresAt, 5*time.Second)
+	ms.AssertExpectations(t)
+}
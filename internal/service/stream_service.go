@@ -0,0 +1,277 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type StreamRepository interface {
+	Publish(ctx context.Context, record *dto.StreamEventRecord) error
+	Since(ctx context.Context, afterID string, count int64) ([]*dto.StreamEventRecord, error)
+	Subscribe(ctx context.Context) (<-chan *dto.StreamEventRecord, func() error, error)
+}
+
+type streamSubscriber struct {
+	userID    int64
+	events    chan *dto.StreamEventRecord
+	closeOnce sync.Once
+}
+
+func (sub *streamSubscriber) close() {
+	sub.closeOnce.Do(func() { close(sub.events) })
+}
+
+// Redis の Pub/Sub で受け取ったイベントを、このプロセスに接続中のユーザーへ振り分ける。
+// 接続数の上限はプロセスごとに数える
+type streamService struct {
+	streamRepository StreamRepository
+	maxConnsPerUser  int
+
+	mu          sync.Mutex
+	subscribers map[int64]map[*streamSubscriber]struct{}
+}
+
+func NewStreamService(r StreamRepository, maxConnsPerUser int) *streamService {
+	return &streamService{
+		streamRepository: r,
+		maxConnsPerUser:  maxConnsPerUser,
+		subscribers:      make(map[int64]map[*streamSubscriber]struct{}),
+	}
+}
+
+// ホームタイムラインにツイートが追加されたことをフォロワーへ知らせる
+func (s *streamService) PublishTimeline(ctx context.Context, tweetID int64, userIDs []int64) error {
+	return s.publish(ctx, &dto.StreamEventRecord{
+		Type:    models.StreamEventTimeline,
+		TweetID: tweetID,
+	}, userIDs)
+}
+
+func (s *streamService) PublishNotification(ctx context.Context, notificationType string, actorID, tweetID int64, userIDs []int64) error {
+	return s.publish(ctx, &dto.StreamEventRecord{
+		Type:             models.StreamEventNotification,
+		TweetID:          tweetID,
+		ActorID:          actorID,
+		NotificationType: notificationType,
+	}, userIDs)
+}
+
+func (s *streamService) publish(ctx context.Context, base *dto.StreamEventRecord, userIDs []int64) error {
+	for start := 0; start < len(userIDs); start += streamPublishBatchSize {
+		end := min(start+streamPublishBatchSize, len(userIDs))
+		event := *base
+		event.UserIDs = userIDs[start:end]
+		if err := s.streamRepository.Publish(ctx, &event); err != nil {
+			return fmt.Errorf("イベントの配信に失敗しました (type: %s): %w", base.Type, err)
+		}
+	}
+	return nil
+}
+
+// ctx が終わるまで購読を続け、切断された場合は再購読する。終了時には全接続を閉じる
+func (s *streamService) Run(ctx context.Context) {
+	defer s.CloseAll()
+
+	for {
+		events, closeFn, err := s.streamRepository.Subscribe(ctx)
+		if err != nil {
+			slog.Error("StreamService: イベントの購読に失敗しました", "err", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(2 * time.Second):
+				continue
+			}
+		}
+
+		s.consume(ctx, events)
+		_ = closeFn()
+
+		if ctx.Err() != nil {
+			return
+		}
+		slog.Warn("StreamService: 購読が切断されました。再購読します")
+	}
+}
+
+func (s *streamService) consume(ctx context.Context, events <-chan *dto.StreamEventRecord) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			s.dispatch(event)
+		}
+	}
+}
+
+func (s *streamService) dispatch(event *dto.StreamEventRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, userID := range event.UserIDs {
+		for sub := range s.subscribers[userID] {
+			select {
+			case sub.events <- event:
+			default:
+				// 読み取りが追いつかない接続は切断し、Last-Event-ID での再接続に任せる
+				slog.Warn("StreamService: バッファが溢れたため接続を切断します", "user_id", userID)
+				s.removeLocked(sub)
+				sub.close()
+			}
+		}
+	}
+}
+
+// lastEventID より後に取りこぼしたイベントを再送してから、新しいイベントを流す。
+// 返したチャネルは ctx の終了、cancel の呼び出し、またはサーバーの停止で閉じられる
+func (s *streamService) Subscribe(ctx context.Context, userID int64, lastEventID string) (<-chan *dto.StreamEventRecord, func(), error) {
+	if userID <= 0 {
+		return nil, nil, errcode.ErrInvalidUserID
+	}
+	if lastEventID != "" {
+		if _, _, ok := dto.ParseStreamID(lastEventID); !ok {
+			return nil, nil, errcode.ErrInvalidLastEventID
+		}
+	}
+
+	sub, err := s.register(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := make(chan *dto.StreamEventRecord)
+	go func() {
+		defer close(out)
+		send := func(event *dto.StreamEventRecord) bool {
+			select {
+			case out <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		// 購読を先に登録しているため、再送分と重複したイベントは ID で読み飛ばす
+		last := lastEventID
+		if lastEventID != "" {
+			var ok bool
+			if last, ok = s.replay(ctx, userID, lastEventID, send); !ok {
+				return
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-sub.events:
+				if !ok {
+					return
+				}
+				if !event.IsAfter(last) {
+					continue
+				}
+				if !send(event) {
+					return
+				}
+				last = event.ID
+			}
+		}
+	}()
+
+	cancel := func() {
+		s.mu.Lock()
+		s.removeLocked(sub)
+		s.mu.Unlock()
+		sub.close()
+	}
+	return out, cancel, nil
+}
+
+// 取りこぼしたイベントを Stream の先頭に追いつくまで古い順に送る。再送数か読み取り数が上限に
+// 達した場合や読み取りに失敗した場合は resync を送り、読み終えた位置から先はライブ配信に任せる
+func (s *streamService) replay(ctx context.Context, userID int64, lastEventID string, send func(*dto.StreamEventRecord) bool) (string, bool) {
+	cursor := lastEventID
+	sent, scanned := 0, 0
+	resync := func() (string, bool) {
+		return cursor, send(&dto.StreamEventRecord{ID: cursor, Type: models.StreamEventResync})
+	}
+
+	for {
+		page, err := s.streamRepository.Since(ctx, cursor, streamReplayPageSize)
+		if err != nil {
+			slog.Warn("StreamService: 取りこぼしたイベントの再送に失敗しました", "user_id", userID, "err", err)
+			return resync()
+		}
+		if len(page) == 0 {
+			return cursor, true
+		}
+
+		for _, event := range page {
+			cursor = event.ID
+			scanned++
+			if event.IsFor(userID) {
+				if !send(event) {
+					return cursor, false
+				}
+				sent++
+			}
+			if sent >= maxStreamReplay || scanned >= maxStreamReplayScan {
+				slog.Info("StreamService: 再送の上限に達したため再取得を促します", "user_id", userID, "sent", sent, "scanned", scanned)
+				return resync()
+			}
+		}
+	}
+}
+
+func (s *streamService) register(userID int64) (*streamSubscriber, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subs := s.subscribers[userID]
+	if len(subs) >= s.maxConnsPerUser {
+		return nil, errcode.ErrTooManyStreams
+	}
+	if subs == nil {
+		subs = make(map[*streamSubscriber]struct{})
+		s.subscribers[userID] = subs
+	}
+
+	sub := &streamSubscriber{
+		userID: userID,
+		events: make(chan *dto.StreamEventRecord, streamBufferSize),
+	}
+	subs[sub] = struct{}{}
+	return sub, nil
+}
+
+func (s *streamService) removeLocked(sub *streamSubscriber) {
+	subs := s.subscribers[sub.userID]
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(s.subscribers, sub.userID)
+	}
+}
+
+// サーバー停止時に呼ぶ。接続中のストリームをすべて閉じる
+func (s *streamService) CloseAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, subs := range s.subscribers {
+		for sub := range subs {
+			sub.close()
+		}
+	}
+	s.subscribers = make(map[int64]map[*streamSubscriber]struct{})
+}
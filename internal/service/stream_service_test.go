@@ -0,0 +1,228 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"aita/internal/errcode"
+	"aita/internal/models"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func receiveIDs(t *testing.T, events <-chan *dto.StreamEventRecord, n int) []string {
+	t.Helper()
+	ids := make([]string, 0, n)
+	for len(ids) < n {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return ids
+			}
+			ids = append(ids, e.ID)
+		case <-time.After(time.Second):
+			t.Fatalf("イベントが届きません (受信済み: %v)", ids)
+		}
+	}
+	return ids
+}
+
+func TestStreamSubscribe(t *testing.T) {
+	t.Run("正常系: 取りこぼしを再送し、重複したライブイベントは読み飛ばす", func(t *testing.T) {
+		mr := new(mockStreamRepository)
+		mr.On("Since", mock.Anything, "100-0", int64(streamReplayPageSize)).Return([]*dto.StreamEventRecord{
+			{ID: "101-0", UserIDs: []int64{1}},
+			{ID: "102-0", UserIDs: []int64{2}},
+			{ID: "103-0", UserIDs: []int64{1, 2}},
+		}, nil)
+		mr.On("Since", mock.Anything, "103-0", int64(streamReplayPageSize)).Return(nil, nil)
+		svc := NewStreamService(mr, 3)
+
+		ctx, cancelCtx := context.WithCancel(context.Background())
+		defer cancelCtx()
+		events, cancel, err := svc.Subscribe(ctx, 1, "100-0")
+		require.NoError(t, err)
+		defer cancel()
+
+		svc.dispatch(&dto.StreamEventRecord{ID: "103-0", UserIDs: []int64{1}})
+		svc.dispatch(&dto.StreamEventRecord{ID: "104-0", UserIDs: []int64{2}})
+		svc.dispatch(&dto.StreamEventRecord{ID: "105-0", UserIDs: []int64{1}})
+
+		assert.Equal(t, []string{"101-0", "103-0", "105-0"}, receiveIDs(t, events, 3))
+	})
+
+	t.Run("正常系: 他のユーザー宛てで埋まったページを越えて先頭まで再送する", func(t *testing.T) {
+		others := make([]*dto.StreamEventRecord, streamReplayPageSize)
+		for i := range others {
+			others[i] = &dto.StreamEventRecord{ID: fmt.Sprintf("%d-0", 101+i), UserIDs: []int64{2}}
+		}
+		lastOther := others[len(others)-1].ID
+
+		mr := new(mockStreamRepository)
+		mr.On("Since", mock.Anything, "100-0", int64(streamReplayPageSize)).Return(others, nil).Once()
+		mr.On("Since", mock.Anything, lastOther, int64(streamReplayPageSize)).Return([]*dto.StreamEventRecord{
+			{ID: "9000-0", UserIDs: []int64{1}},
+		}, nil).Once()
+		mr.On("Since", mock.Anything, "9000-0", int64(streamReplayPageSize)).Return(nil, nil).Once()
+		svc := NewStreamService(mr, 3)
+
+		ctx, cancelCtx := context.WithCancel(context.Background())
+		defer cancelCtx()
+		events, cancel, err := svc.Subscribe(ctx, 1, "100-0")
+		require.NoError(t, err)
+		defer cancel()
+
+		assert.Equal(t, []string{"9000-0"}, receiveIDs(t, events, 1))
+		mr.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 再送数が上限に達したら resync を送ってライブ配信に切り替える", func(t *testing.T) {
+		mine := make([]*dto.StreamEventRecord, maxStreamReplay+1)
+		for i := range mine {
+			mine[i] = &dto.StreamEventRecord{ID: fmt.Sprintf("%d-0", 101+i), UserIDs: []int64{1}}
+		}
+		capped := mine[maxStreamReplay-1].ID
+
+		mr := new(mockStreamRepository)
+		mr.On("Since", mock.Anything, "100-0", int64(streamReplayPageSize)).Return(mine[:streamReplayPageSize], nil).Once()
+		mr.On("Since", mock.Anything, mine[streamReplayPageSize-1].ID, int64(streamReplayPageSize)).Return(mine[streamReplayPageSize:], nil).Once()
+		svc := NewStreamService(mr, 3)
+
+		ctx, cancelCtx := context.WithCancel(context.Background())
+		defer cancelCtx()
+		events, cancel, err := svc.Subscribe(ctx, 1, "100-0")
+		require.NoError(t, err)
+		defer cancel()
+
+		ids := receiveIDs(t, events, maxStreamReplay)
+		assert.Equal(t, capped, ids[len(ids)-1])
+
+		select {
+		case e := <-events:
+			assert.Equal(t, models.StreamEventResync, e.Type)
+			assert.Equal(t, capped, e.ID)
+		case <-time.After(time.Second):
+			t.Fatal("resync が届きません")
+		}
+
+		svc.dispatch(&dto.StreamEventRecord{ID: "99999-0", UserIDs: []int64{1}})
+		assert.Equal(t, []string{"99999-0"}, receiveIDs(t, events, 1))
+	})
+
+	t.Run("異常系: 取りこぼしの読み取りに失敗したら resync を送る", func(t *testing.T) {
+		mr := new(mockStreamRepository)
+		mr.On("Since", mock.Anything, "100-0", int64(streamReplayPageSize)).Return(nil, errMockInternal)
+		svc := NewStreamService(mr, 3)
+
+		ctx, cancelCtx := context.WithCancel(context.Background())
+		defer cancelCtx()
+		events, cancel, err := svc.Subscribe(ctx, 1, "100-0")
+		require.NoError(t, err)
+		defer cancel()
+
+		select {
+		case e := <-events:
+			assert.Equal(t, models.StreamEventResync, e.Type)
+			assert.Equal(t, "100-0", e.ID)
+		case <-time.After(time.Second):
+			t.Fatal("resync が届きません")
+		}
+	})
+
+	t.Run("異常系: ユーザーごとの接続数の上限", func(t *testing.T) {
+		svc := NewStreamService(new(mockStreamRepository), 1)
+		ctx := context.Background()
+
+		_, cancel, err := svc.Subscribe(ctx, 1, "")
+		require.NoError(t, err)
+		_, _, err = svc.Subscribe(ctx, 1, "")
+		assert.ErrorIs(t, err, errcode.ErrTooManyStreams)
+
+		cancel()
+		_, cancel, err = svc.Subscribe(ctx, 1, "")
+		require.NoError(t, err)
+		cancel()
+	})
+
+	t.Run("異常系: Last-Event-ID の形式が正しくない", func(t *testing.T) {
+		svc := NewStreamService(new(mockStreamRepository), 1)
+		_, _, err := svc.Subscribe(context.Background(), 1, "abc")
+		assert.ErrorIs(t, err, errcode.ErrInvalidLastEventID)
+	})
+
+	t.Run("正常系: 読み取りが追いつかない接続は切断する", func(t *testing.T) {
+		svc := NewStreamService(new(mockStreamRepository), 1)
+		events, cancel, err := svc.Subscribe(context.Background(), 1, "")
+		require.NoError(t, err)
+		defer cancel()
+
+		// 出力側が受け取らないため、1件は送信待ち、残りがバッファを埋める
+		for i := 0; i < streamBufferSize+2; i++ {
+			svc.dispatch(&dto.StreamEventRecord{ID: "1-0", UserIDs: []int64{1}})
+		}
+
+		closed := false
+		timeout := time.After(time.Second)
+		for !closed {
+			select {
+			case _, ok := <-events:
+				closed = !ok
+			case <-timeout:
+				t.Fatal("接続が切断されません")
+			}
+		}
+		_, cancel2, err := svc.Subscribe(context.Background(), 1, "")
+		require.NoError(t, err)
+		cancel2()
+	})
+}
+
+func TestStreamPublishTimeline(t *testing.T) {
+	userIDs := make([]int64, streamPublishBatchSize+1)
+	for i := range userIDs {
+		userIDs[i] = int64(i + 1)
+	}
+
+	mr := new(mockStreamRepository)
+	mr.On("Publish", mock.Anything, mock.MatchedBy(func(r *dto.StreamEventRecord) bool {
+		return r.Type == models.StreamEventTimeline && r.TweetID == 9 && len(r.UserIDs) == streamPublishBatchSize
+	})).Return(nil).Once()
+	mr.On("Publish", mock.Anything, mock.MatchedBy(func(r *dto.StreamEventRecord) bool {
+		return len(r.UserIDs) == 1 && r.UserIDs[0] == int64(streamPublishBatchSize+1)
+	})).Return(nil).Once()
+	svc := NewStreamService(mr, 1)
+
+	require.NoError(t, svc.PublishTimeline(context.Background(), 9, userIDs))
+	mr.AssertExpectations(t)
+}
+
+func TestStreamRun(t *testing.T) {
+	feed := make(chan *dto.StreamEventRecord, 1)
+	mr := new(mockStreamRepository)
+	mr.On("Subscribe", mock.Anything).Return((<-chan *dto.StreamEventRecord)(feed), func() error { return nil }, nil)
+	svc := NewStreamService(mr, 1)
+
+	events, cancel, err := svc.Subscribe(context.Background(), 7, "")
+	require.NoError(t, err)
+	defer cancel()
+
+	ctx, stop := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Run(ctx)
+		close(done)
+	}()
+
+	feed <- &dto.StreamEventRecord{ID: "1-0", Type: models.StreamEventNotification, UserIDs: []int64{7}}
+	assert.Equal(t, []string{"1-0"}, receiveIDs(t, events, 1))
+
+	// 停止時には接続中のストリームも閉じる
+	stop()
+	<-done
+	_, ok := <-events
+	assert.False(t, ok)
+}
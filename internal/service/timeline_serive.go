@@ -25,17 +25,23 @@ type TweetProvider interface{
 	GetMyTweets(ctx context.Context, userID int64, page, size int) ([]*dto.TweetRecord, error)
 }
 
+type ViewRecorder interface {
+	RecordViews(ctx context.Context, viewer string, tweetIDs []int64)
+}
+
 type timeLineService struct {
 	timeLineRepository TimeLineRepository
 	tweetProvider      TweetProvider
+	viewRecorder       ViewRecorder
 	sf                 *singleflight.Group
 	pool               *ants.Pool
 } 
 
-func NewTimeLineService(r TimeLineRepository, t TweetProvider, p *ants.Pool) *timeLineService {
+func NewTimeLineService(r TimeLineRepository, t TweetProvider, vr ViewRecorder, p *ants.Pool) *timeLineService {
 	return &timeLineService{
 		timeLineRepository: r,
 		tweetProvider: t,
+		viewRecorder: vr,
 		sf: &singleflight.Group{},
 		pool: p,
 	}
@@ -145,6 +151,12 @@ func (s *timeLineService) GetHomeTimeLine(ctx context.Context, userID int64, pag
 
 	finalResults := append(records, additionalTweets...)
 
+	viewedIDs := make([]int64, len(finalResults))
+	for i, t := range finalResults {
+		viewedIDs[i] = t.ID
+	}
+	s.viewRecorder.RecordViews(ctx, dto.ViewerKey(userID, ""), viewedIDs)
+
 	return finalResults, nil
 }
 
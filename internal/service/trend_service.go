@@ -0,0 +1,191 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// URL のフラグメントや HTML の文字参照を拾わないよう、直前が英数字でない場合のみハッシュタグとみなす
+var hashtagPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_&#＃])[#＃]([\p{L}\p{N}_]{1,50})`)
+
+type TrendRepository interface {
+	IncrHashtags(ctx context.Context, bucket int64, hashtags []string, ttl time.Duration) error
+	SumBuckets(ctx context.Context, buckets []int64) (map[string]int64, error)
+	SaveTop(ctx context.Context, records []*dto.TrendRecord, ttl time.Duration) error
+	GetTop(ctx context.Context) ([]*dto.TrendRecord, error)
+}
+
+type TrendUserProvider interface {
+	ToMyAccount(ctx context.Context, userID int64) (*dto.UserRecord, error)
+}
+
+type trendService struct {
+	trendRepository TrendRepository
+	tweetProvider   TweetProvider
+	userProvider    TrendUserProvider
+	minAccountAge   time.Duration
+	now             func() time.Time
+}
+
+func NewTrendService(tr TrendRepository, tp TweetProvider, up TrendUserProvider, minAccountAge time.Duration) *trendService {
+	return &trendService{
+		trendRepository: tr,
+		tweetProvider:   tp,
+		userProvider:    up,
+		minAccountAge:   minAccountAge,
+		now:             time.Now,
+	}
+}
+
+// ツイートのハッシュタグを投稿時刻の枠に数える。
+// 作成直後のアカウントによるスパムでトレンドが作られないよう、若いアカウントの投稿は数えない
+func (s *trendService) RecordTweet(ctx context.Context, tweetID int64) error {
+	tweets, err := s.tweetProvider.GetTweets(ctx, []int64{tweetID})
+	if err != nil {
+		return err
+	}
+	if len(tweets) == 0 {
+		return nil
+	}
+	tweet := tweets[0]
+
+	hashtags := extractHashtags(tweet.Content)
+	if len(hashtags) == 0 {
+		return nil
+	}
+
+	now := s.now()
+	bucket := trendBucket(tweet.CreatedAt)
+	if bucket <= trendBucket(now)-trendRecentBuckets-trendBaselineBuckets {
+		return nil
+	}
+
+	author, err := s.userProvider.ToMyAccount(ctx, tweet.UserID)
+	if err != nil {
+		return fmt.Errorf("RecordTweet: 投稿者の取得に失敗しました (tweet_id: %d): %w", tweetID, err)
+	}
+	if now.Sub(author.CreatedAt) < s.minAccountAge {
+		slog.Debug("作成直後のアカウントのためトレンドに数えません", "tweet_id", tweetID, "user_id", tweet.UserID)
+		return nil
+	}
+
+	return s.trendRepository.IncrHashtags(ctx, bucket, hashtags, trendBucketTTL)
+}
+
+// 直近の出現数を過去の平均と比べて伸びの大きい順に並べ、上位を保存する
+func (s *trendService) RefreshTrends(ctx context.Context) (int, error) {
+	current := trendBucket(s.now())
+
+	recent := make([]int64, trendRecentBuckets)
+	for i := range recent {
+		recent[i] = current - int64(i)
+	}
+	baseline := make([]int64, trendBaselineBuckets)
+	for i := range baseline {
+		baseline[i] = current - trendRecentBuckets - int64(i)
+	}
+
+	recentCounts, err := s.trendRepository.SumBuckets(ctx, recent)
+	if err != nil {
+		return 0, fmt.Errorf("RefreshTrends: 直近の集計の取得に失敗しました: %w", err)
+	}
+	baselineCounts, err := s.trendRepository.SumBuckets(ctx, baseline)
+	if err != nil {
+		return 0, fmt.Errorf("RefreshTrends: 過去の集計の取得に失敗しました: %w", err)
+	}
+
+	trends := scoreTrends(recentCounts, baselineCounts)
+	if err := s.trendRepository.SaveTop(ctx, trends, trendTopTTL); err != nil {
+		return 0, fmt.Errorf("RefreshTrends: トレンドの保存に失敗しました: %w", err)
+	}
+	return len(trends), nil
+}
+
+func (s *trendService) GetTrends(ctx context.Context, limit int) ([]*dto.TrendRecord, error) {
+	if limit <= 0 {
+		limit = defaultTrendLimit
+	}
+	limit = min(limit, maxTrendTopN)
+
+	trends, err := s.trendRepository.GetTop(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetTrends: トレンドの取得に失敗しました: %w", err)
+	}
+	if len(trends) > limit {
+		trends = trends[:limit]
+	}
+	return trends, nil
+}
+
+// 過去の平均から期待される出現数との差を、期待値の平方根で割ったものをスコアにする。
+// 普段から多いハッシュタグより、急に増えたハッシュタグが上位になる
+func scoreTrends(recent, baseline map[string]int64) []*dto.TrendRecord {
+	trends := make([]*dto.TrendRecord, 0, len(recent))
+	for tag, count := range recent {
+		if count < minTrendTweetCount {
+			continue
+		}
+		expected := float64(baseline[tag]) * trendRecentBuckets / trendBaselineBuckets
+		score := (float64(count) - expected) / math.Sqrt(expected+1)
+		if score <= 0 {
+			continue
+		}
+		trends = append(trends, &dto.TrendRecord{Hashtag: tag, TweetCount: count, Score: score})
+	}
+
+	slices.SortFunc(trends, func(a, b *dto.TrendRecord) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.TweetCount, a.TweetCount); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Hashtag, b.Hashtag)
+	})
+
+	if len(trends) > maxTrendTopN {
+		trends = trends[:maxTrendTopN]
+	}
+	return trends
+}
+
+func trendBucket(t time.Time) int64 {
+	return t.Unix() / int64(trendBucketSize/time.Second)
+}
+
+// 大文字小文字は区別せず、同じツイート内の重複と数字だけのタグは除く
+func extractHashtags(content string) []string {
+	matches := hashtagPattern.FindAllStringSubmatch(content, -1)
+	hashtags := make([]string, 0, len(matches))
+	seen := make(map[string]bool, len(matches))
+	for _, m := range matches {
+		tag := strings.ToLower(m[1])
+		if seen[tag] || isAllDigits(tag) {
+			continue
+		}
+		seen[tag] = true
+		hashtags = append(hashtags, tag)
+		if len(hashtags) == maxHashtagsPerTweet {
+			break
+		}
+	}
+	return hashtags
+}
+
+func isAllDigits(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"aita/internal/dto"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractHashtags(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{name: "英数字と日本語", content: "#Go と #ゴーファー の話", want: []string{"go", "ゴーファー"}},
+		{name: "全角の＃", content: "＃東京 に来た", want: []string{"東京"}},
+		{name: "大文字小文字の重複は1件", content: "#Go #go #GO", want: []string{"go"}},
+		{name: "数字だけのタグは除く", content: "#1 #2024 #go2024", want: []string{"go2024"}},
+		{name: "URL のフラグメントは拾わない", content: "https://example.com/page#section と a#b", want: []string{}},
+		{name: "ハッシュタグなし", content: "こんにちは", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, extractHashtags(tt.content))
+		})
+	}
+}
+
+func TestRecordTweet(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tweet := &dto.TweetRecord{ID: 1, UserID: 5, Content: "#Go を始めた", CreatedAt: now.Add(-time.Minute)}
+
+	tests := []struct {
+		name      string
+		tweet     *dto.TweetRecord
+		setupMock func(mr *mockTrendRepository, mu *mockTrendUserProvider)
+		wantCount bool
+	}{
+		{
+			name:  "正常系: 投稿時刻の枠に数える",
+			tweet: tweet,
+			setupMock: func(mr *mockTrendRepository, mu *mockTrendUserProvider) {
+				mu.On("ToMyAccount", mock.Anything, int64(5)).Return(&dto.UserRecord{ID: 5, CreatedAt: now.Add(-30 * 24 * time.Hour)}, nil)
+				mr.On("IncrHashtags", mock.Anything, trendBucket(tweet.CreatedAt), []string{"go"}, trendBucketTTL).Return(nil)
+			},
+			wantCount: true,
+		},
+		{
+			name:  "作成直後のアカウントは数えない",
+			tweet: tweet,
+			setupMock: func(mr *mockTrendRepository, mu *mockTrendUserProvider) {
+				mu.On("ToMyAccount", mock.Anything, int64(5)).Return(&dto.UserRecord{ID: 5, CreatedAt: now.Add(-time.Hour)}, nil)
+			},
+		},
+		{
+			name:      "ハッシュタグがなければ投稿者を取得しない",
+			tweet:     &dto.TweetRecord{ID: 1, UserID: 5, Content: "こんにちは", CreatedAt: now},
+			setupMock: func(mr *mockTrendRepository, mu *mockTrendUserProvider) {},
+		},
+		{
+			name:      "集計期間より古いツイートは数えない",
+			tweet:     &dto.TweetRecord{ID: 1, UserID: 5, Content: "#Go", CreatedAt: now.Add(-48 * time.Hour)},
+			setupMock: func(mr *mockTrendRepository, mu *mockTrendUserProvider) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := new(mockTrendRepository)
+			mu := new(mockTrendUserProvider)
+			mp := new(mockTweetProvider)
+			mp.On("GetTweets", mock.Anything, []int64{1}).Return([]*dto.TweetRecord{tt.tweet}, nil)
+			tt.setupMock(mr, mu)
+			svc := NewTrendService(mr, mp, mu, 72*time.Hour)
+			svc.now = func() time.Time { return now }
+
+			require.NoError(t, svc.RecordTweet(context.Background(), 1))
+			if !tt.wantCount {
+				mr.AssertNotCalled(t, "IncrHashtags", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			mr.AssertExpectations(t)
+			mu.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScoreTrends(t *testing.T) {
+	// 過去24時間の出現数は、1時間あたりに直すと1/24になる
+	recent := map[string]int64{
+		"spike":  20,
+		"rising": 30,
+		"steady": 50,
+		"rare":   3,
+		"fading": 10,
+	}
+	baseline := map[string]int64{
+		"spike":  24,
+		"rising": 240,
+		"steady": 1200,
+		"fading": 2400,
+	}
+
+	trends := scoreTrends(recent, baseline)
+
+	require.Len(t, trends, 2)
+	assert.Equal(t, "spike", trends[0].Hashtag)
+	assert.Equal(t, "rising", trends[1].Hashtag)
+	assert.Equal(t, int64(20), trends[0].TweetCount)
+}
+
+func TestRefreshTrends(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	current := trendBucket(now)
+
+	mr := new(mockTrendRepository)
+	mr.On("SumBuckets", mock.Anything, mock.MatchedBy(func(b []int64) bool {
+		return len(b) == trendRecentBuckets && b[0] == current
+	})).Return(map[string]int64{"go": 10}, nil)
+	mr.On("SumBuckets", mock.Anything, mock.MatchedBy(func(b []int64) bool {
+		return len(b) == trendBaselineBuckets && b[0] == current-trendRecentBuckets
+	})).Return(map[string]int64{}, nil)
+	mr.On("SaveTop", mock.Anything, mock.MatchedBy(func(r []*dto.TrendRecord) bool {
+		return len(r) == 1 && r[0].Hashtag == "go"
+	}), trendTopTTL).Return(nil)
+	svc := NewTrendService(mr, new(mockTweetProvider), new(mockTrendUserProvider), 0)
+	svc.now = func() time.Time { return now }
+
+	count, err := svc.RefreshTrends(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	mr.AssertExpectations(t)
+}
+
+func TestGetTrends(t *testing.T) {
+	top := make([]*dto.TrendRecord, 15)
+	for i := range top {
+		top[i] = &dto.TrendRecord{Hashtag: "tag"}
+	}
+	mr := new(mockTrendRepository)
+	mr.On("GetTop", mock.Anything).Return(top, nil)
+	svc := NewTrendService(mr, new(mockTweetProvider), new(mockTrendUserProvider), 0)
+
+	trends, err := svc.GetTrends(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Len(t, trends, defaultTrendLimit)
+}
@@ -7,15 +7,23 @@ import (
 	"fmt"
 	"log/slog"
 	"time"
+	"unicode/utf8"
 )
 
 type TweetRepository interface {
 	Create(ctx context.Context, record *dto.TweetRecord) (*dto.TweetRecord, error) 
+	Insert(ctx context.Context, record *dto.TweetRecord) (*dto.TweetRecord, error)
+	Cache(record *dto.TweetRecord)
 	Get(ctx context.Context, tweetID int64) (*dto.TweetRecord, error) 
-	Update(ctx context.Context, newContent string, tweetID int64) (*dto.TweetRecord, error) 
+	Update(ctx context.Context, newContent string, tweetID int64, policy dto.EditPolicy) (*dto.TweetRecord, error) 
 	Delete(ctx context.Context, tweetID int64) error 
 	MultiGet(ctx context.Context, tweetIDs []int64) ([]*dto.TweetRecord, error)
 	GetTweetsByAuthor(ctx context.Context, userID int64, page, size int) ([]int64, error)
+	GetTweetIDsByAuthors(ctx context.Context, authorIDs []int64, cursor int64, limit int) ([]int64, error)
+	GetRevisions(ctx context.Context, tweetID int64) ([]*dto.TweetRevisionRecord, error)
+	GetDeleted(ctx context.Context, tweetID int64) (*dto.TweetRecord, error)
+	Restore(ctx context.Context, tweetID int64, gracePeriod time.Duration) (*dto.TweetRecord, error)
+	Purge(ctx context.Context, retention time.Duration, limit int) (int64, error)
 }
 
 type MessageSender interface {
@@ -25,16 +33,20 @@ type MessageSender interface {
 type tweetService struct {
 	tweetRepository TweetRepository
 	messageSender 	MessageSender
+	editPolicy      dto.EditPolicy
+	deletionPolicy  dto.DeletionPolicy
 }
 
-func NewTweetService(tr TweetRepository, m MessageSender) *tweetService {
+func NewTweetService(tr TweetRepository, m MessageSender, ep dto.EditPolicy, dp dto.DeletionPolicy) *tweetService {
 	return &tweetService{
 		tweetRepository: tr,
 		messageSender: m,
+		editPolicy: ep,
+		deletionPolicy: dp,
 	}
 }
 
-func (s *tweetService) PostTweet(ctx context.Context, userID int64, content string, imageURL *string) (*dto.TweetRecord, error) {
+func (s *tweetService) PostTweet(ctx context.Context, userID int64, content string, media []dto.MediaAttachment, poll *dto.PollInput) (*dto.TweetRecord, error) {
 	if userID <= 0 {
 		return nil, errcode.ErrInvalidUserID
 	}
@@ -43,10 +55,22 @@ func (s *tweetService) PostTweet(ctx context.Context, userID int64, content stri
         return nil, errcode.ErrRequiredFieldMissing
     }
 
-	initialTweet := &dto.TweetRecord{
-		UserID:   userID,
-		Content:  content,
-		ImageURL: imageURL,
+	if err := validateMediaAttachments(media); err != nil {
+		return nil, err
+	}
+	if err := validatePollInput(poll); err != nil {
+		return nil, err
+	}
+
+	initialTweet := newTweetRecord(userID, content, media)
+	if poll != nil {
+		initialTweet.Poll = &dto.PollRecord{ClosesAt: time.Now().UTC().Add(poll.Duration)}
+		for i, label := range poll.Options {
+			initialTweet.Poll.Options = append(initialTweet.Poll.Options, dto.PollOptionRecord{
+				Position: i,
+				Label:    label,
+			})
+		}
 	}
 	
 	savedTweet, err := s.tweetRepository.Create(ctx, initialTweet)
@@ -62,9 +86,46 @@ func (s *tweetService) PostTweet(ctx context.Context, userID int64, content stri
 		dto.ActionCreate,
 	)
 
+	savedTweet.ApplyEditPolicy(s.editPolicy)
+	return savedTweet, nil
+}
+
+// 呼び出し側のトランザクション内で使う。コミットまではキャッシュ登録も配信もしないため、コミット後に AnnounceTweet を呼ぶこと
+func (s *tweetService) InsertTweet(ctx context.Context, userID int64, content string, media []dto.MediaAttachment) (*dto.TweetRecord, error) {
+	if userID <= 0 {
+		return nil, errcode.ErrInvalidUserID
+	}
+
+	if content == "" {
+		return nil, errcode.ErrRequiredFieldMissing
+	}
+
+	if err := validateMediaAttachments(media); err != nil {
+		return nil, err
+	}
+
+	savedTweet, err := s.tweetRepository.Insert(ctx, newTweetRecord(userID, content, media))
+	if err != nil {
+		return nil, fmt.Errorf("ツイートの挿入に失敗しました: %w", err)
+	}
+
 	return savedTweet, nil
 }
 
+func (s *tweetService) AnnounceTweet(ctx context.Context, tweet *dto.TweetRecord) {
+	s.tweetRepository.Cache(tweet)
+
+	_ = s.messageSender.AsyncToMQ(
+		ctx,
+		tweet.ID,
+		tweet.UserID,
+		tweet.CreatedAt,
+		dto.ActionCreate,
+	)
+
+	tweet.ApplyEditPolicy(s.editPolicy)
+}
+
 func (s *tweetService) FetchTweet(ctx context.Context, tweetID int64) (*dto.TweetRecord, error) {
 	if tweetID <= 0 {
 		return nil, errcode.ErrInvalidTweetID
@@ -76,9 +137,31 @@ func (s *tweetService) FetchTweet(ctx context.Context, tweetID int64) (*dto.Twee
 		return nil, fmt.Errorf("ツイート情報の取得に失敗しました: %w", err)
 	}
 
+	tweet.ApplyEditPolicy(s.editPolicy)
 	return tweet, nil
 }
 
+// 未編集のツイートはリビジョンを持たないため、現在の本文を版0として返す
+func (s *tweetService) FetchHistory(ctx context.Context, tweetID int64) ([]*dto.TweetRevisionRecord, error) {
+	tweet, err := s.FetchTweet(ctx, tweetID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.tweetRepository.GetRevisions(ctx, tweetID)
+	if err != nil {
+		return nil, fmt.Errorf("編集履歴の取得に失敗しました: %w", err)
+	}
+
+	if len(revisions) == 0 {
+		return []*dto.TweetRevisionRecord{
+			{Revision: 0, Content: tweet.Content, CreatedAt: tweet.CreatedAt},
+		}, nil
+	}
+
+	return revisions, nil
+}
+
 func (s *tweetService) ToMyTweet(ctx context.Context, tweetID int64, userID int64) (*dto.TweetRecord, error) {
 	if userID <= 0 {
 		return nil, errcode.ErrInvalidUserID
@@ -90,8 +173,8 @@ func (s *tweetService) ToMyTweet(ctx context.Context, tweetID int64, userID int6
 	}
 
 	if tweet.UserID != userID {
-		slog.Info("権限外のアクセスですが、メタデータは保持します", "tweet_id", tweet.ID)
-		return tweet, errcode.ErrForbidden
+		slog.Info("権限外のアクセスです", "tweet_id", tweet.ID, "user_id", userID)
+		return nil, errcode.ErrForbidden
 	}
 
 	return tweet, nil
@@ -108,9 +191,6 @@ func (s *tweetService) EditTweet(ctx context.Context, newContent string, tweetID
 	}
 	tweet, err := s.ToMyTweet(ctx, tweetID, userID)
 	if err != nil {
-		if err == errcode.ErrForbidden{
-			return tweet, true, nil
-		}
 		return nil, false, err
 	}
 
@@ -118,16 +198,21 @@ func (s *tweetService) EditTweet(ctx context.Context, newContent string, tweetID
 		return tweet, false, nil
 	}
 
-	if !tweet.CanBeUpdated() {
+	// 早期判定。最終的な判定はストア側の UPDATE で行う
+	if tweet.HasReachedEditLimit(s.editPolicy) {
+		return nil, false, errcode.ErrEditLimitExceeded
+	}
+	if tweet.IsEditWindowExpired(s.editPolicy) {
 		return nil, false, errcode.ErrEditTimeExpired
 	}
 
-	tweet, err = s.tweetRepository.Update(ctx, newContent, tweetID)
+	tweet, err = s.tweetRepository.Update(ctx, newContent, tweetID, s.editPolicy)
 
 	if err != nil {
 		return nil, false, fmt.Errorf("ツイート編集に失敗しました: %w", err)
 	}
 
+	tweet.ApplyEditPolicy(s.editPolicy)
 	return tweet, true, nil
 }
 
@@ -137,10 +222,6 @@ func (s *tweetService) RemoveTweet(ctx context.Context, tweetID int64, userID in
 	}
 	deletedTweet, err := s.ToMyTweet(ctx, tweetID, userID)
 	if err != nil {
-		if err == errcode.ErrForbidden {
-			return nil
-		}
-
 		return err
 	}
 
@@ -162,6 +243,54 @@ func (s *tweetService) RemoveTweet(ctx context.Context, tweetID int64, userID in
 }
 
 
+func (s *tweetService) RestoreTweet(ctx context.Context, tweetID int64, userID int64) (*dto.TweetRecord, error) {
+	if userID <= 0 {
+		return nil, errcode.ErrInvalidUserID
+	}
+
+	if tweetID <= 0 {
+		return nil, errcode.ErrInvalidTweetID
+	}
+
+	deletedTweet, err := s.tweetRepository.GetDeleted(ctx, tweetID)
+	if err != nil {
+		return nil, fmt.Errorf("削除済みツイートの取得に失敗しました: %w", err)
+	}
+
+	if deletedTweet.UserID != userID {
+		return nil, errcode.ErrForbidden
+	}
+
+	if deletedTweet.DeletedAt == nil || time.Since(*deletedTweet.DeletedAt) > s.deletionPolicy.GracePeriod {
+		return nil, errcode.ErrRestorePeriodExpired
+	}
+
+	restoredTweet, err := s.tweetRepository.Restore(ctx, tweetID, s.deletionPolicy.GracePeriod)
+	if err != nil {
+		return nil, fmt.Errorf("ツイートの復元に失敗しました: %w", err)
+	}
+
+	_ = s.messageSender.AsyncToMQ(
+		ctx,
+		restoredTweet.ID,
+		restoredTweet.UserID,
+		restoredTweet.CreatedAt,
+		dto.ActionCreate,
+	)
+
+	restoredTweet.ApplyEditPolicy(s.editPolicy)
+	return restoredTweet, nil
+}
+
+func (s *tweetService) PurgeDeletedTweets(ctx context.Context, limit int) (int64, error) {
+	purged, err := s.tweetRepository.Purge(ctx, s.deletionPolicy.Retention, limit)
+	if err != nil {
+		return 0, fmt.Errorf("PurgeDeletedTweets: 削除済みツイートの物理削除に失敗しました: %w", err)
+	}
+
+	return purged, nil
+}
+
 func (s *tweetService) GetTweets(ctx context.Context, tweetIDs []int64) ([]*dto.TweetRecord, error) {
     if len(tweetIDs) == 0 {
         return []*dto.TweetRecord{}, nil
@@ -172,6 +301,7 @@ func (s *tweetService) GetTweets(ctx context.Context, tweetIDs []int64) ([]*dto.
         return nil, fmt.Errorf("TimeLineService.GetTweets: ツイートリストの一括取得に失敗しました: %w", err)
     }
 
+    s.applyEditPolicy(tweets)
     return tweets, nil
 }
 
@@ -202,5 +332,80 @@ func (s *tweetService) GetMyTweets(ctx context.Context, userID int64, page, size
             userID, len(ids), err)
     }
 
+    s.applyEditPolicy(tweets)
     return tweets, nil
-}
\ No newline at end of file
+}
+
+// 複数の投稿者のツイートを読み込み時に集めて新しい順に返す。次のページがなければ nextCursor は 0
+func (s *tweetService) GetTweetsByAuthors(ctx context.Context, authorIDs []int64, cursor int64, limit int) ([]*dto.TweetRecord, int64, error) {
+	if len(authorIDs) == 0 {
+		return []*dto.TweetRecord{}, 0, nil
+	}
+
+	ids, err := s.tweetRepository.GetTweetIDsByAuthors(ctx, authorIDs, cursor, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("GetTweetsByAuthors: ツイートIDの取得に失敗しました (authors: %d): %w", len(authorIDs), err)
+	}
+	if len(ids) == 0 {
+		return []*dto.TweetRecord{}, 0, nil
+	}
+
+	var nextCursor int64
+	if len(ids) == limit {
+		nextCursor = ids[len(ids)-1]
+	}
+
+	tweets, err := s.tweetRepository.MultiGet(ctx, ids)
+	if err != nil {
+		return nil, 0, fmt.Errorf("GetTweetsByAuthors: 投稿内容のバルク変換に失敗しました (count: %d): %w", len(ids), err)
+	}
+
+	s.applyEditPolicy(tweets)
+	return tweets, nextCursor, nil
+}
+
+func (s *tweetService) applyEditPolicy(tweets []*dto.TweetRecord) {
+	for _, t := range tweets {
+		t.ApplyEditPolicy(s.editPolicy)
+	}
+}
+
+func validatePollInput(poll *dto.PollInput) error {
+	if poll == nil {
+		return nil
+	}
+	if len(poll.Options) < minPollOptions || len(poll.Options) > maxPollOptions {
+		return errcode.ErrInvalidPoll
+	}
+	if poll.Duration < minPollDuration || poll.Duration > maxPollDuration {
+		return errcode.ErrInvalidPoll
+	}
+
+	seen := make(map[string]struct{}, len(poll.Options))
+	for _, label := range poll.Options {
+		if label == "" || utf8.RuneCountInString(label) > maxPollOptionLength {
+			return errcode.ErrInvalidPoll
+		}
+		if _, ok := seen[label]; ok {
+			return errcode.ErrInvalidPoll
+		}
+		seen[label] = struct{}{}
+	}
+	return nil
+}
+
+func newTweetRecord(userID int64, content string, media []dto.MediaAttachment) *dto.TweetRecord {
+	record := &dto.TweetRecord{
+		UserID:  userID,
+		Content: content,
+	}
+	for i, m := range media {
+		record.Media = append(record.Media, dto.TweetMediaRecord{
+			MediaID:  m.MediaID,
+			Position: i,
+			AltText:  m.AltText,
+		})
+	}
+
+	return record
+}
@@ -17,12 +17,15 @@ import (
 
 func TestPostTweet(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	imageURL := utils.StringPtr("https://example.com/mock.jpg")
+	attachments := []dto.MediaAttachment{{MediaID: 7, AltText: "猫"}, {MediaID: 8}}
+	mediaURL := utils.StringPtr("/media/101/mock.jpg")
 	fixedTime := time.Now().UTC()
 	tests := []struct {
 		name      string
 		userID    int64
 		inputBody *app.CreateTweetRequest
+		media     []dto.MediaAttachment
+		poll      *dto.PollInput
 		setupMock func(mt *mockTweetRepository, mm *mockMessageSender)
 		wantedErr error
 		errMsg    string
@@ -31,20 +34,25 @@ func TestPostTweet(t *testing.T) {
 			name:   "【正常系】ツイート投稿成功",
 			userID: 101,
 			inputBody: &app.CreateTweetRequest{
-				Content:  "Hello world",
-				ImageURL: imageURL,
+				Content: "Hello world",
 			},
+			media: attachments,
 			setupMock: func(mt *mockTweetRepository, mm *mockMessageSender) {
 				expectedTweet := &dto.TweetRecord{
 					ID:        1,
 					UserID:    101,
 					Content:   "Hello world",
-					ImageURL:  imageURL,
+					ImageURL:  mediaURL,
+					Media: []dto.TweetMediaRecord{
+						{MediaID: 7, Position: 0, AltText: "猫", URL: *mediaURL},
+						{MediaID: 8, Position: 1},
+					},
 					CreatedAt: fixedTime,
 					UpdatedAt: fixedTime,
 				}
 				mt.On("Create", mock.Anything, mock.MatchedBy(func(t *dto.TweetRecord) bool {
-					return t.UserID == 101 && t.Content == "Hello world" && t.ImageURL == imageURL
+					return t.UserID == 101 && t.Content == "Hello world" &&
+						len(t.Media) == 2 && t.Media[0].AltText == "猫" && t.Media[1].MediaID == 8 && t.Media[1].Position == 1
 				})).Return(expectedTweet, nil)
 				mm.On("AsyncToMQ", 
                     mock.Anything, 
@@ -74,13 +82,80 @@ func TestPostTweet(t *testing.T) {
 			setupMock: func(mt *mockTweetRepository, mm *mockMessageSender) {},
 			wantedErr: errcode.ErrRequiredFieldMissing,
 		},
+		{
+			name:   "【異常系】添付メディアが4件を超える",
+			userID: 101,
+			inputBody: &app.CreateTweetRequest{
+				Content: "Hello world",
+			},
+			media:     []dto.MediaAttachment{{MediaID: 1}, {MediaID: 2}, {MediaID: 3}, {MediaID: 4}, {MediaID: 5}},
+			setupMock: func(mt *mockTweetRepository, mm *mockMessageSender) {},
+			wantedErr: errcode.ErrInvalidMediaAttachment,
+		},
+		{
+			name:   "【異常系】同じメディアを重複して添付",
+			userID: 101,
+			inputBody: &app.CreateTweetRequest{
+				Content: "Hello world",
+			},
+			media:     []dto.MediaAttachment{{MediaID: 1}, {MediaID: 1}},
+			setupMock: func(mt *mockTweetRepository, mm *mockMessageSender) {},
+			wantedErr: errcode.ErrInvalidMediaAttachment,
+		},
+		{
+			name:   "【正常系】投票付きツイート投稿成功",
+			userID: 101,
+			inputBody: &app.CreateTweetRequest{
+				Content: "どれが好き?",
+			},
+			poll: &dto.PollInput{Options: []string{"犬", "猫", "鳥"}, Duration: time.Hour},
+			setupMock: func(mt *mockTweetRepository, mm *mockMessageSender) {
+				mt.On("Create", mock.Anything, mock.MatchedBy(func(t *dto.TweetRecord) bool {
+					return t.Poll != nil && len(t.Poll.Options) == 3 &&
+						t.Poll.Options[1].Label == "猫" && t.Poll.Options[1].Position == 1 &&
+						t.Poll.ClosesAt.After(time.Now().Add(59*time.Minute))
+				})).Return(&dto.TweetRecord{ID: 2, UserID: 101, Content: "どれが好き?", CreatedAt: fixedTime}, nil)
+				mm.On("AsyncToMQ", mock.Anything, int64(2), int64(101), fixedTime, dto.ActionCreate).Return(nil)
+			},
+			wantedErr: nil,
+		},
+		{
+			name:   "【異常系】投票の選択肢が1件",
+			userID: 101,
+			inputBody: &app.CreateTweetRequest{
+				Content: "Hello world",
+			},
+			poll:      &dto.PollInput{Options: []string{"はい"}, Duration: time.Hour},
+			setupMock: func(mt *mockTweetRepository, mm *mockMessageSender) {},
+			wantedErr: errcode.ErrInvalidPoll,
+		},
+		{
+			name:   "【異常系】投票の期間が7日を超える",
+			userID: 101,
+			inputBody: &app.CreateTweetRequest{
+				Content: "Hello world",
+			},
+			poll:      &dto.PollInput{Options: []string{"はい", "いいえ"}, Duration: 8 * 24 * time.Hour},
+			setupMock: func(mt *mockTweetRepository, mm *mockMessageSender) {},
+			wantedErr: errcode.ErrInvalidPoll,
+		},
+		{
+			name:   "【異常系】投票の選択肢が重複",
+			userID: 101,
+			inputBody: &app.CreateTweetRequest{
+				Content: "Hello world",
+			},
+			poll:      &dto.PollInput{Options: []string{"はい", "はい"}, Duration: time.Hour},
+			setupMock: func(mt *mockTweetRepository, mm *mockMessageSender) {},
+			wantedErr: errcode.ErrInvalidPoll,
+		},
 		{
 			name:   "【異常系】データベースエラー（挿入失敗）",
 			userID: 99999,
 			inputBody: &app.CreateTweetRequest{
-				Content:  "Hello world",
-				ImageURL: imageURL,
+				Content: "Hello world",
 			},
+			media: attachments,
 			setupMock: func(mt *mockTweetRepository, mm *mockMessageSender) {
 				mt.On("Create", mock.Anything, mock.MatchedBy(func(t *dto.TweetRecord) bool {
 					return t.UserID == 99999 && t.Content == "Hello world"
@@ -96,10 +171,10 @@ func TestPostTweet(t *testing.T) {
 			mt := new(mockTweetRepository)
 			mm := new(mockMessageSender)
 			tt.setupMock(mt, mm)
-			svc := NewTweetService(mt, mm)
+			svc := NewTweetService(mt, mm, testEditPolicy, testDeletionPolicy)
 			ctx := context.Background()
 
-			res, err := svc.PostTweet(ctx, tt.userID, tt.inputBody.Content, tt.inputBody.ImageURL)
+			res, err := svc.PostTweet(ctx, tt.userID, tt.inputBody.Content, tt.media, tt.poll)
 
 			if tt.wantedErr != nil {
 				assert.ErrorIs(t, err, tt.wantedErr, "期待されるエラータイプが一致します")
@@ -111,7 +186,10 @@ func TestPostTweet(t *testing.T) {
 				require.NoError(t, err)
 				require.NotNil(t, res)
 				assert.Equal(t, tt.inputBody.Content, res.Content)
-				assert.Equal(t, tt.inputBody.ImageURL, res.ImageURL)
+				if len(tt.media) > 0 {
+					assert.Equal(t, mediaURL, res.ImageURL)
+					assert.Len(t, res.Media, len(tt.media))
+				}
 				assert.Equal(t, tt.userID, res.UserID)
 				assert.Equal(t, time.UTC, res.CreatedAt.Location())
 				assert.Equal(t, time.UTC, res.UpdatedAt.Location())
@@ -122,6 +200,33 @@ func TestPostTweet(t *testing.T) {
 	}
 }
 
+func TestInsertTweetAndAnnounceTweet(t *testing.T) {
+	fixedTime := time.Now().UTC()
+	saved := &dto.TweetRecord{ID: 1, UserID: 101, Content: "下書きから", CreatedAt: fixedTime, UpdatedAt: fixedTime}
+
+	mt := new(mockTweetRepository)
+	mm := new(mockMessageSender)
+	mt.On("Insert", mock.Anything, mock.MatchedBy(func(tr *dto.TweetRecord) bool {
+		return tr.UserID == 101 && tr.Content == "下書きから"
+	})).Return(saved, nil).Once()
+	svc := NewTweetService(mt, mm, testEditPolicy, testDeletionPolicy)
+	ctx := context.Background()
+
+	res, err := svc.InsertTweet(ctx, 101, "下書きから", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.ID)
+	mt.AssertNotCalled(t, "Cache", mock.Anything)
+	mm.AssertNotCalled(t, "AsyncToMQ", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	mt.On("Cache", saved).Once()
+	mm.On("AsyncToMQ", mock.Anything, int64(1), int64(101), fixedTime, dto.ActionCreate).Return(nil).Once()
+
+	svc.AnnounceTweet(ctx, res)
+
+	mt.AssertExpectations(t)
+	mm.AssertExpectations(t)
+}
+
 func TestFetchTweet(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	tests := []struct {
@@ -167,7 +272,7 @@ func TestFetchTweet(t *testing.T) {
 			mt := new(mockTweetRepository)
 			mm := new(mockMessageSender)
 			tt.setupMock(mt)
-			svc := NewTweetService(mt, mm)
+			svc := NewTweetService(mt, mm, testEditPolicy, testDeletionPolicy)
 			ctx := context.Background()
 			res, err := svc.FetchTweet(ctx, tt.inputTweetID)
 
@@ -191,6 +296,93 @@ func TestFetchTweet(t *testing.T) {
 	}
 }
 
+func TestFetchHistory(t *testing.T) {
+	createdAt := time.Now().UTC().Add(-5 * time.Minute)
+	tests := []struct {
+		name            string
+		inputTweetID    int64
+		setupMock       func(mt *mockTweetRepository)
+		wantedErr       error
+		errMsg          string
+		wantedContents  []string
+	}{
+		{
+			name:         "正常系: 編集履歴を古い順に返す",
+			inputTweetID: 101,
+			setupMock: func(mt *mockTweetRepository) {
+				mt.On("Get", mock.Anything, int64(101)).Return(&dto.TweetRecord{ID: 101, Content: "v2", CreatedAt: createdAt, EditCount: 2}, nil)
+				mt.On("GetRevisions", mock.Anything, int64(101)).Return([]*dto.TweetRevisionRecord{
+					{Revision: 0, Content: "v0", CreatedAt: createdAt},
+					{Revision: 1, Content: "v1", CreatedAt: createdAt.Add(time.Minute)},
+					{Revision: 2, Content: "v2", CreatedAt: createdAt.Add(2 * time.Minute)},
+				}, nil)
+			},
+			wantedContents: []string{"v0", "v1", "v2"},
+		},
+		{
+			name:         "正常系: 未編集のツイートは現在の本文のみを返す",
+			inputTweetID: 101,
+			setupMock: func(mt *mockTweetRepository) {
+				mt.On("Get", mock.Anything, int64(101)).Return(&dto.TweetRecord{ID: 101, Content: "original", CreatedAt: createdAt}, nil)
+				mt.On("GetRevisions", mock.Anything, int64(101)).Return([]*dto.TweetRevisionRecord{}, nil)
+			},
+			wantedContents: []string{"original"},
+		},
+		{
+			name:         "異常系:パラメーターエラー, 無効なツイートID",
+			inputTweetID: 0,
+			setupMock:    func(mt *mockTweetRepository) {},
+			wantedErr:    errcode.ErrInvalidTweetID,
+		},
+		{
+			name:         "異常系：ツイートが存在しない場合",
+			inputTweetID: 101,
+			setupMock: func(mt *mockTweetRepository) {
+				mt.On("Get", mock.Anything, int64(101)).Return(nil, errcode.ErrTweetNotFound)
+			},
+			wantedErr: errcode.ErrTweetNotFound,
+		},
+		{
+			name:         "異常系：履歴の取得に失敗した場合",
+			inputTweetID: 101,
+			setupMock: func(mt *mockTweetRepository) {
+				mt.On("Get", mock.Anything, int64(101)).Return(&dto.TweetRecord{ID: 101, Content: "v1", CreatedAt: createdAt}, nil)
+				mt.On("GetRevisions", mock.Anything, int64(101)).Return(nil, errMockInternal)
+			},
+			wantedErr: errMockInternal,
+			errMsg:    "編集履歴の取得に失敗しました",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mt := new(mockTweetRepository)
+			mm := new(mockMessageSender)
+			tt.setupMock(mt)
+			svc := NewTweetService(mt, mm, testEditPolicy, testDeletionPolicy)
+
+			res, err := svc.FetchHistory(context.Background(), tt.inputTweetID)
+
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+				assert.Nil(t, res)
+				if tt.errMsg != "" {
+					assert.Contains(t, err.Error(), tt.errMsg)
+				}
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res, len(tt.wantedContents))
+				for i, r := range res {
+					assert.Equal(t, i, r.Revision)
+					assert.Equal(t, tt.wantedContents[i], r.Content)
+				}
+			}
+
+			mt.AssertExpectations(t)
+		})
+	}
+}
+
 func TestToMyTweet(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	tweetID := int64(101)
@@ -266,7 +458,7 @@ func TestToMyTweet(t *testing.T) {
 			mt := new(mockTweetRepository)
 			mm := new(mockMessageSender)
 			tt.setupMock(mt)
-			svc := NewTweetService(mt, mm)
+			svc := NewTweetService(mt, mm, testEditPolicy, testDeletionPolicy)
 			ctx := context.Background()
 			res, err := svc.ToMyTweet(ctx, tt.inputTweetID, tt.inputUserID)
 
@@ -325,12 +517,12 @@ func TestEditTweet(t *testing.T) {
 					UpdatedAt: time.Now().UTC(),
 					IsEdited: true,
 				}
-				mt.On("Update", mock.Anything, "updated content", int64(101)).Return(updatedTweet, nil)
+				mt.On("Update", mock.Anything, "updated content", int64(101), testEditPolicy).Return(updatedTweet, nil)
 			},
 			wantedErr: nil,
 		},
 		{
-			name:         "異常系：投稿から10分以上経過しているため編集不可",
+			name:         "異常系：編集可能期間を過ぎているため編集不可",
 			inputContent: "too late",
 			inputTweetID: 101,
 			inputUserID:  102,
@@ -345,6 +537,42 @@ func TestEditTweet(t *testing.T) {
 			},
 			wantedErr: errcode.ErrEditTimeExpired,
 		},
+		{
+			name:         "異常系：編集回数の上限に達しているため編集不可",
+			inputContent: "one more",
+			inputTweetID: 101,
+			inputUserID:  102,
+			setupMock: func(mt *mockTweetRepository) {
+				editedTweet := &dto.TweetRecord{
+					ID:        101,
+					UserID:    102,
+					Content:   "content",
+					CreatedAt: time.Now().UTC().Add(-1 * time.Minute),
+					EditCount: testEditPolicy.MaxEdits,
+				}
+				mt.On("Get", mock.Anything, int64(101)).Return(editedTweet, nil)
+			},
+			wantedErr: errcode.ErrEditLimitExceeded,
+		},
+		{
+			name:         "異常系：同時編集によりストア側で上限超過が検出された",
+			inputContent: "race",
+			inputTweetID: 101,
+			inputUserID:  102,
+			setupMock: func(mt *mockTweetRepository) {
+				existingTweet := &dto.TweetRecord{
+					ID:        101,
+					UserID:    102,
+					Content:   "content",
+					CreatedAt: time.Now().UTC().Add(-1 * time.Minute),
+					EditCount: testEditPolicy.MaxEdits - 1,
+				}
+				mt.On("Get", mock.Anything, int64(101)).Return(existingTweet, nil)
+				mt.On("Update", mock.Anything, "race", int64(101), testEditPolicy).Return(nil, errcode.ErrEditLimitExceeded)
+			},
+			wantedErr: errcode.ErrEditLimitExceeded,
+			errMsg:    "ツイート編集に失敗しました",
+		},
 		{
 			name:         "異常系：他のユーザーのツイートは編集不可（権限エラー）",
 			inputContent: "hack",
@@ -390,7 +618,7 @@ func TestEditTweet(t *testing.T) {
 					CreatedAt: time.Now().UTC(),
 				}
 				mt.On("Get", mock.Anything, int64(101)).Return(existingTweet, nil)
-				mt.On("Update", mock.Anything, "new content", int64(101)).Return(nil, errMockInternal)
+				mt.On("Update", mock.Anything, "new content", int64(101), testEditPolicy).Return(nil, errMockInternal)
 			},
 			wantedErr: errMockInternal,
 			errMsg:    "ツイート編集に失敗しました",
@@ -402,7 +630,7 @@ func TestEditTweet(t *testing.T) {
 			mt := new(mockTweetRepository)
 			mm := new(mockMessageSender)
 			tt.setupMock(mt)
-			svc := NewTweetService(mt, mm)
+			svc := NewTweetService(mt, mm, testEditPolicy, testDeletionPolicy)
 
 			res, bool, err := svc.EditTweet(context.Background(), tt.inputContent, tt.inputTweetID, tt.inputUserID)
 
@@ -420,6 +648,11 @@ func TestEditTweet(t *testing.T) {
 				require.NoError(t, err)
 				require.NotNil(t, res)
 				require.Equal(t, tt.inputContent, res.Content)
+				if bool {
+					assert.Greater(t, res.EditWindowRemaining, time.Duration(0))
+					assert.LessOrEqual(t, res.EditWindowRemaining, testEditPolicy.Window)
+					assert.Equal(t, testEditPolicy.MaxEdits-res.EditCount, res.RemainingEdits)
+				}
 			}
 
 			mt.AssertExpectations(t)
@@ -488,7 +721,7 @@ func TestRemoveTweet(t *testing.T) {
 			mt := new(mockTweetRepository)
 			mm := new(mockMessageSender)
 			tt.setupMock(mt, mm)
-			svc := NewTweetService(mt, mm)
+			svc := NewTweetService(mt, mm, testEditPolicy, testDeletionPolicy)
 			ctx := context.Background()
 
 			err := svc.RemoveTweet(ctx, tt.inputTweetID, tt.inputUserID)
@@ -508,3 +741,126 @@ func TestRemoveTweet(t *testing.T) {
 		})
 	}
 }
+
+func TestRestoreTweet(t *testing.T) {
+	createdAt := time.Now().UTC().Add(-1 * time.Hour)
+	recentlyDeleted := time.Now().UTC().Add(-5 * time.Minute)
+	longAgoDeleted := time.Now().UTC().Add(-2 * time.Hour)
+	tests := []struct {
+		name         string
+		inputTweetID int64
+		inputUserID  int64
+		setupMock    func(mt *mockTweetRepository, mm *mockMessageSender)
+		wantedErr    error
+		errMsg       string
+	}{
+		{
+			name:         "正常系: 猶予期間内のツイートが復元され、作成イベントが再送される",
+			inputTweetID: 201,
+			inputUserID:  202,
+			setupMock: func(mt *mockTweetRepository, mm *mockMessageSender) {
+				mt.On("GetDeleted", mock.Anything, int64(201)).Return(&dto.TweetRecord{ID: 201, UserID: 202, CreatedAt: createdAt, DeletedAt: &recentlyDeleted}, nil)
+				mt.On("Restore", mock.Anything, int64(201), testDeletionPolicy.GracePeriod).Return(&dto.TweetRecord{ID: 201, UserID: 202, CreatedAt: createdAt}, nil)
+				mm.On("AsyncToMQ", mock.Anything, int64(201), int64(202), createdAt, dto.ActionCreate).Return(nil)
+			},
+		},
+		{
+			name:         "異常系: 無効なツイートID",
+			inputTweetID: 0,
+			inputUserID:  202,
+			setupMock:    func(mt *mockTweetRepository, mm *mockMessageSender) {},
+			wantedErr:    errcode.ErrInvalidTweetID,
+		},
+		{
+			name:         "異常系: 削除済みツイートが存在しない",
+			inputTweetID: 404,
+			inputUserID:  202,
+			setupMock: func(mt *mockTweetRepository, mm *mockMessageSender) {
+				mt.On("GetDeleted", mock.Anything, int64(404)).Return(nil, errcode.ErrTweetNotFound)
+			},
+			wantedErr: errcode.ErrTweetNotFound,
+			errMsg:    "削除済みツイートの取得に失敗しました",
+		},
+		{
+			name:         "異常系: 他人のツイートは復元不可（権限エラー）",
+			inputTweetID: 201,
+			inputUserID:  999,
+			setupMock: func(mt *mockTweetRepository, mm *mockMessageSender) {
+				mt.On("GetDeleted", mock.Anything, int64(201)).Return(&dto.TweetRecord{ID: 201, UserID: 202, DeletedAt: &recentlyDeleted}, nil)
+			},
+			wantedErr: errcode.ErrForbidden,
+		},
+		{
+			name:         "異常系: 猶予期間を過ぎたツイートは復元不可",
+			inputTweetID: 201,
+			inputUserID:  202,
+			setupMock: func(mt *mockTweetRepository, mm *mockMessageSender) {
+				mt.On("GetDeleted", mock.Anything, int64(201)).Return(&dto.TweetRecord{ID: 201, UserID: 202, DeletedAt: &longAgoDeleted}, nil)
+			},
+			wantedErr: errcode.ErrRestorePeriodExpired,
+		},
+		{
+			name:         "異常系: DBエラーによる復元失敗",
+			inputTweetID: 201,
+			inputUserID:  202,
+			setupMock: func(mt *mockTweetRepository, mm *mockMessageSender) {
+				mt.On("GetDeleted", mock.Anything, int64(201)).Return(&dto.TweetRecord{ID: 201, UserID: 202, DeletedAt: &recentlyDeleted}, nil)
+				mt.On("Restore", mock.Anything, int64(201), testDeletionPolicy.GracePeriod).Return(nil, errMockInternal)
+			},
+			wantedErr: errMockInternal,
+			errMsg:    "ツイートの復元に失敗しました",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mt := new(mockTweetRepository)
+			mm := new(mockMessageSender)
+			tt.setupMock(mt, mm)
+			svc := NewTweetService(mt, mm, testEditPolicy, testDeletionPolicy)
+
+			res, err := svc.RestoreTweet(context.Background(), tt.inputTweetID, tt.inputUserID)
+
+			if tt.wantedErr != nil {
+				assert.ErrorIs(t, err, tt.wantedErr)
+				assert.Nil(t, res)
+				if tt.errMsg != "" {
+					assert.Contains(t, err.Error(), tt.errMsg)
+				}
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				assert.Equal(t, tt.inputTweetID, res.ID)
+				assert.Nil(t, res.DeletedAt)
+			}
+
+			mt.AssertExpectations(t)
+			mm.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetTweetsByAuthors(t *testing.T) {
+	t.Run("正常系: 削除済みで件数が減ってもIDでページが埋まれば次のカーソルを返す", func(t *testing.T) {
+		mt := new(mockTweetRepository)
+		mt.On("GetTweetIDsByAuthors", mock.Anything, []int64{1, 2}, int64(0), 2).Return([]int64{9, 7}, nil)
+		mt.On("MultiGet", mock.Anything, []int64{9, 7}).Return([]*dto.TweetRecord{{ID: 9}}, nil)
+		svc := NewTweetService(mt, new(mockMessageSender), testEditPolicy, testDeletionPolicy)
+
+		tweets, next, err := svc.GetTweetsByAuthors(context.Background(), []int64{1, 2}, 0, 2)
+		require.NoError(t, err)
+		assert.Len(t, tweets, 1)
+		assert.Equal(t, int64(7), next)
+	})
+
+	t.Run("正常系: 投稿者がいなければ取得しない", func(t *testing.T) {
+		mt := new(mockTweetRepository)
+		svc := NewTweetService(mt, new(mockMessageSender), testEditPolicy, testDeletionPolicy)
+
+		tweets, next, err := svc.GetTweetsByAuthors(context.Background(), nil, 0, 20)
+		require.NoError(t, err)
+		assert.Empty(t, tweets)
+		assert.Zero(t, next)
+		mt.AssertNotCalled(t, "GetTweetIDsByAuthors", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -18,6 +18,7 @@ type UserRepository interface {
 	IncreaseFollowing(ctx context.Context, id int64, delta int64) error 
 	Exists(ctx context.Context, id int64) (bool, error)
 	GetBaseInfos(ctx context.Context, userIDs []int64) ([]*dto.UserSlimRecord, error) 
+	GetIDsByUsernames(ctx context.Context, usernames []string) ([]*dto.UserSlimRecord, error)
 }
 
 type PasswordHasher interface {
@@ -152,4 +153,11 @@ func (s *userService) GetInfoLists(ctx context.Context, userIDs []int64) ([]*dto
     }
 	
     return infos, nil
-}
\ No newline at end of file
+}
+func (s *userService) GetIDsByUsernames(ctx context.Context, usernames []string) ([]*dto.UserSlimRecord, error) {
+	if len(usernames) == 0 {
+		return []*dto.UserSlimRecord{}, nil
+	}
+
+	return s.userRepository.GetIDsByUsernames(ctx, usernames)
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/panjf2000/ants/v2"
+)
+
+type ViewRepository interface {
+	Record(ctx context.Context, viewer string, window int64, tweetIDs []int64, ttl time.Duration) (int64, error)
+	Flush(ctx context.Context) (int, error)
+}
+
+type viewService struct {
+	viewRepository ViewRepository
+	pool           *ants.Pool
+	now            func() time.Time
+}
+
+func NewViewService(vr ViewRepository, p *ants.Pool) *viewService {
+	return &viewService{
+		viewRepository: vr,
+		pool:           p,
+		now:            time.Now,
+	}
+}
+
+// 読み込みを遅くしないよう非同期で記録する。閲覧数は概算なので失敗しても呼び出し元には返さない
+func (s *viewService) RecordViews(ctx context.Context, viewer string, tweetIDs []int64) {
+	if viewer == "" || len(tweetIDs) == 0 {
+		return
+	}
+
+	err := s.pool.Submit(func() {
+		bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		s.recordViews(bgCtx, viewer, tweetIDs)
+	})
+	if err != nil {
+		slog.Warn("閲覧記録のタスク投入に失敗しました", "count", len(tweetIDs), "err", err)
+	}
+}
+
+// 同じ閲覧者は時間枠ごとに1回だけ数える
+func (s *viewService) recordViews(ctx context.Context, viewer string, tweetIDs []int64) {
+	ids := make([]int64, 0, len(tweetIDs))
+	seen := make(map[int64]bool, len(tweetIDs))
+	for _, id := range tweetIDs {
+		if id <= 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	window := s.now().Unix() / int64(viewDedupWindow/time.Second)
+	if _, err := s.viewRepository.Record(ctx, viewer, window, ids, viewDedupWindow+time.Hour); err != nil {
+		slog.Warn("閲覧の記録に失敗しました", "count", len(ids), "err", err)
+	}
+}
+
+func (s *viewService) FlushViews(ctx context.Context) (int, error) {
+	return s.viewRepository.Flush(ctx)
+}
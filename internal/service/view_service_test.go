@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRecordViews(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	window := now.Unix() / int64(viewDedupWindow/time.Second)
+
+	tests := []struct {
+		name      string
+		tweetIDs  []int64
+		setupMock func(mr *mockViewRepository)
+	}{
+		{
+			name:     "正常系: 重複と不正なIDを除いて現在の時間枠に記録する",
+			tweetIDs: []int64{3, 1, 3, 0, 2},
+			setupMock: func(mr *mockViewRepository) {
+				mr.On("Record", mock.Anything, "u:7", window, []int64{3, 1, 2}, viewDedupWindow+time.Hour).Return(int64(3), nil)
+			},
+		},
+		{
+			name:     "記録に失敗しても呼び出し元には返さない",
+			tweetIDs: []int64{1},
+			setupMock: func(mr *mockViewRepository) {
+				mr.On("Record", mock.Anything, "u:7", window, []int64{1}, mock.Anything).Return(int64(0), errors.New("redis down"))
+			},
+		},
+		{
+			name:      "有効なIDがなければ記録しない",
+			tweetIDs:  []int64{0, -1},
+			setupMock: func(mr *mockViewRepository) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := new(mockViewRepository)
+			tt.setupMock(mr)
+			svc := NewViewService(mr, nil)
+			svc.now = func() time.Time { return now }
+
+			svc.recordViews(context.Background(), "u:7", tt.tweetIDs)
+			mr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRecordViewsSkipsUnknownViewer(t *testing.T) {
+	mr := new(mockViewRepository)
+	svc := NewViewService(mr, nil)
+
+	svc.RecordViews(context.Background(), "", []int64{1})
+	mr.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
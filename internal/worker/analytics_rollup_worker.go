@@ -0,0 +1,48 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type AnalyticsRollup interface {
+	RollupRecent(ctx context.Context) (int64, error)
+}
+
+type analyticsRollupWorker struct {
+	rollup   AnalyticsRollup
+	interval time.Duration
+}
+
+func NewAnalyticsRollupWorker(r AnalyticsRollup, interval time.Duration) *analyticsRollupWorker {
+	return &analyticsRollupWorker{
+		rollup:   r,
+		interval: interval,
+	}
+}
+
+func (w *analyticsRollupWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.runOnce(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.runOnce(ctx)
+		}
+	}
+}
+
+func (w *analyticsRollupWorker) runOnce(ctx context.Context) {
+	rows, err := w.rollup.RollupRecent(ctx)
+	if err != nil {
+		slog.Error("AnalyticsRollupWorker: 日別集計の作成に失敗しました", "err", err)
+		return
+	}
+	slog.Debug("AnalyticsRollupWorker: 日別集計を更新しました", "rows", rows)
+}
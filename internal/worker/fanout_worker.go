@@ -27,18 +27,36 @@ type TLHelper interface {
 
 
 
+type BookmarkCleaner interface {
+	RemoveBookmarksForTweet(ctx context.Context, tweetID int64) error
+}
+
+type MentionNotifier interface {
+	NotifyMentions(ctx context.Context, tweetID int64) error
+}
+
+type TimelinePublisher interface {
+	PublishTimeline(ctx context.Context, tweetID int64, userIDs []int64) error
+}
+
 type fanoutWorker struct {
 	mQConsumer 			MQConsumer
 	followerProvider 	FollwerProvider
 	tLHelper   			TLHelper
+	bookmarkCleaner     BookmarkCleaner
+	mentionNotifier     MentionNotifier
+	timelinePublisher   TimelinePublisher
 	pool                *ants.Pool
 }
 
-func NewFanoutWorker(c MQConsumer, p FollwerProvider, h TLHelper, ap *ants.Pool) *fanoutWorker{
+func NewFanoutWorker(c MQConsumer, p FollwerProvider, h TLHelper, b BookmarkCleaner, m MentionNotifier, tp TimelinePublisher, ap *ants.Pool) *fanoutWorker{
 	return &fanoutWorker{
 		mQConsumer: c,
 		followerProvider: p,
 		tLHelper: h,
+		bookmarkCleaner: b,
+		mentionNotifier: m,
+		timelinePublisher: tp,
 		pool: ap,
 	}
 }
@@ -109,6 +127,11 @@ func(w *fanoutWorker) handleTask(ctx context.Context, messageID string, values m
 }
 
 func (w *fanoutWorker) processCreate(ctx context.Context, task *dto.FanoutTask) error {
+    // 通知の失敗でタイムラインへの拡散を止めない
+    if err := w.mentionNotifier.NotifyMentions(ctx, task.TweetID); err != nil {
+        slog.Error("FanoutWorker: メンション通知に失敗しました", "tweet_id", task.TweetID, "error", err)
+    }
+
     followers, err := w.followerProvider.GetFollowerIDs(ctx, task.AuthorID)
     if err != nil {
         return err
@@ -138,10 +161,23 @@ func (w *fanoutWorker) processCreate(ctx context.Context, task *dto.FanoutTask)
     }
 
     wg.Wait() 
-    return bizErr 
+    if bizErr != nil {
+        return bizErr
+    }
+
+    // タイムラインへの書き込みが済んでから接続中のフォロワーへ知らせる。配信の失敗で再試行はしない
+    if err := w.timelinePublisher.PublishTimeline(ctx, task.TweetID, followers); err != nil {
+        slog.Warn("FanoutWorker: タイムラインのリアルタイム配信に失敗しました", "tweet_id", task.TweetID, "error", err)
+    }
+    return nil
 }
 
 func (w *fanoutWorker) processDelete(ctx context.Context, task *dto.FanoutTask) error {
+    if err := w.bookmarkCleaner.RemoveBookmarksForTweet(ctx, task.TweetID); err != nil {
+        slog.Error("FanoutWorker: ブックマークの削除に失敗しました", "tweet_id", task.TweetID, "error", err)
+        return err
+    }
+
     followers, err := w.followerProvider.GetFollowerIDs(ctx, task.AuthorID)
     if err != nil {
         return err
@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"aita/internal/dto"
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/panjf2000/ants/v2"
+)
+
+type LinkPreviewUnfurler interface {
+	UnfurlTweet(ctx context.Context, tweetID int64) error
+}
+
+// ツイートのストリームを拡散処理とは別のコンシューマーグループで読み、リンクプレビューを取得する。
+// プレビューは補助的な情報なので、失敗しても再試行せずに確認応答する
+type linkPreviewWorker struct {
+	mQConsumer MQConsumer
+	unfurler   LinkPreviewUnfurler
+	pool       *ants.Pool
+	timeout    time.Duration
+}
+
+func NewLinkPreviewWorker(c MQConsumer, u LinkPreviewUnfurler, ap *ants.Pool, timeout time.Duration) *linkPreviewWorker {
+	return &linkPreviewWorker{
+		mQConsumer: c,
+		unfurler:   u,
+		pool:       ap,
+		timeout:    timeout,
+	}
+}
+
+func (w *linkPreviewWorker) Start(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			message, err := w.mQConsumer.Dequeue(ctx)
+			if err != nil {
+				slog.Error("LinkPreviewWorker: インフラ接続エラー", "error", err)
+				time.Sleep(2 * time.Second)
+				continue
+			}
+			if message == nil {
+				continue
+			}
+
+			w.handleTask(ctx, message.ID, message.Values)
+			_ = w.mQConsumer.Ack(ctx, message.ID)
+		}
+	}
+}
+
+func (w *linkPreviewWorker) handleTask(ctx context.Context, messageID string, values map[string]any) {
+	task := &dto.FanoutTask{}
+	if err := task.FromMap(messageID, values); err != nil {
+		slog.Error("LinkPreviewWorker: データ解析エラー。このメッセージを破棄します。", "msg_id", messageID, "error", err)
+		return
+	}
+	if task.Action != dto.ActionCreate {
+		return
+	}
+
+	err := w.pool.Submit(func() {
+		taskCtx, cancel := context.WithTimeout(ctx, w.timeout)
+		defer cancel()
+
+		if err := w.unfurler.UnfurlTweet(taskCtx, task.TweetID); err != nil {
+			slog.Error("LinkPreviewWorker: プレビューの取得に失敗しました", "tweet_id", task.TweetID, "error", err)
+		}
+	})
+	if err != nil {
+		slog.Warn("LinkPreviewWorker: タスク投入に失敗しました", "tweet_id", task.TweetID, "error", err)
+	}
+}
@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type MediaCollector interface {
+	CollectOrphans(ctx context.Context, limit int) (int, error)
+}
+
+type mediaGCWorker struct {
+	collector MediaCollector
+	interval  time.Duration
+	batchSize int
+}
+
+func NewMediaGCWorker(c MediaCollector, interval time.Duration) *mediaGCWorker {
+	return &mediaGCWorker{
+		collector: c,
+		interval:  interval,
+		batchSize: 200,
+	}
+}
+
+func (w *mediaGCWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.runOnce(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.runOnce(ctx)
+		}
+	}
+}
+
+func (w *mediaGCWorker) runOnce(ctx context.Context) {
+	total := 0
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		collected, err := w.collector.CollectOrphans(ctx, w.batchSize)
+		if err != nil {
+			slog.Error("MediaGCWorker: 未使用メディアの削除に失敗しました", "err", err)
+			return
+		}
+
+		total += collected
+		if collected < w.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		slog.Info("MediaGCWorker: 未使用のメディアを削除しました", "count", total)
+	}
+}
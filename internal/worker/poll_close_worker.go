@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type PollCloser interface {
+	CloseExpiredPolls(ctx context.Context, limit int) (int, error)
+}
+
+type pollCloseWorker struct {
+	closer    PollCloser
+	interval  time.Duration
+	batchSize int
+}
+
+func NewPollCloseWorker(c PollCloser, interval time.Duration) *pollCloseWorker {
+	return &pollCloseWorker{
+		closer:    c,
+		interval:  interval,
+		batchSize: 200,
+	}
+}
+
+func (w *pollCloseWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.runOnce(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.runOnce(ctx)
+		}
+	}
+}
+
+func (w *pollCloseWorker) runOnce(ctx context.Context) {
+	total := 0
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		closed, err := w.closer.CloseExpiredPolls(ctx, w.batchSize)
+		if err != nil {
+			slog.Error("PollCloseWorker: 投票の締切処理に失敗しました", "err", err)
+			return
+		}
+
+		total += closed
+		if closed < w.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		slog.Info("PollCloseWorker: 投票を締め切りました", "count", total)
+	}
+}
@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type PollTallyPersister interface {
+	PersistTallies(ctx context.Context, limit int) (int, error)
+}
+
+type pollTallyWorker struct {
+	persister PollTallyPersister
+	interval  time.Duration
+	batchSize int
+}
+
+func NewPollTallyWorker(p PollTallyPersister, interval time.Duration) *pollTallyWorker {
+	return &pollTallyWorker{
+		persister: p,
+		interval:  interval,
+		batchSize: 500,
+	}
+}
+
+func (w *pollTallyWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.runOnce(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.runOnce(ctx)
+		}
+	}
+}
+
+func (w *pollTallyWorker) runOnce(ctx context.Context) {
+	total := 0
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		persisted, err := w.persister.PersistTallies(ctx, w.batchSize)
+		if err != nil {
+			slog.Error("PollTallyWorker: 投票集計の永続化に失敗しました", "err", err)
+			return
+		}
+
+		total += persisted
+		if persisted < w.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		slog.Info("PollTallyWorker: 投票集計を永続化しました", "count", total)
+	}
+}
@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/panjf2000/ants/v2"
+)
+
+type RecommendationRefresher interface {
+	RefreshForUser(ctx context.Context, userID int64) error
+	RefreshPopular(ctx context.Context) error
+	ListActiveUserIDs(ctx context.Context, afterID int64, limit int) ([]int64, error)
+}
+
+type recommendationWorker struct {
+	refresher RecommendationRefresher
+	pool      *ants.Pool
+	interval  time.Duration
+	batchSize int
+}
+
+func NewRecommendationWorker(r RecommendationRefresher, p *ants.Pool, interval time.Duration) *recommendationWorker {
+	return &recommendationWorker{
+		refresher: r,
+		pool:      p,
+		interval:  interval,
+		batchSize: 200,
+	}
+}
+
+func (w *recommendationWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.runOnce(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.runOnce(ctx)
+		}
+	}
+}
+
+func (w *recommendationWorker) runOnce(ctx context.Context) {
+	started := time.Now()
+
+	if err := w.refresher.RefreshPopular(ctx); err != nil {
+		slog.Error("RecommendationWorker: 人気ユーザーの再計算に失敗しました", "err", err)
+	}
+
+	var cursor int64
+	total := 0
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		ids, err := w.refresher.ListActiveUserIDs(ctx, cursor, w.batchSize)
+		if err != nil {
+			slog.Error("RecommendationWorker: 対象ユーザーの取得に失敗しました", "cursor", cursor, "err", err)
+			return
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		var wg sync.WaitGroup
+		for _, id := range ids {
+			userID := id
+			wg.Add(1)
+			err := w.pool.Submit(func() {
+				defer wg.Done()
+				if err := w.refresher.RefreshForUser(ctx, userID); err != nil {
+					slog.Warn("RecommendationWorker: おすすめの再計算に失敗しました", "user_id", userID, "err", err)
+				}
+			})
+			if err != nil {
+				wg.Done()
+				slog.Warn("RecommendationWorker: タスク投入に失敗しました", "user_id", userID, "err", err)
+			}
+		}
+		wg.Wait()
+
+		total += len(ids)
+		cursor = ids[len(ids)-1]
+	}
+
+	slog.Info("RecommendationWorker: おすすめユーザーの再計算が完了しました", "users", total, "elapsed", time.Since(started))
+}
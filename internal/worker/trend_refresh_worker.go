@@ -0,0 +1,48 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type TrendRefresher interface {
+	RefreshTrends(ctx context.Context) (int, error)
+}
+
+type trendRefreshWorker struct {
+	refresher TrendRefresher
+	interval  time.Duration
+}
+
+func NewTrendRefreshWorker(r TrendRefresher, interval time.Duration) *trendRefreshWorker {
+	return &trendRefreshWorker{
+		refresher: r,
+		interval:  interval,
+	}
+}
+
+func (w *trendRefreshWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.runOnce(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.runOnce(ctx)
+		}
+	}
+}
+
+func (w *trendRefreshWorker) runOnce(ctx context.Context) {
+	count, err := w.refresher.RefreshTrends(ctx)
+	if err != nil {
+		slog.Error("TrendRefreshWorker: トレンドの集計に失敗しました", "err", err)
+		return
+	}
+	slog.Debug("TrendRefreshWorker: トレンドを更新しました", "count", count)
+}
@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"aita/internal/dto"
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/panjf2000/ants/v2"
+)
+
+type TrendRecorder interface {
+	RecordTweet(ctx context.Context, tweetID int64) error
+}
+
+// ツイートのストリームを専用のコンシューマーグループで読み、ハッシュタグを数える。
+// トレンドは多少の取りこぼしを許容するので、失敗しても再試行せずに確認応答する
+type trendWorker struct {
+	mQConsumer MQConsumer
+	recorder   TrendRecorder
+	pool       *ants.Pool
+	timeout    time.Duration
+}
+
+func NewTrendWorker(c MQConsumer, r TrendRecorder, ap *ants.Pool) *trendWorker {
+	return &trendWorker{
+		mQConsumer: c,
+		recorder:   r,
+		pool:       ap,
+		timeout:    5 * time.Second,
+	}
+}
+
+func (w *trendWorker) Start(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			message, err := w.mQConsumer.Dequeue(ctx)
+			if err != nil {
+				slog.Error("TrendWorker: インフラ接続エラー", "error", err)
+				time.Sleep(2 * time.Second)
+				continue
+			}
+			if message == nil {
+				continue
+			}
+
+			w.handleTask(ctx, message.ID, message.Values)
+			_ = w.mQConsumer.Ack(ctx, message.ID)
+		}
+	}
+}
+
+func (w *trendWorker) handleTask(ctx context.Context, messageID string, values map[string]any) {
+	task := &dto.FanoutTask{}
+	if err := task.FromMap(messageID, values); err != nil {
+		slog.Error("TrendWorker: データ解析エラー。このメッセージを破棄します。", "msg_id", messageID, "error", err)
+		return
+	}
+	if task.Action != dto.ActionCreate {
+		return
+	}
+
+	err := w.pool.Submit(func() {
+		taskCtx, cancel := context.WithTimeout(ctx, w.timeout)
+		defer cancel()
+
+		if err := w.recorder.RecordTweet(taskCtx, task.TweetID); err != nil {
+			slog.Error("TrendWorker: ハッシュタグの集計に失敗しました", "tweet_id", task.TweetID, "error", err)
+		}
+	})
+	if err != nil {
+		slog.Warn("TrendWorker: タスク投入に失敗しました", "tweet_id", task.TweetID, "error", err)
+	}
+}
@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type TweetPurger interface {
+	PurgeDeletedTweets(ctx context.Context, limit int) (int64, error)
+}
+
+type tweetPurgeWorker struct {
+	purger    TweetPurger
+	interval  time.Duration
+	batchSize int
+}
+
+func NewTweetPurgeWorker(p TweetPurger, interval time.Duration) *tweetPurgeWorker {
+	return &tweetPurgeWorker{
+		purger:    p,
+		interval:  interval,
+		batchSize: 500,
+	}
+}
+
+func (w *tweetPurgeWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.runOnce(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.runOnce(ctx)
+		}
+	}
+}
+
+func (w *tweetPurgeWorker) runOnce(ctx context.Context) {
+	var total int64
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		purged, err := w.purger.PurgeDeletedTweets(ctx, w.batchSize)
+		if err != nil {
+			slog.Error("TweetPurgeWorker: 削除済みツイートの物理削除に失敗しました", "err", err)
+			return
+		}
+
+		total += purged
+		if purged < int64(w.batchSize) {
+			break
+		}
+	}
+
+	if total > 0 {
+		slog.Info("TweetPurgeWorker: 保持期間を過ぎたツイートを物理削除しました", "count", total)
+	}
+}
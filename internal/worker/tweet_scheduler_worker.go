@@ -0,0 +1,67 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type ScheduledTweetPublisher interface {
+	PublishDue(ctx context.Context, limit int) (int, error)
+	ResyncSchedules(ctx context.Context) error
+}
+
+type tweetSchedulerWorker struct {
+	publisher      ScheduledTweetPublisher
+	interval       time.Duration
+	resyncInterval time.Duration
+	batchSize      int
+}
+
+func NewTweetSchedulerWorker(p ScheduledTweetPublisher, interval time.Duration) *tweetSchedulerWorker {
+	return &tweetSchedulerWorker{
+		publisher:      p,
+		interval:       interval,
+		resyncInterval: 5 * time.Minute,
+		batchSize:      100,
+	}
+}
+
+func (w *tweetSchedulerWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	resyncTicker := time.NewTicker(w.resyncInterval)
+	defer resyncTicker.Stop()
+
+	w.resync(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-resyncTicker.C:
+			w.resync(ctx)
+		case <-ticker.C:
+			w.runOnce(ctx)
+		}
+	}
+}
+
+func (w *tweetSchedulerWorker) runOnce(ctx context.Context) {
+	published, err := w.publisher.PublishDue(ctx, w.batchSize)
+	if err != nil {
+		slog.Error("TweetSchedulerWorker: 予約投稿の公開に失敗しました", "err", err)
+		return
+	}
+
+	if published > 0 {
+		slog.Info("TweetSchedulerWorker: 予約投稿を公開しました", "count", published)
+	}
+}
+
+// Redis が失われても Postgres の未公開予約から ZSET を復元する
+func (w *tweetSchedulerWorker) resync(ctx context.Context) {
+	if err := w.publisher.ResyncSchedules(ctx); err != nil {
+		slog.Error("TweetSchedulerWorker: 予約投稿の再同期に失敗しました", "err", err)
+	}
+}
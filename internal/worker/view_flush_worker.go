@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type ViewFlusher interface {
+	FlushViews(ctx context.Context) (int, error)
+}
+
+type viewFlushWorker struct {
+	flusher  ViewFlusher
+	interval time.Duration
+}
+
+func NewViewFlushWorker(f ViewFlusher, interval time.Duration) *viewFlushWorker {
+	return &viewFlushWorker{
+		flusher:  f,
+		interval: interval,
+	}
+}
+
+func (w *viewFlushWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// 停止時に溜まっている分を書き出す
+			flushCtx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			w.runOnce(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			w.runOnce(ctx)
+		}
+	}
+}
+
+func (w *viewFlushWorker) runOnce(ctx context.Context) {
+	flushed, err := w.flusher.FlushViews(ctx)
+	if err != nil {
+		slog.Error("ViewFlushWorker: 閲覧数の永続化に失敗しました", "err", err)
+		return
+	}
+	if flushed > 0 {
+		slog.Info("ViewFlushWorker: 閲覧数を永続化しました", "count", flushed)
+	}
+}
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_users_follower_count ON users(follower_count DESC, id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS tweet_revisions;
+
+ALTER TABLE tweets
+DROP COLUMN IF EXISTS edit_count,
+DROP COLUMN IF EXISTS last_edited_at;
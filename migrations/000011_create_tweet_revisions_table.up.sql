@@ -0,0 +1,13 @@
+ALTER TABLE tweets
+ADD COLUMN edit_count INT NOT NULL DEFAULT 0,
+ADD COLUMN last_edited_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE tweet_revisions (
+    id         BIGSERIAL PRIMARY KEY,
+    tweet_id   BIGINT NOT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    revision   INT NOT NULL,
+    content    VARCHAR(1000) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT unique_tweet_revision UNIQUE (tweet_id, revision)
+);
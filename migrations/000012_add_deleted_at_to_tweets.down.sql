@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_tweets_deleted_at;
+
+ALTER TABLE tweets
+DROP COLUMN IF EXISTS deleted_at;
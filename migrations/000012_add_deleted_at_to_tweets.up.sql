@@ -0,0 +1,4 @@
+ALTER TABLE tweets
+ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_tweets_deleted_at ON tweets(deleted_at) WHERE deleted_at IS NOT NULL;
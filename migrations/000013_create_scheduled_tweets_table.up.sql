@@ -0,0 +1,15 @@
+CREATE TABLE scheduled_tweets (
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content      VARCHAR(1000) NOT NULL,
+    image_url    VARCHAR(255),
+    publish_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+    status       VARCHAR(16) NOT NULL DEFAULT 'pending',
+    tweet_id     BIGINT REFERENCES tweets(id) ON DELETE SET NULL,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT check_scheduled_tweet_status CHECK (status IN ('pending', 'published', 'canceled', 'failed'))
+);
+
+CREATE INDEX idx_scheduled_tweets_pending ON scheduled_tweets(publish_at) WHERE status = 'pending';
+CREATE INDEX idx_scheduled_tweets_user_id ON scheduled_tweets(user_id, publish_at);
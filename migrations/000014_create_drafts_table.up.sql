@@ -0,0 +1,10 @@
+CREATE TABLE drafts (
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content     VARCHAR(1000) NOT NULL,
+    image_url   VARCHAR(255),
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_drafts_user_id ON drafts(user_id, updated_at DESC);
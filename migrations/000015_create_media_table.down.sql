@@ -0,0 +1,7 @@
+ALTER TABLE scheduled_tweets DROP COLUMN IF EXISTS media_id;
+ALTER TABLE scheduled_tweets ADD COLUMN image_url VARCHAR(255);
+
+ALTER TABLE drafts DROP COLUMN IF EXISTS media_id;
+ALTER TABLE drafts ADD COLUMN image_url VARCHAR(255);
+
+DROP TABLE IF EXISTS media;
@@ -0,0 +1,23 @@
+CREATE TABLE media (
+    id             BIGSERIAL PRIMARY KEY,
+    user_id        BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    storage_key    VARCHAR(255) NOT NULL UNIQUE,
+    thumbnail_key  VARCHAR(255) NOT NULL,
+    url            VARCHAR(255) NOT NULL,
+    thumbnail_url  VARCHAR(255) NOT NULL,
+    content_type   VARCHAR(50) NOT NULL,
+    size_bytes     BIGINT NOT NULL,
+    width          INT NOT NULL,
+    height         INT NOT NULL,
+    tweet_id       BIGINT REFERENCES tweets(id) ON DELETE SET NULL,
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_media_tweet_id ON media(tweet_id);
+CREATE INDEX idx_media_unattached ON media(created_at) WHERE tweet_id IS NULL;
+
+ALTER TABLE drafts DROP COLUMN image_url;
+ALTER TABLE drafts ADD COLUMN media_id BIGINT REFERENCES media(id) ON DELETE SET NULL;
+
+ALTER TABLE scheduled_tweets DROP COLUMN image_url;
+ALTER TABLE scheduled_tweets ADD COLUMN media_id BIGINT REFERENCES media(id) ON DELETE SET NULL;
@@ -0,0 +1,19 @@
+ALTER TABLE scheduled_tweets ADD COLUMN media_id BIGINT REFERENCES media(id) ON DELETE SET NULL;
+UPDATE scheduled_tweets SET media_id = (media->0->>'media_id')::BIGINT WHERE jsonb_array_length(media) > 0;
+ALTER TABLE scheduled_tweets DROP COLUMN media;
+
+ALTER TABLE drafts ADD COLUMN media_id BIGINT REFERENCES media(id) ON DELETE SET NULL;
+UPDATE drafts SET media_id = (media->0->>'media_id')::BIGINT WHERE jsonb_array_length(media) > 0;
+ALTER TABLE drafts DROP COLUMN media;
+
+DROP INDEX IF EXISTS idx_media_created_at;
+ALTER TABLE media ADD COLUMN tweet_id BIGINT REFERENCES tweets(id) ON DELETE SET NULL;
+
+UPDATE media m SET tweet_id = tm.tweet_id
+FROM tweet_media tm
+WHERE tm.media_id = m.id AND tm.position = 0;
+
+CREATE INDEX idx_media_tweet_id ON media(tweet_id);
+CREATE INDEX idx_media_unattached ON media(created_at) WHERE tweet_id IS NULL;
+
+DROP TABLE IF EXISTS tweet_media;
@@ -0,0 +1,26 @@
+CREATE TABLE tweet_media (
+    tweet_id    BIGINT NOT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    media_id    BIGINT NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    position    SMALLINT NOT NULL,
+    alt_text    VARCHAR(1000) NOT NULL DEFAULT '',
+
+    PRIMARY KEY (tweet_id, position),
+    CONSTRAINT unique_tweet_media_media_id UNIQUE (media_id),
+    CONSTRAINT check_tweet_media_position CHECK (position BETWEEN 0 AND 3)
+);
+
+INSERT INTO tweet_media(tweet_id, media_id, position)
+SELECT tweet_id, id, 0 FROM media WHERE tweet_id IS NOT NULL;
+
+ALTER TABLE media DROP COLUMN tweet_id;
+CREATE INDEX idx_media_created_at ON media(created_at);
+
+ALTER TABLE drafts ADD COLUMN media JSONB NOT NULL DEFAULT '[]';
+UPDATE drafts SET media = jsonb_build_array(jsonb_build_object('media_id', media_id, 'alt_text', ''))
+WHERE media_id IS NOT NULL;
+ALTER TABLE drafts DROP COLUMN media_id;
+
+ALTER TABLE scheduled_tweets ADD COLUMN media JSONB NOT NULL DEFAULT '[]';
+UPDATE scheduled_tweets SET media = jsonb_build_array(jsonb_build_object('media_id', media_id, 'alt_text', ''))
+WHERE media_id IS NOT NULL;
+ALTER TABLE scheduled_tweets DROP COLUMN media_id;
@@ -0,0 +1,31 @@
+CREATE TABLE polls (
+    id          BIGSERIAL PRIMARY KEY,
+    tweet_id    BIGINT NOT NULL UNIQUE REFERENCES tweets(id) ON DELETE CASCADE,
+    closes_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+    closed_at   TIMESTAMP WITH TIME ZONE,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_polls_open ON polls(closes_at) WHERE closed_at IS NULL;
+
+CREATE TABLE poll_options (
+    id          BIGSERIAL PRIMARY KEY,
+    poll_id     BIGINT NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+    position    SMALLINT NOT NULL,
+    label       VARCHAR(25) NOT NULL,
+    vote_count  BIGINT NOT NULL DEFAULT 0,
+
+    CONSTRAINT unique_poll_option_position UNIQUE (poll_id, position),
+    CONSTRAINT check_poll_option_position CHECK (position BETWEEN 0 AND 3)
+);
+
+CREATE TABLE poll_votes (
+    poll_id     BIGINT NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+    user_id     BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    option_id   BIGINT NOT NULL REFERENCES poll_options(id) ON DELETE CASCADE,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT unique_poll_vote UNIQUE (poll_id, user_id)
+);
+
+CREATE INDEX idx_poll_votes_option_id ON poll_votes(option_id);
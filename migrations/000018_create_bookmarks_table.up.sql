@@ -0,0 +1,11 @@
+CREATE TABLE bookmarks (
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tweet_id    BIGINT NOT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT unique_bookmark UNIQUE (user_id, tweet_id)
+);
+
+CREATE INDEX idx_bookmarks_user_id_id ON bookmarks(user_id, id DESC);
+CREATE INDEX idx_bookmarks_tweet_id ON bookmarks(tweet_id);
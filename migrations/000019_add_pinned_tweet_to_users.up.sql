@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN pinned_tweet_id BIGINT REFERENCES tweets(id) ON DELETE SET NULL;
@@ -0,0 +1,17 @@
+CREATE TABLE notifications (
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    actor_id    BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type        VARCHAR(20) NOT NULL,
+    tweet_id    BIGINT REFERENCES tweets(id) ON DELETE CASCADE,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT check_notification_type CHECK (type IN ('follow', 'mention', 'like', 'reply', 'retweet')),
+    CONSTRAINT check_notification_not_self CHECK (user_id <> actor_id)
+);
+
+-- 同じ相手から同じ通知が重複しないようにする。tweet_id が NULL の通知(フォロー)も対象にする
+CREATE UNIQUE INDEX unique_notification ON notifications(user_id, type, actor_id, COALESCE(tweet_id, 0));
+CREATE INDEX idx_notifications_user_id_id ON notifications(user_id, id DESC);
+
+ALTER TABLE users ADD COLUMN notifications_read_id BIGINT NOT NULL DEFAULT 0;
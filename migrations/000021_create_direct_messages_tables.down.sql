@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS dm_messages;
+DROP TABLE IF EXISTS dm_participants;
+DROP TABLE IF EXISTS dm_conversations;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS check_users_dm_policy;
+ALTER TABLE users DROP COLUMN IF EXISTS dm_policy;
@@ -0,0 +1,37 @@
+-- 誰から DM を受け取るか。following は自分がフォローしている相手からのみ
+ALTER TABLE users ADD COLUMN dm_policy VARCHAR(20) NOT NULL DEFAULT 'everyone';
+ALTER TABLE users ADD CONSTRAINT check_users_dm_policy CHECK (dm_policy IN ('everyone', 'following', 'nobody'));
+
+CREATE TABLE dm_conversations (
+    id              BIGSERIAL PRIMARY KEY,
+    is_group        BOOLEAN NOT NULL DEFAULT FALSE,
+    -- 1対1の会話を重複して作らないためのキー("小さいユーザーID:大きいユーザーID")。グループは NULL
+    direct_key      VARCHAR(50),
+    created_by      BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_message_at TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT unique_dm_conversations_direct_key UNIQUE (direct_key)
+);
+
+CREATE TABLE dm_participants (
+    conversation_id      BIGINT NOT NULL REFERENCES dm_conversations(id) ON DELETE CASCADE,
+    user_id              BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- この ID 以下のメッセージは既読
+    last_read_message_id BIGINT NOT NULL DEFAULT 0,
+    joined_at            TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (conversation_id, user_id)
+);
+
+CREATE INDEX idx_dm_participants_user_id ON dm_participants(user_id);
+
+CREATE TABLE dm_messages (
+    id              BIGSERIAL PRIMARY KEY,
+    conversation_id BIGINT NOT NULL REFERENCES dm_conversations(id) ON DELETE CASCADE,
+    sender_id       BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content         VARCHAR(1000) NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_dm_messages_conversation_id_id ON dm_messages(conversation_id, id DESC);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tweets_user_id_id;
+DROP TABLE IF EXISTS list_members;
+DROP TABLE IF EXISTS lists;
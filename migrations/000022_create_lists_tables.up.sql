@@ -0,0 +1,25 @@
+CREATE TABLE lists (
+    id          BIGSERIAL PRIMARY KEY,
+    owner_id    BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name        VARCHAR(25) NOT NULL,
+    description VARCHAR(100) NOT NULL DEFAULT '',
+    -- 非公開のリストは作成者のみ閲覧できる
+    is_private  BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_lists_owner_id ON lists(owner_id, id DESC);
+
+CREATE TABLE list_members (
+    list_id    BIGINT NOT NULL REFERENCES lists(id) ON DELETE CASCADE,
+    user_id    BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (list_id, user_id)
+);
+
+CREATE INDEX idx_list_members_user_id ON list_members(user_id);
+
+-- リストのタイムラインは読み込み時にメンバーの最新ツイートを集めるため、投稿者ごとの新しい順で引けるようにする
+CREATE INDEX idx_tweets_user_id_id ON tweets(user_id, id DESC) WHERE deleted_at IS NULL;
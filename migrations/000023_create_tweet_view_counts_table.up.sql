@@ -0,0 +1,6 @@
+-- Redis で重複を除いて数えた閲覧数を定期的に加算する。
+-- tweets を更新すると updated_at のトリガーが動くため別テーブルに持つ
+CREATE TABLE tweet_view_counts (
+    tweet_id   BIGINT PRIMARY KEY REFERENCES tweets(id) ON DELETE CASCADE,
+    view_count BIGINT NOT NULL DEFAULT 0
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_tweets_created_at;
+DROP TABLE IF EXISTS user_daily_stats;
+DROP TABLE IF EXISTS tweet_daily_views;
+DROP TABLE IF EXISTS follow_events;
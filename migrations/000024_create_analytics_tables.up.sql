@@ -0,0 +1,35 @@
+-- follows は解除で行が消えるため、増減を日別に数えられるよう履歴を残す
+CREATE TABLE follow_events (
+    id           BIGSERIAL PRIMARY KEY,
+    follower_id  BIGINT NOT NULL,
+    following_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    action       VARCHAR(10) NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_follow_event_action CHECK (action IN ('follow', 'unfollow'))
+);
+
+CREATE INDEX idx_follow_events_created_at ON follow_events(created_at);
+
+-- 閲覧数を永続化した日(UTC)ごとの増分
+CREATE TABLE tweet_daily_views (
+    tweet_id   BIGINT NOT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    day        DATE NOT NULL,
+    view_count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (tweet_id, day)
+);
+
+CREATE INDEX idx_tweet_daily_views_day ON tweet_daily_views(day);
+
+-- 投稿者向けの日別集計。ワーカーが直近の日を元のテーブルから作り直す
+CREATE TABLE user_daily_stats (
+    user_id          BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    day              DATE NOT NULL,
+    followers_gained BIGINT NOT NULL DEFAULT 0,
+    followers_lost   BIGINT NOT NULL DEFAULT 0,
+    tweets_posted    BIGINT NOT NULL DEFAULT 0,
+    impressions      BIGINT NOT NULL DEFAULT 0,
+    updated_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, day)
+);
+
+CREATE INDEX idx_tweets_created_at ON tweets(created_at);
@@ -0,0 +1,4 @@
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP WITH TIME ZONE;
+
+-- 確認の仕組みができる前に登録したユーザーは確認済みとして扱う
+UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL;
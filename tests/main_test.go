@@ -24,6 +24,32 @@ var (
 	testTweetStore   	repository.TweetStore
 	testTweetCache   	repository.TweetCache
 	testTimeLineCache   repository.TimeLineCache
+	testRecommendationStore repository.RecommendationStore
+	testRecommendationCache repository.RecommendationCache
+	testScheduledTweetStore repository.ScheduledTweetStore
+	testScheduleCache       repository.ScheduleCache
+	testDraftStore          repository.DraftStore
+	testMediaStore          repository.MediaStore
+	testPollStore           repository.PollStore
+	testPollCache           repository.PollCache
+	testBookmarkStore       repository.BookmarkStore
+	testBookmarkCache       repository.BookmarkCache
+	testLinkPreviewCache    repository.LinkPreviewCache
+	testNotificationStore   repository.NotificationStore
+	testNotificationCache   repository.NotificationCache
+	testEventStreamCache    repository.EventStreamCache
+	testTopicCache          repository.TopicCache
+	testTrendCache          repository.TrendCache
+	testViewStore           repository.ViewStore
+	testViewCache           repository.ViewCache
+	testAnalyticsStore      repository.AnalyticsStore
+	testUserTokenStore      repository.UserTokenStore
+	testRateLimitCache      repository.RateLimitCache
+	testDMStore             repository.DMStore
+	testDMCache             repository.DMCache
+	testListStore           repository.ListStore
+	testListCache           repository.ListCache
+	testTransactor          service.TransactionManager
 	testTokemanager  	service.TokenManager
 	testHasher       	service.PasswordHasher
 	testContext      	*testConfig.TestContext
@@ -45,6 +71,32 @@ func TestMain(m *testing.M) {
 	testSessionStore = db.NewRedisSessionStore(testContext.TestRDB)
 	testTweetStore = db.NewPostgresTweetStore(testContext.TestDB)
 	testFollowStore = db.NewPostgresFollowStore(testContext.TestDB)
+	testRecommendationStore = db.NewPostgresRecommendationStore(testContext.TestDB)
+	testRecommendationCache = cache.NewRedisRecommendationCache(testContext.TestRDB)
+	testScheduledTweetStore = db.NewPostgresScheduledTweetStore(testContext.TestDB)
+	testDraftStore = db.NewPostgresDraftStore(testContext.TestDB)
+	testMediaStore = db.NewPostgresMediaStore(testContext.TestDB)
+	testTransactor = db.NewTransactor(testContext.TestDB)
+	testScheduleCache = cache.NewRedisScheduleCache(testContext.TestRDB)
+	testPollStore = db.NewPostgresPollStore(testContext.TestDB)
+	testPollCache = cache.NewRedisPollCache(testContext.TestRDB)
+	testBookmarkStore = db.NewPostgresBookmarkStore(testContext.TestDB)
+	testBookmarkCache = cache.NewRedisBookmarkCache(testContext.TestRDB)
+	testLinkPreviewCache = cache.NewRedisLinkPreviewCache(testContext.TestRDB)
+	testNotificationStore = db.NewPostgresNotificationStore(testContext.TestDB)
+	testNotificationCache = cache.NewRedisNotificationCache(testContext.TestRDB)
+	testEventStreamCache = cache.NewRedisEventStreamCache(testContext.TestRDB, 1000)
+	testTopicCache = cache.NewRedisTopicCache(testContext.TestRDB)
+	testTrendCache = cache.NewRedisTrendCache(testContext.TestRDB)
+	testViewStore = db.NewPostgresViewStore(testContext.TestDB)
+	testViewCache = cache.NewRedisViewCache(testContext.TestRDB)
+	testAnalyticsStore = db.NewPostgresAnalyticsStore(testContext.TestDB)
+	testUserTokenStore = db.NewPostgresUserTokenStore(testContext.TestDB)
+	testRateLimitCache = cache.NewRedisRateLimitCache(testContext.TestRDB)
+	testDMStore = db.NewPostgresDMStore(testContext.TestDB)
+	testDMCache = cache.NewRedisDMCache(testContext.TestRDB)
+	testListStore = db.NewPostgresListStore(testContext.TestDB)
+	testListCache = cache.NewRedisListCache(testContext.TestRDB)
 	
 	testStream := "test:aita:tweet:stream"
 	testGroup  := "test:fanout:group"
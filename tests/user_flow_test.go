@@ -2,7 +2,10 @@ package tests
 
 import (
 	"aita/internal/api"
+	"aita/internal/dto"
 	"aita/internal/pkg/app"
+	"aita/internal/pkg/mailer"
+	"aita/internal/pkg/storage"
 	"aita/internal/producer"
 	"aita/internal/repository"
 	"aita/internal/service"
@@ -14,6 +17,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/panjf2000/ants/v2"
@@ -39,17 +43,54 @@ func TestUserLifeCycleIntegration(t *testing.T) {
 	userRepository := repository.NewUserRepository(testUserStore, testUserCache, testPool)
 	sesseionRepository := repository.NewSessionRepository(testSessionStore)
 	followRepository := repository.NewFollowRepository(testFollowStore, testFollowCache, testPool)
-	tweetRepository := repository.NewTweetRepository(testTweetStore, testTweetCache, testPool)
+	tweetRepository := repository.NewTweetRepository(testTweetStore, testTweetCache, testLinkPreviewCache, testViewStore, testViewCache, testPool)
 	userService := service.NewUserService(userRepository, testHasher)
-	sessionService := service.NewSessionService(sesseionRepository, userService, testTokemanager)
-	followService := service.NewFollowService(followRepository, userService)
-	tweetService := service.NewTweetService(tweetRepository, fanoutProduer)
-	userHandler := api.NewUserHandler(userService, sessionService)
-	tweetHandler := api.NewTweetHandler(tweetService)
+	sessionService := service.NewSessionService(sesseionRepository, userService, testTokemanager, dto.NewSessionPolicy(0, 0, 0))
+	tweetService := service.NewTweetService(tweetRepository, fanoutProduer, dto.NewEditPolicy(dto.DefaultEditWindow, dto.DefaultMaxEdits), dto.DeletionPolicy{GracePeriod: 30 * time.Minute, Retention: 720 * time.Hour})
+	notificationRepository := repository.NewNotificationRepository(testNotificationStore, testNotificationCache)
+	streamService := service.NewStreamService(repository.NewStreamRepository(testEventStreamCache), 3)
+	notificationService := service.NewNotificationService(notificationRepository, userService, tweetService, streamService)
+	followService := service.NewFollowService(followRepository, userService, testTransactor, notificationService)
+	testMailer, err := mailer.NewLogMailer("no-reply@aita.test", t.TempDir())
+	require.NoError(t, err, "テスト用メーラーの初期化に失敗しました")
+	userTokenRepository := repository.NewUserTokenRepository(testUserTokenStore)
+	emailVerificationService := service.NewEmailVerificationService(userRepository, userTokenRepository, repository.NewRateLimitRepository(testRateLimitCache), testTransactor, testTokemanager, testMailer, testWorkerPool, dto.NewVerificationPolicy(""), 24*time.Hour, "http://localhost:8080/email/verify")
+	accountService := service.NewAccountService(userRepository, userTokenRepository, testTransactor, sessionService, emailVerificationService, testHasher, 30*24*time.Hour)
+	userHandler := api.NewUserHandler(userService, sessionService, emailVerificationService, accountService)
+	scheduledTweetRepository := repository.NewScheduledTweetRepository(testScheduledTweetStore, testScheduleCache)
+	scheduledTweetService := service.NewScheduledTweetService(scheduledTweetRepository, fanoutProduer)
+	viewService := service.NewViewService(repository.NewViewRepository(testViewStore, testViewCache), testWorkerPool)
+	tweetHandler := api.NewTweetHandler(tweetService, scheduledTweetService, viewService)
 	followHandler := api.NewFollowHandler(followService)
+	recommendationRepository := repository.NewRecommendationRepository(testRecommendationStore, testRecommendationCache, testPool)
+	recommendationService := service.NewRecommendationService(recommendationRepository, followService, userService)
+	recommendationHandler := api.NewRecommendationHandler(recommendationService)
+	draftRepository := repository.NewDraftRepository(testDraftStore)
+	draftService := service.NewDraftService(draftRepository, tweetService, testTransactor)
+	draftHandler := api.NewDraftHandler(draftService)
+	mediaStorage, err := storage.NewLocalStorage(t.TempDir(), "/media")
+	require.NoError(t, err, "テスト用メディアストレージの初期化に失敗しました")
+	mediaRepository := repository.NewMediaRepository(testMediaStore)
+	mediaService := service.NewMediaService(mediaRepository, mediaStorage, dto.MediaPolicy{MaxBytes: 5 << 20, MaxPixels: 40_000_000, ThumbnailSize: 320, OrphanTTL: 24 * time.Hour})
+	mediaHandler := api.NewMediaHandler(mediaService, 5<<20)
+	pollHandler := api.NewPollHandler(service.NewPollService(repository.NewPollRepository(testPollStore, testPollCache)))
+	bookmarkRepository := repository.NewBookmarkRepository(testBookmarkStore, testBookmarkCache, testPool)
+	bookmarkHandler := api.NewBookmarkHandler(service.NewBookmarkService(bookmarkRepository, tweetService))
+	profileHandler := api.NewProfileHandler(service.NewProfileService(userRepository, tweetService))
+	notificationHandler := api.NewNotificationHandler(notificationService)
+	streamHandler := api.NewStreamHandler(streamService, 15*time.Second)
+	topicRepository := repository.NewTopicRepository(testTopicCache)
+	dmService := service.NewDMService(repository.NewDMRepository(testDMStore, testDMCache), userService, topicRepository)
+	gatewayService := service.NewGatewayService(topicRepository, streamService, tweetService, dmService)
+	gatewayHandler := api.NewGatewayHandler(gatewayService, 30*time.Second, nil)
+	dmHandler := api.NewDMHandler(dmService)
+	listHandler := api.NewListHandler(service.NewListService(repository.NewListRepository(testListStore, testListCache), tweetService, userService))
+	trendHandler := api.NewTrendHandler(service.NewTrendService(repository.NewTrendRepository(testTrendCache), tweetService, userService, 0))
+	analyticsHandler := api.NewAnalyticsHandler(service.NewAnalyticsService(repository.NewAnalyticsRepository(testAnalyticsStore)))
+	passwordHandler := api.NewPasswordHandler(service.NewPasswordService(userRepository, userTokenRepository, testTransactor, sessionService, testTokemanager, testHasher, testMailer, testWorkerPool, 30*time.Minute, "http://localhost:8080/password/reset"))
 
 	gin.SetMode(gin.TestMode)
-	r := api.SetupRouter(userHandler, tweetHandler, followHandler,sessionService)
+	r := api.SetupRouter(userHandler, tweetHandler, followHandler, recommendationHandler, draftHandler, mediaHandler, pollHandler, bookmarkHandler, profileHandler, notificationHandler, streamHandler, gatewayHandler, dmHandler, listHandler, trendHandler, analyticsHandler, passwordHandler, api.NewEmailVerificationHandler(emailVerificationService), api.NewSessionHandler(sessionService), sessionService)
 	signupPayload := app.SignupRequest{
 		Username: "frontend_dev",
 		Email:    "dev@aita.com",
@@ -78,7 +119,7 @@ func TestUserLifeCycleIntegration(t *testing.T) {
 
 	var loginResp struct {
 		Data struct {
-			Token string `json:"session_token"`
+			Token string `json:"access_token"`
 		} `json:"data"`
 	}
 	err = json.Unmarshal(w.Body.Bytes(), &loginResp)